@@ -20,4 +20,21 @@ const (
 	ErrMsgReverse         = "Reverse must be a boolean"
 	ErrMsgCountTotal      = "CountTotal must be a boolean"
 	ErrMsgHeightInteger   = "Height must be in integer format"
+	ErrMsgSearchQuery     = "Search query is required"
+	ErrMsgSearchLimit     = "Limit must be between 1 and 20"
+	ErrMsgAccountType     = "Account type is not valid"
+	ErrMsgStatsTime       = "From and to must be dates (YYYY-MM-DD) or RFC3339 timestamps"
+	ErrMsgStatsRange      = "From must not be after to"
+	ErrMsgStatsHourlySpan = "Hourly stats span at most 31 days"
+	ErrMsgStatsDailySpan  = "Daily stats span at most 366 days"
+	ErrMsgStatsInterval   = "Interval must be hour or day"
+	ErrMsgTraitType       = "Trait type is required to filter by trait value"
+
+	ErrMsgVerificationId      = "Verification id is not a valid integer"
+	ErrMsgVerificationBody    = "Request body must be a JSON object with address and files"
+	ErrMsgVerificationAddress = "Address is not a valid account address"
+	ErrMsgVerificationFiles   = "Files must contain between 1 and 256 files"
+	ErrMsgVerificationPath    = "File paths must be unique relative paths of Move.toml or .move files"
+	ErrMsgVerificationToml    = "Package must contain Move.toml at its root"
+	ErrMsgVerificationSize    = "Package sources must not exceed 2 MiB"
 )
@@ -24,6 +24,10 @@ type Config struct {
 		MaxIdleConns     int
 		ConnMaxLifetime  time.Duration
 		ConnMaxIdleTime  time.Duration
+
+		// VerificationConnectionString is a writable connection used to queue module verifications,
+		// submissions are disabled when it is empty
+		VerificationConnectionString string
 	}
 
 	// Repository configuration
@@ -66,6 +70,7 @@ func New() *Config {
 	config.Database.MaxIdleConns = getIntEnv("DB_MAX_IDLE_CONNS", 5)
 	config.Database.ConnMaxLifetime = getDurationEnv("DB_CONN_MAX_LIFETIME", 30*time.Minute)
 	config.Database.ConnMaxIdleTime = getDurationEnv("DB_CONN_MAX_IDLE_TIME", 5*time.Minute)
+	config.Database.VerificationConnectionString = getEnv("VERIFICATION_DB_CONNECTION_STRING", "")
 
 	// Repository configuration
 	config.Repository.CountQueryTimeout = getDurationEnv("REPOSITORY_COUNT_QUERY_TIMEOUT", 5*time.Second)
@@ -24,6 +24,77 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/indexer/account/v1/accounts": {
+            "get": {
+                "description": "Retrieve a list of accounts, optionally filtered by account type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get accounts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "pagination.offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit for pagination",
+                        "name": "pagination.limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Whether to count total accounts",
+                        "name": "pagination.count_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Whether to reverse the order of accounts",
+                        "name": "pagination.reverse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter accounts by type, e.g. 'BaseAccount', 'ModuleAccount' or 'ContractAccount'",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/indexer/account/v1/{accountAddress}": {
             "get": {
                 "description": "Retrieve account details by account address",
@@ -68,6 +139,78 @@ const docTemplate = `{
                 }
             }
         },
+        "/indexer/account/v1/{accountAddress}/deposited_proposals": {
+            "get": {
+                "description": "Retrieve the proposals an account deposited on with the total it deposited on each",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get account deposited proposals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account address",
+                        "name": "accountAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "pagination.offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit for pagination",
+                        "name": "pagination.limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Whether to count total proposals",
+                        "name": "pagination.count_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Whether to reverse the order of proposals",
+                        "name": "pagination.reverse",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountDepositedProposalsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/indexer/account/v1/{accountAddress}/proposals": {
             "get": {
                 "description": "Retrieve proposals associated with an account",
@@ -140,6 +283,50 @@ const docTemplate = `{
                 }
             }
         },
+        "/indexer/account/v1/{accountAddress}/summary": {
+            "get": {
+                "description": "Retrieve the activity summary of an account: first seen and last active blocks, transaction counts as signer, as participant and by category, Nfts and collections held and proposals voted on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get account summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account address",
+                        "name": "accountAddress",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccountSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/indexer/account/v1/{accountAddress}/txs": {
             "get": {
                 "description": "Retrieve transactions associated with an account",
@@ -648,9 +835,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/indexer/module/v1/modules/{vmAddress}/{name}/histories": {
+        "/indexer/module/v1/modules/{vmAddress}/{name}/functions": {
             "get": {
-                "description": "Retrieve a list of module histories with pagination",
+                "description": "Retrieve the call count, failure rate and unique callers of each entry function of a module over the last 1, 7 and 30 days",
                 "consumes": [
                     "application/json"
                 ],
@@ -660,7 +847,7 @@ const docTemplate = `{
                 "tags": [
                     "Module"
                 ],
-                "summary": "Get module histories",
+                "summary": "Get module functions call stats",
                 "parameters": [
                     {
                         "type": "string",
@@ -675,34 +862,13 @@ const docTemplate = `{
                         "name": "name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "pagination.offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Limit for pagination",
-                        "name": "pagination.limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "default": false,
-                        "description": "Count total",
-                        "name": "pagination.count_total",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ModuleHistoriesResponse"
+                            "$ref": "#/definitions/dto.ModuleFunctionsResponse"
                         }
                     },
                     "400": {
@@ -720,9 +886,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/indexer/module/v1/modules/{vmAddress}/{name}/proposals": {
+        "/indexer/module/v1/modules/{vmAddress}/{name}/histories": {
             "get": {
-                "description": "Retrieve a module proposal",
+                "description": "Retrieve a list of module histories with pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -732,7 +898,7 @@ const docTemplate = `{
                 "tags": [
                     "Module"
                 ],
-                "summary": "Get module proposal",
+                "summary": "Get module histories",
                 "parameters": [
                     {
                         "type": "string",
@@ -774,7 +940,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ModuleProposalsResponse"
+                            "$ref": "#/definitions/dto.ModuleHistoriesResponse"
                         }
                     },
                     "400": {
@@ -792,9 +958,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/indexer/module/v1/modules/{vmAddress}/{name}/publish_info": {
+        "/indexer/module/v1/modules/{vmAddress}/{name}/histories/{height}/verification": {
             "get": {
-                "description": "Retrieve a module publish info",
+                "description": "Retrieve the verified source files and ABI of the module version published at a height",
                 "consumes": [
                     "application/json"
                 ],
@@ -804,7 +970,7 @@ const docTemplate = `{
                 "tags": [
                     "Module"
                 ],
-                "summary": "Get module publish info",
+                "summary": "Get module history verified source",
                 "parameters": [
                     {
                         "type": "string",
@@ -819,13 +985,20 @@ const docTemplate = `{
                         "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Publish block height",
+                        "name": "height",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ModulePublishInfoResponse"
+                            "$ref": "#/definitions/dto.VerifiedModuleResponse"
                         }
                     },
                     "400": {
@@ -834,6 +1007,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/apperror.Response"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -843,9 +1022,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/indexer/module/v1/modules/{vmAddress}/{name}/stats": {
+        "/indexer/module/v1/modules/{vmAddress}/{name}/proposals": {
             "get": {
-                "description": "Retrieve a module stats",
+                "description": "Retrieve a module proposal",
                 "consumes": [
                     "application/json"
                 ],
@@ -855,7 +1034,7 @@ const docTemplate = `{
                 "tags": [
                     "Module"
                 ],
-                "summary": "Get module stats by module id",
+                "summary": "Get module proposal",
                 "parameters": [
                     {
                         "type": "string",
@@ -870,13 +1049,34 @@ const docTemplate = `{
                         "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "pagination.offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit for pagination",
+                        "name": "pagination.limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Count total",
+                        "name": "pagination.count_total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ModuleStatsResponse"
+                            "$ref": "#/definitions/dto.ModuleProposalsResponse"
                         }
                     },
                     "400": {
@@ -894,9 +1094,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/indexer/module/v1/modules/{vmAddress}/{name}/transactions": {
+        "/indexer/module/v1/modules/{vmAddress}/{name}/publish_info": {
             "get": {
-                "description": "Retrieve a module transaction",
+                "description": "Retrieve a module publish info",
                 "consumes": [
                     "application/json"
                 ],
@@ -906,7 +1106,7 @@ const docTemplate = `{
                 "tags": [
                     "Module"
                 ],
-                "summary": "Get module transaction",
+                "summary": "Get module publish info",
                 "parameters": [
                     {
                         "type": "string",
@@ -921,11 +1121,113 @@ const docTemplate = `{
                         "name": "name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ModulePublishInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/indexer/module/v1/modules/{vmAddress}/{name}/stats": {
+            "get": {
+                "description": "Retrieve a module stats",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Module"
+                ],
+                "summary": "Get module stats by module id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM address",
+                        "name": "vmAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Module name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ModuleStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/indexer/module/v1/modules/{vmAddress}/{name}/transactions": {
+            "get": {
+                "description": "Retrieve a module transaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Module"
+                ],
+                "summary": "Get module transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM address",
+                        "name": "vmAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Module name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
                         "name": "pagination.offset",
                         "in": "query"
                     },
@@ -966,6 +1268,159 @@ const docTemplate = `{
                 }
             }
         },
+        "/indexer/module/v1/modules/{vmAddress}/{name}/verification": {
+            "get": {
+                "description": "Retrieve the verified source files and ABI of the current module version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Module"
+                ],
+                "summary": "Get module verified source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM address",
+                        "name": "vmAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Module name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerifiedModuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/indexer/module/v1/verifications": {
+            "post": {
+                "description": "Queue a Move package (Move.toml and .move sources) to be compiled and verified against the modules published at the address. The versions published before the indexer recorded module digests cannot be matched, only their current version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Module"
+                ],
+                "summary": "Submit module verification",
+                "parameters": [
+                    {
+                        "description": "Move package",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ModuleVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/db.ModuleVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/indexer/module/v1/verifications/{id}": {
+            "get": {
+                "description": "Retrieve the status of a module verification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Module"
+                ],
+                "summary": "Get module verification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Verification id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.ModuleVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/indexer/nft/v1/collections": {
             "get": {
                 "description": "Retrieve a list of Nft collections with optional search and pagination",
@@ -1305,9 +1760,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/indexer/nft/v1/token/{nftAddress}/mint_info": {
+        "/indexer/nft/v1/collections/{collectionAddress}/transfer_volume": {
             "get": {
-                "description": "Retrieve mint information for a specific Nft by its address",
+                "description": "Retrieve the number of Nft transfers of a collection, and of Nfts transferred, per hour or day, oldest first. The range defaults to the last day for hourly volumes and the last 30 days for daily ones, and spans at most 31 and 366 days respectively",
                 "consumes": [
                     "application/json"
                 ],
@@ -1317,21 +1772,40 @@ const docTemplate = `{
                 "tags": [
                     "Nft"
                 ],
-                "summary": "Get Nft mint information",
+                "summary": "Get Nft collection transfer volume",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Nft address",
-                        "name": "nftAddress",
+                        "description": "Collection address of the Nft",
+                        "name": "collectionAddress",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "day",
+                        "description": "Interval of the volumes, hour or day",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.NftMintInfoResponse"
+                            "$ref": "#/definitions/dto.CollectionTransferVolumeResponse"
                         }
                     },
                     "400": {
@@ -1349,7 +1823,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/indexer/nft/v1/token/{nftAddress}/mutate_events": {
+        "/indexer/nft/v1/token/{nftAddress}/mint_info": {
+            "get": {
+                "description": "Retrieve mint information for a specific Nft by its address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Nft"
+                ],
+                "summary": "Get Nft mint information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nft address",
+                        "name": "nftAddress",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NftMintInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/indexer/nft/v1/token/{nftAddress}/mutate_events": {
             "get": {
                 "description": "Retrieve mutate events for a specific Nft by its address with pagination",
                 "consumes": [
@@ -1414,6 +1932,135 @@ const docTemplate = `{
                 }
             }
         },
+        "/indexer/nft/v1/token/{nftAddress}/owner/{height}": {
+            "get": {
+                "description": "Retrieve the owner of a specific Nft as of a block height, with the change that made it the owner, and whether the Nft is burned by then. Nfts not minted yet at the height are not found",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Nft"
+                ],
+                "summary": "Get Nft owner at height",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nft address",
+                        "name": "nftAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Block height",
+                        "name": "height",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NftOwnerAtHeightResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/indexer/nft/v1/token/{nftAddress}/owners": {
+            "get": {
+                "description": "Retrieve the ownership history of a specific Nft by its address with pagination: every change of its owner with the transaction and the time it happened at. The change minting the Nft has no previous owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Nft"
+                ],
+                "summary": "Get Nft owners",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nft address",
+                        "name": "nftAddress",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "pagination.offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit for pagination",
+                        "name": "pagination.limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Whether to count total owner changes",
+                        "name": "pagination.count_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Whether to reverse the order of changes",
+                        "name": "pagination.reverse",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NftOwnersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/indexer/nft/v1/token/{nftAddress}/txs": {
             "get": {
                 "description": "Retrieve transactions related to a specific Nft by its address with pagination",
@@ -1590,6 +2237,18 @@ const docTemplate = `{
                         "name": "search",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Keep the Nfts having an attribute of this trait type",
+                        "name": "trait_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep the Nfts whose trait_type attribute has this value",
+                        "name": "trait_value",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 0,
@@ -1851,6 +2510,122 @@ const docTemplate = `{
                 }
             }
         },
+        "/indexer/proposal/v1/proposals/{proposalId}/deposit_summary": {
+            "get": {
+                "description": "Retrieve the deposit of a proposal against its minimum deposit, and the seconds left until its deposit end time while it is in deposit period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Proposal"
+                ],
+                "summary": "Get deposit summary of a proposal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Proposal Id",
+                        "name": "proposalId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProposalDepositSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/indexer/proposal/v1/proposals/{proposalId}/deposits": {
+            "get": {
+                "description": "Retrieve the deposits made on a proposal with the hash and timestamp of their transactions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Proposal"
+                ],
+                "summary": "Get deposits of a proposal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "pagination.offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit for pagination",
+                        "name": "pagination.limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Reverse order for pagination",
+                        "name": "pagination.reverse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Count total number of deposits",
+                        "name": "pagination.count_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proposal Id",
+                        "name": "proposalId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProposalDepositsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/indexer/proposal/v1/proposals/{proposalId}/info": {
             "get": {
                 "description": "Retrieve proposal details",
@@ -1892,6 +2667,47 @@ const docTemplate = `{
                 }
             }
         },
+        "/indexer/proposal/v1/proposals/{proposalId}/tally_history": {
+            "get": {
+                "description": "Retrieve the snapshots of the live tally of a proposal taken during its voting period, with the quorum and threshold progress, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Proposal"
+                ],
+                "summary": "Get tally history of a proposal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Proposal Id",
+                        "name": "proposalId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProposalTallyHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/indexer/proposal/v1/proposals/{proposalId}/validator_votes": {
             "get": {
                 "description": "Retrieve list of all proposal votes by validators",
@@ -1957,7 +2773,141 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProposalValidatorVotesResponse"
+                            "$ref": "#/definitions/dto.ProposalValidatorVotesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/indexer/proposal/v1/proposals/{proposalId}/votes": {
+            "get": {
+                "description": "Retrieve list of all proposal votes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Proposal"
+                ],
+                "summary": "Get all proposal votes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "pagination.offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit for pagination",
+                        "name": "pagination.limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Reverse order for pagination",
+                        "name": "pagination.reverse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Count total number of transactions",
+                        "name": "pagination.count_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Proposal Id",
+                        "name": "proposalId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "Search proposal vote by voter address",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "Search proposal votes by vote option",
+                        "name": "answer",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProposalVotesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/indexer/search/v1": {
+            "get": {
+                "description": "Classify the query as a block height, transaction hash, account, validator, module, proposal id, collection or Nft address and return the matching entities. Other queries are matched as a prefix of validator monikers, collection names and proposal titles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search across entities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Maximum number of prefix matches per type",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SearchResponse"
                         }
                     },
                     "400": {
@@ -1975,64 +2925,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/indexer/proposal/v1/proposals/{proposalId}/votes": {
+        "/indexer/stats/v1/daily": {
             "get": {
-                "description": "Retrieve list of all proposal votes",
+                "description": "Retrieve the daily rollups of the chain activity, oldest first. The range defaults to the last 30 days and spans at most 366 days",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Proposal"
+                    "Stats"
                 ],
-                "summary": "Get all proposal votes",
+                "summary": "Get daily chain stats",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "pagination.offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Limit for pagination",
-                        "name": "pagination.limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "default": false,
-                        "description": "Reverse order for pagination",
-                        "name": "pagination.reverse",
+                        "type": "string",
+                        "description": "Start of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "default": false,
-                        "description": "Count total number of transactions",
-                        "name": "pagination.count_total",
+                        "type": "string",
+                        "description": "End of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp",
+                        "name": "to",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChainStatsResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Proposal Id",
-                        "name": "proposalId",
-                        "in": "path",
-                        "required": true
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/indexer/stats/v1/hourly": {
+            "get": {
+                "description": "Retrieve the hourly rollups of the chain activity, oldest first. The range defaults to the last day and spans at most 31 days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Get hourly chain stats",
+                "parameters": [
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "Search proposal vote by voter address",
-                        "name": "search",
+                        "description": "Start of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "Search proposal votes by vote option",
-                        "name": "answer",
+                        "description": "End of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -2040,7 +2999,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProposalVotesResponse"
+                            "$ref": "#/definitions/dto.ChainStatsResponse"
                         }
                     },
                     "400": {
@@ -2500,6 +3459,75 @@ const docTemplate = `{
                 }
             }
         },
+        "/indexer/validator/v1/validators/{operatorAddr}/downtime_episodes": {
+            "get": {
+                "description": "Retrieves the missed-block streaks and missed-block rates detected for a validator, with their first and last missed blocks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validator"
+                ],
+                "summary": "Get validator downtime episodes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "pagination.offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit for pagination",
+                        "name": "pagination.limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Reverse order for pagination",
+                        "name": "pagination.reverse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Count total number of episodes",
+                        "name": "pagination.count_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Validator operator address",
+                        "name": "operatorAddr",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidatorDowntimeEpisodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/indexer/validator/v1/validators/{operatorAddr}/historical_powers": {
             "get": {
                 "description": "Retrieves historical powers of a validator to be rendered",
@@ -2651,6 +3679,75 @@ const docTemplate = `{
                 }
             }
         },
+        "/indexer/validator/v1/validators/{operatorAddr}/slash_events": {
+            "get": {
+                "description": "Retrieves the slashing history of a validator: slashes with their reason, power, burned coins and infraction height, jails and unjails",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validator"
+                ],
+                "summary": "Get validator slash events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "pagination.offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit for pagination",
+                        "name": "pagination.limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Reverse order for pagination",
+                        "name": "pagination.reverse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Count total number of events",
+                        "name": "pagination.count_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Validator operator address",
+                        "name": "operatorAddr",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidatorSlashEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/indexer/validator/v1/validators/{operatorAddr}/uptime": {
             "get": {
                 "description": "Get validator uptime from the operator address",
@@ -2816,17 +3913,98 @@ const docTemplate = `{
                 "address": {
                     "type": "string"
                 },
-                "name": {
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "vm_address_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ModuleVerification": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "compiler_version": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "package_name": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccountDepositedProposal": {
+            "type": "object",
+            "properties": {
+                "deposit_end_time": {
+                    "type": "string"
+                },
+                "deposited": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Coin"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_emergency": {
+                    "type": "boolean"
+                },
+                "is_expedited": {
+                    "type": "boolean"
+                },
+                "last_deposit_timestamp": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 },
-                "type": {
+                "title": {
                     "type": "string"
                 },
-                "vm_address_id": {
+                "voting_end_time": {
                     "type": "string"
                 }
             }
         },
+        "dto.AccountDepositedProposalsResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationResponse"
+                },
+                "proposals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AccountDepositedProposal"
+                    }
+                }
+            }
+        },
         "dto.AccountProposal": {
             "type": "object",
             "properties": {
@@ -2876,6 +4054,41 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.AccountSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "collections_held": {
+                    "type": "integer"
+                },
+                "first_seen_height": {
+                    "type": "integer"
+                },
+                "first_seen_timestamp": {
+                    "type": "string"
+                },
+                "last_active_height": {
+                    "type": "integer"
+                },
+                "last_active_timestamp": {
+                    "type": "string"
+                },
+                "nfts_held": {
+                    "type": "integer"
+                },
+                "proposals_voted": {
+                    "type": "integer"
+                },
+                "signer_tx_count": {
+                    "type": "integer"
+                },
+                "tx_category_counts": {
+                    "$ref": "#/definitions/dto.AccountTxCategoryCounts"
+                },
+                "tx_count": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.AccountTx": {
             "type": "object",
             "properties": {
@@ -2920,6 +4133,26 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.AccountTxCategoryCounts": {
+            "type": "object",
+            "properties": {
+                "ibc": {
+                    "type": "integer"
+                },
+                "move_execute": {
+                    "type": "integer"
+                },
+                "move_publish": {
+                    "type": "integer"
+                },
+                "nft": {
+                    "type": "integer"
+                },
+                "send": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.AccountTxsResponse": {
             "type": "object",
             "properties": {
@@ -2934,6 +4167,20 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.AccountsResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Account"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationResponse"
+                }
+            }
+        },
         "dto.AllValidatorsResponse": {
             "type": "object",
             "properties": {
@@ -3126,6 +4373,61 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ChainStat": {
+            "type": "object",
+            "properties": {
+                "active_accounts": {
+                    "type": "integer"
+                },
+                "avg_block_time": {
+                    "type": "number"
+                },
+                "block_count": {
+                    "type": "integer"
+                },
+                "failed_tx_count": {
+                    "type": "integer"
+                },
+                "fees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Coin"
+                    }
+                },
+                "gas_used": {
+                    "type": "integer"
+                },
+                "module_calls": {
+                    "type": "integer"
+                },
+                "new_accounts": {
+                    "type": "integer"
+                },
+                "nft_mints": {
+                    "type": "integer"
+                },
+                "success_tx_count": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "tx_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ChainStatsResponse": {
+            "type": "object",
+            "properties": {
+                "stats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ChainStat"
+                    }
+                }
+            }
+        },
         "dto.Coin": {
             "type": "object",
             "properties": {
@@ -3219,6 +4521,31 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.CollectionTransferVolume": {
+            "type": "object",
+            "properties": {
+                "nft_count": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "transfer_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CollectionTransferVolumeResponse": {
+            "type": "object",
+            "properties": {
+                "transfer_volume": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CollectionTransferVolume"
+                    }
+                }
+            }
+        },
         "dto.Event": {
             "type": "object",
             "properties": {
@@ -3264,6 +4591,30 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.FunctionCallsWindow": {
+            "type": "object",
+            "properties": {
+                "call_count": {
+                    "type": "integer"
+                },
+                "failure_count": {
+                    "type": "integer"
+                },
+                "failure_rate": {
+                    "type": "number"
+                },
+                "gas_used": {
+                    "description": "Gas of the transactions making the calls, counted once for each call of a transaction",
+                    "type": "integer"
+                },
+                "unique_callers": {
+                    "type": "integer"
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.Log": {
             "type": "object",
             "properties": {
@@ -3289,6 +4640,31 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ModuleFunctionResponse": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FunctionCallsWindow"
+                    }
+                },
+                "function_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ModuleFunctionsResponse": {
+            "type": "object",
+            "properties": {
+                "functions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ModuleFunctionResponse"
+                    }
+                }
+            }
+        },
         "dto.ModuleHistoriesResponse": {
             "type": "object",
             "properties": {
@@ -3306,9 +4682,15 @@ const docTemplate = `{
         "dto.ModuleHistoryResponse": {
             "type": "object",
             "properties": {
+                "digest": {
+                    "type": "string"
+                },
                 "height": {
                     "type": "integer"
                 },
+                "is_verified": {
+                    "type": "boolean"
+                },
                 "previous_policy": {
                     "type": "string"
                 },
@@ -3418,9 +4800,29 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ModuleSourceFile": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.ModuleStatsResponse": {
             "type": "object",
             "properties": {
+                "calls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FunctionCallsWindow"
+                    }
+                },
+                "total_entry_executed": {
+                    "type": "integer"
+                },
                 "total_histories": {
                     "type": "integer"
                 },
@@ -3493,6 +4895,20 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ModuleVerificationRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ModuleSourceFile"
+                    }
+                }
+            }
+        },
         "dto.ModulesResponse": {
             "type": "object",
             "properties": {
@@ -3527,6 +4943,17 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.NftAttribute": {
+            "type": "object",
+            "properties": {
+                "trait_type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.NftByAddressNft": {
             "type": "object",
             "properties": {
@@ -3564,6 +4991,9 @@ const docTemplate = `{
                 "collection_name": {
                     "type": "string"
                 },
+                "metadata": {
+                    "$ref": "#/definitions/dto.NftMetadata"
+                },
                 "nft": {
                     "$ref": "#/definitions/dto.NftByAddressNft"
                 },
@@ -3584,43 +5014,97 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
-                "name": {
+                "name": {
+                    "type": "string"
+                },
+                "nft": {
+                    "$ref": "#/definitions/dto.NftCollectionCollectionNft"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NftCollectionCollectionNft": {
+            "type": "object",
+            "properties": {
+                "length": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.NftCollectionResponse": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "$ref": "#/definitions/dto.NftCollectionCollection"
+                },
+                "object_addr": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NftCollectionsResponse": {
+            "type": "object",
+            "properties": {
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NftCollectionResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationResponse"
+                }
+            }
+        },
+        "dto.NftMetadata": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NftAttribute"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image": {
                     "type": "string"
                 },
-                "nft": {
-                    "$ref": "#/definitions/dto.NftCollectionCollectionNft"
+                "name": {
+                    "type": "string"
                 },
-                "uri": {
+                "thumbnail_url": {
                     "type": "string"
                 }
             }
         },
-        "dto.NftCollectionCollectionNft": {
+        "dto.NftMintInfoResponse": {
             "type": "object",
             "properties": {
-                "length": {
+                "height": {
                     "type": "integer"
-                }
-            }
-        },
-        "dto.NftCollectionResponse": {
-            "type": "object",
-            "properties": {
-                "collection": {
-                    "$ref": "#/definitions/dto.NftCollectionCollection"
                 },
-                "object_addr": {
+                "minter": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "txhash": {
                     "type": "string"
                 }
             }
         },
-        "dto.NftCollectionsResponse": {
+        "dto.NftMutateEventsResponse": {
             "type": "object",
             "properties": {
-                "collections": {
+                "nft_mutate_events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.NftCollectionResponse"
+                        "$ref": "#/definitions/dto.MutateEventModel"
                     }
                 },
                 "pagination": {
@@ -3628,16 +5112,19 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.NftMintInfoResponse": {
+        "dto.NftOwner": {
             "type": "object",
             "properties": {
+                "from": {
+                    "type": "string"
+                },
                 "height": {
                     "type": "integer"
                 },
-                "minter": {
+                "timestamp": {
                     "type": "string"
                 },
-                "timestamp": {
+                "to": {
                     "type": "string"
                 },
                 "txhash": {
@@ -3645,13 +5132,30 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.NftMutateEventsResponse": {
+        "dto.NftOwnerAtHeightResponse": {
             "type": "object",
             "properties": {
-                "nft_mutate_events": {
+                "height": {
+                    "type": "integer"
+                },
+                "is_burned": {
+                    "type": "boolean"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "since": {
+                    "$ref": "#/definitions/dto.NftOwner"
+                }
+            }
+        },
+        "dto.NftOwnersResponse": {
+            "type": "object",
+            "properties": {
+                "nft_owners": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.MutateEventModel"
+                        "$ref": "#/definitions/dto.NftOwner"
                     }
                 },
                 "pagination": {
@@ -3797,6 +5301,55 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ProposalDepositSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "deposit_end_time": {
+                    "type": "string"
+                },
+                "min_deposit": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Coin"
+                    }
+                },
+                "missing_deposit": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Coin"
+                    }
+                },
+                "progress": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "time_left": {
+                    "type": "integer"
+                },
+                "total_deposit": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Coin"
+                    }
+                }
+            }
+        },
+        "dto.ProposalDepositsResponse": {
+            "type": "object",
+            "properties": {
+                "deposits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProposalDeposit"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationResponse"
+                }
+            }
+        },
         "dto.ProposalInfo": {
             "type": "object",
             "properties": {
@@ -3944,6 +5497,58 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ProposalTallyHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "tally_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProposalTallySnapshotModel"
+                    }
+                }
+            }
+        },
+        "dto.ProposalTallySnapshotModel": {
+            "type": "object",
+            "properties": {
+                "abstain": {
+                    "type": "integer"
+                },
+                "no": {
+                    "type": "integer"
+                },
+                "no_with_veto": {
+                    "type": "integer"
+                },
+                "quorum": {
+                    "type": "number"
+                },
+                "quorum_progress": {
+                    "type": "number"
+                },
+                "tally_height": {
+                    "type": "integer"
+                },
+                "threshold": {
+                    "type": "number"
+                },
+                "threshold_progress": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_staking_power": {
+                    "type": "integer"
+                },
+                "total_vesting_power": {
+                    "type": "integer"
+                },
+                "yes": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.ProposalValidatorAnswerCounts": {
             "type": "object",
             "properties": {
@@ -4075,6 +5680,67 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SearchResult"
+                    }
+                }
+            }
+        },
+        "dto.SearchResult": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "description": "Collection is the collection address of a matched Nft",
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/dto.SearchResultType"
+                }
+            }
+        },
+        "dto.SearchResultType": {
+            "type": "string",
+            "enum": [
+                "block",
+                "tx",
+                "account",
+                "validator",
+                "module",
+                "proposal",
+                "collection",
+                "nft"
+            ],
+            "x-enum-varnames": [
+                "SearchResultTypeBlock",
+                "SearchResultTypeTx",
+                "SearchResultTypeAccount",
+                "SearchResultTypeValidator",
+                "SearchResultTypeModule",
+                "SearchResultTypeProposal",
+                "SearchResultTypeCollection",
+                "SearchResultTypeNft"
+            ]
+        },
         "dto.SignerInfo": {
             "type": "object",
             "properties": {
@@ -4306,6 +5972,49 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ValidatorDowntimeEpisodeModel": {
+            "type": "object",
+            "properties": {
+                "end_height": {
+                    "type": "integer"
+                },
+                "end_timestamp": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_resolved": {
+                    "type": "boolean"
+                },
+                "missed_count": {
+                    "type": "integer"
+                },
+                "start_height": {
+                    "type": "integer"
+                },
+                "start_timestamp": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ValidatorDowntimeEpisodesResponse": {
+            "type": "object",
+            "properties": {
+                "downtime_episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ValidatorDowntimeEpisodeModel"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationResponse"
+                }
+            }
+        },
         "dto.ValidatorHistoricalPowerModel": {
             "type": "object",
             "properties": {
@@ -4432,12 +6141,38 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ValidatorSlashEventsResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationResponse"
+                },
+                "slash_events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ValidatorUptimeEventModel"
+                    }
+                }
+            }
+        },
         "dto.ValidatorUptimeEventModel": {
             "type": "object",
             "properties": {
+                "burned_coins": {
+                    "type": "object"
+                },
                 "height": {
                     "type": "integer"
                 },
+                "infraction_height": {
+                    "type": "integer"
+                },
+                "power": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
                 "timestamp": {
                     "type": "string"
                 },
@@ -4583,6 +6318,38 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "dto.VerifiedModuleResponse": {
+            "type": "object",
+            "properties": {
+                "abi": {
+                    "type": "object"
+                },
+                "compiler_version": {
+                    "type": "string"
+                },
+                "digest": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ModuleSourceFile"
+                    }
+                },
+                "module_id": {
+                    "type": "string"
+                },
+                "package_name": {
+                    "type": "string"
+                },
+                "verification_id": {
+                    "type": "integer"
+                },
+                "verified_at": {
+                    "type": "string"
+                }
+            }
         }
     },
     "tags": [
@@ -3,8 +3,15 @@ package dto
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
 )
 
+type AccountsResponse struct {
+	Accounts   []db.Account       `json:"accounts"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
 type AccountTxModel struct {
 	Height        int64           `json:"height"`
 	Timestamp     string          `json:"timestamp"`
@@ -60,3 +67,60 @@ type AccountProposalsResponse struct {
 	Proposals  []AccountProposal  `json:"proposals"`
 	Pagination PaginationResponse `json:"pagination"`
 }
+
+type AccountDepositedProposal struct {
+	ID                   int64      `json:"id"`
+	Title                string     `json:"title"`
+	Status               string     `json:"status"`
+	IsEmergency          bool       `json:"is_emergency"`
+	IsExpedited          bool       `json:"is_expedited"`
+	DepositEndTime       time.Time  `json:"deposit_end_time"`
+	VotingEndTime        *time.Time `json:"voting_end_time"`
+	Deposited            Coins      `json:"deposited"`
+	LastDepositTimestamp *time.Time `json:"last_deposit_timestamp"`
+}
+
+type AccountDepositedProposalsResponse struct {
+	Proposals  []AccountDepositedProposal `json:"proposals"`
+	Pagination PaginationResponse         `json:"pagination"`
+}
+
+type AccountDepositedProposalModel struct {
+	ID                   int64           `gorm:"column:id"`
+	Title                string          `gorm:"column:title"`
+	Status               string          `gorm:"column:status"`
+	IsEmergency          bool            `gorm:"column:is_emergency"`
+	IsExpedited          bool            `gorm:"column:is_expedited"`
+	DepositEndTime       time.Time       `gorm:"column:deposit_end_time"`
+	VotingEndTime        *time.Time      `gorm:"column:voting_end_time"`
+	Amounts              json.RawMessage `gorm:"column:amounts"`
+	LastDepositTimestamp *time.Time      `gorm:"column:last_deposit_timestamp"`
+}
+
+// /indexer/account/v1/{accountAddress}/summary
+
+type AccountSummaryResponse struct {
+	FirstSeenHeight     *int64                  `json:"first_seen_height"`
+	FirstSeenTimestamp  *time.Time              `json:"first_seen_timestamp"`
+	LastActiveHeight    *int64                  `json:"last_active_height"`
+	LastActiveTimestamp *time.Time              `json:"last_active_timestamp"`
+	TxCount             int64                   `json:"tx_count"`
+	SignerTxCount       int64                   `json:"signer_tx_count"`
+	TxCategoryCounts    AccountTxCategoryCounts `json:"tx_category_counts"`
+	CollectionsHeld     int64                   `json:"collections_held"`
+	NftsHeld            int64                   `json:"nfts_held"`
+	ProposalsVoted      int64                   `json:"proposals_voted"`
+}
+
+type AccountTxCategoryCounts struct {
+	Send        int64 `json:"send"`
+	Ibc         int64 `json:"ibc"`
+	MoveExecute int64 `json:"move_execute"`
+	MovePublish int64 `json:"move_publish"`
+	Nft         int64 `json:"nft"`
+}
+
+type AccountNftHoldingsModel struct {
+	CollectionsHeld int64 `gorm:"column:collections_held"`
+	NftsHeld        int64 `gorm:"column:nfts_held"`
+}
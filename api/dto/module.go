@@ -2,6 +2,7 @@ package dto
 
 import (
 	"encoding/json"
+	"time"
 )
 
 // ModuleResponse represents the response for a module
@@ -28,6 +29,8 @@ type ModuleHistoryResponse struct {
 	UpgradePolicy  string          `json:"upgrade_policy"`
 	Timestamp      string          `json:"timestamp"`
 	PreviousPolicy *string         `json:"previous_policy"`
+	Digest         *string         `json:"digest"`
+	IsVerified     bool            `json:"is_verified"`
 }
 
 // ModuleHistoriesResponse represents the response for a list of module histories
@@ -102,7 +105,74 @@ type ModuleTxsResponse struct {
 
 // ModuleStatsResponse represents the response for a module stats
 type ModuleStatsResponse struct {
-	TotalHistories int64  `json:"total_histories"`
-	TotalProposals *int64 `json:"total_proposals"`
-	TotalTxs       int64  `json:"total_txs"`
+	TotalHistories     int64                 `json:"total_histories"`
+	TotalProposals     *int64                `json:"total_proposals"`
+	TotalTxs           int64                 `json:"total_txs"`
+	TotalEntryExecuted int64                 `json:"total_entry_executed"`
+	Calls              []FunctionCallsWindow `json:"calls" gorm:"-"`
+}
+
+// FunctionCallStatsModel represents the entry function calls of a module since a day
+type FunctionCallStatsModel struct {
+	FunctionName  string `json:"function_name"`
+	CallCount     int64  `json:"call_count"`
+	FailureCount  int64  `json:"failure_count"`
+	GasUsed       int64  `json:"gas_used"`
+	UniqueCallers int64  `json:"unique_callers"`
+}
+
+// FunctionCallsWindow represents the entry function calls over the last days of a window, by UTC day
+type FunctionCallsWindow struct {
+	Window       string  `json:"window"`
+	CallCount    int64   `json:"call_count"`
+	FailureCount int64   `json:"failure_count"`
+	FailureRate  float64 `json:"failure_rate"`
+	// Gas of the transactions making the calls, counted once for each call of a transaction
+	GasUsed       int64 `json:"gas_used"`
+	UniqueCallers int64 `json:"unique_callers"`
+}
+
+// ModuleFunctionResponse represents the call statistics of a module entry function
+type ModuleFunctionResponse struct {
+	FunctionName string                `json:"function_name"`
+	Calls        []FunctionCallsWindow `json:"calls"`
+}
+
+// ModuleFunctionsResponse represents the response for the entry functions of a module
+type ModuleFunctionsResponse struct {
+	Functions []ModuleFunctionResponse `json:"functions"`
+}
+
+// ModuleSourceFile represents a source file of a Move package
+type ModuleSourceFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// ModuleVerificationRequest represents the request to verify a Move package published at an address
+type ModuleVerificationRequest struct {
+	Address string             `json:"address"`
+	Files   []ModuleSourceFile `json:"files"`
+}
+
+type VerifiedModuleModel struct {
+	ModuleID        string          `json:"module_id"`
+	Digest          string          `json:"digest"`
+	VerificationID  int64           `json:"verification_id"`
+	PackageName     string          `json:"package_name"`
+	CompilerVersion string          `json:"compiler_version"`
+	Abi             json.RawMessage `json:"abi" swaggertype:"object"`
+	VerifiedAt      time.Time       `json:"verified_at"`
+}
+
+// VerifiedModuleResponse represents the response for a verified module version with its package sources
+type VerifiedModuleResponse struct {
+	ModuleID        string             `json:"module_id"`
+	Digest          string             `json:"digest"`
+	VerificationID  int64              `json:"verification_id"`
+	PackageName     string             `json:"package_name"`
+	CompilerVersion string             `json:"compiler_version"`
+	Abi             json.RawMessage    `json:"abi" swaggertype:"object"`
+	VerifiedAt      time.Time          `json:"verified_at"`
+	Files           []ModuleSourceFile `json:"files"`
 }
@@ -1,6 +1,9 @@
 package dto
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 type NftCollectionCollectionNft struct {
 	Length int64 `json:"length"`
@@ -34,6 +37,13 @@ type NftByAddressModel struct {
 	ID             string `json:"id"`
 	Collection     string `json:"collection"`
 	CollectionName string `json:"collection_name"`
+
+	// off-chain metadata, null until it is fetched
+	MetadataName        *string         `json:"metadata_name"`
+	MetadataDescription *string         `json:"metadata_description"`
+	MetadataImage       *string         `json:"metadata_image"`
+	ThumbnailURL        *string         `json:"thumbnail_url"`
+	Attributes          json.RawMessage `json:"attributes" swaggertype:"object"`
 }
 
 type NftByAddressNftCollection struct {
@@ -48,12 +58,27 @@ type NftByAddressNft struct {
 	IsBurned    bool                      `json:"is_burned"`
 }
 
+type NftAttribute struct {
+	TraitType string `json:"trait_type"`
+	Value     string `json:"value"`
+}
+
+// NftMetadata is the off-chain metadata resolved from the URI of an Nft
+type NftMetadata struct {
+	Name         string         `json:"name"`
+	Description  string         `json:"description"`
+	Image        string         `json:"image"`
+	ThumbnailURL string         `json:"thumbnail_url"`
+	Attributes   []NftAttribute `json:"attributes"`
+}
+
 type NftByAddressResponse struct {
 	ObjectAddr     string          `json:"object_addr"`
 	CollectionAddr string          `json:"collection_addr"`
 	CollectionName string          `json:"collection_name"`
 	OwnerAddr      string          `json:"owner_addr"`
 	Nft            NftByAddressNft `json:"nft"`
+	Metadata       *NftMetadata    `json:"metadata"`
 }
 
 type NftsByAddressResponse struct {
@@ -110,6 +135,46 @@ type NftTxsResponse struct {
 	Pagination PaginationResponse `json:"pagination"`
 }
 
+type NftOwnerModel struct {
+	From      *string `json:"from"`
+	To        string  `json:"to"`
+	Hash      string  `json:"hash"`
+	Height    int64   `json:"height"`
+	Timestamp string  `json:"timestamp"`
+}
+
+// NftOwner is a change of the owner of an Nft, from no one when it is minted
+type NftOwner struct {
+	From      *string `json:"from"`
+	To        string  `json:"to"`
+	Height    int64   `json:"height"`
+	Timestamp string  `json:"timestamp"`
+	TxHash    string  `json:"txhash"`
+}
+
+type NftOwnersResponse struct {
+	NftOwners  []NftOwner         `json:"nft_owners"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+// NftOwnerAtHeightResponse is the owner of an Nft as of a height, with the change that made it the owner
+type NftOwnerAtHeightResponse struct {
+	Height   int64    `json:"height"`
+	Owner    string   `json:"owner"`
+	IsBurned bool     `json:"is_burned"`
+	Since    NftOwner `json:"since"`
+}
+
+type CollectionTransferVolume struct {
+	Timestamp     time.Time `json:"timestamp"`
+	TransferCount int64     `json:"transfer_count"`
+	NftCount      int64     `json:"nft_count"`
+}
+
+type CollectionTransferVolumeResponse struct {
+	TransferVolume []CollectionTransferVolume `json:"transfer_volume"`
+}
+
 type CollectionByAccountAddressModel struct {
 	Name        string `json:"name"`
 	URI         string `json:"uri"`
@@ -117,6 +117,32 @@ type ProposalDepositModel struct {
 	Timestamp time.Time       `gorm:"column:timestamp"`
 }
 
+// /indexer/proposal/v1/proposals/{proposalId}/deposits
+
+type ProposalDepositsResponse struct {
+	Deposits   []ProposalDeposit  `json:"deposits"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+// /indexer/proposal/v1/proposals/{proposalId}/deposit_summary
+
+type ProposalDepositSummaryResponse struct {
+	Status         string    `json:"status"`
+	MinDeposit     Coins     `json:"min_deposit"`
+	TotalDeposit   Coins     `json:"total_deposit"`
+	MissingDeposit Coins     `json:"missing_deposit"`
+	Progress       *float64  `json:"progress"`
+	DepositEndTime time.Time `json:"deposit_end_time"`
+	TimeLeft       int64     `json:"time_left"`
+}
+
+type ProposalDepositSummaryModel struct {
+	Status         string          `gorm:"column:status"`
+	MinDeposit     json.RawMessage `gorm:"column:min_deposit"`
+	TotalDeposit   json.RawMessage `gorm:"column:total_deposit"`
+	DepositEndTime time.Time       `gorm:"column:deposit_end_time"`
+}
+
 // /indexer/proposal/v1/proposals/{proposalId}/votes
 
 type ProposalVotesResponse struct {
@@ -210,3 +236,24 @@ type ProposalAnswerCountsModel struct {
 	IsVoteWeighted bool    `gorm:"column:is_vote_weighted"`
 	IsValidator    bool    `gorm:"column:is_validator"`
 }
+
+// /indexer/proposal/v1/proposals/{proposalId}/tally_history
+
+type ProposalTallyHistoryResponse struct {
+	TallyHistory []ProposalTallySnapshotModel `json:"tally_history"`
+}
+
+type ProposalTallySnapshotModel struct {
+	TallyHeight       int64     `json:"tally_height"`
+	Timestamp         time.Time `json:"timestamp"`
+	Yes               int64     `json:"yes"`
+	No                int64     `json:"no"`
+	Abstain           int64     `json:"abstain"`
+	NoWithVeto        int64     `json:"no_with_veto"`
+	TotalStakingPower int64     `json:"total_staking_power"`
+	TotalVestingPower int64     `json:"total_vesting_power"`
+	Quorum            float64   `json:"quorum"`
+	Threshold         float64   `json:"threshold"`
+	QuorumProgress    float64   `json:"quorum_progress"`
+	ThresholdProgress float64   `json:"threshold_progress"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+// SearchResultType is the kind of entity matched by a search
+type SearchResultType string
+
+const (
+	SearchResultTypeBlock      SearchResultType = "block"
+	SearchResultTypeTx         SearchResultType = "tx"
+	SearchResultTypeAccount    SearchResultType = "account"
+	SearchResultTypeValidator  SearchResultType = "validator"
+	SearchResultTypeModule     SearchResultType = "module"
+	SearchResultTypeProposal   SearchResultType = "proposal"
+	SearchResultTypeCollection SearchResultType = "collection"
+	SearchResultTypeNft        SearchResultType = "nft"
+)
+
+const (
+	SearchDefaultLimit = 5
+	SearchMaxLimit     = 20
+)
+
+// /indexer/search/v1
+
+type SearchResponse struct {
+	Items []SearchResult `json:"items"`
+}
+
+// SearchResult is a minimal summary of a matched entity, ID is what the entity's own endpoints are keyed by
+type SearchResult struct {
+	Type      SearchResultType `json:"type"`
+	ID        string           `json:"id"`
+	Name      string           `json:"name,omitempty"`
+	Height    *int64           `json:"height,omitempty"`
+	Timestamp string           `json:"timestamp,omitempty"`
+	Status    string           `json:"status,omitempty"`
+	// Collection is the collection address of a matched Nft
+	Collection string `json:"collection,omitempty"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// /indexer/stats/v1/hourly and /indexer/stats/v1/daily
+
+type ChainStatsResponse struct {
+	Stats []ChainStat `json:"stats"`
+}
+
+type ChainStat struct {
+	Timestamp      time.Time `json:"timestamp"`
+	BlockCount     int64     `json:"block_count"`
+	AvgBlockTime   float64   `json:"avg_block_time"`
+	TxCount        int64     `json:"tx_count"`
+	SuccessTxCount int64     `json:"success_tx_count"`
+	FailedTxCount  int64     `json:"failed_tx_count"`
+	ActiveAccounts int64     `json:"active_accounts"`
+	NewAccounts    int64     `json:"new_accounts"`
+	GasUsed        int64     `json:"gas_used"`
+	Fees           Coins     `json:"fees"`
+	ModuleCalls    int64     `json:"module_calls"`
+	NftMints       int64     `json:"nft_mints"`
+}
+
+type ChainStatModel struct {
+	Timestamp      time.Time       `gorm:"column:timestamp"`
+	BlockCount     int64           `gorm:"column:block_count"`
+	AvgBlockTime   float64         `gorm:"column:avg_block_time"`
+	TxCount        int64           `gorm:"column:tx_count"`
+	SuccessTxCount int64           `gorm:"column:success_tx_count"`
+	FailedTxCount  int64           `gorm:"column:failed_tx_count"`
+	ActiveAccounts int64           `gorm:"column:active_accounts"`
+	NewAccounts    int64           `gorm:"column:new_accounts"`
+	GasUsed        int64           `gorm:"column:gas_used"`
+	Fees           json.RawMessage `gorm:"column:fees"`
+	ModuleCalls    int64           `gorm:"column:module_calls"`
+	NftMints       int64           `gorm:"column:nft_mints"`
+}
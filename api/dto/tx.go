@@ -122,6 +122,13 @@ type TxModel struct {
 	Timestamp time.Time       `json:"timestamp"`
 }
 
+type TxSummaryModel struct {
+	Hash      string    `json:"hash"`
+	Success   bool      `json:"success"`
+	Height    int64     `json:"height"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 type TxCountResponse struct {
 	Count int64 `json:"count"`
 }
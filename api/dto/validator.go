@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/initia-labs/core-indexer/pkg/db"
@@ -73,9 +74,13 @@ type ValidatorUptimeResponse struct {
 }
 
 type ValidatorUptimeEventModel struct {
-	Height    int64     `json:"height"`
-	Timestamp time.Time `json:"timestamp"`
-	Type      string    `json:"type"`
+	Height           int64           `json:"height"`
+	Timestamp        time.Time       `json:"timestamp"`
+	Type             string          `json:"type"`
+	Reason           *string         `json:"reason"`
+	Power            *int64          `json:"power"`
+	BurnedCoins      json.RawMessage `json:"burned_coins" swaggertype:"object"`
+	InfractionHeight *int64          `json:"infraction_height"`
 }
 
 type ValidatorBlockVoteModel struct {
@@ -110,6 +115,31 @@ type MessageType struct {
 	Type string `json:"type"`
 }
 
+// /indexer/validator/v1/validators/{operatorAddr}/slash_events
+
+type ValidatorSlashEventsResponse struct {
+	SlashEvents []ValidatorUptimeEventModel `json:"slash_events"`
+	Pagination  PaginationResponse          `json:"pagination"`
+}
+
+// /indexer/validator/v1/validators/{operatorAddr}/downtime_episodes
+
+type ValidatorDowntimeEpisodesResponse struct {
+	DowntimeEpisodes []ValidatorDowntimeEpisodeModel `json:"downtime_episodes"`
+	Pagination       PaginationResponse              `json:"pagination"`
+}
+
+type ValidatorDowntimeEpisodeModel struct {
+	ID             int64      `json:"id"`
+	Type           string     `json:"type"`
+	StartHeight    int64      `json:"start_height"`
+	StartTimestamp *time.Time `json:"start_timestamp"`
+	EndHeight      int64      `json:"end_height"`
+	EndTimestamp   *time.Time `json:"end_timestamp"`
+	MissedCount    int64      `json:"missed_count"`
+	IsResolved     bool       `json:"is_resolved"`
+}
+
 // /indexer/validator/v1/validators/{operatorAddr}/proposed-blocks
 
 type ValidatorProposedBlocksResponse struct {
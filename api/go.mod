@@ -3,6 +3,7 @@ module github.com/initia-labs/core-indexer/api
 go 1.25.8
 
 require (
+	github.com/cosmos/cosmos-sdk v0.50.14
 	github.com/gofiber/fiber/v2 v2.52.12
 	github.com/gofiber/swagger v0.1.14
 	github.com/initia-labs/core-indexer/pkg v0.0.0
@@ -99,7 +100,6 @@ require (
 	github.com/cosmos/btcutil v1.0.5 // indirect
 	github.com/cosmos/cosmos-db v1.1.1 // indirect
 	github.com/cosmos/cosmos-proto v1.0.0-beta.5 // indirect
-	github.com/cosmos/cosmos-sdk v0.50.14 // indirect
 	github.com/cosmos/go-bip39 v1.0.0 // indirect
 	github.com/cosmos/gogogateway v1.2.0 // indirect
 	github.com/cosmos/gogoproto v1.7.0 // indirect
@@ -20,6 +20,36 @@ func NewAccountHandler(service services.AccountService) *AccountHandler {
 	}
 }
 
+// GetAccounts godoc
+//
+//	@Summary		Get accounts
+//	@Description	Retrieve a list of accounts, optionally filtered by account type
+//	@Tags			Account
+//	@Accept			json
+//	@Produce		json
+//	@Param			pagination.offset		query		integer	false	"Offset for pagination"						default(0)
+//	@Param			pagination.limit		query		integer	false	"Limit for pagination"						default(10)
+//	@Param			pagination.count_total	query		boolean	false	"Whether to count total accounts"			default(false)
+//	@Param			pagination.reverse		query		boolean	false	"Whether to reverse the order of accounts"	default(true)
+//	@Param			type					query		string	false	"Filter accounts by type, e.g. 'BaseAccount', 'ModuleAccount' or 'ContractAccount'"
+//	@Success		200						{object}	dto.AccountsResponse
+//	@Failure		400						{object}	apperror.Response
+//	@Failure		500						{object}	apperror.Response
+//	@Router			/indexer/account/v1/accounts [get]
+func (h *AccountHandler) GetAccounts(c *fiber.Ctx) error {
+	pagination, err := dto.PaginationFromQuery(c)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	response, err := h.service.GetAccounts(*pagination, c.Query("type"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
+
 // GetAccountByAccountAddress godoc
 //
 //	@Summary		Get account by address
@@ -81,6 +111,67 @@ func (h *AccountHandler) GetAccountProposals(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+// GetAccountDepositedProposals godoc
+//
+//	@Summary		Get account deposited proposals
+//	@Description	Retrieve the proposals an account deposited on with the total it deposited on each
+//	@Tags			Account
+//	@Accept			json
+//	@Produce		json
+//	@Param			accountAddress			path		string	true	"Account address"
+//	@Param			pagination.offset		query		integer	false	"Offset for pagination"						default(0)
+//	@Param			pagination.limit		query		integer	false	"Limit for pagination"						default(10)
+//	@Param			pagination.count_total	query		boolean	false	"Whether to count total proposals"			default(false)
+//	@Param			pagination.reverse		query		boolean	false	"Whether to reverse the order of proposals"	default(true)
+//	@Success		200						{object}	dto.AccountDepositedProposalsResponse
+//	@Failure		400						{object}	apperror.Response
+//	@Failure		500						{object}	apperror.Response
+//	@Router			/indexer/account/v1/{accountAddress}/deposited_proposals [get]
+func (h *AccountHandler) GetAccountDepositedProposals(c *fiber.Ctx) error {
+	accountAddress, err := parser.AccAddressFromString(c.Params("accountAddress"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	pagination, err := dto.PaginationFromQuery(c)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	response, err := h.service.GetAccountDepositedProposals(*pagination, accountAddress.String())
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
+
+// GetAccountSummary godoc
+//
+//	@Summary		Get account summary
+//	@Description	Retrieve the activity summary of an account: first seen and last active blocks, transaction counts as signer, as participant and by category, Nfts and collections held and proposals voted on
+//	@Tags			Account
+//	@Accept			json
+//	@Produce		json
+//	@Param			accountAddress	path		string	true	"Account address"
+//	@Success		200				{object}	dto.AccountSummaryResponse
+//	@Failure		400				{object}	apperror.Response
+//	@Failure		500				{object}	apperror.Response
+//	@Router			/indexer/account/v1/{accountAddress}/summary [get]
+func (h *AccountHandler) GetAccountSummary(c *fiber.Ctx) error {
+	accountAddress, err := parser.AccAddressFromString(c.Params("accountAddress"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	response, err := h.service.GetAccountSummary(accountAddress.String(), parser.BytesToHexWithPrefix(accountAddress))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
+
 // GetAccountTxs godoc
 //
 //	@Summary		Get account transactions
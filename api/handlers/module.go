@@ -246,3 +246,31 @@ func (h *ModuleHandler) GetModuleStats(c *fiber.Ctx) error {
 
 	return c.JSON(response)
 }
+
+// GetModuleFunctions godoc
+//
+//	@Summary		Get module functions call stats
+//	@Description	Retrieve the call count, failure rate and unique callers of each entry function of a module over the last 1, 7 and 30 days
+//	@Tags			Module
+//	@Accept			json
+//	@Produce		json
+//	@Param			vmAddress	path		string	true	"VM address"
+//	@Param			name		path		string	true	"Module name"
+//	@Success		200			{object}	dto.ModuleFunctionsResponse
+//	@Failure		400			{object}	apperror.Response
+//	@Failure		500			{object}	apperror.Response
+//	@Router			/indexer/module/v1/modules/{vmAddress}/{name}/functions [get]
+func (h *ModuleHandler) GetModuleFunctions(c *fiber.Ctx) error {
+	vmAddress, err := parser.AccAddressFromString(c.Params("vmAddress"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+	name := c.Params("name")
+
+	response, err := h.service.GetModuleFunctions(parser.BytesToHexWithPrefix(vmAddress), name)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/initia-labs/core-indexer/pkg/parser"
+
+	"github.com/initia-labs/core-indexer/api/apperror"
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/services"
+)
+
+// ModuleVerificationHandler handles module verification HTTP requests
+type ModuleVerificationHandler struct {
+	service services.ModuleVerificationService
+}
+
+func NewModuleVerificationHandler(service services.ModuleVerificationService) *ModuleVerificationHandler {
+	return &ModuleVerificationHandler{
+		service: service,
+	}
+}
+
+// SubmitModuleVerification godoc
+//
+//	@Summary		Submit module verification
+//	@Description	Queue a Move package (Move.toml and .move sources) to be compiled and verified against the modules published at the address. The versions published before the indexer recorded module digests cannot be matched, only their current version
+//	@Tags			Module
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.ModuleVerificationRequest	true	"Move package"
+//	@Success		202		{object}	db.ModuleVerification
+//	@Failure		400		{object}	apperror.Response
+//	@Failure		500		{object}	apperror.Response
+//	@Router			/indexer/module/v1/verifications [post]
+func (h *ModuleVerificationHandler) SubmitModuleVerification(c *fiber.Ctx) error {
+	var request dto.ModuleVerificationRequest
+	if err := c.BodyParser(&request); err != nil {
+		return apperror.HandleErrorResponse(c, apperror.NewValidationError(apperror.ErrMsgVerificationBody))
+	}
+
+	response, err := h.service.SubmitModuleVerification(request)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response)
+}
+
+// GetModuleVerification godoc
+//
+//	@Summary		Get module verification
+//	@Description	Retrieve the status of a module verification
+//	@Tags			Module
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		integer	true	"Verification id"
+//	@Success		200	{object}	db.ModuleVerification
+//	@Failure		400	{object}	apperror.Response
+//	@Failure		404	{object}	apperror.Response
+//	@Failure		500	{object}	apperror.Response
+//	@Router			/indexer/module/v1/verifications/{id} [get]
+func (h *ModuleVerificationHandler) GetModuleVerification(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, apperror.NewValidationError(apperror.ErrMsgVerificationId))
+	}
+
+	response, err := h.service.GetModuleVerification(id)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
+
+// GetModuleVerifiedSource godoc
+//
+//	@Summary		Get module verified source
+//	@Description	Retrieve the verified source files and ABI of the current module version
+//	@Tags			Module
+//	@Accept			json
+//	@Produce		json
+//	@Param			vmAddress	path		string	true	"VM address"
+//	@Param			name		path		string	true	"Module name"
+//	@Success		200			{object}	dto.VerifiedModuleResponse
+//	@Failure		400			{object}	apperror.Response
+//	@Failure		404			{object}	apperror.Response
+//	@Failure		500			{object}	apperror.Response
+//	@Router			/indexer/module/v1/modules/{vmAddress}/{name}/verification [get]
+func (h *ModuleVerificationHandler) GetModuleVerifiedSource(c *fiber.Ctx) error {
+	vmAddress, err := parser.AccAddressFromString(c.Params("vmAddress"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+	name := c.Params("name")
+
+	response, err := h.service.GetVerifiedModule(parser.BytesToHexWithPrefix(vmAddress), name, nil)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
+
+// GetModuleHistoryVerifiedSource godoc
+//
+//	@Summary		Get module history verified source
+//	@Description	Retrieve the verified source files and ABI of the module version published at a height
+//	@Tags			Module
+//	@Accept			json
+//	@Produce		json
+//	@Param			vmAddress	path		string	true	"VM address"
+//	@Param			name		path		string	true	"Module name"
+//	@Param			height		path		integer	true	"Publish block height"
+//	@Success		200			{object}	dto.VerifiedModuleResponse
+//	@Failure		400			{object}	apperror.Response
+//	@Failure		404			{object}	apperror.Response
+//	@Failure		500			{object}	apperror.Response
+//	@Router			/indexer/module/v1/modules/{vmAddress}/{name}/histories/{height}/verification [get]
+func (h *ModuleVerificationHandler) GetModuleHistoryVerifiedSource(c *fiber.Ctx) error {
+	vmAddress, err := parser.AccAddressFromString(c.Params("vmAddress"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+	name := c.Params("name")
+
+	height, err := strconv.ParseInt(c.Params("height"), 10, 64)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, apperror.NewHeightInteger())
+	}
+
+	response, err := h.service.GetVerifiedModule(parser.BytesToHexWithPrefix(vmAddress), name, &height)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
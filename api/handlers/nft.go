@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/initia-labs/core-indexer/pkg/db"
 	"github.com/initia-labs/core-indexer/pkg/parser"
 
 	"github.com/initia-labs/core-indexer/api/apperror"
@@ -146,6 +149,50 @@ func (h *NftHandler) GetCollectionActivities(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+// GetCollectionTransferVolume godoc
+//
+//	@Summary		Get Nft collection transfer volume
+//	@Description	Retrieve the number of Nft transfers of a collection, and of Nfts transferred, per hour or day, oldest first. The range defaults to the last day for hourly volumes and the last 30 days for daily ones, and spans at most 31 and 366 days respectively
+//	@Tags			Nft
+//	@Accept			json
+//	@Produce		json
+//	@Param			collectionAddress	path		string	true	"Collection address of the Nft"
+//	@Param			interval			query		string	false	"Interval of the volumes, hour or day"									default(day)
+//	@Param			from				query		string	false	"Start of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp"
+//	@Param			to					query		string	false	"End of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp"
+//	@Success		200					{object}	dto.CollectionTransferVolumeResponse
+//	@Failure		400					{object}	apperror.Response
+//	@Failure		500					{object}	apperror.Response
+//	@Router			/indexer/nft/v1/collections/{collectionAddress}/transfer_volume [get]
+func (h *NftHandler) GetCollectionTransferVolume(c *fiber.Ctx) error {
+	collectionAddress, err := parser.AccAddressFromString(c.Params("collectionAddress"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	interval := c.Query("interval", db.ChainStatGranularityDay)
+	if interval != db.ChainStatGranularityHour && interval != db.ChainStatGranularityDay {
+		return apperror.HandleErrorResponse(c, apperror.NewValidationError(apperror.ErrMsgStatsInterval))
+	}
+
+	from, err := parseStatsTime(c.Query("from"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	to, err := parseStatsTime(c.Query("to"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	response, err := h.service.GetCollectionTransferVolume(parser.BytesToHexWithPrefix(collectionAddress), interval, from, to)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
+
 // GetCollectionCreator godoc
 //
 //	@Summary		Get Nft collection creator
@@ -246,6 +293,8 @@ func (h *NftHandler) GetNftByNftAddress(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			collectionAddress		path		string	true	"Collection address of the Nfts"
 //	@Param			search					query		string	false	"Search term for filtering Nfts"
+//	@Param			trait_type				query		string	false	"Keep the Nfts having an attribute of this trait type"
+//	@Param			trait_value				query		string	false	"Keep the Nfts whose trait_type attribute has this value"
 //	@Param			pagination.offset		query		integer	false	"Offset for pagination"			default(0)
 //	@Param			pagination.limit		query		integer	false	"Limit for pagination"			default(10)
 //	@Param			pagination.count_total	query		boolean	false	"Whether to count total Nfts"	default(false)
@@ -266,7 +315,7 @@ func (h *NftHandler) GetNftsByCollectionAddress(c *fiber.Ctx) error {
 		return apperror.HandleErrorResponse(c, err)
 	}
 
-	response, err := h.service.GetNftsByCollectionAddress(*pagination, parser.BytesToHexWithPrefix(collectionAddress), search)
+	response, err := h.service.GetNftsByCollectionAddress(*pagination, parser.BytesToHexWithPrefix(collectionAddress), search, c.Query("trait_type"), c.Query("trait_value"))
 	if err != nil {
 		return apperror.HandleErrorResponse(c, err)
 	}
@@ -414,3 +463,71 @@ func (h *NftHandler) GetNftTxs(c *fiber.Ctx) error {
 
 	return c.JSON(response)
 }
+
+// GetNftOwners godoc
+//
+//	@Summary		Get Nft owners
+//	@Description	Retrieve the ownership history of a specific Nft by its address with pagination: every change of its owner with the transaction and the time it happened at. The change minting the Nft has no previous owner
+//	@Tags			Nft
+//	@Accept			json
+//	@Produce		json
+//	@Param			nftAddress				path		string	true	"Nft address"
+//	@Param			pagination.offset		query		integer	false	"Offset for pagination"						default(0)
+//	@Param			pagination.limit		query		integer	false	"Limit for pagination"						default(10)
+//	@Param			pagination.count_total	query		boolean	false	"Whether to count total owner changes"		default(false)
+//	@Param			pagination.reverse		query		boolean	false	"Whether to reverse the order of changes"	default(true)
+//	@Success		200						{object}	dto.NftOwnersResponse
+//	@Failure		400						{object}	apperror.Response
+//	@Failure		500						{object}	apperror.Response
+//	@Router			/indexer/nft/v1/token/{nftAddress}/owners [get]
+func (h *NftHandler) GetNftOwners(c *fiber.Ctx) error {
+	nftAddress, err := parser.AccAddressFromString(c.Params("nftAddress"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	pagination, err := dto.PaginationFromQuery(c)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	response, err := h.service.GetNftOwners(*pagination, parser.BytesToHexWithPrefix(nftAddress))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
+
+// GetNftOwnerAtHeight godoc
+//
+//	@Summary		Get Nft owner at height
+//	@Description	Retrieve the owner of a specific Nft as of a block height, with the change that made it the owner, and whether the Nft is burned by then. Nfts not minted yet at the height are not found
+//	@Tags			Nft
+//	@Accept			json
+//	@Produce		json
+//	@Param			nftAddress	path		string	true	"Nft address"
+//	@Param			height		path		int		true	"Block height"
+//	@Success		200			{object}	dto.NftOwnerAtHeightResponse
+//	@Failure		400			{object}	apperror.Response
+//	@Failure		404			{object}	apperror.Response
+//	@Failure		500			{object}	apperror.Response
+//	@Router			/indexer/nft/v1/token/{nftAddress}/owner/{height} [get]
+func (h *NftHandler) GetNftOwnerAtHeight(c *fiber.Ctx) error {
+	nftAddress, err := parser.AccAddressFromString(c.Params("nftAddress"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	height, err := strconv.ParseInt(c.Params("height"), 10, 64)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, apperror.NewHeightInteger())
+	}
+
+	response, err := h.service.GetNftOwnerAtHeight(parser.BytesToHexWithPrefix(nftAddress), height)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
@@ -216,3 +216,88 @@ func (h *ProposalHandler) GetProposalAnswerCounts(c *fiber.Ctx) error {
 
 	return c.JSON(counts)
 }
+
+// GetProposalTallyHistory godoc
+//
+//	@Summary		Get tally history of a proposal
+//	@Description	Retrieve the snapshots of the live tally of a proposal taken during its voting period, with the quorum and threshold progress, oldest first
+//	@Tags			Proposal
+//	@Produce		json
+//	@Param			proposalId	path		string	true	"Proposal Id"
+//	@Success		200			{object}	dto.ProposalTallyHistoryResponse
+//	@Failure		400			{object}	apperror.Response
+//	@Failure		500			{object}	apperror.Response
+//	@Router			/indexer/proposal/v1/proposals/{proposalId}/tally_history [get]
+func (h *ProposalHandler) GetProposalTallyHistory(c *fiber.Ctx) error {
+	parsedId, err := strconv.ParseInt(c.Params("proposalId"), 10, 32)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, apperror.NewValidationError(apperror.ErrMsgProposalId))
+	}
+
+	history, err := h.service.GetProposalTallyHistory(int(parsedId))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(history)
+}
+
+// GetProposalDeposits godoc
+//
+//	@Summary		Get deposits of a proposal
+//	@Description	Retrieve the deposits made on a proposal with the hash and timestamp of their transactions
+//	@Tags			Proposal
+//	@Produce		json
+//	@Param			pagination.offset		query		integer	false	"Offset for pagination"					default(0)
+//	@Param			pagination.limit		query		integer	false	"Limit for pagination"					default(10)
+//	@Param			pagination.reverse		query		boolean	false	"Reverse order for pagination"			default(true)
+//	@Param			pagination.count_total	query		boolean	false	"Count total number of deposits"		default(false)
+//	@Param			proposalId				path		string	true	"Proposal Id"
+//	@Success		200						{object}	dto.ProposalDepositsResponse
+//	@Failure		400						{object}	apperror.Response
+//	@Failure		500						{object}	apperror.Response
+//	@Router			/indexer/proposal/v1/proposals/{proposalId}/deposits [get]
+func (h *ProposalHandler) GetProposalDeposits(c *fiber.Ctx) error {
+	pagination, err := dto.PaginationFromQuery(c)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	parsedId, err := strconv.ParseInt(c.Params("proposalId"), 10, 32)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, apperror.NewValidationError(apperror.ErrMsgProposalId))
+	}
+
+	deposits, err := h.service.GetProposalDeposits(*pagination, int(parsedId))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(deposits)
+}
+
+// GetProposalDepositSummary godoc
+//
+//	@Summary		Get deposit summary of a proposal
+//	@Description	Retrieve the deposit of a proposal against its minimum deposit, and the seconds left until its deposit end time while it is in deposit period
+//	@Tags			Proposal
+//	@Produce		json
+//	@Param			proposalId	path		string	true	"Proposal Id"
+//	@Success		200			{object}	dto.ProposalDepositSummaryResponse
+//	@Failure		400			{object}	apperror.Response
+//	@Failure		404			{object}	apperror.Response
+//	@Failure		500			{object}	apperror.Response
+//	@Router			/indexer/proposal/v1/proposals/{proposalId}/deposit_summary [get]
+func (h *ProposalHandler) GetProposalDepositSummary(c *fiber.Ctx) error {
+	parsedId, err := strconv.ParseInt(c.Params("proposalId"), 10, 32)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, apperror.NewValidationError(apperror.ErrMsgProposalId))
+	}
+
+	summary, err := h.service.GetProposalDepositSummary(int(parsedId))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(summary)
+}
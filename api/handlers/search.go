@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/initia-labs/core-indexer/api/apperror"
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/services"
+)
+
+// SearchHandler handles search HTTP requests
+type SearchHandler struct {
+	service services.SearchService
+}
+
+// NewSearchHandler creates a new instance of SearchHandler
+func NewSearchHandler(service services.SearchService) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+	}
+}
+
+// Search godoc
+//
+//	@Summary		Search across entities
+//	@Description	Classify the query as a block height, transaction hash, account, validator, module, proposal id, collection or Nft address and return the matching entities. Other queries are matched as a prefix of validator monikers, collection names and proposal titles
+//	@Tags			Search
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query		string	true	"Search query"
+//	@Param			limit	query		integer	false	"Maximum number of prefix matches per type"	default(5)
+//	@Success		200		{object}	dto.SearchResponse
+//	@Failure		400		{object}	apperror.Response
+//	@Failure		500		{object}	apperror.Response
+//	@Router			/indexer/search/v1 [get]
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return apperror.HandleErrorResponse(c, apperror.NewValidationError(apperror.ErrMsgSearchQuery))
+	}
+
+	limit := dto.SearchDefaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			return apperror.HandleErrorResponse(c, apperror.NewLimitInteger())
+		}
+		if limit < 1 || limit > dto.SearchMaxLimit {
+			return apperror.HandleErrorResponse(c, apperror.NewValidationError(apperror.ErrMsgSearchLimit))
+		}
+	}
+
+	response, err := h.service.Search(query, limit)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/initia-labs/core-indexer/api/apperror"
+	"github.com/initia-labs/core-indexer/api/services"
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+type StatsHandler struct {
+	service services.StatsService
+}
+
+func NewStatsHandler(service services.StatsService) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+	}
+}
+
+// GetHourlyChainStats godoc
+//
+//	@Summary		Get hourly chain stats
+//	@Description	Retrieve the hourly rollups of the chain activity, oldest first. The range defaults to the last day and spans at most 31 days
+//	@Tags			Stats
+//	@Produce		json
+//	@Param			from	query		string	false	"Start of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp"
+//	@Param			to		query		string	false	"End of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp"
+//	@Success		200		{object}	dto.ChainStatsResponse
+//	@Failure		400		{object}	apperror.Response
+//	@Failure		500		{object}	apperror.Response
+//	@Router			/indexer/stats/v1/hourly [get]
+func (h *StatsHandler) GetHourlyChainStats(c *fiber.Ctx) error {
+	return h.getChainStats(c, db.ChainStatGranularityHour)
+}
+
+// GetDailyChainStats godoc
+//
+//	@Summary		Get daily chain stats
+//	@Description	Retrieve the daily rollups of the chain activity, oldest first. The range defaults to the last 30 days and spans at most 366 days
+//	@Tags			Stats
+//	@Produce		json
+//	@Param			from	query		string	false	"Start of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp"
+//	@Param			to		query		string	false	"End of the range, as a date (YYYY-MM-DD) or an RFC3339 timestamp"
+//	@Success		200		{object}	dto.ChainStatsResponse
+//	@Failure		400		{object}	apperror.Response
+//	@Failure		500		{object}	apperror.Response
+//	@Router			/indexer/stats/v1/daily [get]
+func (h *StatsHandler) GetDailyChainStats(c *fiber.Ctx) error {
+	return h.getChainStats(c, db.ChainStatGranularityDay)
+}
+
+func (h *StatsHandler) getChainStats(c *fiber.Ctx, granularity string) error {
+	from, err := parseStatsTime(c.Query("from"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	to, err := parseStatsTime(c.Query("to"))
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	stats, err := h.service.GetChainStats(granularity, from, to)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(stats)
+}
+
+// parseStatsTime parses a date or an RFC3339 timestamp, returning nil for an empty value
+func parseStatsTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+
+	return nil, apperror.NewValidationError(apperror.ErrMsgStatsTime)
+}
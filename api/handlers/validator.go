@@ -184,6 +184,66 @@ func (h *ValidatorHandler) GetValidatorDelegationRelatedTxs(c *fiber.Ctx) error
 	return c.JSON(response)
 }
 
+// GetValidatorSlashEvents godoc
+//
+//	@Summary		Get validator slash events
+//	@Description	Retrieves the slashing history of a validator: slashes with their reason, power, burned coins and infraction height, jails and unjails
+//	@Tags			Validator
+//	@Produce		json
+//	@Param			pagination.offset		query		integer	false	"Offset for pagination"				default(0)
+//	@Param			pagination.limit		query		integer	false	"Limit for pagination"				default(10)
+//	@Param			pagination.reverse		query		boolean	false	"Reverse order for pagination"		default(false)
+//	@Param			pagination.count_total	query		boolean	false	"Count total number of events"		default(false)
+//	@Param			operatorAddr			path		string	true	"Validator operator address"
+//	@Success		200						{object}	dto.ValidatorSlashEventsResponse
+//	@Failure		400						{object}	apperror.Response
+//	@Failure		500						{object}	apperror.Response
+//	@Router			/indexer/validator/v1/validators/{operatorAddr}/slash_events [get]
+func (h *ValidatorHandler) GetValidatorSlashEvents(c *fiber.Ctx) error {
+	pagination, err := dto.PaginationFromQuery(c)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	addr := c.Params("operatorAddr")
+	response, err := h.service.GetValidatorSlashEvents(*pagination, addr)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
+
+// GetValidatorDowntimeEpisodes godoc
+//
+//	@Summary		Get validator downtime episodes
+//	@Description	Retrieves the missed-block streaks and missed-block rates detected for a validator, with their first and last missed blocks
+//	@Tags			Validator
+//	@Produce		json
+//	@Param			pagination.offset		query		integer	false	"Offset for pagination"				default(0)
+//	@Param			pagination.limit		query		integer	false	"Limit for pagination"				default(10)
+//	@Param			pagination.reverse		query		boolean	false	"Reverse order for pagination"		default(false)
+//	@Param			pagination.count_total	query		boolean	false	"Count total number of episodes"	default(false)
+//	@Param			operatorAddr			path		string	true	"Validator operator address"
+//	@Success		200						{object}	dto.ValidatorDowntimeEpisodesResponse
+//	@Failure		400						{object}	apperror.Response
+//	@Failure		500						{object}	apperror.Response
+//	@Router			/indexer/validator/v1/validators/{operatorAddr}/downtime_episodes [get]
+func (h *ValidatorHandler) GetValidatorDowntimeEpisodes(c *fiber.Ctx) error {
+	pagination, err := dto.PaginationFromQuery(c)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	addr := c.Params("operatorAddr")
+	response, err := h.service.GetValidatorDowntimeEpisodes(*pagination, addr)
+	if err != nil {
+		return apperror.HandleErrorResponse(c, err)
+	}
+
+	return c.JSON(response)
+}
+
 // GetValidatorProposedBlocks godoc
 //
 //	@Summary		Get validator proposed blocks
@@ -66,8 +66,8 @@ import (
 //	@tag.description	Validator related endpoints
 
 // initDatabase initializes and returns a database connection
-func initDatabase(cfg *config.Config) *gorm.DB {
-	dbClient, err := db.NewClient(cfg.Database.ConnectionString)
+func initDatabase(cfg *config.Config, connectionString string) *gorm.DB {
+	dbClient, err := db.NewClient(connectionString)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to connect to database")
 	}
@@ -143,12 +143,22 @@ func main() {
 	})
 
 	// Initialize database
-	dbClient := initDatabase(cfg)
+	dbClient := initDatabase(cfg, cfg.Database.ConnectionString)
 	sqlDB, err := dbClient.DB()
 	if err == nil {
 		defer sqlDB.Close()
 	}
 
+	// Initialize the writable database for module verification submissions, if configured
+	var verificationDBClient *gorm.DB
+	if cfg.Database.VerificationConnectionString != "" {
+		verificationDBClient = initDatabase(cfg, cfg.Database.VerificationConnectionString)
+		verificationSQLDB, err := verificationDBClient.DB()
+		if err == nil {
+			defer verificationSQLDB.Close()
+		}
+	}
+
 	// Initialize storage
 	buckets := initStorage(cfg)
 	defer func() {
@@ -208,7 +218,7 @@ func main() {
 	}
 
 	// Setup routes
-	routes.SetupRoutes(app, dbClient, buckets, cfg)
+	routes.SetupRoutes(app, dbClient, verificationDBClient, buckets, cfg)
 
 	// Start server
 	log.Info().Str("port", cfg.Server.Port).Msg("Starting server")
@@ -28,6 +28,45 @@ func NewAccountRepository(db *gorm.DB, countQueryTimeout time.Duration) *Account
 	}
 }
 
+func (r *AccountRepository) GetAccounts(pagination dto.PaginationQuery, accountType string) ([]db.Account, int64, error) {
+	record := make([]db.Account, 0)
+	total := int64(0)
+
+	query := r.db.Model(&db.Account{})
+	if accountType != "" {
+		query = query.Where("accounts.type = ?", accountType)
+	}
+
+	if err := query.
+		Order(clause.OrderByColumn{
+			Column: clause.Column{
+				Name: "accounts.address",
+			},
+			Desc: pagination.Reverse,
+		}).
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("GetAccounts: failed to fetch accounts")
+		return nil, 0, err
+	}
+
+	if pagination.CountTotal {
+		var err error
+		countQuery := r.db.Model(&db.Account{})
+		if accountType != "" {
+			countQuery = countQuery.Where("accounts.type = ?", accountType)
+		}
+		total, err = db.CountWithTimeout(countQuery, r.countQueryTimeout)
+		if err != nil {
+			logger.Get().Error().Err(err).Msg("GetAccounts: failed to count accounts")
+			return nil, 0, err
+		}
+	}
+
+	return record, total, nil
+}
+
 func (r *AccountRepository) GetAccountByAccountAddress(accountAddress string) (*db.Account, error) {
 	var record db.Account
 
@@ -84,6 +123,87 @@ func (r *AccountRepository) GetAccountProposals(pagination dto.PaginationQuery,
 	return record, total, nil
 }
 
+// GetAccountDepositedProposals retrieves the proposals an account deposited on, with the amounts of its deposits
+func (r *AccountRepository) GetAccountDepositedProposals(pagination dto.PaginationQuery, accountAddress string) ([]dto.AccountDepositedProposalModel, int64, error) {
+	record := make([]dto.AccountDepositedProposalModel, 0)
+	total := int64(0)
+
+	if err := r.db.Model(&db.ProposalDeposit{}).
+		Select(`
+			proposals.id,
+			proposals.title,
+			proposals.status,
+			proposals.is_emergency,
+			proposals.is_expedited,
+			proposals.deposit_end_time,
+			proposals.voting_end_time,
+			json_agg(proposal_deposits.amount) AS amounts,
+			MAX(blocks.timestamp) AS last_deposit_timestamp
+		`).
+		Joins("JOIN proposals ON proposals.id = proposal_deposits.proposal_id").
+		Joins("LEFT JOIN transactions ON proposal_deposits.transaction_id = transactions.id").
+		Joins("LEFT JOIN blocks ON transactions.block_height = blocks.height").
+		Where("proposal_deposits.depositor = ?", accountAddress).
+		Group("proposals.id").
+		Order(clause.OrderByColumn{
+			Column: clause.Column{
+				Name: "proposals.id",
+			},
+			Desc: pagination.Reverse,
+		}).
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("GetAccountDepositedProposals: failed to fetch proposals")
+		return nil, 0, err
+	}
+
+	if pagination.CountTotal {
+		var err error
+		total, err = db.CountWithTimeout(r.db.Model(&db.ProposalDeposit{}).Distinct("proposal_id").Where("depositor = ?", accountAddress), r.countQueryTimeout)
+		if err != nil {
+			logger.Get().Error().Err(err).Msg("GetAccountDepositedProposals: failed to count proposals")
+			return nil, 0, err
+		}
+	}
+
+	return record, total, nil
+}
+
+// GetAccountStat retrieves the activity summary of an account, nil when the account has no transaction yet
+func (r *AccountRepository) GetAccountStat(accountAddress string) (*db.AccountStat, error) {
+	stats := make([]db.AccountStat, 0, 1)
+
+	if err := r.db.Model(&db.AccountStat{}).
+		Where("account_id = ?", accountAddress).
+		Limit(1).
+		Find(&stats).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("GetAccountStat: failed to fetch account stats")
+		return nil, err
+	}
+
+	if len(stats) == 0 {
+		return nil, nil
+	}
+
+	return &stats[0], nil
+}
+
+// GetAccountNftHoldings counts the Nfts an account holds and the collections they belong to
+func (r *AccountRepository) GetAccountNftHoldings(vmAddress string) (*dto.AccountNftHoldingsModel, error) {
+	var holdings dto.AccountNftHoldingsModel
+
+	if err := r.db.Model(&db.Nft{}).
+		Select("COUNT(*) AS nfts_held, COUNT(DISTINCT collection) AS collections_held").
+		Where("owner = ? AND is_burned = false", vmAddress).
+		Scan(&holdings).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("GetAccountNftHoldings: failed to count nfts")
+		return nil, err
+	}
+
+	return &holdings, nil
+}
+
 func (r *AccountRepository) GetAccountTxs(
 	pagination dto.PaginationQuery,
 	accountAddress string,
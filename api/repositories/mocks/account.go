@@ -21,6 +21,15 @@ func NewMockAccountRepository() *MockAccountRepository {
 	return &MockAccountRepository{}
 }
 
+// GetAccounts mocks the GetAccounts method
+func (m *MockAccountRepository) GetAccounts(pagination dto.PaginationQuery, accountType string) ([]db.Account, int64, error) {
+	args := m.Called(pagination, accountType)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]db.Account), args.Get(1).(int64), args.Error(2)
+}
+
 // GetAccountByAccountAddress mocks the GetAccountByAccountAddress method
 func (m *MockAccountRepository) GetAccountByAccountAddress(accountAddress string) (*db.Account, error) {
 	args := m.Called(accountAddress)
@@ -39,6 +48,33 @@ func (m *MockAccountRepository) GetAccountProposals(pagination dto.PaginationQue
 	return args.Get(0).([]db.Proposal), args.Get(1).(int64), args.Error(2)
 }
 
+// GetAccountDepositedProposals mocks the GetAccountDepositedProposals method
+func (m *MockAccountRepository) GetAccountDepositedProposals(pagination dto.PaginationQuery, accountAddress string) ([]dto.AccountDepositedProposalModel, int64, error) {
+	args := m.Called(pagination, accountAddress)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]dto.AccountDepositedProposalModel), args.Get(1).(int64), args.Error(2)
+}
+
+// GetAccountStat mocks the GetAccountStat method
+func (m *MockAccountRepository) GetAccountStat(accountAddress string) (*db.AccountStat, error) {
+	args := m.Called(accountAddress)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*db.AccountStat), args.Error(1)
+}
+
+// GetAccountNftHoldings mocks the GetAccountNftHoldings method
+func (m *MockAccountRepository) GetAccountNftHoldings(vmAddress string) (*dto.AccountNftHoldingsModel, error) {
+	args := m.Called(vmAddress)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AccountNftHoldingsModel), args.Error(1)
+}
+
 // GetAccountTxs mocks the GetAccountTxs method
 func (m *MockAccountRepository) GetAccountTxs(
 	pagination dto.PaginationQuery,
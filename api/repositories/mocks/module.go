@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"github.com/initia-labs/core-indexer/api/dto"
@@ -82,3 +84,21 @@ func (m *MockModuleRepository) GetModuleStats(vmAddress string, name string) (*d
 	}
 	return args.Get(0).(*dto.ModuleStatsResponse), args.Error(1)
 }
+
+// GetModuleCallStats mocks the GetModuleCallStats method
+func (m *MockModuleRepository) GetModuleCallStats(moduleID string, since time.Time) (*dto.FunctionCallStatsModel, error) {
+	args := m.Called(moduleID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.FunctionCallStatsModel), args.Error(1)
+}
+
+// GetModuleFunctionCallStats mocks the GetModuleFunctionCallStats method
+func (m *MockModuleRepository) GetModuleFunctionCallStats(moduleID string, since time.Time) ([]dto.FunctionCallStatsModel, error) {
+	args := m.Called(moduleID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.FunctionCallStatsModel), args.Error(1)
+}
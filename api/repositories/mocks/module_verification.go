@@ -0,0 +1,61 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories"
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+// MockModuleVerificationRepository is a mock implementation of ModuleVerificationRepositoryI
+type MockModuleVerificationRepository struct {
+	mock.Mock
+}
+
+// Ensure MockModuleVerificationRepository implements ModuleVerificationRepositoryI interface
+var _ repositories.ModuleVerificationRepositoryI = (*MockModuleVerificationRepository)(nil)
+
+// NewMockModuleVerificationRepository creates a new mock module verification repository
+func NewMockModuleVerificationRepository() *MockModuleVerificationRepository {
+	return &MockModuleVerificationRepository{}
+}
+
+// CanSubmit mocks the CanSubmit method
+func (m *MockModuleVerificationRepository) CanSubmit() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
+
+// CreateModuleVerification mocks the CreateModuleVerification method
+func (m *MockModuleVerificationRepository) CreateModuleVerification(verification *db.ModuleVerification, files []db.ModuleVerificationFile) error {
+	args := m.Called(verification, files)
+	return args.Error(0)
+}
+
+// GetModuleVerification mocks the GetModuleVerification method
+func (m *MockModuleVerificationRepository) GetModuleVerification(id int64) (*db.ModuleVerification, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*db.ModuleVerification), args.Error(1)
+}
+
+// GetModuleVerificationFiles mocks the GetModuleVerificationFiles method
+func (m *MockModuleVerificationRepository) GetModuleVerificationFiles(id int64) ([]db.ModuleVerificationFile, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.ModuleVerificationFile), args.Error(1)
+}
+
+// GetVerifiedModule mocks the GetVerifiedModule method
+func (m *MockModuleVerificationRepository) GetVerifiedModule(moduleID string, height *int64) (*dto.VerifiedModuleModel, error) {
+	args := m.Called(moduleID, height)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VerifiedModuleModel), args.Error(1)
+}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"github.com/initia-labs/core-indexer/api/dto"
@@ -48,6 +50,15 @@ func (m *MockNftRepository) GetCollectionsByCollectionAddress(collectionAddress
 	return args.Get(0).(*db.Collection), args.Error(1)
 }
 
+// SearchCollectionsByNamePrefix mocks the SearchCollectionsByNamePrefix method
+func (m *MockNftRepository) SearchCollectionsByNamePrefix(prefix string, limit int) ([]db.Collection, error) {
+	args := m.Called(prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.Collection), args.Error(1)
+}
+
 // GetCollectionActivities mocks the GetCollectionActivities method
 func (m *MockNftRepository) GetCollectionActivities(pagination dto.PaginationQuery, collectionAddress string, search string) ([]dto.CollectionActivityModel, int64, error) {
 	args := m.Called(pagination, collectionAddress, search)
@@ -84,6 +95,15 @@ func (m *MockNftRepository) GetNftByNftAddress(collectionAddress string, nftAddr
 	return args.Get(0).(*dto.NftByAddressModel), args.Error(1)
 }
 
+// GetNftByID mocks the GetNftByID method
+func (m *MockNftRepository) GetNftByID(nftAddress string) (*dto.NftByAddressModel, error) {
+	args := m.Called(nftAddress)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.NftByAddressModel), args.Error(1)
+}
+
 // GetNftsByAccountAddress mocks the GetNftsByAccountAddress method
 func (m *MockNftRepository) GetNftsByAccountAddress(pagination dto.PaginationQuery, accountAddress string, collectionAddress string, search string) ([]dto.NftByAddressModel, int64, error) {
 	args := m.Called(pagination, accountAddress, collectionAddress, search)
@@ -94,8 +114,8 @@ func (m *MockNftRepository) GetNftsByAccountAddress(pagination dto.PaginationQue
 }
 
 // GetNftsByCollectionAddress mocks the GetNftsByCollectionAddress method
-func (m *MockNftRepository) GetNftsByCollectionAddress(pagination dto.PaginationQuery, collectionAddress string, search string) ([]dto.NftByAddressModel, int64, error) {
-	args := m.Called(pagination, collectionAddress, search)
+func (m *MockNftRepository) GetNftsByCollectionAddress(pagination dto.PaginationQuery, collectionAddress string, search string, traitType string, traitValue string) ([]dto.NftByAddressModel, int64, error) {
+	args := m.Called(pagination, collectionAddress, search, traitType, traitValue)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
@@ -128,3 +148,39 @@ func (m *MockNftRepository) GetNftTxs(pagination dto.PaginationQuery, nftAddress
 	}
 	return args.Get(0).([]dto.NftTxModel), args.Get(1).(int64), args.Error(2)
 }
+
+// GetNftOwners mocks the GetNftOwners method
+func (m *MockNftRepository) GetNftOwners(pagination dto.PaginationQuery, nftAddress string) ([]dto.NftOwnerModel, int64, error) {
+	args := m.Called(pagination, nftAddress)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]dto.NftOwnerModel), args.Get(1).(int64), args.Error(2)
+}
+
+// GetNftOwnerAtHeight mocks the GetNftOwnerAtHeight method
+func (m *MockNftRepository) GetNftOwnerAtHeight(nftAddress string, height int64) (*dto.NftOwnerModel, error) {
+	args := m.Called(nftAddress, height)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.NftOwnerModel), args.Error(1)
+}
+
+// GetNftBurnHeight mocks the GetNftBurnHeight method
+func (m *MockNftRepository) GetNftBurnHeight(nftAddress string) (*int64, error) {
+	args := m.Called(nftAddress)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int64), args.Error(1)
+}
+
+// GetCollectionTransferVolume mocks the GetCollectionTransferVolume method
+func (m *MockNftRepository) GetCollectionTransferVolume(collectionAddress string, granularity string, start, end time.Time) ([]dto.CollectionTransferVolume, error) {
+	args := m.Called(collectionAddress, granularity, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.CollectionTransferVolume), args.Error(1)
+}
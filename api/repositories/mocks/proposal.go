@@ -48,6 +48,15 @@ func (m *MockProposalRepository) SearchProposals(pagination dto.PaginationQuery,
 	return args.Get(0).([]dto.ProposalSummary), args.Get(1).(int64), args.Error(2)
 }
 
+// SearchProposalsByTitlePrefix mocks the SearchProposalsByTitlePrefix method
+func (m *MockProposalRepository) SearchProposalsByTitlePrefix(prefix string, limit int) ([]dto.ProposalSummary, error) {
+	args := m.Called(prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ProposalSummary), args.Error(1)
+}
+
 // GetAllProposalTypes mocks the GetAllProposalTypes method
 func (m *MockProposalRepository) GetAllProposalTypes() (*dto.ProposalsTypesResponse, error) {
 	args := m.Called()
@@ -92,3 +101,30 @@ func (m *MockProposalRepository) GetProposalAnswerCounts(id int) (*dto.ProposalA
 	}
 	return args.Get(0).(*dto.ProposalAnswerCountsResponse), args.Error(1)
 }
+
+// GetProposalTallyHistory mocks the GetProposalTallyHistory method
+func (m *MockProposalRepository) GetProposalTallyHistory(id int) ([]dto.ProposalTallySnapshotModel, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ProposalTallySnapshotModel), args.Error(1)
+}
+
+// GetProposalDeposits mocks the GetProposalDeposits method
+func (m *MockProposalRepository) GetProposalDeposits(pagination dto.PaginationQuery, id int) ([]dto.ProposalDepositModel, int64, error) {
+	args := m.Called(pagination, id)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]dto.ProposalDepositModel), args.Get(1).(int64), args.Error(2)
+}
+
+// GetProposalDepositSummary mocks the GetProposalDepositSummary method
+func (m *MockProposalRepository) GetProposalDepositSummary(id int) (*dto.ProposalDepositSummaryModel, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProposalDepositSummaryModel), args.Error(1)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories"
+)
+
+// MockStatsRepository is a mock implementation of StatsRepositoryI
+type MockStatsRepository struct {
+	mock.Mock
+}
+
+// Ensure MockStatsRepository implements StatsRepositoryI interface
+var _ repositories.StatsRepositoryI = (*MockStatsRepository)(nil)
+
+// NewMockStatsRepository creates a new mock stats repository
+func NewMockStatsRepository() *MockStatsRepository {
+	return &MockStatsRepository{}
+}
+
+// GetChainStats mocks the GetChainStats method
+func (m *MockStatsRepository) GetChainStats(granularity string, from, to time.Time) ([]dto.ChainStatModel, error) {
+	args := m.Called(granularity, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ChainStatModel), args.Error(1)
+}
@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories"
+)
+
+// MockTxRepository is a mock implementation of TxRepositoryI
+type MockTxRepository struct {
+	mock.Mock
+}
+
+// Ensure MockTxRepository implements TxRepositoryI interface
+var _ repositories.TxRepositoryI = (*MockTxRepository)(nil)
+
+// NewMockTxRepository creates a new mock transaction repository
+func NewMockTxRepository() *MockTxRepository {
+	return &MockTxRepository{}
+}
+
+// GetTxByHash mocks the GetTxByHash method
+func (m *MockTxRepository) GetTxByHash(ctx context.Context, hash string) (*dto.TxByHashResponse, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TxByHashResponse), args.Error(1)
+}
+
+// GetTxSummaryByHash mocks the GetTxSummaryByHash method
+func (m *MockTxRepository) GetTxSummaryByHash(hash string) (*dto.TxSummaryModel, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TxSummaryModel), args.Error(1)
+}
+
+// GetTxCount mocks the GetTxCount method
+func (m *MockTxRepository) GetTxCount() (*int64, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int64), args.Error(1)
+}
+
+// GetTxs mocks the GetTxs method
+func (m *MockTxRepository) GetTxs(pagination *dto.PaginationQuery) ([]dto.TxModel, int64, error) {
+	args := m.Called(pagination)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]dto.TxModel), args.Get(1).(int64), args.Error(2)
+}
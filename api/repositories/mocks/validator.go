@@ -0,0 +1,141 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories"
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+// MockValidatorRepository is a mock implementation of ValidatorRepositoryI
+type MockValidatorRepository struct {
+	mock.Mock
+}
+
+// Ensure MockValidatorRepository implements ValidatorRepositoryI interface
+var _ repositories.ValidatorRepositoryI = (*MockValidatorRepository)(nil)
+
+// NewMockValidatorRepository creates a new mock validator repository
+func NewMockValidatorRepository() *MockValidatorRepository {
+	return &MockValidatorRepository{}
+}
+
+// GetValidators mocks the GetValidators method
+func (m *MockValidatorRepository) GetValidators(pagination dto.PaginationQuery, status dto.ValidatorStatusFilter, sortBy, search string) ([]dto.ValidatorWithVoteCountModel, int64, error) {
+	args := m.Called(pagination, status, sortBy, search)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]dto.ValidatorWithVoteCountModel), args.Get(1).(int64), args.Error(2)
+}
+
+// GetValidatorsByPower mocks the GetValidatorsByPower method
+func (m *MockValidatorRepository) GetValidatorsByPower(pagination *dto.PaginationQuery, onlyActive bool) ([]db.Validator, error) {
+	args := m.Called(pagination, onlyActive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.Validator), args.Error(1)
+}
+
+// GetValidatorRow mocks the GetValidatorRow method
+func (m *MockValidatorRepository) GetValidatorRow(operatorAddr string) (*db.Validator, error) {
+	args := m.Called(operatorAddr)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*db.Validator), args.Error(1)
+}
+
+// SearchValidatorsByMonikerPrefix mocks the SearchValidatorsByMonikerPrefix method
+func (m *MockValidatorRepository) SearchValidatorsByMonikerPrefix(prefix string, limit int) ([]db.Validator, error) {
+	args := m.Called(prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]db.Validator), args.Error(1)
+}
+
+// GetValidatorBlockVoteByBlockLimit mocks the GetValidatorBlockVoteByBlockLimit method
+func (m *MockValidatorRepository) GetValidatorBlockVoteByBlockLimit(minHeight, maxHeight int64) ([]dto.ValidatorBlockVoteModel, error) {
+	args := m.Called(minHeight, maxHeight)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ValidatorBlockVoteModel), args.Error(1)
+}
+
+// GetValidatorCommitSignatures mocks the GetValidatorCommitSignatures method
+func (m *MockValidatorRepository) GetValidatorCommitSignatures(operatorAddr string, minHeight, maxHeight int64) ([]dto.ValidatorBlockVoteModel, error) {
+	args := m.Called(operatorAddr, minHeight, maxHeight)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ValidatorBlockVoteModel), args.Error(1)
+}
+
+// GetValidatorSlashEvents mocks the GetValidatorSlashEvents method
+func (m *MockValidatorRepository) GetValidatorSlashEvents(operatorAddr string, minTimestamp time.Time) ([]dto.ValidatorUptimeEventModel, error) {
+	args := m.Called(operatorAddr, minTimestamp)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ValidatorUptimeEventModel), args.Error(1)
+}
+
+// GetValidatorSlashHistory mocks the GetValidatorSlashHistory method
+func (m *MockValidatorRepository) GetValidatorSlashHistory(pagination dto.PaginationQuery, operatorAddr string) ([]dto.ValidatorUptimeEventModel, int64, error) {
+	args := m.Called(pagination, operatorAddr)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]dto.ValidatorUptimeEventModel), args.Get(1).(int64), args.Error(2)
+}
+
+// GetValidatorDowntimeEpisodes mocks the GetValidatorDowntimeEpisodes method
+func (m *MockValidatorRepository) GetValidatorDowntimeEpisodes(pagination dto.PaginationQuery, operatorAddr string) ([]dto.ValidatorDowntimeEpisodeModel, int64, error) {
+	args := m.Called(pagination, operatorAddr)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]dto.ValidatorDowntimeEpisodeModel), args.Get(1).(int64), args.Error(2)
+}
+
+// GetValidatorUptimeInfo mocks the GetValidatorUptimeInfo method
+func (m *MockValidatorRepository) GetValidatorUptimeInfo(operatorAddr string) (*dto.ValidatorWithVoteCountModel, error) {
+	args := m.Called(operatorAddr)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ValidatorWithVoteCountModel), args.Error(1)
+}
+
+// GetValidatorBondedTokenChanges mocks the GetValidatorBondedTokenChanges method
+func (m *MockValidatorRepository) GetValidatorBondedTokenChanges(pagination dto.PaginationQuery, operatorAddr string) ([]db.ValidatorBondedTokenChange, int64, error) {
+	args := m.Called(pagination, operatorAddr)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]db.ValidatorBondedTokenChange), args.Get(1).(int64), args.Error(2)
+}
+
+// GetValidatorProposedBlocks mocks the GetValidatorProposedBlocks method
+func (m *MockValidatorRepository) GetValidatorProposedBlocks(pagination dto.PaginationQuery, operatorAddr string) ([]dto.ValidatorProposedBlockModel, int64, error) {
+	args := m.Called(pagination, operatorAddr)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]dto.ValidatorProposedBlockModel), args.Get(1).(int64), args.Error(2)
+}
+
+// GetValidatorHistoricalPowers mocks the GetValidatorHistoricalPowers method
+func (m *MockValidatorRepository) GetValidatorHistoricalPowers(operatorAddr string) ([]dto.ValidatorHistoricalPowerModel, int64, error) {
+	args := m.Called(operatorAddr)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]dto.ValidatorHistoricalPowerModel), args.Get(1).(int64), args.Error(2)
+}
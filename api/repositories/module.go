@@ -110,6 +110,8 @@ func (r *ModuleRepository) GetModuleHistories(pagination dto.PaginationQuery, vm
 			"module_histories.upgrade_policy",
 			"block_height AS height",
 			"blocks.timestamp",
+			"module_histories.digest",
+			"EXISTS (SELECT 1 FROM verified_modules WHERE verified_modules.module_id = module_histories.module_id AND verified_modules.digest = module_histories.digest) AS is_verified",
 		).
 		Joins("LEFT JOIN blocks ON blocks.height = module_histories.block_height").
 		Where("module_histories.module_id = ?", moduleId).
@@ -258,8 +260,9 @@ func (r *ModuleRepository) GetModuleStats(vmAddress string, name string) (*dto.M
 		SELECT
 			(SELECT COUNT(*) FROM module_transactions WHERE module_id = ?) AS total_txs,
 			(SELECT COUNT(*) FROM module_histories WHERE module_id = ?) AS total_histories,
-			(SELECT COUNT(*) FROM module_proposals WHERE module_id = ?) AS total_proposals
-	`, moduleId, moduleId, moduleId).Scan(&stats).Error
+			(SELECT COUNT(*) FROM module_proposals WHERE module_id = ?) AS total_proposals,
+			(SELECT COALESCE(MAX(module_entry_executed), 0) FROM modules WHERE id = ?) AS total_entry_executed
+	`, moduleId, moduleId, moduleId, moduleId).Scan(&stats).Error
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to get module stats")
 		return nil, err
@@ -267,3 +270,57 @@ func (r *ModuleRepository) GetModuleStats(vmAddress string, name string) (*dto.M
 
 	return &stats, nil
 }
+
+// GetModuleCallStats sums the entry function calls of a module from the given date
+func (r *ModuleRepository) GetModuleCallStats(moduleID string, since time.Time) (*dto.FunctionCallStatsModel, error) {
+	var stats dto.FunctionCallStatsModel
+
+	err := r.db.Raw(`
+		SELECT
+			COALESCE(SUM(call_count), 0) AS call_count,
+			COALESCE(SUM(failure_count), 0) AS failure_count,
+			COALESCE(SUM(gas_used), 0) AS gas_used,
+			(SELECT COUNT(DISTINCT sender) FROM module_function_callers WHERE module_id = ? AND date >= ?) AS unique_callers
+		FROM module_function_stats
+		WHERE module_id = ? AND date >= ?
+	`, moduleID, since, moduleID, since).Scan(&stats).Error
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get module call stats")
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetModuleFunctionCallStats sums the calls of each entry function of a module from the given date
+func (r *ModuleRepository) GetModuleFunctionCallStats(moduleID string, since time.Time) ([]dto.FunctionCallStatsModel, error) {
+	var stats []dto.FunctionCallStatsModel
+
+	err := r.db.Raw(`
+		SELECT
+			stats.function_name,
+			stats.call_count,
+			stats.failure_count,
+			stats.gas_used,
+			COALESCE(callers.unique_callers, 0) AS unique_callers
+		FROM (
+			SELECT function_name, SUM(call_count) AS call_count, SUM(failure_count) AS failure_count, SUM(gas_used) AS gas_used
+			FROM module_function_stats
+			WHERE module_id = ? AND date >= ?
+			GROUP BY function_name
+		) AS stats
+		LEFT JOIN (
+			SELECT function_name, COUNT(DISTINCT sender) AS unique_callers
+			FROM module_function_callers
+			WHERE module_id = ? AND date >= ?
+			GROUP BY function_name
+		) AS callers ON callers.function_name = stats.function_name
+		ORDER BY stats.call_count DESC, stats.function_name
+	`, moduleID, since, moduleID, since).Scan(&stats).Error
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get module function call stats")
+		return nil, err
+	}
+
+	return stats, nil
+}
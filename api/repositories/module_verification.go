@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/logger"
+)
+
+var _ ModuleVerificationRepositoryI = &ModuleVerificationRepository{}
+
+type ModuleVerificationRepository struct {
+	db       *gorm.DB
+	writerDB *gorm.DB
+}
+
+// NewModuleVerificationRepository creates a module verification repository, writerDB is nil when submissions are disabled
+func NewModuleVerificationRepository(db *gorm.DB, writerDB *gorm.DB) *ModuleVerificationRepository {
+	return &ModuleVerificationRepository{
+		db:       db,
+		writerDB: writerDB,
+	}
+}
+
+// CanSubmit reports whether module verifications can be submitted
+func (r *ModuleVerificationRepository) CanSubmit() bool {
+	return r.writerDB != nil
+}
+
+// CreateModuleVerification queues a module verification with its source files
+func (r *ModuleVerificationRepository) CreateModuleVerification(verification *db.ModuleVerification, files []db.ModuleVerificationFile) error {
+	if r.writerDB == nil {
+		return errors.New("module verification submissions are disabled")
+	}
+
+	if err := db.InsertModuleVerification(context.Background(), r.writerDB, verification, files); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create module verification")
+		return err
+	}
+
+	return nil
+}
+
+// GetModuleVerification retrieves a module verification by id
+func (r *ModuleVerificationRepository) GetModuleVerification(id int64) (*db.ModuleVerification, error) {
+	var verification db.ModuleVerification
+
+	if err := r.db.Model(&db.ModuleVerification{}).
+		Where("id = ?", id).
+		First(&verification).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Get().Error().Err(err).Msg("Failed to query module verification")
+		}
+		return nil, err
+	}
+
+	return &verification, nil
+}
+
+// GetModuleVerificationFiles retrieves the source files of a module verification
+func (r *ModuleVerificationRepository) GetModuleVerificationFiles(id int64) ([]db.ModuleVerificationFile, error) {
+	files, err := db.QueryModuleVerificationFiles(context.Background(), r.db, id)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to query module verification files")
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// GetVerifiedModule retrieves the verification of the module version published at the given height,
+// or of the current version when height is nil
+func (r *ModuleVerificationRepository) GetVerifiedModule(moduleID string, height *int64) (*dto.VerifiedModuleModel, error) {
+	var verifiedModule dto.VerifiedModuleModel
+
+	query := r.db.Model(&db.VerifiedModule{}).
+		Select(
+			"verified_modules.module_id",
+			"verified_modules.digest",
+			"verified_modules.verification_id",
+			"verified_modules.abi",
+			"verified_modules.verified_at",
+			"module_verifications.package_name",
+			"module_verifications.compiler_version",
+		).
+		Joins("JOIN module_verifications ON module_verifications.id = verified_modules.verification_id").
+		Where("verified_modules.module_id = ?", moduleID)
+	if height == nil {
+		query = query.Where("verified_modules.digest = (SELECT digest FROM modules WHERE id = ?)", moduleID)
+	} else {
+		query = query.Where("verified_modules.digest = (SELECT digest FROM module_histories WHERE module_id = ? AND block_height = ? LIMIT 1)", moduleID, *height)
+	}
+
+	if err := query.First(&verifiedModule).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Get().Error().Err(err).Msg("Failed to query verified module")
+		}
+		return nil, err
+	}
+
+	return &verifiedModule, nil
+}
@@ -16,6 +16,29 @@ import (
 
 var _ NftRepositoryI = &NftRepository{}
 
+// nftByAddressColumns selects an Nft with its collection name and its off-chain metadata, joined by nftMetadataJoin
+const nftByAddressColumns = `
+	nfts.token_id,
+	nfts.uri,
+	nfts.description,
+	nfts.is_burned,
+	nfts.owner,
+	nfts.id,
+	nfts.collection,
+	collections.name AS collection_name,
+	nft_metadata.name AS metadata_name,
+	nft_metadata.description AS metadata_description,
+	nft_metadata.image AS metadata_image,
+	nft_metadata.thumbnail_url,
+	CASE WHEN nft_metadata.id IS NOT NULL THEN (
+		SELECT COALESCE(json_agg(json_build_object('trait_type', nft_attributes.trait_type, 'value', nft_attributes.value)
+			ORDER BY nft_attributes.trait_type, nft_attributes.value), '[]')
+		FROM nft_attributes WHERE nft_attributes.nft_id = nfts.id
+	) END AS attributes
+`
+
+const nftMetadataJoin = "LEFT JOIN nft_metadata ON nft_metadata.id = nfts.id AND nft_metadata.fetched_at IS NOT NULL"
+
 // NftRepository implements NftRepositoryI
 type NftRepository struct {
 	countQueryTimeout time.Duration
@@ -113,6 +136,23 @@ func (r *NftRepository) GetCollectionsByCollectionAddress(collectionAddress stri
 	return &record, nil
 }
 
+// SearchCollectionsByNamePrefix retrieves the collections whose name starts with prefix
+func (r *NftRepository) SearchCollectionsByNamePrefix(prefix string, limit int) ([]db.Collection, error) {
+	record := make([]db.Collection, 0)
+
+	if err := r.db.Model(&db.Collection{}).
+		Select("name, uri, description, id, creator").
+		Where("name ILIKE ?", utils.EscapeLikePattern(prefix)+"%").
+		Order("name").
+		Limit(limit).
+		Find(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to search collections by name")
+		return nil, err
+	}
+
+	return record, nil
+}
+
 func (r *NftRepository) GetCollectionActivities(pagination dto.PaginationQuery, collectionAddress string, search string) ([]dto.CollectionActivityModel, int64, error) {
 	record := make([]dto.CollectionActivityModel, 0)
 	total := int64(0)
@@ -254,6 +294,23 @@ func (r *NftRepository) GetCollectionMutateEvents(pagination dto.PaginationQuery
 func (r *NftRepository) GetNftByNftAddress(collectionAddress string, nftAddress string) (*dto.NftByAddressModel, error) {
 	var record dto.NftByAddressModel
 
+	if err := r.db.Model(&db.Nft{}).
+		Select(nftByAddressColumns).
+		Joins("LEFT JOIN collections ON nfts.collection = collections.id").
+		Joins(nftMetadataJoin).
+		Where("nfts.collection = ? AND nfts.id = ?", collectionAddress, nftAddress).
+		First(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get Nft by address")
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// GetNftByID retrieves an Nft by its object address, regardless of its collection
+func (r *NftRepository) GetNftByID(nftAddress string) (*dto.NftByAddressModel, error) {
+	var record dto.NftByAddressModel
+
 	if err := r.db.Model(&db.Nft{}).
 		Select(`
 			nfts.token_id,
@@ -266,9 +323,9 @@ func (r *NftRepository) GetNftByNftAddress(collectionAddress string, nftAddress
 			collections.name AS collection_name
 		`).
 		Joins("LEFT JOIN collections ON nfts.collection = collections.id").
-		Where("nfts.collection = ? AND nfts.id = ?", collectionAddress, nftAddress).
+		Where("nfts.id = ?", nftAddress).
 		First(&record).Error; err != nil {
-		logger.Get().Error().Err(err).Msg("Failed to get Nft by address")
+		logger.Get().Error().Err(err).Msg("Failed to get Nft by id")
 		return nil, err
 	}
 
@@ -280,17 +337,9 @@ func (r *NftRepository) GetNftsByAccountAddress(pagination dto.PaginationQuery,
 	total := int64(0)
 
 	query := r.db.Model(&db.Nft{}).
-		Select(`
-			nfts.token_id,
-			nfts.uri,
-			nfts.description,
-			nfts.is_burned,
-			nfts.owner,
-			nfts.id,
-			nfts.collection,
-			collections.name AS collection_name
-		`).
+		Select(nftByAddressColumns).
 		Joins("LEFT JOIN collections ON nfts.collection = collections.id").
+		Joins(nftMetadataJoin).
 		Where("nfts.owner = ? AND nfts.is_burned = false", accountAddress).
 		Limit(pagination.Limit).
 		Offset(pagination.Offset)
@@ -318,22 +367,14 @@ func (r *NftRepository) GetNftsByAccountAddress(pagination dto.PaginationQuery,
 	return record, total, nil
 }
 
-func (r *NftRepository) GetNftsByCollectionAddress(pagination dto.PaginationQuery, collectionAddress string, search string) ([]dto.NftByAddressModel, int64, error) {
+func (r *NftRepository) GetNftsByCollectionAddress(pagination dto.PaginationQuery, collectionAddress string, search string, traitType string, traitValue string) ([]dto.NftByAddressModel, int64, error) {
 	record := make([]dto.NftByAddressModel, 0)
 	total := int64(0)
 
 	query := r.db.Model(&db.Nft{}).
-		Select(`
-			nfts.token_id,
-			nfts.uri,
-			nfts.description,
-			nfts.is_burned,
-			nfts.owner,
-			nfts.id,
-			nfts.collection,
-			collections.name AS collection_name
-		`).
+		Select(nftByAddressColumns).
 		Joins("LEFT JOIN collections ON nfts.collection = collections.id").
+		Joins(nftMetadataJoin).
 		Where("nfts.collection = ? AND nfts.is_burned = false", collectionAddress).
 		Limit(pagination.Limit).
 		Offset(pagination.Offset)
@@ -343,6 +384,8 @@ func (r *NftRepository) GetNftsByCollectionAddress(pagination dto.PaginationQuer
 
 	applyNftFilters(query, collectionAddress, search)
 	applyNftFilters(countQuery, collectionAddress, search)
+	applyNftAttributeFilter(query, traitType, traitValue)
+	applyNftAttributeFilter(countQuery, traitType, traitValue)
 
 	if err := query.Find(&record).Error; err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to query Nfts by collection address")
@@ -462,6 +505,121 @@ func (r *NftRepository) GetNftTxs(pagination dto.PaginationQuery, nftAddress str
 	return record, total, nil
 }
 
+func (r *NftRepository) GetNftOwners(pagination dto.PaginationQuery, nftAddress string) ([]dto.NftOwnerModel, int64, error) {
+	record := make([]dto.NftOwnerModel, 0)
+	total := int64(0)
+
+	if err := r.db.Model(&db.NftHistory{}).
+		Select(`
+			nft_histories."from",
+			nft_histories."to",
+			transactions.hash,
+			blocks.height,
+			blocks.timestamp
+		`).
+		Joins("LEFT JOIN transactions ON nft_histories.tx_id = transactions.id").
+		Joins("LEFT JOIN blocks ON nft_histories.block_height = blocks.height").
+		Where("nft_histories.nft_id = ?", nftAddress).
+		Clauses(clause.OrderBy{
+			Columns: []clause.OrderByColumn{
+				{Column: clause.Column{Name: "nft_histories.block_height"}, Desc: pagination.Reverse},
+				{Column: clause.Column{Name: "transactions.block_index"}, Desc: pagination.Reverse},
+			},
+		}).
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to query Nft owners")
+		return nil, 0, err
+	}
+
+	if pagination.CountTotal {
+		var err error
+		total, err = db.CountWithTimeout(r.db.Model(&db.NftHistory{}).Where("nft_histories.nft_id = ?", nftAddress), r.countQueryTimeout)
+		if err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to count Nft owners")
+			return nil, 0, err
+		}
+	}
+
+	return record, total, nil
+}
+
+// GetNftOwnerAtHeight retrieves the latest owner change of an Nft at or before the given height
+func (r *NftRepository) GetNftOwnerAtHeight(nftAddress string, height int64) (*dto.NftOwnerModel, error) {
+	var record dto.NftOwnerModel
+
+	if err := r.db.Model(&db.NftHistory{}).
+		Select(`
+			nft_histories."from",
+			nft_histories."to",
+			transactions.hash,
+			blocks.height,
+			blocks.timestamp
+		`).
+		Joins("LEFT JOIN transactions ON nft_histories.tx_id = transactions.id").
+		Joins("LEFT JOIN blocks ON nft_histories.block_height = blocks.height").
+		Where("nft_histories.nft_id = ? AND nft_histories.block_height <= ?", nftAddress, height).
+		Order("nft_histories.block_height DESC, transactions.block_index DESC").
+		First(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get Nft owner at height")
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// GetNftBurnHeight retrieves the height an Nft is burned at, or nil when it is not burned
+func (r *NftRepository) GetNftBurnHeight(nftAddress string) (*int64, error) {
+	var height *int64
+
+	if err := r.db.Model(&db.NftTransaction{}).
+		Select("MIN(block_height)").
+		Where("nft_transactions.is_nft_burn = true AND nft_transactions.nft_id = ?", nftAddress).
+		Scan(&height).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get Nft burn height")
+		return nil, err
+	}
+
+	return height, nil
+}
+
+// GetCollectionTransferVolume retrieves the Nft transfers of a collection between start and end, per hour or day
+func (r *NftRepository) GetCollectionTransferVolume(collectionAddress string, granularity string, start, end time.Time) ([]dto.CollectionTransferVolume, error) {
+	record := make([]dto.CollectionTransferVolume, 0)
+
+	if err := r.db.Model(&db.NftHistory{}).
+		Select(`
+			date_trunc(?, blocks.timestamp) AS timestamp,
+			COUNT(*) AS transfer_count,
+			COUNT(DISTINCT nft_histories.nft_id) AS nft_count
+		`, granularity).
+		Joins("JOIN nfts ON nft_histories.nft_id = nfts.id").
+		Joins("JOIN blocks ON nft_histories.block_height = blocks.height").
+		Where(`nfts.collection = ? AND nft_histories."from" IS NOT NULL`, collectionAddress).
+		Where("blocks.timestamp >= ? AND blocks.timestamp < ?", start, end).
+		Group("1").
+		Order("1").
+		Scan(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to query collection transfer volume")
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// applyNftAttributeFilter keeps the Nfts having an attribute of the trait type, with the value when it is given
+func applyNftAttributeFilter(query *gorm.DB, traitType string, traitValue string) *gorm.DB {
+	if traitType == "" {
+		return query
+	}
+
+	if traitValue == "" {
+		return query.Where("EXISTS (SELECT 1 FROM nft_attributes WHERE nft_attributes.nft_id = nfts.id AND nft_attributes.trait_type = ?)", traitType)
+	}
+	return query.Where("EXISTS (SELECT 1 FROM nft_attributes WHERE nft_attributes.nft_id = nfts.id AND nft_attributes.trait_type = ? AND nft_attributes.value = ?)", traitType, traitValue)
+}
+
 func applyNftFilters(query *gorm.DB, collectionAddress string, search string) *gorm.DB {
 	if collectionAddress != "" {
 		query = query.Where("nfts.collection = ?", collectionAddress)
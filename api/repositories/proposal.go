@@ -13,6 +13,7 @@ import (
 	"gorm.io/gorm/clause"
 
 	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/utils"
 	"github.com/initia-labs/core-indexer/pkg/db"
 	"github.com/initia-labs/core-indexer/pkg/logger"
 )
@@ -130,6 +131,23 @@ func (r *ProposalRepository) SearchProposals(pagination dto.PaginationQuery, pro
 	return proposals, total, nil
 }
 
+// SearchProposalsByTitlePrefix retrieves the proposals whose title starts with prefix, latest first
+func (r *ProposalRepository) SearchProposalsByTitlePrefix(prefix string, limit int) ([]dto.ProposalSummary, error) {
+	proposals := make([]dto.ProposalSummary, 0)
+
+	if err := r.db.Model(&db.Proposal{}).
+		Select("proposals.id, proposals.title, proposals.types, proposals.voting_end_time, proposals.deposit_end_time, proposals.resolved_height, proposals.status, proposals.is_expedited, proposals.is_emergency, proposals.proposer_id as proposer").
+		Where("proposals.title ILIKE ?", utils.EscapeLikePattern(prefix)+"%").
+		Order("proposals.id DESC").
+		Limit(limit).
+		Find(&proposals).Error; err != nil {
+		logger.Get().Error().Err(err).Msgf("Failed to search proposals by title")
+		return nil, err
+	}
+
+	return proposals, nil
+}
+
 func (r *ProposalRepository) GetAllProposalTypes() (*dto.ProposalsTypesResponse, error) {
 	var proposals []struct {
 		Types json.RawMessage `gorm:"column:types"`
@@ -541,3 +559,69 @@ func (r *ProposalRepository) GetProposalAnswerCounts(id int) (*dto.ProposalAnswe
 
 	return response, nil
 }
+
+// GetProposalTallyHistory retrieves the tally snapshots of a proposal taken during its voting period
+func (r *ProposalRepository) GetProposalTallyHistory(id int) ([]dto.ProposalTallySnapshotModel, error) {
+	var snapshots []dto.ProposalTallySnapshotModel
+	if err := r.db.Model(&db.ProposalTallySnapshot{}).
+		Where("proposal_id = ?", id).
+		Order("tally_height").
+		Find(&snapshots).Error; err != nil {
+		logger.Get().Error().Err(err).Msgf("Failed to query proposal tally snapshots for id: %d", id)
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// GetProposalDeposits retrieves the deposits of a proposal with the hash and timestamp of their transactions
+func (r *ProposalRepository) GetProposalDeposits(pagination dto.PaginationQuery, id int) ([]dto.ProposalDepositModel, int64, error) {
+	var deposits []dto.ProposalDepositModel
+	var total int64
+
+	if err := r.db.Model(&db.ProposalDeposit{}).
+		Select("proposal_deposits.amount, proposal_deposits.depositor, transactions.hash as tx_hash, blocks.timestamp").
+		Joins("LEFT JOIN transactions ON proposal_deposits.transaction_id = transactions.id").
+		Joins("LEFT JOIN blocks ON transactions.block_height = blocks.height").
+		Where("proposal_deposits.proposal_id = ?", id).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{
+				Name: "blocks.height",
+			},
+			Desc: pagination.Reverse,
+		}).
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&deposits).Error; err != nil {
+		logger.Get().Error().Err(err).Msgf("Failed to query proposal deposits for %d", id)
+		return nil, 0, err
+	}
+
+	if pagination.CountTotal {
+		var err error
+		total, err = db.CountWithTimeout(r.db.Model(&db.ProposalDeposit{}).Where("proposal_id = ?", id), r.countQueryTimeout)
+		if err != nil {
+			logger.Get().Error().Err(err).Msgf("Failed to count proposal deposits for %d", id)
+			return nil, 0, err
+		}
+	}
+
+	return deposits, total, nil
+}
+
+// GetProposalDepositSummary retrieves the status, minimum deposit, total deposit and deposit end time of a proposal
+func (r *ProposalRepository) GetProposalDepositSummary(id int) (*dto.ProposalDepositSummaryModel, error) {
+	var summary dto.ProposalDepositSummaryModel
+
+	if err := r.db.Model(&db.Proposal{}).
+		Select("status, min_deposit, total_deposit, deposit_end_time").
+		Where("id = ?", id).
+		First(&summary).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Get().Error().Err(err).Msgf("Failed to query proposal deposit summary for %d", id)
+		}
+		return nil, err
+	}
+
+	return &summary, nil
+}
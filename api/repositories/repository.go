@@ -12,24 +12,28 @@ import (
 )
 
 type Repositories struct {
-	BlockRepository     *BlockRepository
-	ModuleRepository    *ModuleRepository
-	NftRepository       *NftRepository
-	ProposalRepository  *ProposalRepository
-	TxRepository        *TxRepository
-	ValidatorRepository *ValidatorRepository
-	AccountRepository   *AccountRepository
+	BlockRepository              *BlockRepository
+	ModuleRepository             *ModuleRepository
+	ModuleVerificationRepository *ModuleVerificationRepository
+	NftRepository                *NftRepository
+	ProposalRepository           *ProposalRepository
+	TxRepository                 *TxRepository
+	ValidatorRepository          *ValidatorRepository
+	AccountRepository            *AccountRepository
+	StatsRepository              *StatsRepository
 }
 
-func SetupRepositories(dbClient *gorm.DB, buckets []*blob.Bucket, countQueryTimeout time.Duration) *Repositories {
+func SetupRepositories(dbClient *gorm.DB, verificationDBClient *gorm.DB, buckets []*blob.Bucket, countQueryTimeout time.Duration) *Repositories {
 	return &Repositories{
-		BlockRepository:     NewBlockRepository(dbClient, countQueryTimeout),
-		ModuleRepository:    NewModuleRepository(dbClient, countQueryTimeout),
-		NftRepository:       NewNftRepository(dbClient, countQueryTimeout),
-		ProposalRepository:  NewProposalRepository(dbClient, countQueryTimeout),
-		TxRepository:        NewTxRepository(dbClient, buckets, countQueryTimeout),
-		ValidatorRepository: NewValidatorRepository(dbClient, countQueryTimeout),
-		AccountRepository:   NewAccountRepository(dbClient, countQueryTimeout),
+		BlockRepository:              NewBlockRepository(dbClient, countQueryTimeout),
+		ModuleRepository:             NewModuleRepository(dbClient, countQueryTimeout),
+		ModuleVerificationRepository: NewModuleVerificationRepository(dbClient, verificationDBClient),
+		NftRepository:                NewNftRepository(dbClient, countQueryTimeout),
+		ProposalRepository:           NewProposalRepository(dbClient, countQueryTimeout),
+		TxRepository:                 NewTxRepository(dbClient, buckets, countQueryTimeout),
+		ValidatorRepository:          NewValidatorRepository(dbClient, countQueryTimeout),
+		AccountRepository:            NewAccountRepository(dbClient, countQueryTimeout),
+		StatsRepository:              NewStatsRepository(dbClient),
 	}
 }
 
@@ -42,6 +46,17 @@ type ModuleRepositoryI interface {
 	GetModuleProposals(pagination dto.PaginationQuery, vmAddress string, name string) ([]dto.ModuleProposalModel, int64, error)
 	GetModuleTransactions(pagination dto.PaginationQuery, vmAddress string, name string) ([]dto.ModuleTxResponse, int64, error)
 	GetModuleStats(vmAddress string, name string) (*dto.ModuleStatsResponse, error)
+	GetModuleCallStats(moduleID string, since time.Time) (*dto.FunctionCallStatsModel, error)
+	GetModuleFunctionCallStats(moduleID string, since time.Time) ([]dto.FunctionCallStatsModel, error)
+}
+
+// ModuleVerificationRepositoryI defines the interface for module verification data access operations
+type ModuleVerificationRepositoryI interface {
+	CanSubmit() bool
+	CreateModuleVerification(verification *db.ModuleVerification, files []db.ModuleVerificationFile) error
+	GetModuleVerification(id int64) (*db.ModuleVerification, error)
+	GetModuleVerificationFiles(id int64) ([]db.ModuleVerificationFile, error)
+	GetVerifiedModule(moduleID string, height *int64) (*dto.VerifiedModuleModel, error)
 }
 
 // NftRepositoryI defines the interface for Nft data access operations
@@ -50,15 +65,21 @@ type NftRepositoryI interface {
 	GetCollections(pagination dto.PaginationQuery, search string) ([]db.Collection, int64, error)
 	GetCollectionsByAccountAddress(accountAddress string) ([]dto.CollectionByAccountAddressModel, error)
 	GetCollectionsByCollectionAddress(collectionAddress string) (*db.Collection, error)
+	SearchCollectionsByNamePrefix(prefix string, limit int) ([]db.Collection, error)
 	GetCollectionActivities(pagination dto.PaginationQuery, collectionAddress string, search string) ([]dto.CollectionActivityModel, int64, error)
 	GetCollectionCreator(collectionAddress string) (*dto.CollectionCreatorModel, error)
 	GetCollectionMutateEvents(pagination dto.PaginationQuery, collectionAddress string) ([]dto.MutateEventModel, int64, error)
 	GetNftByNftAddress(collectionAddress string, nftAddress string) (*dto.NftByAddressModel, error)
+	GetNftByID(nftAddress string) (*dto.NftByAddressModel, error)
 	GetNftsByAccountAddress(pagination dto.PaginationQuery, accountAddress string, collectionAddress string, search string) ([]dto.NftByAddressModel, int64, error)
-	GetNftsByCollectionAddress(pagination dto.PaginationQuery, collectionAddress string, search string) ([]dto.NftByAddressModel, int64, error)
+	GetNftsByCollectionAddress(pagination dto.PaginationQuery, collectionAddress string, search string, traitType string, traitValue string) ([]dto.NftByAddressModel, int64, error)
 	GetNftMintInfo(nftAddress string) (*dto.NftMintInfoModel, error)
 	GetNftMutateEvents(pagination dto.PaginationQuery, nftAddress string) ([]dto.MutateEventModel, int64, error)
 	GetNftTxs(pagination dto.PaginationQuery, nftAddress string) ([]dto.NftTxModel, int64, error)
+	GetNftOwners(pagination dto.PaginationQuery, nftAddress string) ([]dto.NftOwnerModel, int64, error)
+	GetNftOwnerAtHeight(nftAddress string, height int64) (*dto.NftOwnerModel, error)
+	GetNftBurnHeight(nftAddress string) (*int64, error)
+	GetCollectionTransferVolume(collectionAddress string, granularity string, start, end time.Time) ([]dto.CollectionTransferVolume, error)
 }
 
 // ProposalRepositoryI defines the interface for proposal data access operations
@@ -66,16 +87,21 @@ type ProposalRepositoryI interface {
 	GetProposals(pagination *dto.PaginationQuery) ([]db.Proposal, error)
 	GetProposalVotesByValidator(operatorAddr string) ([]db.ProposalVote, error)
 	SearchProposals(pagination dto.PaginationQuery, proposer, search string, statuses, types []string) ([]dto.ProposalSummary, int64, error)
+	SearchProposalsByTitlePrefix(prefix string, limit int) ([]dto.ProposalSummary, error)
 	GetAllProposalTypes() (*dto.ProposalsTypesResponse, error)
 	GetProposalInfo(id int) (*dto.ProposalInfo, error)
 	GetProposalVotes(id int, limit, offset int64, search, answer string) ([]dto.ProposalVote, int64, error)
 	GetProposalValidatorVotes(id int) ([]dto.ProposalVote, error)
 	GetProposalAnswerCounts(id int) (*dto.ProposalAnswerCountsResponse, error)
+	GetProposalTallyHistory(id int) ([]dto.ProposalTallySnapshotModel, error)
+	GetProposalDeposits(pagination dto.PaginationQuery, id int) ([]dto.ProposalDepositModel, int64, error)
+	GetProposalDepositSummary(id int) (*dto.ProposalDepositSummaryModel, error)
 }
 
 // TxRepositoryI defines the interface for transaction data access operations
 type TxRepositoryI interface {
 	GetTxByHash(ctx context.Context, hash string) (*dto.TxByHashResponse, error)
+	GetTxSummaryByHash(hash string) (*dto.TxSummaryModel, error)
 	GetTxCount() (*int64, error)
 	GetTxs(pagination *dto.PaginationQuery) ([]dto.TxModel, int64, error)
 }
@@ -91,8 +117,12 @@ type BlockRepositoryI interface {
 }
 
 type AccountRepositoryI interface {
+	GetAccounts(pagination dto.PaginationQuery, accountType string) ([]db.Account, int64, error)
 	GetAccountByAccountAddress(accountAddress string) (*db.Account, error)
 	GetAccountProposals(pagination dto.PaginationQuery, accountAddress string) ([]db.Proposal, int64, error)
+	GetAccountDepositedProposals(pagination dto.PaginationQuery, accountAddress string) ([]dto.AccountDepositedProposalModel, int64, error)
+	GetAccountStat(accountAddress string) (*db.AccountStat, error)
+	GetAccountNftHoldings(vmAddress string) (*dto.AccountNftHoldingsModel, error)
 	GetAccountTxs(
 		pagination dto.PaginationQuery,
 		accountAddress string,
@@ -112,11 +142,19 @@ type ValidatorRepositoryI interface {
 	GetValidators(pagination dto.PaginationQuery, status dto.ValidatorStatusFilter, sortBy, search string) ([]dto.ValidatorWithVoteCountModel, int64, error)
 	GetValidatorsByPower(pagination *dto.PaginationQuery, onlyActive bool) ([]db.Validator, error)
 	GetValidatorRow(operatorAddr string) (*db.Validator, error)
+	SearchValidatorsByMonikerPrefix(prefix string, limit int) ([]db.Validator, error)
 	GetValidatorBlockVoteByBlockLimit(minHeight, maxHeight int64) ([]dto.ValidatorBlockVoteModel, error)
 	GetValidatorCommitSignatures(operatorAddr string, minHeight, maxHeight int64) ([]dto.ValidatorBlockVoteModel, error)
 	GetValidatorSlashEvents(operatorAddr string, minTimestamp time.Time) ([]dto.ValidatorUptimeEventModel, error)
+	GetValidatorSlashHistory(pagination dto.PaginationQuery, operatorAddr string) ([]dto.ValidatorUptimeEventModel, int64, error)
+	GetValidatorDowntimeEpisodes(pagination dto.PaginationQuery, operatorAddr string) ([]dto.ValidatorDowntimeEpisodeModel, int64, error)
 	GetValidatorUptimeInfo(operatorAddr string) (*dto.ValidatorWithVoteCountModel, error)
 	GetValidatorBondedTokenChanges(pagination dto.PaginationQuery, operatorAddr string) ([]db.ValidatorBondedTokenChange, int64, error)
 	GetValidatorProposedBlocks(pagination dto.PaginationQuery, operatorAddr string) ([]dto.ValidatorProposedBlockModel, int64, error)
 	GetValidatorHistoricalPowers(operatorAddr string) ([]dto.ValidatorHistoricalPowerModel, int64, error)
 }
+
+// StatsRepositoryI defines the interface for chain stats data access operations
+type StatsRepositoryI interface {
+	GetChainStats(granularity string, from, to time.Time) ([]dto.ChainStatModel, error)
+}
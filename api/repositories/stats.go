@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/logger"
+)
+
+var _ StatsRepositoryI = &StatsRepository{}
+
+// StatsRepository implements StatsRepositoryI
+type StatsRepository struct {
+	db *gorm.DB
+}
+
+func NewStatsRepository(db *gorm.DB) *StatsRepository {
+	return &StatsRepository{
+		db: db,
+	}
+}
+
+// GetChainStats retrieves the hourly or daily rollups of the periods starting between from and to, oldest first
+func (r *StatsRepository) GetChainStats(granularity string, from, to time.Time) ([]dto.ChainStatModel, error) {
+	stats := make([]dto.ChainStatModel, 0)
+
+	if err := r.db.Model(&db.ChainStat{}).
+		Where("granularity = ? AND timestamp >= ? AND timestamp <= ?", granularity, from, to).
+		Order("timestamp").
+		Find(&stats).Error; err != nil {
+		logger.Get().Error().Err(err).Msgf("Failed to query %s chain stats", granularity)
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -139,6 +139,23 @@ func (r *TxRepository) GetTxCount() (*int64, error) {
 	return &record.TxCount, nil
 }
 
+// GetTxSummaryByHash retrieves the indexed summary of a transaction by hash
+func (r *TxRepository) GetTxSummaryByHash(hash string) (*dto.TxSummaryModel, error) {
+	var record dto.TxSummaryModel
+
+	if err := r.db.
+		Model(&db.Transaction{}).
+		Select("transactions.hash, transactions.success, blocks.height, blocks.timestamp").
+		Joins("LEFT JOIN blocks ON transactions.block_height = blocks.height").
+		Where("transactions.hash = ?", "\\x"+hash).
+		First(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to query transaction by hash")
+		return nil, err
+	}
+
+	return &record, nil
+}
+
 // GetTxs retrieves a list of transactions with pagination
 func (r *TxRepository) GetTxs(pagination *dto.PaginationQuery) ([]dto.TxModel, int64, error) {
 	record := make([]dto.TxModel, 0)
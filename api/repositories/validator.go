@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm/clause"
 
 	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/utils"
 	"github.com/initia-labs/core-indexer/pkg/db"
 	"github.com/initia-labs/core-indexer/pkg/logger"
 )
@@ -196,6 +197,22 @@ func (r *ValidatorRepository) GetValidatorRow(operatorAddr string) (*db.Validato
 	return &record, nil
 }
 
+// SearchValidatorsByMonikerPrefix retrieves the validators whose moniker starts with prefix, by voting power
+func (r *ValidatorRepository) SearchValidatorsByMonikerPrefix(prefix string, limit int) ([]db.Validator, error) {
+	record := make([]db.Validator, 0)
+
+	if err := r.db.Model(&db.Validator{}).
+		Where("moniker ILIKE ?", utils.EscapeLikePattern(prefix)+"%").
+		Order("voting_power DESC").
+		Limit(limit).
+		Find(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to search validators by moniker")
+		return nil, err
+	}
+
+	return record, nil
+}
+
 func (r *ValidatorRepository) GetValidatorBlockVoteByBlockLimit(minHeight, maxHeight int64) ([]dto.ValidatorBlockVoteModel, error) {
 	var record []dto.ValidatorBlockVoteModel
 
@@ -234,11 +251,14 @@ func (r *ValidatorRepository) GetValidatorCommitSignatures(operatorAddr string,
 	return record, nil
 }
 
+const validatorSlashEventColumns = "blocks.height as height, blocks.timestamp as timestamp, validator_slash_events.type as type, " +
+	"validator_slash_events.reason, validator_slash_events.power, validator_slash_events.burned_coins, validator_slash_events.infraction_height"
+
 func (r *ValidatorRepository) GetValidatorSlashEvents(operatorAddr string, minTimestamp time.Time) ([]dto.ValidatorUptimeEventModel, error) {
 	var record []dto.ValidatorUptimeEventModel
 
 	if err := r.db.Model(&db.ValidatorSlashEvent{}).
-		Select("blocks.height as height, blocks.timestamp as timestamp, validator_slash_events.type as type").
+		Select(validatorSlashEventColumns).
 		Joins("JOIN blocks ON validator_slash_events.block_height = blocks.height").
 		Where("validator_slash_events.validator_address = ? AND blocks.timestamp >= ?",
 			operatorAddr, minTimestamp).
@@ -256,6 +276,84 @@ func (r *ValidatorRepository) GetValidatorSlashEvents(operatorAddr string, minTi
 	return record, nil
 }
 
+// GetValidatorSlashHistory retrieves the slash, jail and unjail events of a validator
+func (r *ValidatorRepository) GetValidatorSlashHistory(pagination dto.PaginationQuery, operatorAddr string) ([]dto.ValidatorUptimeEventModel, int64, error) {
+	var record []dto.ValidatorUptimeEventModel
+	var total int64
+
+	if err := r.db.Model(&db.ValidatorSlashEvent{}).
+		Select(validatorSlashEventColumns).
+		Joins("JOIN blocks ON validator_slash_events.block_height = blocks.height").
+		Where("validator_slash_events.validator_address = ?", operatorAddr).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{
+				Name: "validator_slash_events.block_height",
+			},
+			Desc: pagination.Reverse,
+		}).
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msgf("Failed to query validator slash history for %s", operatorAddr)
+		return nil, 0, err
+	}
+
+	if pagination.CountTotal {
+		var err error
+		total, err = db.CountWithTimeout(r.db.Model(&db.ValidatorSlashEvent{}).Where("validator_address = ?", operatorAddr), r.countQueryTimeout)
+		if err != nil {
+			logger.Get().Error().Err(err).Msgf("Failed to count validator slash history for %s", operatorAddr)
+			return nil, 0, err
+		}
+	}
+
+	return record, total, nil
+}
+
+// GetValidatorDowntimeEpisodes retrieves the downtime episodes of a validator with the timestamps of their first and last missed blocks
+func (r *ValidatorRepository) GetValidatorDowntimeEpisodes(pagination dto.PaginationQuery, operatorAddr string) ([]dto.ValidatorDowntimeEpisodeModel, int64, error) {
+	var record []dto.ValidatorDowntimeEpisodeModel
+	var total int64
+
+	if err := r.db.Model(&db.ValidatorDowntimeEpisode{}).
+		Select(
+			"validator_downtime_episodes.id",
+			"validator_downtime_episodes.type",
+			"validator_downtime_episodes.start_height",
+			"start_blocks.timestamp AS start_timestamp",
+			"validator_downtime_episodes.end_height",
+			"end_blocks.timestamp AS end_timestamp",
+			"validator_downtime_episodes.missed_count",
+			"validator_downtime_episodes.is_resolved",
+		).
+		Joins("LEFT JOIN blocks AS start_blocks ON start_blocks.height = validator_downtime_episodes.start_height").
+		Joins("LEFT JOIN blocks AS end_blocks ON end_blocks.height = validator_downtime_episodes.end_height").
+		Where("validator_downtime_episodes.validator_address = ?", operatorAddr).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{
+				Name: "validator_downtime_episodes.start_height",
+			},
+			Desc: pagination.Reverse,
+		}).
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&record).Error; err != nil {
+		logger.Get().Error().Err(err).Msgf("Failed to query validator downtime episodes for %s", operatorAddr)
+		return nil, 0, err
+	}
+
+	if pagination.CountTotal {
+		var err error
+		total, err = db.CountWithTimeout(r.db.Model(&db.ValidatorDowntimeEpisode{}).Where("validator_address = ?", operatorAddr), r.countQueryTimeout)
+		if err != nil {
+			logger.Get().Error().Err(err).Msgf("Failed to count validator downtime episodes for %s", operatorAddr)
+			return nil, 0, err
+		}
+	}
+
+	return record, total, nil
+}
+
 func (r *ValidatorRepository) GetValidatorUptimeInfo(operatorAddr string) (*dto.ValidatorWithVoteCountModel, error) {
 	var record dto.ValidatorWithVoteCountModel
 
@@ -18,8 +18,11 @@ func SetupAccountRoutes(app *fiber.App, accountRepo repositories.AccountReposito
 	// Account routes
 	v1 := app.Group("/indexer/account/v1")
 	{
+		v1.Get("/accounts", accountHandler.GetAccounts)
 		v1.Get("/:accountAddress", accountHandler.GetAccountByAccountAddress)
 		v1.Get("/:accountAddress/proposals", accountHandler.GetAccountProposals)
+		v1.Get("/:accountAddress/deposited_proposals", accountHandler.GetAccountDepositedProposals)
+		v1.Get("/:accountAddress/summary", accountHandler.GetAccountSummary)
 		v1.Get("/:accountAddress/txs", accountHandler.GetAccountTxs)
 	}
 }
@@ -8,9 +8,11 @@ import (
 	"github.com/initia-labs/core-indexer/api/services"
 )
 
-func SetupModuleRoutes(app *fiber.App, moduleRepo repositories.ModuleRepositoryI) {
+func SetupModuleRoutes(app *fiber.App, moduleRepo repositories.ModuleRepositoryI, verificationRepo repositories.ModuleVerificationRepositoryI) {
 	moduleService := services.NewModuleService(moduleRepo)
 	moduleHandler := handlers.NewModuleHandler(moduleService)
+	verificationService := services.NewModuleVerificationService(verificationRepo)
+	verificationHandler := handlers.NewModuleVerificationHandler(verificationService)
 
 	// Module routes
 	v1 := app.Group("/indexer/module/v1")
@@ -25,6 +27,19 @@ func SetupModuleRoutes(app *fiber.App, moduleRepo repositories.ModuleRepositoryI
 			modules.Get("/:vmAddress/:name/proposals", moduleHandler.GetModuleProposals)
 			modules.Get("/:vmAddress/:name/transactions", moduleHandler.GetModuleTransactions)
 			modules.Get("/:vmAddress/:name/stats", moduleHandler.GetModuleStats)
+			modules.Get("/:vmAddress/:name/functions", moduleHandler.GetModuleFunctions)
+			modules.Get("/:vmAddress/:name/verification", verificationHandler.GetModuleVerifiedSource)
+			modules.Get("/:vmAddress/:name/histories/:height/verification", verificationHandler.GetModuleHistoryVerifiedSource)
+		}
+
+		// Verifications
+		verifications := v1.Group("/verifications")
+		{
+			// submissions need the writable verification database
+			if verificationRepo.CanSubmit() {
+				verifications.Post("/", verificationHandler.SubmitModuleVerification)
+			}
+			verifications.Get("/:id", verificationHandler.GetModuleVerification)
 		}
 	}
 }
@@ -28,6 +28,7 @@ func SetupNftRoutes(app *fiber.App, nftRepo repositories.NftRepositoryI) {
 			collections.Get("/:collectionAddress/activities", nftHandler.GetCollectionActivities)
 			collections.Get("/:collectionAddress/creator", nftHandler.GetCollectionCreator)
 			collections.Get("/:collectionAddress/mutate_events", nftHandler.GetCollectionMutateEvents)
+			collections.Get("/:collectionAddress/transfer_volume", nftHandler.GetCollectionTransferVolume)
 		}
 
 		tokens := v1.Group("/tokens")
@@ -42,6 +43,8 @@ func SetupNftRoutes(app *fiber.App, nftRepo repositories.NftRepositoryI) {
 			token.Get("/:nftAddress/mint_info", nftHandler.GetNftMintInfo)
 			token.Get("/:nftAddress/mutate_events", nftHandler.GetNftMutateEvents)
 			token.Get("/:nftAddress/txs", nftHandler.GetNftTxs)
+			token.Get("/:nftAddress/owners", nftHandler.GetNftOwners)
+			token.Get("/:nftAddress/owner/:height", nftHandler.GetNftOwnerAtHeight)
 		}
 	}
 }
@@ -23,4 +23,7 @@ func SetupProposalRoutes(app *fiber.App, repo repositories.ProposalRepositoryI)
 	proposal.Get("/votes", proposalHandler.GetProposalVotes)
 	proposal.Get("/validator_votes", proposalHandler.GetProposalValidatorVotes)
 	proposal.Get("/answer_counts", proposalHandler.GetProposalAnswerCounts)
+	proposal.Get("/tally_history", proposalHandler.GetProposalTallyHistory)
+	proposal.Get("/deposits", proposalHandler.GetProposalDeposits)
+	proposal.Get("/deposit_summary", proposalHandler.GetProposalDepositSummary)
 }
@@ -9,15 +9,17 @@ import (
 	"github.com/initia-labs/core-indexer/api/repositories"
 )
 
-// SetupRoutes configures all the routes for the API
-func SetupRoutes(app *fiber.App, dbClient *gorm.DB, buckets []*blob.Bucket, config *config.Config) {
-	repos := repositories.SetupRepositories(dbClient, buckets, config.Repository.CountQueryTimeout)
+// SetupRoutes configures all the routes for the API, verificationDBClient is nil when verification submissions are disabled
+func SetupRoutes(app *fiber.App, dbClient *gorm.DB, verificationDBClient *gorm.DB, buckets []*blob.Bucket, config *config.Config) {
+	repos := repositories.SetupRepositories(dbClient, verificationDBClient, buckets, config.Repository.CountQueryTimeout)
 
 	SetupBlockRoutes(app, repos.BlockRepository)
-	SetupModuleRoutes(app, repos.ModuleRepository)
+	SetupModuleRoutes(app, repos.ModuleRepository, repos.ModuleVerificationRepository)
 	SetupNftRoutes(app, repos.NftRepository)
 	SetupProposalRoutes(app, repos.ProposalRepository)
 	SetupTxRoutes(app, repos.TxRepository, repos.AccountRepository, config)
 	SetupValidatorRoutes(app, repos.ValidatorRepository, repos.BlockRepository, repos.ProposalRepository)
 	SetupAccountRoutes(app, repos.AccountRepository)
+	SetupSearchRoutes(app, repos)
+	SetupStatsRoutes(app, repos.StatsRepository)
 }
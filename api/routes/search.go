@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/initia-labs/core-indexer/api/handlers"
+	"github.com/initia-labs/core-indexer/api/repositories"
+	"github.com/initia-labs/core-indexer/api/services"
+)
+
+// SetupSearchRoutes sets up the search routes
+func SetupSearchRoutes(app *fiber.App, repos *repositories.Repositories) {
+	searchService := services.NewSearchService(
+		repos.AccountRepository,
+		repos.BlockRepository,
+		repos.ModuleRepository,
+		repos.NftRepository,
+		repos.ProposalRepository,
+		repos.TxRepository,
+		repos.ValidatorRepository,
+	)
+	searchHandler := handlers.NewSearchHandler(searchService)
+
+	v1 := app.Group("/indexer/search/v1")
+	v1.Get("/", searchHandler.Search)
+}
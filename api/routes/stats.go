@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/initia-labs/core-indexer/api/handlers"
+	"github.com/initia-labs/core-indexer/api/repositories"
+	"github.com/initia-labs/core-indexer/api/services"
+)
+
+func SetupStatsRoutes(app *fiber.App, statsRepo repositories.StatsRepositoryI) {
+	statsService := services.NewStatsService(statsRepo)
+
+	statsHandler := handlers.NewStatsHandler(statsService)
+
+	v1 := app.Group("/indexer/stats/v1")
+	{
+		v1.Get("/hourly", statsHandler.GetHourlyChainStats)
+		v1.Get("/daily", statsHandler.GetDailyChainStats)
+	}
+}
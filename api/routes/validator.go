@@ -24,6 +24,8 @@ func SetupValidatorRoutes(app *fiber.App, validatorRepo repositories.ValidatorRe
 	validator.Get("/info", validatorHandler.GetValidatorInfo)
 	validator.Get("/uptime", validatorHandler.GetValidatorUptime)
 	validator.Get("/delegation_related_txs", validatorHandler.GetValidatorDelegationRelatedTxs)
+	validator.Get("/slash_events", validatorHandler.GetValidatorSlashEvents)
+	validator.Get("/downtime_episodes", validatorHandler.GetValidatorDowntimeEpisodes)
 	validator.Get("/proposed_blocks", validatorHandler.GetValidatorProposedBlocks)
 	validator.Get("/historical_powers", validatorHandler.GetValidatorHistoricalPowers)
 	validator.Get("/voted_proposals", validatorHandler.GetValidatorVotedProposals)
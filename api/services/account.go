@@ -1,16 +1,21 @@
 package services
 
 import (
+	"encoding/json"
 	"fmt"
 
+	"github.com/initia-labs/core-indexer/api/apperror"
 	"github.com/initia-labs/core-indexer/api/dto"
 	"github.com/initia-labs/core-indexer/api/repositories"
 	"github.com/initia-labs/core-indexer/pkg/db"
 )
 
 type AccountService interface {
+	GetAccounts(pagination dto.PaginationQuery, accountType string) (*dto.AccountsResponse, error)
 	GetAccountByAccountAddress(accountAddress string) (*db.Account, error)
 	GetAccountProposals(pagination dto.PaginationQuery, accountAddress string) (*dto.AccountProposalsResponse, error)
+	GetAccountDepositedProposals(pagination dto.PaginationQuery, accountAddress string) (*dto.AccountDepositedProposalsResponse, error)
+	GetAccountSummary(accountAddress, vmAddress string) (*dto.AccountSummaryResponse, error)
 	GetAccountTxs(pagination dto.PaginationQuery, accountAddress string, search string, isSend bool, isIbc bool, isOpinit bool, isMovePublish bool, isMoveUpgrade bool, isMoveExecute bool, isMoveScript bool, isSigner *bool) (*dto.AccountTxsResponse, error)
 }
 
@@ -24,6 +29,36 @@ func NewAccountService(repo repositories.AccountRepositoryI) AccountService {
 	}
 }
 
+func (s *accountService) GetAccounts(pagination dto.PaginationQuery, accountType string) (*dto.AccountsResponse, error) {
+	allowedTypes := map[db.AccountType]struct{}{
+		db.BaseAccount:              {},
+		db.InterchainAccount:        {},
+		db.ModuleAccount:            {},
+		db.ContinuousVestingAccount: {},
+		db.DelayedVestingAccount:    {},
+		db.ClawbackVestingAccount:   {},
+		db.PermanentLockedAccount:   {},
+		db.PeriodicVestingAccount:   {},
+		db.ContractAccount:          {},
+	}
+
+	if accountType != "" {
+		if _, ok := allowedTypes[db.AccountType(accountType)]; !ok {
+			return nil, apperror.NewValidationError(apperror.ErrMsgAccountType)
+		}
+	}
+
+	accounts, total, err := s.repo.GetAccounts(pagination, accountType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AccountsResponse{
+		Accounts:   accounts,
+		Pagination: dto.NewPaginationResponse(pagination.Offset, pagination.Limit, total),
+	}, nil
+}
+
 func (s *accountService) GetAccountByAccountAddress(accountAddress string) (*db.Account, error) {
 	account, err := s.repo.GetAccountByAccountAddress(accountAddress)
 	if err != nil {
@@ -67,6 +102,75 @@ func (s *accountService) GetAccountProposals(pagination dto.PaginationQuery, acc
 	return response, nil
 }
 
+// GetAccountDepositedProposals retrieves the proposals an account deposited on with the total it deposited on each
+func (s *accountService) GetAccountDepositedProposals(pagination dto.PaginationQuery, accountAddress string) (*dto.AccountDepositedProposalsResponse, error) {
+	proposals, total, err := s.repo.GetAccountDepositedProposals(pagination, accountAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AccountDepositedProposalsResponse{
+		Proposals:  make([]dto.AccountDepositedProposal, len(proposals)),
+		Pagination: dto.NewPaginationResponse(pagination.Offset, pagination.Limit, total),
+	}
+
+	for idx, proposal := range proposals {
+		var amounts []dto.Coins
+		if err := json.Unmarshal(proposal.Amounts, &amounts); err != nil {
+			return nil, err
+		}
+		response.Proposals[idx] = dto.AccountDepositedProposal{
+			ID:                   proposal.ID,
+			Title:                proposal.Title,
+			Status:               proposal.Status,
+			IsEmergency:          proposal.IsEmergency,
+			IsExpedited:          proposal.IsExpedited,
+			DepositEndTime:       proposal.DepositEndTime,
+			VotingEndTime:        proposal.VotingEndTime,
+			Deposited:            sumCoins(amounts...),
+			LastDepositTimestamp: proposal.LastDepositTimestamp,
+		}
+	}
+
+	return response, nil
+}
+
+// GetAccountSummary combines the activity of an account maintained by the indexer with the Nfts it currently holds
+func (s *accountService) GetAccountSummary(accountAddress, vmAddress string) (*dto.AccountSummaryResponse, error) {
+	stat, err := s.repo.GetAccountStat(accountAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings, err := s.repo.GetAccountNftHoldings(vmAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AccountSummaryResponse{
+		CollectionsHeld: holdings.CollectionsHeld,
+		NftsHeld:        holdings.NftsHeld,
+	}
+	if stat != nil {
+		response.FirstSeenHeight = &stat.FirstSeenHeight
+		response.FirstSeenTimestamp = &stat.FirstSeenTimestamp
+		response.LastActiveHeight = &stat.LastActiveHeight
+		response.LastActiveTimestamp = &stat.LastActiveTimestamp
+		response.TxCount = stat.TxCount
+		response.SignerTxCount = stat.SignerTxCount
+		response.TxCategoryCounts = dto.AccountTxCategoryCounts{
+			Send:        stat.SendTxCount,
+			Ibc:         stat.IbcTxCount,
+			MoveExecute: stat.MoveExecuteTxCount,
+			MovePublish: stat.MovePublishTxCount,
+			Nft:         stat.NftTxCount,
+		}
+		response.ProposalsVoted = stat.ProposalsVoted
+	}
+
+	return response, nil
+}
+
 func (s *accountService) GetAccountTxs(
 	pagination dto.PaginationQuery,
 	accountAddress string,
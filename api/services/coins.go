@@ -0,0 +1,91 @@
+package services
+
+import (
+	"math/big"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+)
+
+// sumCoins adds up the amounts of the given coins per denom, keeping the order in which the denoms first appear
+func sumCoins(coinSets ...dto.Coins) dto.Coins {
+	totals := make(map[string]*big.Int)
+	denoms := make([]string, 0)
+	for _, coins := range coinSets {
+		for _, coin := range coins {
+			amount, ok := new(big.Int).SetString(coin.Amount, 10)
+			if !ok {
+				continue
+			}
+			if _, ok := totals[coin.Denom]; !ok {
+				totals[coin.Denom] = new(big.Int)
+				denoms = append(denoms, coin.Denom)
+			}
+			totals[coin.Denom].Add(totals[coin.Denom], amount)
+		}
+	}
+
+	sum := make(dto.Coins, len(denoms))
+	for idx, denom := range denoms {
+		sum[idx] = dto.Coin{Denom: denom, Amount: totals[denom].String()}
+	}
+
+	return sum
+}
+
+// missingCoins returns the amounts still needed for current to cover required
+func missingCoins(required, current dto.Coins) dto.Coins {
+	have := coinAmounts(current)
+	missing := make(dto.Coins, 0)
+	for _, coin := range required {
+		need, ok := new(big.Int).SetString(coin.Amount, 10)
+		if !ok {
+			continue
+		}
+		if amount, ok := have[coin.Denom]; ok {
+			need.Sub(need, amount)
+		}
+		if need.Sign() > 0 {
+			missing = append(missing, dto.Coin{Denom: coin.Denom, Amount: need.String()})
+		}
+	}
+
+	return missing
+}
+
+// coinsCoverage returns how much of required is covered by current, between 0 and 1. Like the chain check of a
+// deposit against the minimum deposit, every denom of required has to be covered, so the least covered one counts.
+func coinsCoverage(required, current dto.Coins) float64 {
+	have := coinAmounts(current)
+	coverage := 1.0
+	for _, coin := range required {
+		need, ok := new(big.Float).SetString(coin.Amount)
+		if !ok || need.Sign() <= 0 {
+			continue
+		}
+		amount, ok := have[coin.Denom]
+		if !ok {
+			return 0
+		}
+		ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), need).Float64()
+		coverage = min(coverage, ratio)
+	}
+
+	return coverage
+}
+
+func coinAmounts(coins dto.Coins) map[string]*big.Int {
+	amounts := make(map[string]*big.Int, len(coins))
+	for _, coin := range coins {
+		amount, ok := new(big.Int).SetString(coin.Amount, 10)
+		if !ok {
+			continue
+		}
+		if total, ok := amounts[coin.Denom]; ok {
+			total.Add(total, amount)
+		} else {
+			amounts[coin.Denom] = amount
+		}
+	}
+
+	return amounts
+}
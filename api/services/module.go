@@ -2,7 +2,9 @@ package services
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/initia-labs/core-indexer/api/dto"
 	"github.com/initia-labs/core-indexer/api/repositories"
@@ -17,6 +19,17 @@ type ModuleService interface {
 	GetModuleProposals(pagination dto.PaginationQuery, vmAddress string, name string) (*dto.ModuleProposalsResponse, error)
 	GetModuleTransactions(pagination dto.PaginationQuery, vmAddress string, name string) (*dto.ModuleTxsResponse, error)
 	GetModuleStats(vmAddress string, name string) (*dto.ModuleStatsResponse, error)
+	GetModuleFunctions(vmAddress string, name string) (*dto.ModuleFunctionsResponse, error)
+}
+
+// callStatsWindows are the windows of the function call statistics, in UTC days including the current one
+var callStatsWindows = []struct {
+	Name string
+	Days int
+}{
+	{Name: "1d", Days: 1},
+	{Name: "7d", Days: 7},
+	{Name: "30d", Days: 30},
 }
 
 type moduleService struct {
@@ -150,12 +163,89 @@ func (s *moduleService) GetModuleTransactions(pagination dto.PaginationQuery, vm
 	}, nil
 }
 
-// GetModuleStats retrieves a module stats by module id
+// GetModuleStats retrieves a module stats by module id, with its entry function calls over each window
 func (s *moduleService) GetModuleStats(vmAddress string, name string) (*dto.ModuleStatsResponse, error) {
 	stats, err := s.repo.GetModuleStats(vmAddress, name)
 	if err != nil {
 		return nil, err
 	}
 
+	moduleID := fmt.Sprintf("%s::%s", vmAddress, name)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	stats.Calls = make([]dto.FunctionCallsWindow, 0, len(callStatsWindows))
+	for _, window := range callStatsWindows {
+		callStats, err := s.repo.GetModuleCallStats(moduleID, today.AddDate(0, 0, 1-window.Days))
+		if err != nil {
+			return nil, err
+		}
+		stats.Calls = append(stats.Calls, newFunctionCallsWindow(window.Name, *callStats))
+	}
+
 	return stats, nil
 }
+
+// GetModuleFunctions retrieves the call statistics of each entry function of a module over each window
+func (s *moduleService) GetModuleFunctions(vmAddress string, name string) (*dto.ModuleFunctionsResponse, error) {
+	moduleID := fmt.Sprintf("%s::%s", vmAddress, name)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	functions := make([]dto.ModuleFunctionResponse, 0)
+	indexes := make(map[string]int)
+	for windowIdx, window := range callStatsWindows {
+		callStats, err := s.repo.GetModuleFunctionCallStats(moduleID, today.AddDate(0, 0, 1-window.Days))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, functionStats := range callStats {
+			idx, ok := indexes[functionStats.FunctionName]
+			if !ok {
+				idx = len(functions)
+				indexes[functionStats.FunctionName] = idx
+				functions = append(functions, dto.ModuleFunctionResponse{
+					FunctionName: functionStats.FunctionName,
+					Calls:        make([]dto.FunctionCallsWindow, windowIdx, len(callStatsWindows)),
+				})
+				// functions not called in the previous, shorter windows
+				for i := range windowIdx {
+					functions[idx].Calls[i] = dto.FunctionCallsWindow{Window: callStatsWindows[i].Name}
+				}
+			}
+			functions[idx].Calls = append(functions[idx].Calls, newFunctionCallsWindow(window.Name, functionStats))
+		}
+
+		for idx := range functions {
+			if len(functions[idx].Calls) <= windowIdx {
+				functions[idx].Calls = append(functions[idx].Calls, dto.FunctionCallsWindow{Window: window.Name})
+			}
+		}
+	}
+
+	// most called functions over the longest window first
+	last := len(callStatsWindows) - 1
+	sort.SliceStable(functions, func(i, j int) bool {
+		if functions[i].Calls[last].CallCount != functions[j].Calls[last].CallCount {
+			return functions[i].Calls[last].CallCount > functions[j].Calls[last].CallCount
+		}
+		return functions[i].FunctionName < functions[j].FunctionName
+	})
+
+	return &dto.ModuleFunctionsResponse{
+		Functions: functions,
+	}, nil
+}
+
+func newFunctionCallsWindow(window string, stats dto.FunctionCallStatsModel) dto.FunctionCallsWindow {
+	calls := dto.FunctionCallsWindow{
+		Window:        window,
+		CallCount:     stats.CallCount,
+		FailureCount:  stats.FailureCount,
+		GasUsed:       stats.GasUsed,
+		UniqueCallers: stats.UniqueCallers,
+	}
+	if stats.CallCount > 0 {
+		calls.FailureRate = float64(stats.FailureCount) / float64(stats.CallCount)
+	}
+
+	return calls
+}
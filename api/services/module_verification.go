@@ -0,0 +1,131 @@
+package services
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/core-indexer/api/apperror"
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories"
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/parser"
+)
+
+const (
+	maxVerificationFiles        = 256
+	maxVerificationPackageBytes = 2 << 20
+	moveManifestPath            = "Move.toml"
+)
+
+type ModuleVerificationService interface {
+	SubmitModuleVerification(request dto.ModuleVerificationRequest) (*db.ModuleVerification, error)
+	GetModuleVerification(id int64) (*db.ModuleVerification, error)
+	GetVerifiedModule(vmAddress string, name string, height *int64) (*dto.VerifiedModuleResponse, error)
+}
+
+type moduleVerificationService struct {
+	repo repositories.ModuleVerificationRepositoryI
+}
+
+func NewModuleVerificationService(repo repositories.ModuleVerificationRepositoryI) ModuleVerificationService {
+	return &moduleVerificationService{
+		repo: repo,
+	}
+}
+
+// SubmitModuleVerification validates a Move package and queues it for verification
+func (s *moduleVerificationService) SubmitModuleVerification(request dto.ModuleVerificationRequest) (*db.ModuleVerification, error) {
+	address, err := parser.AccAddressFromString(request.Address)
+	if err != nil {
+		return nil, apperror.NewValidationError(apperror.ErrMsgVerificationAddress)
+	}
+
+	if len(request.Files) == 0 || len(request.Files) > maxVerificationFiles {
+		return nil, apperror.NewValidationError(apperror.ErrMsgVerificationFiles)
+	}
+
+	files := make([]db.ModuleVerificationFile, 0, len(request.Files))
+	paths := make(map[string]bool, len(request.Files))
+	size := 0
+	for _, file := range request.Files {
+		if !isValidSourcePath(file.Path) || paths[file.Path] {
+			return nil, apperror.NewValidationError(apperror.ErrMsgVerificationPath)
+		}
+		paths[file.Path] = true
+
+		size += len(file.Content)
+		if size > maxVerificationPackageBytes {
+			return nil, apperror.NewValidationError(apperror.ErrMsgVerificationSize)
+		}
+
+		files = append(files, db.ModuleVerificationFile{
+			Path:    file.Path,
+			Content: file.Content,
+		})
+	}
+	if !paths[moveManifestPath] {
+		return nil, apperror.NewValidationError(apperror.ErrMsgVerificationToml)
+	}
+
+	verification := &db.ModuleVerification{
+		Address:     parser.BytesToHexWithPrefix(address),
+		Status:      string(db.VerificationPending),
+		SubmittedAt: time.Now().UTC(),
+	}
+	if err := s.repo.CreateModuleVerification(verification, files); err != nil {
+		return nil, err
+	}
+
+	return verification, nil
+}
+
+// GetModuleVerification retrieves the status of a module verification
+func (s *moduleVerificationService) GetModuleVerification(id int64) (*db.ModuleVerification, error) {
+	return s.repo.GetModuleVerification(id)
+}
+
+// GetVerifiedModule retrieves the verified sources of a module version, the current one when height is nil
+func (s *moduleVerificationService) GetVerifiedModule(vmAddress string, name string, height *int64) (*dto.VerifiedModuleResponse, error) {
+	verifiedModule, err := s.repo.GetVerifiedModule(fmt.Sprintf("%s::%s", vmAddress, name), height)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.repo.GetModuleVerificationFiles(verifiedModule.VerificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceFiles := make([]dto.ModuleSourceFile, len(files))
+	for i, file := range files {
+		sourceFiles[i] = dto.ModuleSourceFile{
+			Path:    file.Path,
+			Content: file.Content,
+		}
+	}
+
+	return &dto.VerifiedModuleResponse{
+		ModuleID:        verifiedModule.ModuleID,
+		Digest:          verifiedModule.Digest,
+		VerificationID:  verifiedModule.VerificationID,
+		PackageName:     verifiedModule.PackageName,
+		CompilerVersion: verifiedModule.CompilerVersion,
+		Abi:             verifiedModule.Abi,
+		VerifiedAt:      verifiedModule.VerifiedAt,
+		Files:           sourceFiles,
+	}, nil
+}
+
+// isValidSourcePath reports whether p is a clean relative path of the package manifest or a Move source file
+func isValidSourcePath(p string) bool {
+	if p == "" || p != path.Clean(p) || path.IsAbs(p) || strings.Contains(p, "\\") {
+		return false
+	}
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return false
+	}
+
+	return p == moveManifestPath || strings.HasSuffix(p, ".move")
+}
@@ -1,8 +1,11 @@
 package services
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/initia-labs/core-indexer/api/apperror"
 	"github.com/initia-labs/core-indexer/api/dto"
 	"github.com/initia-labs/core-indexer/api/repositories"
 )
@@ -17,10 +20,13 @@ type NftService interface {
 	GetCollectionMutateEvents(pagination dto.PaginationQuery, collectionAddress string) (*dto.CollectionMutateEventsResponse, error)
 	GetNftByNftAddress(collectionAddress string, nftAddress string) (*dto.NftByAddressResponse, error)
 	GetNftsByAccountAddress(pagination dto.PaginationQuery, accountAddress string, collectionAddress string, search string) (*dto.NftsByAddressResponse, error)
-	GetNftsByCollectionAddress(pagination dto.PaginationQuery, collectionAddress string, search string) (*dto.NftsByAddressResponse, error)
+	GetNftsByCollectionAddress(pagination dto.PaginationQuery, collectionAddress string, search string, traitType string, traitValue string) (*dto.NftsByAddressResponse, error)
 	GetNftMintInfo(nftAddress string) (*dto.NftMintInfoResponse, error)
 	GetNftMutateEvents(pagination dto.PaginationQuery, nftAddress string) (*dto.NftMutateEventsResponse, error)
 	GetNftTxs(pagination dto.PaginationQuery, nftAddress string) (*dto.NftTxsResponse, error)
+	GetNftOwners(pagination dto.PaginationQuery, nftAddress string) (*dto.NftOwnersResponse, error)
+	GetNftOwnerAtHeight(nftAddress string, height int64) (*dto.NftOwnerAtHeightResponse, error)
+	GetCollectionTransferVolume(collectionAddress string, granularity string, from, to *time.Time) (*dto.CollectionTransferVolumeResponse, error)
 }
 
 // nftService implements the NftService interface
@@ -176,21 +182,12 @@ func (s *nftService) GetNftByNftAddress(collectionAddress string, nftAddress str
 		return nil, err
 	}
 
-	return &dto.NftByAddressResponse{
-		ObjectAddr:     nft.ID,
-		CollectionAddr: nft.Collection,
-		CollectionName: nft.CollectionName,
-		OwnerAddr:      nft.Owner,
-		Nft: dto.NftByAddressNft{
-			Collection: dto.NftByAddressNftCollection{
-				Inner: nft.Collection,
-			},
-			Description: nft.Description,
-			TokenID:     nft.TokenID,
-			URI:         nft.URI,
-			IsBurned:    nft.IsBurned,
-		},
-	}, nil
+	response, err := newNftByAddressResponse(*nft)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
 }
 
 func (s *nftService) GetNftsByAccountAddress(pagination dto.PaginationQuery, accountAddress string, collectionAddress string, search string) (*dto.NftsByAddressResponse, error) {
@@ -206,28 +203,21 @@ func (s *nftService) GetNftsByAccountAddress(pagination dto.PaginationQuery, acc
 	}
 
 	for idx, nft := range nfts {
-		response.Tokens[idx] = dto.NftByAddressResponse{
-			ObjectAddr:     nft.ID,
-			CollectionAddr: nft.Collection,
-			CollectionName: nft.CollectionName,
-			OwnerAddr:      nft.Owner,
-			Nft: dto.NftByAddressNft{
-				Collection: dto.NftByAddressNftCollection{
-					Inner: nft.Collection,
-				},
-				Description: nft.Description,
-				TokenID:     nft.TokenID,
-				URI:         nft.URI,
-				IsBurned:    nft.IsBurned,
-			},
+		response.Tokens[idx], err = newNftByAddressResponse(nft)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	return response, nil
 }
 
-func (s *nftService) GetNftsByCollectionAddress(pagination dto.PaginationQuery, collectionAddress string, search string) (*dto.NftsByAddressResponse, error) {
-	nfts, total, err := s.repo.GetNftsByCollectionAddress(pagination, collectionAddress, search)
+func (s *nftService) GetNftsByCollectionAddress(pagination dto.PaginationQuery, collectionAddress string, search string, traitType string, traitValue string) (*dto.NftsByAddressResponse, error) {
+	if traitValue != "" && traitType == "" {
+		return nil, apperror.NewValidationError(apperror.ErrMsgTraitType)
+	}
+
+	nfts, total, err := s.repo.GetNftsByCollectionAddress(pagination, collectionAddress, search, traitType, traitValue)
 
 	if err != nil {
 		return nil, err
@@ -239,20 +229,9 @@ func (s *nftService) GetNftsByCollectionAddress(pagination dto.PaginationQuery,
 	}
 
 	for idx, nft := range nfts {
-		response.Tokens[idx] = dto.NftByAddressResponse{
-			ObjectAddr:     nft.ID,
-			CollectionAddr: nft.Collection,
-			CollectionName: nft.CollectionName,
-			OwnerAddr:      nft.Owner,
-			Nft: dto.NftByAddressNft{
-				Collection: dto.NftByAddressNftCollection{
-					Inner: nft.Collection,
-				},
-				Description: nft.Description,
-				TokenID:     nft.TokenID,
-				URI:         nft.URI,
-				IsBurned:    nft.IsBurned,
-			},
+		response.Tokens[idx], err = newNftByAddressResponse(nft)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -310,3 +289,115 @@ func (s *nftService) GetNftTxs(pagination dto.PaginationQuery, nftAddress string
 
 	return response, nil
 }
+
+// GetNftOwners retrieves the ownership history of an Nft, from its mint
+func (s *nftService) GetNftOwners(pagination dto.PaginationQuery, nftAddress string) (*dto.NftOwnersResponse, error) {
+	owners, total, err := s.repo.GetNftOwners(pagination, nftAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.NftOwnersResponse{
+		NftOwners:  make([]dto.NftOwner, len(owners)),
+		Pagination: dto.NewPaginationResponse(pagination.Offset, pagination.Limit, total),
+	}
+
+	for idx, owner := range owners {
+		response.NftOwners[idx] = newNftOwner(owner)
+	}
+
+	return response, nil
+}
+
+// GetNftOwnerAtHeight retrieves the owner of an Nft as of a height, and whether it is burned by then
+func (s *nftService) GetNftOwnerAtHeight(nftAddress string, height int64) (*dto.NftOwnerAtHeightResponse, error) {
+	owner, err := s.repo.GetNftOwnerAtHeight(nftAddress, height)
+	if err != nil {
+		return nil, err
+	}
+
+	burnHeight, err := s.repo.GetNftBurnHeight(nftAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NftOwnerAtHeightResponse{
+		Height:   height,
+		Owner:    owner.To,
+		IsBurned: burnHeight != nil && *burnHeight <= height,
+		Since:    newNftOwner(*owner),
+	}, nil
+}
+
+// GetCollectionTransferVolume retrieves the hourly or daily Nft transfers of a collection between from and to, which
+// default to the last day for hourly volumes and the last 30 days for daily ones
+func (s *nftService) GetCollectionTransferVolume(collectionAddress string, granularity string, from, to *time.Time) (*dto.CollectionTransferVolumeResponse, error) {
+	start, end, err := statsRange(granularity, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := s.repo.GetCollectionTransferVolume(collectionAddress, granularity, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CollectionTransferVolumeResponse{
+		TransferVolume: volume,
+	}, nil
+}
+
+func newNftOwner(owner dto.NftOwnerModel) dto.NftOwner {
+	return dto.NftOwner{
+		From:      owner.From,
+		To:        owner.To,
+		Height:    owner.Height,
+		Timestamp: owner.Timestamp,
+		TxHash:    fmt.Sprintf("%x", owner.Hash),
+	}
+}
+
+// newNftByAddressResponse builds the response of an Nft, with its off-chain metadata once it is fetched
+func newNftByAddressResponse(nft dto.NftByAddressModel) (dto.NftByAddressResponse, error) {
+	response := dto.NftByAddressResponse{
+		ObjectAddr:     nft.ID,
+		CollectionAddr: nft.Collection,
+		CollectionName: nft.CollectionName,
+		OwnerAddr:      nft.Owner,
+		Nft: dto.NftByAddressNft{
+			Collection: dto.NftByAddressNftCollection{
+				Inner: nft.Collection,
+			},
+			Description: nft.Description,
+			TokenID:     nft.TokenID,
+			URI:         nft.URI,
+			IsBurned:    nft.IsBurned,
+		},
+	}
+
+	if nft.MetadataName == nil {
+		return response, nil
+	}
+
+	metadata := dto.NftMetadata{
+		Name:       *nft.MetadataName,
+		Attributes: make([]dto.NftAttribute, 0),
+	}
+	if nft.MetadataDescription != nil {
+		metadata.Description = *nft.MetadataDescription
+	}
+	if nft.MetadataImage != nil {
+		metadata.Image = *nft.MetadataImage
+	}
+	if nft.ThumbnailURL != nil {
+		metadata.ThumbnailURL = *nft.ThumbnailURL
+	}
+	if len(nft.Attributes) > 0 {
+		if err := json.Unmarshal(nft.Attributes, &metadata.Attributes); err != nil {
+			return dto.NftByAddressResponse{}, err
+		}
+	}
+	response.Metadata = &metadata
+
+	return response, nil
+}
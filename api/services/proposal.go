@@ -1,11 +1,15 @@
 package services
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/initia-labs/core-indexer/api/apperror"
 	"github.com/initia-labs/core-indexer/api/dto"
 	"github.com/initia-labs/core-indexer/api/repositories"
+	"github.com/initia-labs/core-indexer/pkg/db"
 )
 
 type ProposalService interface {
@@ -15,6 +19,9 @@ type ProposalService interface {
 	GetProposalVotes(pagination dto.PaginationQuery, proposalId int, search, answer string) (*dto.ProposalVotesResponse, error)
 	GetProposalValidatorVotes(pagination dto.PaginationQuery, proposalId int, search, answer string) (*dto.ProposalValidatorVotesResponse, error)
 	GetProposalAnswerCounts(proposalId int) (*dto.ProposalAnswerCountsResponse, error)
+	GetProposalTallyHistory(proposalId int) (*dto.ProposalTallyHistoryResponse, error)
+	GetProposalDeposits(pagination dto.PaginationQuery, proposalId int) (*dto.ProposalDepositsResponse, error)
+	GetProposalDepositSummary(proposalId int) (*dto.ProposalDepositSummaryResponse, error)
 }
 
 type proposalService struct {
@@ -185,3 +192,86 @@ func matchValidatorVote(vote dto.ProposalVote, answer string) bool {
 func (s *proposalService) GetProposalAnswerCounts(proposalId int) (*dto.ProposalAnswerCountsResponse, error) {
 	return s.repo.GetProposalAnswerCounts(proposalId)
 }
+
+// GetProposalTallyHistory retrieves the live tally snapshots of a proposal, oldest first
+func (s *proposalService) GetProposalTallyHistory(proposalId int) (*dto.ProposalTallyHistoryResponse, error) {
+	snapshots, err := s.repo.GetProposalTallyHistory(proposalId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ProposalTallyHistoryResponse{
+		TallyHistory: snapshots,
+	}, nil
+}
+
+// GetProposalDeposits retrieves the deposits of a proposal, newest first unless reversed
+func (s *proposalService) GetProposalDeposits(pagination dto.PaginationQuery, proposalId int) (*dto.ProposalDepositsResponse, error) {
+	deposits, total, err := s.repo.GetProposalDeposits(pagination, proposalId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ProposalDepositsResponse{
+		Deposits:   make([]dto.ProposalDeposit, len(deposits)),
+		Pagination: dto.NewPaginationResponse(pagination.Offset, pagination.Limit, total),
+	}
+
+	for idx, deposit := range deposits {
+		var amount dto.Coins
+		if err := json.Unmarshal(deposit.Amount, &amount); err != nil {
+			return nil, err
+		}
+		response.Deposits[idx] = dto.ProposalDeposit{
+			Amount:    amount,
+			Depositor: deposit.Depositor,
+			TxHash:    fmt.Sprintf("%x", deposit.TxHash),
+			Timestamp: deposit.Timestamp,
+		}
+	}
+
+	return response, nil
+}
+
+// GetProposalDepositSummary compares the deposit of a proposal with its minimum deposit.
+// Progress is left empty for proposals indexed before their minimum deposit was recorded.
+func (s *proposalService) GetProposalDepositSummary(proposalId int) (*dto.ProposalDepositSummaryResponse, error) {
+	summary, err := s.repo.GetProposalDepositSummary(proposalId)
+	if err != nil {
+		return nil, err
+	}
+
+	var minDeposit, totalDeposit dto.Coins
+	if len(summary.MinDeposit) > 0 {
+		if err := json.Unmarshal(summary.MinDeposit, &minDeposit); err != nil {
+			return nil, err
+		}
+	}
+	if len(summary.TotalDeposit) > 0 {
+		if err := json.Unmarshal(summary.TotalDeposit, &totalDeposit); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &dto.ProposalDepositSummaryResponse{
+		Status:         summary.Status,
+		MinDeposit:     minDeposit,
+		TotalDeposit:   totalDeposit,
+		MissingDeposit: dto.Coins{},
+		DepositEndTime: summary.DepositEndTime,
+	}
+
+	if minDeposit != nil {
+		response.MissingDeposit = missingCoins(minDeposit, totalDeposit)
+		progress := coinsCoverage(minDeposit, totalDeposit)
+		response.Progress = &progress
+	}
+
+	if summary.Status == string(db.ProposalStatusDepositPeriod) {
+		if timeLeft := time.Until(summary.DepositEndTime); timeLeft > 0 {
+			response.TimeLeft = int64(timeLeft.Seconds())
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/pkg/parser"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories"
+	"github.com/initia-labs/core-indexer/api/utils"
+)
+
+// objectAddressLength is the byte length of Move object addresses, such as collections and Nfts
+const objectAddressLength = 32
+
+// SearchService defines the interface for searching across entities
+type SearchService interface {
+	Search(query string, limit int) (*dto.SearchResponse, error)
+}
+
+type searchService struct {
+	accountRepo   repositories.AccountRepositoryI
+	blockRepo     repositories.BlockRepositoryI
+	moduleRepo    repositories.ModuleRepositoryI
+	nftRepo       repositories.NftRepositoryI
+	proposalRepo  repositories.ProposalRepositoryI
+	txRepo        repositories.TxRepositoryI
+	validatorRepo repositories.ValidatorRepositoryI
+}
+
+// NewSearchService creates a new instance of SearchService
+func NewSearchService(
+	accountRepo repositories.AccountRepositoryI,
+	blockRepo repositories.BlockRepositoryI,
+	moduleRepo repositories.ModuleRepositoryI,
+	nftRepo repositories.NftRepositoryI,
+	proposalRepo repositories.ProposalRepositoryI,
+	txRepo repositories.TxRepositoryI,
+	validatorRepo repositories.ValidatorRepositoryI,
+) SearchService {
+	return &searchService{
+		accountRepo:   accountRepo,
+		blockRepo:     blockRepo,
+		moduleRepo:    moduleRepo,
+		nftRepo:       nftRepo,
+		proposalRepo:  proposalRepo,
+		txRepo:        txRepo,
+		validatorRepo: validatorRepo,
+	}
+}
+
+// Search classifies the query and returns the entities it identifies. Queries that are not a hash,
+// an address or a module id are also matched as a prefix of validator monikers, collection names and
+// proposal titles, with at most limit results per type.
+func (s *searchService) Search(query string, limit int) (*dto.SearchResponse, error) {
+	query = strings.TrimSpace(query)
+	items := make([]dto.SearchResult, 0)
+
+	var (
+		found []dto.SearchResult
+		err   error
+	)
+	switch {
+	case utils.IsTxHash(query):
+		found, err = s.searchTx(strings.ToLower(query))
+	case strings.Contains(query, "::"):
+		found, err = s.searchModule(query)
+	case isValidatorAddress(query):
+		found, err = s.searchValidator(query)
+	case strings.HasPrefix(query, "0x") || isAccountAddress(query):
+		found, err = s.searchAddress(query)
+	default:
+		if height, parseErr := strconv.ParseInt(query, 10, 64); parseErr == nil && height >= 0 {
+			if found, err = s.searchHeight(height); err != nil {
+				return nil, err
+			}
+			items = append(items, found...)
+		}
+		found, err = s.searchPrefix(query, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SearchResponse{Items: append(items, found...)}, nil
+}
+
+func isValidatorAddress(query string) bool {
+	_, err := sdk.ValAddressFromBech32(query)
+	return err == nil
+}
+
+func isAccountAddress(query string) bool {
+	_, err := sdk.AccAddressFromBech32(query)
+	return err == nil
+}
+
+// ignoreNotFound turns a missing record into an empty match
+func ignoreNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *searchService) searchTx(hash string) ([]dto.SearchResult, error) {
+	tx, err := s.txRepo.GetTxSummaryByHash(hash)
+	if err != nil {
+		return nil, ignoreNotFound(err)
+	}
+
+	status := "success"
+	if !tx.Success {
+		status = "failed"
+	}
+
+	return []dto.SearchResult{{
+		Type:      dto.SearchResultTypeTx,
+		ID:        fmt.Sprintf("%x", tx.Hash),
+		Height:    &tx.Height,
+		Timestamp: tx.Timestamp.Format(time.RFC3339Nano),
+		Status:    status,
+	}}, nil
+}
+
+func (s *searchService) searchModule(query string) ([]dto.SearchResult, error) {
+	address, name, _ := strings.Cut(query, "::")
+	vmAddress, err := parser.AccAddressFromString(address)
+	if err != nil || name == "" {
+		return nil, nil
+	}
+
+	moduleAddress := parser.BytesToHexWithPrefix(vmAddress)
+	infos, err := s.moduleRepo.GetModulePublishInfo(moduleAddress, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, nil
+	}
+
+	return []dto.SearchResult{{
+		Type:      dto.SearchResultTypeModule,
+		ID:        moduleAddress + "::" + name,
+		Name:      name,
+		Height:    &infos[0].Height,
+		Timestamp: infos[0].Timestamp,
+	}}, nil
+}
+
+func (s *searchService) searchValidator(operatorAddr string) ([]dto.SearchResult, error) {
+	validator, err := s.validatorRepo.GetValidatorRow(operatorAddr)
+	if err != nil {
+		return nil, ignoreNotFound(err)
+	}
+
+	return []dto.SearchResult{validatorResult(validator.OperatorAddress, validator.Moniker, validator.IsActive)}, nil
+}
+
+func validatorResult(operatorAddr, moniker string, isActive bool) dto.SearchResult {
+	status := string(dto.ValidatorStatusFilterInactive)
+	if isActive {
+		status = string(dto.ValidatorStatusFilterActive)
+	}
+
+	return dto.SearchResult{
+		Type:   dto.SearchResultTypeValidator,
+		ID:     operatorAddr,
+		Name:   moniker,
+		Status: status,
+	}
+}
+
+// searchAddress matches an account address, and for object addresses also a collection or an Nft
+func (s *searchService) searchAddress(query string) ([]dto.SearchResult, error) {
+	address, err := parser.AccAddressFromString(query)
+	if err != nil {
+		return nil, nil
+	}
+
+	items := make([]dto.SearchResult, 0)
+
+	if len(address) == objectAddressLength {
+		objectAddress := parser.BytesToHexWithPrefix(address)
+
+		collection, err := s.nftRepo.GetCollectionsByCollectionAddress(objectAddress)
+		if err = ignoreNotFound(err); err != nil {
+			return nil, err
+		}
+		if collection != nil {
+			items = append(items, dto.SearchResult{
+				Type: dto.SearchResultTypeCollection,
+				ID:   collection.ID,
+				Name: collection.Name,
+			})
+		}
+
+		nft, err := s.nftRepo.GetNftByID(objectAddress)
+		if err = ignoreNotFound(err); err != nil {
+			return nil, err
+		}
+		if nft != nil {
+			items = append(items, dto.SearchResult{
+				Type:       dto.SearchResultTypeNft,
+				ID:         nft.ID,
+				Name:       nft.TokenID,
+				Collection: nft.Collection,
+			})
+		}
+	}
+
+	account, err := s.accountRepo.GetAccountByAccountAddress(address.String())
+	if err = ignoreNotFound(err); err != nil {
+		return nil, err
+	}
+	if account != nil {
+		items = append(items, dto.SearchResult{
+			Type: dto.SearchResultTypeAccount,
+			ID:   account.Address,
+			Name: account.Name,
+		})
+	}
+
+	return items, nil
+}
+
+// searchHeight matches a block height and a proposal id
+func (s *searchService) searchHeight(height int64) ([]dto.SearchResult, error) {
+	items := make([]dto.SearchResult, 0)
+
+	block, err := s.blockRepo.GetBlockInfo(height)
+	if err = ignoreNotFound(err); err != nil {
+		return nil, err
+	}
+	if block != nil {
+		items = append(items, dto.SearchResult{
+			Type:      dto.SearchResultTypeBlock,
+			ID:        strconv.FormatInt(block.Height, 10),
+			Height:    &block.Height,
+			Timestamp: block.Timestamp,
+		})
+	}
+
+	// proposal ids are int4 in the database
+	if height > math.MaxInt32 {
+		return items, nil
+	}
+
+	proposals, _, err := s.proposalRepo.SearchProposals(dto.PaginationQuery{Limit: 1}, "", strconv.FormatInt(height, 10), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, proposal := range proposals {
+		items = append(items, proposalResult(proposal))
+	}
+
+	return items, nil
+}
+
+func proposalResult(proposal dto.ProposalSummary) dto.SearchResult {
+	return dto.SearchResult{
+		Type:   dto.SearchResultTypeProposal,
+		ID:     strconv.Itoa(proposal.Id),
+		Name:   proposal.Title,
+		Status: proposal.Status,
+	}
+}
+
+// searchPrefix matches the query as a prefix of validator monikers, collection names and proposal titles
+func (s *searchService) searchPrefix(prefix string, limit int) ([]dto.SearchResult, error) {
+	items := make([]dto.SearchResult, 0)
+	if prefix == "" {
+		return items, nil
+	}
+
+	validators, err := s.validatorRepo.SearchValidatorsByMonikerPrefix(prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, validator := range validators {
+		items = append(items, validatorResult(validator.OperatorAddress, validator.Moniker, validator.IsActive))
+	}
+
+	collections, err := s.nftRepo.SearchCollectionsByNamePrefix(prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, collection := range collections {
+		items = append(items, dto.SearchResult{
+			Type: dto.SearchResultTypeCollection,
+			ID:   collection.ID,
+			Name: collection.Name,
+		})
+	}
+
+	proposals, err := s.proposalRepo.SearchProposalsByTitlePrefix(prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, proposal := range proposals {
+		items = append(items, proposalResult(proposal))
+	}
+
+	return items, nil
+}
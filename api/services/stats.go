@@ -0,0 +1,100 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/initia-labs/core-indexer/api/apperror"
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories"
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+const (
+	defaultHourlyStatsSpan = 24 * time.Hour
+	defaultDailyStatsSpan  = 30 * 24 * time.Hour
+	maxHourlyStatsSpan     = 31 * 24 * time.Hour
+	maxDailyStatsSpan      = 366 * 24 * time.Hour
+)
+
+type StatsService interface {
+	GetChainStats(granularity string, from, to *time.Time) (*dto.ChainStatsResponse, error)
+}
+
+type statsService struct {
+	repo repositories.StatsRepositoryI
+}
+
+func NewStatsService(repo repositories.StatsRepositoryI) StatsService {
+	return &statsService{
+		repo: repo,
+	}
+}
+
+// GetChainStats retrieves the hourly or daily rollups of the chain activity between from and to, which default to
+// the last day for hourly rollups and the last 30 days for daily ones
+func (s *statsService) GetChainStats(granularity string, from, to *time.Time) (*dto.ChainStatsResponse, error) {
+	start, end, err := statsRange(granularity, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.GetChainStats(granularity, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ChainStatsResponse{
+		Stats: make([]dto.ChainStat, len(stats)),
+	}
+	for idx, stat := range stats {
+		var fees dto.Coins
+		if err := json.Unmarshal(stat.Fees, &fees); err != nil {
+			return nil, err
+		}
+		response.Stats[idx] = dto.ChainStat{
+			Timestamp:      stat.Timestamp,
+			BlockCount:     stat.BlockCount,
+			AvgBlockTime:   stat.AvgBlockTime,
+			TxCount:        stat.TxCount,
+			SuccessTxCount: stat.SuccessTxCount,
+			FailedTxCount:  stat.FailedTxCount,
+			ActiveAccounts: stat.ActiveAccounts,
+			NewAccounts:    stat.NewAccounts,
+			GasUsed:        stat.GasUsed,
+			Fees:           fees,
+			ModuleCalls:    stat.ModuleCalls,
+			NftMints:       stat.NftMints,
+		}
+	}
+
+	return response, nil
+}
+
+// statsRange resolves the range of hourly or daily stats between from and to, which default to the last day for hourly
+// stats and the last 30 days for daily ones, and starts at the beginning of the hour or the day from falls in
+func statsRange(granularity string, from, to *time.Time) (time.Time, time.Time, error) {
+	defaultSpan, maxSpan, spanErrMsg := defaultHourlyStatsSpan, maxHourlyStatsSpan, apperror.ErrMsgStatsHourlySpan
+	if granularity == db.ChainStatGranularityDay {
+		defaultSpan, maxSpan, spanErrMsg = defaultDailyStatsSpan, maxDailyStatsSpan, apperror.ErrMsgStatsDailySpan
+	}
+
+	end := time.Now().UTC()
+	if to != nil {
+		end = to.UTC()
+	}
+	start := end.Add(-defaultSpan)
+	if from != nil {
+		start = from.UTC()
+	}
+	start, _ = db.ChainStatPeriod(granularity, start)
+
+	if start.After(end) {
+		return time.Time{}, time.Time{}, apperror.NewValidationError(apperror.ErrMsgStatsRange)
+	}
+	if end.Sub(start) > maxSpan {
+		return time.Time{}, time.Time{}, apperror.NewValidationError(spanErrMsg)
+	}
+
+	return start, end, nil
+}
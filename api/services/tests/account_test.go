@@ -3,6 +3,7 @@ package services_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -16,6 +17,55 @@ const (
 	AccountAddress = "init1m8p6rakcfl4z5ruwa0578cqgn8c86mkc6ety2z"
 )
 
+func TestAccountService_GetAccounts(t *testing.T) {
+	pagination := dto.PaginationQuery{
+		Limit:      10,
+		Offset:     0,
+		Reverse:    false,
+		CountTotal: true,
+	}
+
+	t.Run("filter by type", func(t *testing.T) {
+		mockRepo := mocks.NewMockAccountRepository()
+
+		expectedAccounts := []db.Account{
+			{Address: AccountAddress, Type: string(db.ContractAccount), VMAddressID: "vm_address_123"},
+		}
+		mockRepo.On("GetAccounts", pagination, string(db.ContractAccount)).Return(expectedAccounts, int64(1), nil)
+
+		service := services.NewAccountService(mockRepo)
+		result, err := service.GetAccounts(pagination, string(db.ContractAccount))
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedAccounts, result.Accounts)
+		assert.Equal(t, "1", result.Pagination.Total)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		mockRepo := mocks.NewMockAccountRepository()
+		mockRepo.On("GetAccounts", pagination, "").Return([]db.Account{}, int64(0), nil)
+
+		service := services.NewAccountService(mockRepo)
+		result, err := service.GetAccounts(pagination, "")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Accounts)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		mockRepo := mocks.NewMockAccountRepository()
+
+		service := services.NewAccountService(mockRepo)
+		result, err := service.GetAccounts(pagination, "validator")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "GetAccounts", pagination, "validator")
+	})
+}
+
 func TestAccountService_GetAccountByAccountAddress(t *testing.T) {
 	// Create mock repository
 	mockRepo := mocks.NewMockAccountRepository()
@@ -108,6 +158,116 @@ func TestAccountService_GetAccountProposals(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAccountService_GetAccountDepositedProposals(t *testing.T) {
+	// Create mock repository
+	mockRepo := mocks.NewMockAccountRepository()
+
+	// Test data
+	pagination := dto.PaginationQuery{
+		Limit:      10,
+		Offset:     0,
+		CountTotal: true,
+	}
+
+	expectedProposals := []dto.AccountDepositedProposalModel{
+		{
+			ID:      1,
+			Title:   "Test Proposal",
+			Status:  "DepositPeriod",
+			Amounts: []byte(`[[{"denom":"uinit","amount":"100"}],[{"denom":"uinit","amount":"250"},{"denom":"uusdc","amount":"5"}]]`),
+		},
+	}
+
+	// Set up mock expectations
+	mockRepo.On("GetAccountDepositedProposals", pagination, AccountAddress).Return(expectedProposals, int64(1), nil)
+
+	// Create service with mock repository
+	service := services.NewAccountService(mockRepo)
+
+	// Call the method
+	result, err := service.GetAccountDepositedProposals(pagination, AccountAddress)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Len(t, result.Proposals, 1)
+	assert.Equal(t, "1", result.Pagination.Total)
+	assert.Equal(t, "Test Proposal", result.Proposals[0].Title)
+	assert.Equal(t, dto.Coins{
+		{Denom: "uinit", Amount: "350"},
+		{Denom: "uusdc", Amount: "5"},
+	}, result.Proposals[0].Deposited)
+
+	// Verify mock was called as expected
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAccountService_GetAccountSummary(t *testing.T) {
+	vmAddress := "0xd9c3a1f6d84fea2a0f8eebe9e3e0089f07d76ed8"
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	height := func(h int64) *int64 { return &h }
+	at := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name           string
+		mockStat       *db.AccountStat
+		mockHoldings   *dto.AccountNftHoldingsModel
+		expectedResult *dto.AccountSummaryResponse
+	}{
+		{
+			name: "active account",
+			mockStat: &db.AccountStat{
+				AccountID:           AccountAddress,
+				FirstSeenHeight:     10,
+				FirstSeenTimestamp:  timestamp,
+				LastActiveHeight:    20,
+				LastActiveTimestamp: timestamp.Add(time.Minute),
+				TxCount:             5,
+				SignerTxCount:       3,
+				SendTxCount:         2,
+				MoveExecuteTxCount:  1,
+				NftTxCount:          1,
+				ProposalsVoted:      4,
+			},
+			mockHoldings: &dto.AccountNftHoldingsModel{CollectionsHeld: 1, NftsHeld: 2},
+			expectedResult: &dto.AccountSummaryResponse{
+				FirstSeenHeight:     height(10),
+				FirstSeenTimestamp:  at(timestamp),
+				LastActiveHeight:    height(20),
+				LastActiveTimestamp: at(timestamp.Add(time.Minute)),
+				TxCount:             5,
+				SignerTxCount:       3,
+				TxCategoryCounts:    dto.AccountTxCategoryCounts{Send: 2, MoveExecute: 1, Nft: 1},
+				CollectionsHeld:     1,
+				NftsHeld:            2,
+				ProposalsVoted:      4,
+			},
+		},
+		{
+			name:           "account without transactions",
+			mockHoldings:   &dto.AccountNftHoldingsModel{},
+			expectedResult: &dto.AccountSummaryResponse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockAccountRepository()
+			service := services.NewAccountService(mockRepo)
+
+			mockRepo.On("GetAccountStat", AccountAddress).Return(tt.mockStat, nil)
+			mockRepo.On("GetAccountNftHoldings", vmAddress).Return(tt.mockHoldings, nil)
+
+			result, err := service.GetAccountSummary(AccountAddress, vmAddress)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestAccountService_GetAccountTxs(t *testing.T) {
 	// Create mock repository
 	mockRepo := mocks.NewMockAccountRepository()
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 
 	"github.com/initia-labs/core-indexer/api/dto"
@@ -575,6 +576,7 @@ func TestModuleService_GetModuleStats(t *testing.T) {
 		vmAddress      string
 		moduleName     string
 		mockStats      *dto.ModuleStatsResponse
+		mockCallStats  *dto.FunctionCallStatsModel
 		mockError      error
 		expectedResult *dto.ModuleStatsResponse
 		expectedError  error
@@ -589,7 +591,14 @@ func TestModuleService_GetModuleStats(t *testing.T) {
 					var count int64 = 2
 					return &count
 				}(),
-				TotalTxs: 10,
+				TotalTxs:           10,
+				TotalEntryExecuted: 3,
+			},
+			mockCallStats: &dto.FunctionCallStatsModel{
+				CallCount:     4,
+				FailureCount:  1,
+				GasUsed:       400,
+				UniqueCallers: 2,
 			},
 			mockError: nil,
 			expectedResult: &dto.ModuleStatsResponse{
@@ -598,7 +607,32 @@ func TestModuleService_GetModuleStats(t *testing.T) {
 					var count int64 = 2
 					return &count
 				}(),
-				TotalTxs: 10,
+				TotalTxs:           10,
+				TotalEntryExecuted: 3,
+				Calls: []dto.FunctionCallsWindow{
+					{Window: "1d", CallCount: 4, FailureCount: 1, FailureRate: 0.25, GasUsed: 400, UniqueCallers: 2},
+					{Window: "7d", CallCount: 4, FailureCount: 1, FailureRate: 0.25, GasUsed: 400, UniqueCallers: 2},
+					{Window: "30d", CallCount: 4, FailureCount: 1, FailureRate: 0.25, GasUsed: 400, UniqueCallers: 2},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "no calls",
+			vmAddress:  "0x123",
+			moduleName: "test_module",
+			mockStats: &dto.ModuleStatsResponse{
+				TotalHistories: 1,
+			},
+			mockCallStats: &dto.FunctionCallStatsModel{},
+			mockError:     nil,
+			expectedResult: &dto.ModuleStatsResponse{
+				TotalHistories: 1,
+				Calls: []dto.FunctionCallsWindow{
+					{Window: "1d"},
+					{Window: "7d"},
+					{Window: "30d"},
+				},
 			},
 			expectedError: nil,
 		},
@@ -619,6 +653,9 @@ func TestModuleService_GetModuleStats(t *testing.T) {
 			service := services.NewModuleService(mockRepo)
 
 			mockRepo.On("GetModuleStats", tt.vmAddress, tt.moduleName).Return(tt.mockStats, tt.mockError)
+			if tt.mockCallStats != nil {
+				mockRepo.On("GetModuleCallStats", tt.vmAddress+"::"+tt.moduleName, mock.Anything).Return(tt.mockCallStats, nil)
+			}
 
 			result, err := service.GetModuleStats(tt.vmAddress, tt.moduleName)
 
@@ -635,6 +672,114 @@ func TestModuleService_GetModuleStats(t *testing.T) {
 	}
 }
 
+func TestModuleService_GetModuleFunctions(t *testing.T) {
+	tests := []struct {
+		name           string
+		vmAddress      string
+		moduleName     string
+		mockCallStats  [][]dto.FunctionCallStatsModel
+		mockError      error
+		expectedResult *dto.ModuleFunctionsResponse
+		expectedError  error
+	}{
+		{
+			name:       "successful get module functions",
+			vmAddress:  "0x123",
+			moduleName: "test_module",
+			mockCallStats: [][]dto.FunctionCallStatsModel{
+				{
+					{FunctionName: "transfer", CallCount: 2, GasUsed: 200, UniqueCallers: 1},
+				},
+				{
+					{FunctionName: "transfer", CallCount: 5, FailureCount: 1, GasUsed: 500, UniqueCallers: 3},
+					{FunctionName: "mint", CallCount: 2, FailureCount: 2, GasUsed: 100, UniqueCallers: 1},
+				},
+				{
+					{FunctionName: "mint", CallCount: 10, FailureCount: 2, GasUsed: 1000, UniqueCallers: 4},
+					{FunctionName: "transfer", CallCount: 5, FailureCount: 1, GasUsed: 500, UniqueCallers: 3},
+					{FunctionName: "burn", CallCount: 1, GasUsed: 50, UniqueCallers: 1},
+				},
+			},
+			mockError: nil,
+			expectedResult: &dto.ModuleFunctionsResponse{
+				Functions: []dto.ModuleFunctionResponse{
+					{
+						FunctionName: "mint",
+						Calls: []dto.FunctionCallsWindow{
+							{Window: "1d"},
+							{Window: "7d", CallCount: 2, FailureCount: 2, FailureRate: 1, GasUsed: 100, UniqueCallers: 1},
+							{Window: "30d", CallCount: 10, FailureCount: 2, FailureRate: 0.2, GasUsed: 1000, UniqueCallers: 4},
+						},
+					},
+					{
+						FunctionName: "transfer",
+						Calls: []dto.FunctionCallsWindow{
+							{Window: "1d", CallCount: 2, GasUsed: 200, UniqueCallers: 1},
+							{Window: "7d", CallCount: 5, FailureCount: 1, FailureRate: 0.2, GasUsed: 500, UniqueCallers: 3},
+							{Window: "30d", CallCount: 5, FailureCount: 1, FailureRate: 0.2, GasUsed: 500, UniqueCallers: 3},
+						},
+					},
+					{
+						FunctionName: "burn",
+						Calls: []dto.FunctionCallsWindow{
+							{Window: "1d"},
+							{Window: "7d"},
+							{Window: "30d", CallCount: 1, GasUsed: 50, UniqueCallers: 1},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "no calls",
+			vmAddress:  "0x123",
+			moduleName: "test_module",
+			mockCallStats: [][]dto.FunctionCallStatsModel{
+				{}, {}, {},
+			},
+			mockError: nil,
+			expectedResult: &dto.ModuleFunctionsResponse{
+				Functions: []dto.ModuleFunctionResponse{},
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "repository error",
+			vmAddress:      "0x123",
+			moduleName:     "test_module",
+			mockCallStats:  [][]dto.FunctionCallStatsModel{nil},
+			mockError:      errors.New("database error"),
+			expectedResult: nil,
+			expectedError:  errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockModuleRepository()
+			service := services.NewModuleService(mockRepo)
+
+			// one call per window, from the shortest to the longest
+			for _, callStats := range tt.mockCallStats {
+				mockRepo.On("GetModuleFunctionCallStats", tt.vmAddress+"::"+tt.moduleName, mock.Anything).Return(callStats, tt.mockError).Once()
+			}
+
+			result, err := service.GetModuleFunctions(tt.vmAddress, tt.moduleName)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestModuleService_NewModuleService(t *testing.T) {
 	mockRepo := mocks.NewMockModuleRepository()
 	service := services.NewModuleService(mockRepo)
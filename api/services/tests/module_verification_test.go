@@ -0,0 +1,160 @@
+package services_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/api/apperror"
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories/mocks"
+	"github.com/initia-labs/core-indexer/api/services"
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+func TestModuleVerificationService_SubmitModuleVerification(t *testing.T) {
+	manifest := dto.ModuleSourceFile{Path: "Move.toml", Content: "[package]\nname = \"token\""}
+	source := dto.ModuleSourceFile{Path: "sources/token.move", Content: "module 0xcafe::token {}"}
+
+	t.Run("queues a valid package", func(t *testing.T) {
+		mockRepo := mocks.NewMockModuleVerificationRepository()
+		expectedFiles := []db.ModuleVerificationFile{
+			{Path: manifest.Path, Content: manifest.Content},
+			{Path: source.Path, Content: source.Content},
+		}
+		mockRepo.On("CreateModuleVerification", mock.AnythingOfType("*db.ModuleVerification"), expectedFiles).Return(nil)
+
+		service := services.NewModuleVerificationService(mockRepo)
+		result, err := service.SubmitModuleVerification(dto.ModuleVerificationRequest{
+			Address: "0x000000000000000000000000000000000000cafe",
+			Files:   []dto.ModuleSourceFile{manifest, source},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0xcafe", result.Address)
+		assert.Equal(t, string(db.VerificationPending), result.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	invalidRequests := []struct {
+		name    string
+		request dto.ModuleVerificationRequest
+		message string
+	}{
+		{
+			name:    "invalid address",
+			request: dto.ModuleVerificationRequest{Address: "cafe", Files: []dto.ModuleSourceFile{manifest}},
+			message: apperror.ErrMsgVerificationAddress,
+		},
+		{
+			name:    "no files",
+			request: dto.ModuleVerificationRequest{Address: "0xcafe"},
+			message: apperror.ErrMsgVerificationFiles,
+		},
+		{
+			name:    "missing manifest",
+			request: dto.ModuleVerificationRequest{Address: "0xcafe", Files: []dto.ModuleSourceFile{source}},
+			message: apperror.ErrMsgVerificationToml,
+		},
+		{
+			name: "path outside the package",
+			request: dto.ModuleVerificationRequest{Address: "0xcafe", Files: []dto.ModuleSourceFile{
+				manifest, {Path: "../token.move"},
+			}},
+			message: apperror.ErrMsgVerificationPath,
+		},
+		{
+			name: "unclean path",
+			request: dto.ModuleVerificationRequest{Address: "0xcafe", Files: []dto.ModuleSourceFile{
+				manifest, {Path: "sources/../../token.move"},
+			}},
+			message: apperror.ErrMsgVerificationPath,
+		},
+		{
+			name: "not a move source",
+			request: dto.ModuleVerificationRequest{Address: "0xcafe", Files: []dto.ModuleSourceFile{
+				manifest, {Path: "build.sh"},
+			}},
+			message: apperror.ErrMsgVerificationPath,
+		},
+		{
+			name:    "duplicate path",
+			request: dto.ModuleVerificationRequest{Address: "0xcafe", Files: []dto.ModuleSourceFile{manifest, manifest}},
+			message: apperror.ErrMsgVerificationPath,
+		},
+		{
+			name: "package too large",
+			request: dto.ModuleVerificationRequest{Address: "0xcafe", Files: []dto.ModuleSourceFile{
+				manifest, {Path: "sources/big.move", Content: string(make([]byte, 2<<20))},
+			}},
+			message: apperror.ErrMsgVerificationSize,
+		},
+	}
+
+	for _, tt := range invalidRequests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockModuleVerificationRepository()
+
+			service := services.NewModuleVerificationService(mockRepo)
+			result, err := service.SubmitModuleVerification(tt.request)
+
+			assert.Nil(t, result)
+			assert.Equal(t, apperror.NewValidationError(tt.message), err)
+			mockRepo.AssertNotCalled(t, "CreateModuleVerification", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestModuleVerificationService_GetVerifiedModule(t *testing.T) {
+	verifiedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	verifiedModule := &dto.VerifiedModuleModel{
+		ModuleID:        "0xcafe::token",
+		Digest:          "digest",
+		VerificationID:  7,
+		PackageName:     "token",
+		CompilerVersion: "movevm v1.2.0",
+		Abi:             json.RawMessage(`{"name":"token"}`),
+		VerifiedAt:      verifiedAt,
+	}
+
+	t.Run("current version", func(t *testing.T) {
+		mockRepo := mocks.NewMockModuleVerificationRepository()
+		mockRepo.On("GetVerifiedModule", "0xcafe::token", (*int64)(nil)).Return(verifiedModule, nil)
+		mockRepo.On("GetModuleVerificationFiles", int64(7)).Return([]db.ModuleVerificationFile{
+			{VerificationID: 7, Path: "Move.toml", Content: "[package]"},
+		}, nil)
+
+		service := services.NewModuleVerificationService(mockRepo)
+		result, err := service.GetVerifiedModule("0xcafe", "token", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.VerifiedModuleResponse{
+			ModuleID:        "0xcafe::token",
+			Digest:          "digest",
+			VerificationID:  7,
+			PackageName:     "token",
+			CompilerVersion: "movevm v1.2.0",
+			Abi:             json.RawMessage(`{"name":"token"}`),
+			VerifiedAt:      verifiedAt,
+			Files:           []dto.ModuleSourceFile{{Path: "Move.toml", Content: "[package]"}},
+		}, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unverified history entry", func(t *testing.T) {
+		height := int64(100)
+		mockRepo := mocks.NewMockModuleVerificationRepository()
+		mockRepo.On("GetVerifiedModule", "0xcafe::token", &height).Return(nil, gorm.ErrRecordNotFound)
+
+		service := services.NewModuleVerificationService(mockRepo)
+		result, err := service.GetVerifiedModule("0xcafe", "token", &height)
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "GetModuleVerificationFiles", mock.Anything)
+	})
+}
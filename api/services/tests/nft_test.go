@@ -1,11 +1,15 @@
 package services_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 
+	"github.com/initia-labs/core-indexer/api/apperror"
 	"github.com/initia-labs/core-indexer/api/dto"
 	"github.com/initia-labs/core-indexer/api/repositories/mocks"
 	"github.com/initia-labs/core-indexer/api/services"
@@ -559,13 +563,13 @@ func TestNftService_GetNftsByCollectionAddress(t *testing.T) {
 	}
 
 	// Set up mock expectations
-	mockRepo.On("GetNftsByCollectionAddress", pagination, CollectionAddress, search).Return(expectedNfts, int64(2), nil)
+	mockRepo.On("GetNftsByCollectionAddress", pagination, CollectionAddress, search, "", "").Return(expectedNfts, int64(2), nil)
 
 	// Create service with mock repository
 	service := services.NewNftService(mockRepo)
 
 	// Call the method
-	result, err := service.GetNftsByCollectionAddress(pagination, CollectionAddress, search)
+	result, err := service.GetNftsByCollectionAddress(pagination, CollectionAddress, search, "", "")
 
 	// Assertions
 	assert.NoError(t, err)
@@ -589,6 +593,73 @@ func TestNftService_GetNftsByCollectionAddress(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestNftService_GetNftsByCollectionAddress_Attribute(t *testing.T) {
+	mockRepo := mocks.NewMockNftRepository()
+
+	pagination := dto.PaginationQuery{
+		Limit:  10,
+		Offset: 0,
+	}
+
+	mockRepo.On("GetNftsByCollectionAddress", pagination, CollectionAddress, "", "Background", "Blue").Return([]dto.NftByAddressModel{}, int64(0), nil)
+
+	service := services.NewNftService(mockRepo)
+
+	result, err := service.GetNftsByCollectionAddress(pagination, CollectionAddress, "", "Background", "Blue")
+	assert.NoError(t, err)
+	assert.Empty(t, result.Tokens)
+
+	// a trait value alone is rejected
+	result, err = service.GetNftsByCollectionAddress(pagination, CollectionAddress, "", "", "Blue")
+	assert.Nil(t, result)
+	assert.Equal(t, apperror.NewValidationError(apperror.ErrMsgTraitType), err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNftService_GetNftByNftAddress_Metadata(t *testing.T) {
+	mockRepo := mocks.NewMockNftRepository()
+
+	name := "Nft #1"
+	image := "ipfs://bafy/1.png"
+	thumbnailURL := "https://storage.googleapis.com/thumbnails/nfts/1.jpg"
+	expectedNft := &dto.NftByAddressModel{
+		TokenID:        "token1",
+		URI:            "ipfs://bafy/1.json",
+		ID:             NftAddress,
+		Collection:     CollectionAddress,
+		CollectionName: "Test Collection",
+		MetadataName:   &name,
+		MetadataImage:  &image,
+		ThumbnailURL:   &thumbnailURL,
+		Attributes:     json.RawMessage(`[{"trait_type":"Background","value":"Blue"},{"trait_type":"Level","value":"5"}]`),
+	}
+
+	mockRepo.On("GetNftByNftAddress", CollectionAddress, NftAddress).Return(expectedNft, nil)
+	mockRepo.On("GetNftByNftAddress", CollectionAddress, "0x2").Return(&dto.NftByAddressModel{ID: "0x2", Collection: CollectionAddress}, nil)
+
+	service := services.NewNftService(mockRepo)
+
+	result, err := service.GetNftByNftAddress(CollectionAddress, NftAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.NftMetadata{
+		Name:         "Nft #1",
+		Image:        "ipfs://bafy/1.png",
+		ThumbnailURL: "https://storage.googleapis.com/thumbnails/nfts/1.jpg",
+		Attributes: []dto.NftAttribute{
+			{TraitType: "Background", Value: "Blue"},
+			{TraitType: "Level", Value: "5"},
+		},
+	}, result.Metadata)
+
+	// the metadata is null until it is fetched
+	result, err = service.GetNftByNftAddress(CollectionAddress, "0x2")
+	assert.NoError(t, err)
+	assert.Nil(t, result.Metadata)
+
+	mockRepo.AssertExpectations(t)
+}
+
 func TestNftService_GetNftMintInfo(t *testing.T) {
 	// Create mock repository
 	mockRepo := mocks.NewMockNftRepository()
@@ -774,6 +845,139 @@ func TestNftService_GetNftTxs(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestNftService_GetNftOwners(t *testing.T) {
+	mockRepo := mocks.NewMockNftRepository()
+
+	pagination := dto.PaginationQuery{
+		Limit:      10,
+		Offset:     0,
+		CountTotal: true,
+	}
+	minter := "0x1111111111111111111111111111111111111111"
+
+	mockRepo.On("GetNftOwners", pagination, NftAddress).Return([]dto.NftOwnerModel{
+		{From: nil, To: minter, Hash: "0x1234", Height: 1000, Timestamp: "2024-01-01T10:00:00Z"},
+		{From: &minter, To: "0x2222222222222222222222222222222222222222", Hash: "0xabcd", Height: 1001, Timestamp: "2024-01-01T11:00:00Z"},
+	}, int64(2), nil)
+
+	service := services.NewNftService(mockRepo)
+
+	result, err := service.GetNftOwners(pagination, NftAddress)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.NftOwnersResponse{
+		NftOwners: []dto.NftOwner{
+			{From: nil, To: minter, Height: 1000, Timestamp: "2024-01-01T10:00:00Z", TxHash: "307831323334"},
+			{From: &minter, To: "0x2222222222222222222222222222222222222222", Height: 1001, Timestamp: "2024-01-01T11:00:00Z", TxHash: "307861626364"},
+		},
+		Pagination: dto.NewPaginationResponse(0, 10, 2),
+	}, result)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNftService_GetNftOwnerAtHeight(t *testing.T) {
+	from := "0x1111111111111111111111111111111111111111"
+	owner := &dto.NftOwnerModel{
+		From:      &from,
+		To:        "0x2222222222222222222222222222222222222222",
+		Hash:      "0xabcd",
+		Height:    1001,
+		Timestamp: "2024-01-01T11:00:00Z",
+	}
+	burnHeight := int64(1500)
+
+	tests := []struct {
+		name           string
+		height         int64
+		burnHeight     *int64
+		expectedBurned bool
+	}{
+		{name: "not burned", height: 1200, burnHeight: nil, expectedBurned: false},
+		{name: "burned later", height: 1200, burnHeight: &burnHeight, expectedBurned: false},
+		{name: "burned by then", height: 1500, burnHeight: &burnHeight, expectedBurned: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockNftRepository()
+			mockRepo.On("GetNftOwnerAtHeight", NftAddress, tt.height).Return(owner, nil)
+			mockRepo.On("GetNftBurnHeight", NftAddress).Return(tt.burnHeight, nil)
+
+			service := services.NewNftService(mockRepo)
+
+			result, err := service.GetNftOwnerAtHeight(NftAddress, tt.height)
+
+			assert.NoError(t, err)
+			assert.Equal(t, &dto.NftOwnerAtHeightResponse{
+				Height:   tt.height,
+				Owner:    "0x2222222222222222222222222222222222222222",
+				IsBurned: tt.expectedBurned,
+				Since: dto.NftOwner{
+					From:      &from,
+					To:        "0x2222222222222222222222222222222222222222",
+					Height:    1001,
+					Timestamp: "2024-01-01T11:00:00Z",
+					TxHash:    "307861626364",
+				},
+			}, result)
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNftService_GetNftOwnerAtHeight_NotMinted(t *testing.T) {
+	mockRepo := mocks.NewMockNftRepository()
+	mockRepo.On("GetNftOwnerAtHeight", NftAddress, int64(10)).Return(nil, gorm.ErrRecordNotFound)
+
+	service := services.NewNftService(mockRepo)
+
+	result, err := service.GetNftOwnerAtHeight(NftAddress, 10)
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, result)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNftService_GetCollectionTransferVolume(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("daily volume", func(t *testing.T) {
+		mockRepo := mocks.NewMockNftRepository()
+		volume := []dto.CollectionTransferVolume{
+			{Timestamp: day, TransferCount: 3, NftCount: 2},
+		}
+		from := day.Add(6 * time.Hour)
+		to := day.AddDate(0, 0, 2)
+		mockRepo.On("GetCollectionTransferVolume", CollectionAddress, db.ChainStatGranularityDay, day, to).Return(volume, nil)
+
+		service := services.NewNftService(mockRepo)
+
+		result, err := service.GetCollectionTransferVolume(CollectionAddress, db.ChainStatGranularityDay, &from, &to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.CollectionTransferVolumeResponse{TransferVolume: volume}, result)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("hourly span too long", func(t *testing.T) {
+		mockRepo := mocks.NewMockNftRepository()
+		to := day.AddDate(0, 2, 0)
+
+		service := services.NewNftService(mockRepo)
+
+		result, err := service.GetCollectionTransferVolume(CollectionAddress, db.ChainStatGranularityHour, &day, &to)
+
+		assert.Equal(t, apperror.NewValidationError(apperror.ErrMsgStatsHourlySpan), err)
+		assert.Nil(t, result)
+
+		mockRepo.AssertNotCalled(t, "GetCollectionTransferVolume")
+	})
+}
+
 func TestNftService_PaginationScenarios(t *testing.T) {
 	testCases := []struct {
 		name              string
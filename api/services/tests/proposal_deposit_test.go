@@ -0,0 +1,144 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories/mocks"
+	"github.com/initia-labs/core-indexer/api/services"
+)
+
+func TestProposalService_GetProposalDeposits(t *testing.T) {
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	pagination := dto.PaginationQuery{
+		Limit:      10,
+		Offset:     0,
+		CountTotal: true,
+		Reverse:    true,
+	}
+
+	mockRepo := mocks.NewMockProposalRepository()
+	service := services.NewProposalService(mockRepo)
+
+	mockRepo.On("GetProposalDeposits", pagination, 1).Return([]dto.ProposalDepositModel{
+		{
+			Amount:    []byte(`[{"denom":"uinit","amount":"100"}]`),
+			Depositor: AccountAddress,
+			TxHash:    "\x01\xab",
+			Timestamp: timestamp,
+		},
+	}, int64(1), nil)
+
+	result, err := service.GetProposalDeposits(pagination, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, &dto.ProposalDepositsResponse{
+		Deposits: []dto.ProposalDeposit{
+			{
+				Amount:    dto.Coins{{Denom: "uinit", Amount: "100"}},
+				Depositor: AccountAddress,
+				TxHash:    "01ab",
+				Timestamp: timestamp,
+			},
+		},
+		Pagination: dto.NewPaginationResponse(0, 10, 1),
+	}, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProposalService_GetProposalDepositSummary(t *testing.T) {
+	progress := func(value float64) *float64 { return &value }
+
+	tests := []struct {
+		name             string
+		mockSummary      *dto.ProposalDepositSummaryModel
+		mockError        error
+		expectedMissing  dto.Coins
+		expectedProgress *float64
+		expectTimeLeft   bool
+		expectedError    error
+	}{
+		{
+			name: "deposit period",
+			mockSummary: &dto.ProposalDepositSummaryModel{
+				Status:         "DepositPeriod",
+				MinDeposit:     []byte(`[{"denom":"uinit","amount":"400"}]`),
+				TotalDeposit:   []byte(`[{"denom":"uinit","amount":"100"}]`),
+				DepositEndTime: time.Now().Add(time.Hour),
+			},
+			expectedMissing:  dto.Coins{{Denom: "uinit", Amount: "300"}},
+			expectedProgress: progress(0.25),
+			expectTimeLeft:   true,
+		},
+		{
+			name: "every denom of the minimum deposit is required",
+			mockSummary: &dto.ProposalDepositSummaryModel{
+				Status:         "DepositPeriod",
+				MinDeposit:     []byte(`[{"denom":"uinit","amount":"400"},{"denom":"uusdc","amount":"10"}]`),
+				TotalDeposit:   []byte(`[{"denom":"uinit","amount":"500"}]`),
+				DepositEndTime: time.Now().Add(time.Hour),
+			},
+			expectedMissing:  dto.Coins{{Denom: "uusdc", Amount: "10"}},
+			expectedProgress: progress(0),
+			expectTimeLeft:   true,
+		},
+		{
+			name: "voting period",
+			mockSummary: &dto.ProposalDepositSummaryModel{
+				Status:         "VotingPeriod",
+				MinDeposit:     []byte(`[{"denom":"uinit","amount":"400"}]`),
+				TotalDeposit:   []byte(`[{"denom":"uinit","amount":"600"}]`),
+				DepositEndTime: time.Now().Add(time.Hour),
+			},
+			expectedMissing:  dto.Coins{},
+			expectedProgress: progress(1),
+		},
+		{
+			name: "unknown minimum deposit",
+			mockSummary: &dto.ProposalDepositSummaryModel{
+				Status:         "DepositPeriod",
+				TotalDeposit:   []byte(`[{"denom":"uinit","amount":"100"}]`),
+				DepositEndTime: time.Now().Add(-time.Hour),
+			},
+			expectedMissing: dto.Coins{},
+		},
+		{
+			name:          "proposal not found",
+			mockError:     gorm.ErrRecordNotFound,
+			expectedError: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockProposalRepository()
+			service := services.NewProposalService(mockRepo)
+
+			mockRepo.On("GetProposalDepositSummary", 1).Return(tt.mockSummary, tt.mockError)
+
+			result, err := service.GetProposalDepositSummary(1)
+
+			if tt.expectedError != nil {
+				assert.True(t, errors.Is(err, tt.expectedError))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.mockSummary.Status, result.Status)
+				assert.Equal(t, tt.expectedMissing, result.MissingDeposit)
+				assert.Equal(t, tt.expectedProgress, result.Progress)
+				if tt.expectTimeLeft {
+					assert.Greater(t, result.TimeLeft, int64(0))
+				} else {
+					assert.Zero(t, result.TimeLeft)
+				}
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
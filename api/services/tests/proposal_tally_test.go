@@ -0,0 +1,89 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories/mocks"
+	"github.com/initia-labs/core-indexer/api/services"
+)
+
+func TestProposalService_GetProposalTallyHistory(t *testing.T) {
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []dto.ProposalTallySnapshotModel{
+		{
+			TallyHeight:       100,
+			Timestamp:         timestamp,
+			Yes:               100,
+			TotalStakingPower: 1000,
+			Quorum:            0.334,
+			Threshold:         0.5,
+			QuorumProgress:    0.1,
+			ThresholdProgress: 1,
+		},
+		{
+			TallyHeight:       160,
+			Timestamp:         timestamp.Add(5 * time.Minute),
+			Yes:               300,
+			No:                100,
+			TotalStakingPower: 1000,
+			Quorum:            0.334,
+			Threshold:         0.5,
+			QuorumProgress:    0.4,
+			ThresholdProgress: 0.75,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		proposalID     int
+		mockSnapshots  []dto.ProposalTallySnapshotModel
+		mockError      error
+		expectedResult *dto.ProposalTallyHistoryResponse
+		expectedError  error
+	}{
+		{
+			name:           "successful get proposal tally history",
+			proposalID:     1,
+			mockSnapshots:  snapshots,
+			expectedResult: &dto.ProposalTallyHistoryResponse{TallyHistory: snapshots},
+		},
+		{
+			name:           "proposal without snapshots",
+			proposalID:     2,
+			mockSnapshots:  []dto.ProposalTallySnapshotModel{},
+			expectedResult: &dto.ProposalTallyHistoryResponse{TallyHistory: []dto.ProposalTallySnapshotModel{}},
+		},
+		{
+			name:          "repository error",
+			proposalID:    1,
+			mockError:     errors.New("database error"),
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockProposalRepository()
+			service := services.NewProposalService(mockRepo)
+
+			mockRepo.On("GetProposalTallyHistory", tt.proposalID).Return(tt.mockSnapshots, tt.mockError)
+
+			result, err := service.GetProposalTallyHistory(tt.proposalID)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package services_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories/mocks"
+	"github.com/initia-labs/core-indexer/api/services"
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/sdkconfig"
+)
+
+func init() {
+	sdkconfig.ConfigureSDK()
+}
+
+type searchMocks struct {
+	account   *mocks.MockAccountRepository
+	block     *mocks.BlockRepository
+	module    *mocks.MockModuleRepository
+	nft       *mocks.MockNftRepository
+	proposal  *mocks.MockProposalRepository
+	tx        *mocks.MockTxRepository
+	validator *mocks.MockValidatorRepository
+}
+
+func newSearchService() (services.SearchService, *searchMocks) {
+	m := &searchMocks{
+		account:   mocks.NewMockAccountRepository(),
+		block:     mocks.NewMockBlockRepository(),
+		module:    mocks.NewMockModuleRepository(),
+		nft:       mocks.NewMockNftRepository(),
+		proposal:  mocks.NewMockProposalRepository(),
+		tx:        mocks.NewMockTxRepository(),
+		validator: mocks.NewMockValidatorRepository(),
+	}
+	return services.NewSearchService(m.account, m.block, m.module, m.nft, m.proposal, m.tx, m.validator), m
+}
+
+func TestSearchService_TxHash(t *testing.T) {
+	service, m := newSearchService()
+
+	hash := bytes.Repeat([]byte{0xab}, 32)
+	timestamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	m.tx.On("GetTxSummaryByHash", "abababababababababababababababababababababababababababababababab").
+		Return(&dto.TxSummaryModel{Hash: string(hash), Success: false, Height: 42, Timestamp: timestamp}, nil)
+
+	result, err := service.Search("ABABABABABABABABABABABABABABABABABABABABABABABABABABABABABABABAB", dto.SearchDefaultLimit)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, dto.SearchResultTypeTx, result.Items[0].Type)
+	assert.Equal(t, "abababababababababababababababababababababababababababababababab", result.Items[0].ID)
+	assert.Equal(t, int64(42), *result.Items[0].Height)
+	assert.Equal(t, "failed", result.Items[0].Status)
+	assert.Equal(t, "2025-01-02T03:04:05Z", result.Items[0].Timestamp)
+	m.tx.AssertExpectations(t)
+}
+
+func TestSearchService_Height(t *testing.T) {
+	service, m := newSearchService()
+
+	m.block.On("GetBlockInfo", int64(12)).Return(&dto.BlockInfoModel{Height: 12, Timestamp: "2025-01-02T03:04:05Z"}, nil)
+	m.proposal.On("SearchProposals", dto.PaginationQuery{Limit: 1}, "", "12", []string(nil), []string(nil)).
+		Return([]dto.ProposalSummary{{Id: 12, Title: "Upgrade", Status: "PASSED"}}, int64(0), nil)
+	m.validator.On("SearchValidatorsByMonikerPrefix", "12", 5).Return([]db.Validator{}, nil)
+	m.nft.On("SearchCollectionsByNamePrefix", "12", 5).Return([]db.Collection{}, nil)
+	m.proposal.On("SearchProposalsByTitlePrefix", "12", 5).Return([]dto.ProposalSummary{}, nil)
+
+	result, err := service.Search("12", dto.SearchDefaultLimit)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, dto.SearchResultTypeBlock, result.Items[0].Type)
+	assert.Equal(t, "12", result.Items[0].ID)
+	assert.Equal(t, dto.SearchResultTypeProposal, result.Items[1].Type)
+	assert.Equal(t, "Upgrade", result.Items[1].Name)
+	assert.Equal(t, "PASSED", result.Items[1].Status)
+	m.block.AssertExpectations(t)
+	m.proposal.AssertExpectations(t)
+}
+
+func TestSearchService_HeightNotFound(t *testing.T) {
+	service, m := newSearchService()
+
+	m.block.On("GetBlockInfo", int64(99999999999)).Return((*dto.BlockInfoModel)(nil), gorm.ErrRecordNotFound)
+	m.validator.On("SearchValidatorsByMonikerPrefix", "99999999999", 5).Return([]db.Validator{}, nil)
+	m.nft.On("SearchCollectionsByNamePrefix", "99999999999", 5).Return([]db.Collection{}, nil)
+	m.proposal.On("SearchProposalsByTitlePrefix", "99999999999", 5).Return([]dto.ProposalSummary{}, nil)
+
+	result, err := service.Search("99999999999", dto.SearchDefaultLimit)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Items)
+	// proposal ids never exceed int4, so no id lookup is made
+	m.proposal.AssertNotCalled(t, "SearchProposals", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSearchService_Prefix(t *testing.T) {
+	service, m := newSearchService()
+
+	m.validator.On("SearchValidatorsByMonikerPrefix", "ini", 3).
+		Return([]db.Validator{{OperatorAddress: "initvaloper1test", Moniker: "Initia Labs", IsActive: true}}, nil)
+	m.nft.On("SearchCollectionsByNamePrefix", "ini", 3).
+		Return([]db.Collection{{ID: CollectionAddress, Name: "Initia Punks"}}, nil)
+	m.proposal.On("SearchProposalsByTitlePrefix", "ini", 3).
+		Return([]dto.ProposalSummary{{Id: 7, Title: "Initial parameters", Status: "REJECTED"}}, nil)
+
+	result, err := service.Search("  ini ", 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.SearchResult{
+		{Type: dto.SearchResultTypeValidator, ID: "initvaloper1test", Name: "Initia Labs", Status: "active"},
+		{Type: dto.SearchResultTypeCollection, ID: CollectionAddress, Name: "Initia Punks"},
+		{Type: dto.SearchResultTypeProposal, ID: "7", Name: "Initial parameters", Status: "REJECTED"},
+	}, result.Items)
+	m.validator.AssertExpectations(t)
+	m.nft.AssertExpectations(t)
+	m.proposal.AssertExpectations(t)
+}
+
+func TestSearchService_ValidatorAddress(t *testing.T) {
+	service, m := newSearchService()
+
+	operatorAddr := sdk.ValAddress(bytes.Repeat([]byte{0x01}, 20)).String()
+	m.validator.On("GetValidatorRow", operatorAddr).
+		Return(&db.Validator{OperatorAddress: operatorAddr, Moniker: "Validator 1"}, nil)
+
+	result, err := service.Search(operatorAddr, dto.SearchDefaultLimit)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.SearchResult{
+		{Type: dto.SearchResultTypeValidator, ID: operatorAddr, Name: "Validator 1", Status: "inactive"},
+	}, result.Items)
+}
+
+func TestSearchService_AccountAddress(t *testing.T) {
+	service, m := newSearchService()
+
+	m.account.On("GetAccountByAccountAddress", AccountAddress).
+		Return(&db.Account{Address: AccountAddress, Name: "alice"}, nil)
+
+	hexAddress := "0x" + accAddressHex(AccountAddress)
+	for _, query := range []string{AccountAddress, hexAddress} {
+		result, err := service.Search(query, dto.SearchDefaultLimit)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.SearchResult{
+			{Type: dto.SearchResultTypeAccount, ID: AccountAddress, Name: "alice"},
+		}, result.Items)
+	}
+	// collections and Nfts are only looked up for object addresses
+	m.nft.AssertNotCalled(t, "GetCollectionsByCollectionAddress", mock.Anything)
+}
+
+func TestSearchService_ObjectAddress(t *testing.T) {
+	service, m := newSearchService()
+
+	objectAddress := "0x" + "0f" + string(bytes.Repeat([]byte("cd"), 31))
+	objectAccount := sdk.AccAddress(append([]byte{0x0f}, bytes.Repeat([]byte{0xcd}, 31)...)).String()
+	m.nft.On("GetCollectionsByCollectionAddress", "0xfcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcd").
+		Return(nil, gorm.ErrRecordNotFound)
+	m.nft.On("GetNftByID", "0xfcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcd").
+		Return(&dto.NftByAddressModel{ID: "0xfcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcd", TokenID: "Punk #1", Collection: CollectionAddress}, nil)
+	m.account.On("GetAccountByAccountAddress", objectAccount).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := service.Search(objectAddress, dto.SearchDefaultLimit)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.SearchResult{
+		{Type: dto.SearchResultTypeNft, ID: "0xfcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcd", Name: "Punk #1", Collection: CollectionAddress},
+	}, result.Items)
+	m.nft.AssertExpectations(t)
+	m.account.AssertExpectations(t)
+}
+
+func TestSearchService_Module(t *testing.T) {
+	service, m := newSearchService()
+
+	m.module.On("GetModulePublishInfo", "0x1", "coin").
+		Return([]dto.ModulePublishInfoModel{{Height: 10, Timestamp: "2025-01-02T03:04:05Z"}}, nil)
+	m.module.On("GetModulePublishInfo", "0x1", "missing").Return([]dto.ModulePublishInfoModel{}, nil)
+
+	result, err := service.Search("0x0001::coin", dto.SearchDefaultLimit)
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, dto.SearchResultTypeModule, result.Items[0].Type)
+	assert.Equal(t, "0x1::coin", result.Items[0].ID)
+	assert.Equal(t, int64(10), *result.Items[0].Height)
+
+	result, err = service.Search("0x1::missing", dto.SearchDefaultLimit)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Items)
+}
+
+func accAddressHex(address string) string {
+	accAddress, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(accAddress)
+}
@@ -0,0 +1,129 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/initia-labs/core-indexer/api/apperror"
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories/mocks"
+	"github.com/initia-labs/core-indexer/api/services"
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+func TestStatsService_GetChainStats(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name           string
+		granularity    string
+		from           *time.Time
+		to             *time.Time
+		expectedFrom   time.Time
+		mockStats      []dto.ChainStatModel
+		mockError      error
+		expectedResult *dto.ChainStatsResponse
+		expectedError  error
+	}{
+		{
+			name:         "daily stats",
+			granularity:  db.ChainStatGranularityDay,
+			from:         at(day.Add(12 * time.Hour)),
+			to:           at(day.AddDate(0, 0, 1)),
+			expectedFrom: day,
+			mockStats: []dto.ChainStatModel{
+				{
+					Timestamp:      day,
+					BlockCount:     100,
+					AvgBlockTime:   0.5,
+					TxCount:        10,
+					SuccessTxCount: 9,
+					FailedTxCount:  1,
+					ActiveAccounts: 4,
+					NewAccounts:    2,
+					GasUsed:        1000,
+					Fees:           []byte(`[{"denom":"uinit","amount":"150"}]`),
+					ModuleCalls:    3,
+					NftMints:       1,
+				},
+			},
+			expectedResult: &dto.ChainStatsResponse{
+				Stats: []dto.ChainStat{
+					{
+						Timestamp:      day,
+						BlockCount:     100,
+						AvgBlockTime:   0.5,
+						TxCount:        10,
+						SuccessTxCount: 9,
+						FailedTxCount:  1,
+						ActiveAccounts: 4,
+						NewAccounts:    2,
+						GasUsed:        1000,
+						Fees:           dto.Coins{{Denom: "uinit", Amount: "150"}},
+						ModuleCalls:    3,
+						NftMints:       1,
+					},
+				},
+			},
+		},
+		{
+			name:           "hourly stats default to the last day",
+			granularity:    db.ChainStatGranularityHour,
+			to:             at(day.Add(30 * time.Minute)),
+			expectedFrom:   day.Add(-24 * time.Hour),
+			mockStats:      []dto.ChainStatModel{},
+			expectedResult: &dto.ChainStatsResponse{Stats: []dto.ChainStat{}},
+		},
+		{
+			name:          "from after to",
+			granularity:   db.ChainStatGranularityDay,
+			from:          at(day.AddDate(0, 0, 2)),
+			to:            at(day),
+			expectedError: apperror.NewValidationError(apperror.ErrMsgStatsRange),
+		},
+		{
+			name:          "hourly span too large",
+			granularity:   db.ChainStatGranularityHour,
+			from:          at(day),
+			to:            at(day.AddDate(0, 0, 32)),
+			expectedError: apperror.NewValidationError(apperror.ErrMsgStatsHourlySpan),
+		},
+		{
+			name:          "repository error",
+			granularity:   db.ChainStatGranularityDay,
+			from:          at(day),
+			to:            at(day.AddDate(0, 0, 1)),
+			expectedFrom:  day,
+			mockError:     errors.New("database error"),
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockStatsRepository()
+			service := services.NewStatsService(mockRepo)
+
+			if tt.mockStats != nil || tt.mockError != nil {
+				mockRepo.On("GetChainStats", tt.granularity, tt.expectedFrom, mock.Anything).Return(tt.mockStats, tt.mockError)
+			}
+
+			result, err := service.GetChainStats(tt.granularity, tt.from, tt.to)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
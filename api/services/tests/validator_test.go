@@ -0,0 +1,262 @@
+package services_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/core-indexer/api/dto"
+	"github.com/initia-labs/core-indexer/api/repositories/mocks"
+	"github.com/initia-labs/core-indexer/api/services"
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+func TestValidatorService_GetValidatorSlashEvents(t *testing.T) {
+	reason := "double_sign"
+	var power, infractionHeight int64 = 1000, 95
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		pagination     dto.PaginationQuery
+		operatorAddr   string
+		mockEvents     []dto.ValidatorUptimeEventModel
+		mockTotal      int64
+		mockError      error
+		expectedResult *dto.ValidatorSlashEventsResponse
+		expectedError  error
+	}{
+		{
+			name: "successful get validator slash events",
+			pagination: dto.PaginationQuery{
+				Limit:      10,
+				Offset:     0,
+				CountTotal: true,
+			},
+			operatorAddr: "initvaloper1test",
+			mockEvents: []dto.ValidatorUptimeEventModel{
+				{
+					Height:           100,
+					Timestamp:        timestamp,
+					Type:             "Slashed",
+					Reason:           &reason,
+					Power:            &power,
+					BurnedCoins:      json.RawMessage(`[{"denom":"uinit","amount":"50"}]`),
+					InfractionHeight: &infractionHeight,
+				},
+				{
+					Height:           100,
+					Timestamp:        timestamp,
+					Type:             "Jailed",
+					Reason:           &reason,
+					InfractionHeight: &infractionHeight,
+				},
+			},
+			mockTotal: 2,
+			mockError: nil,
+			expectedResult: &dto.ValidatorSlashEventsResponse{
+				SlashEvents: []dto.ValidatorUptimeEventModel{
+					{
+						Height:           100,
+						Timestamp:        timestamp,
+						Type:             "Slashed",
+						Reason:           &reason,
+						Power:            &power,
+						BurnedCoins:      json.RawMessage(`[{"denom":"uinit","amount":"50"}]`),
+						InfractionHeight: &infractionHeight,
+					},
+					{
+						Height:           100,
+						Timestamp:        timestamp,
+						Type:             "Jailed",
+						Reason:           &reason,
+						InfractionHeight: &infractionHeight,
+					},
+				},
+				Pagination: dto.NewPaginationResponse(0, 10, 2),
+			},
+			expectedError: nil,
+		},
+		{
+			name: "repository error",
+			pagination: dto.PaginationQuery{
+				Limit:  10,
+				Offset: 0,
+			},
+			operatorAddr:   "initvaloper1test",
+			mockEvents:     nil,
+			mockTotal:      0,
+			mockError:      errors.New("database error"),
+			expectedResult: nil,
+			expectedError:  errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockValidatorRepository()
+			service := services.NewValidatorService(mockRepo, mocks.NewMockBlockRepository(), mocks.NewMockProposalRepository())
+
+			mockRepo.On("GetValidatorSlashHistory", tt.pagination, tt.operatorAddr).Return(tt.mockEvents, tt.mockTotal, tt.mockError)
+
+			result, err := service.GetValidatorSlashEvents(tt.pagination, tt.operatorAddr)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidatorService_GetValidatorDowntimeEpisodes(t *testing.T) {
+	startTimestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTimestamp := startTimestamp.Add(time.Minute)
+
+	tests := []struct {
+		name           string
+		pagination     dto.PaginationQuery
+		operatorAddr   string
+		mockEpisodes   []dto.ValidatorDowntimeEpisodeModel
+		mockTotal      int64
+		mockError      error
+		expectedResult *dto.ValidatorDowntimeEpisodesResponse
+		expectedError  error
+	}{
+		{
+			name: "successful get validator downtime episodes",
+			pagination: dto.PaginationQuery{
+				Limit:      10,
+				Offset:     0,
+				CountTotal: true,
+			},
+			operatorAddr: "initvaloper1test",
+			mockEpisodes: []dto.ValidatorDowntimeEpisodeModel{
+				{
+					ID:             2,
+					Type:           "MissedBlockStreak",
+					StartHeight:    200,
+					StartTimestamp: &startTimestamp,
+					EndHeight:      215,
+					EndTimestamp:   &endTimestamp,
+					MissedCount:    16,
+					IsResolved:     false,
+				},
+				{
+					ID:             1,
+					Type:           "MissedBlockRate",
+					StartHeight:    100,
+					StartTimestamp: &startTimestamp,
+					EndHeight:      180,
+					EndTimestamp:   &endTimestamp,
+					MissedCount:    55,
+					IsResolved:     true,
+				},
+			},
+			mockTotal: 2,
+			mockError: nil,
+			expectedResult: &dto.ValidatorDowntimeEpisodesResponse{
+				DowntimeEpisodes: []dto.ValidatorDowntimeEpisodeModel{
+					{
+						ID:             2,
+						Type:           "MissedBlockStreak",
+						StartHeight:    200,
+						StartTimestamp: &startTimestamp,
+						EndHeight:      215,
+						EndTimestamp:   &endTimestamp,
+						MissedCount:    16,
+						IsResolved:     false,
+					},
+					{
+						ID:             1,
+						Type:           "MissedBlockRate",
+						StartHeight:    100,
+						StartTimestamp: &startTimestamp,
+						EndHeight:      180,
+						EndTimestamp:   &endTimestamp,
+						MissedCount:    55,
+						IsResolved:     true,
+					},
+				},
+				Pagination: dto.NewPaginationResponse(0, 10, 2),
+			},
+			expectedError: nil,
+		},
+		{
+			name: "repository error",
+			pagination: dto.PaginationQuery{
+				Limit:  10,
+				Offset: 0,
+			},
+			operatorAddr:   "initvaloper1test",
+			mockEpisodes:   nil,
+			mockTotal:      0,
+			mockError:      errors.New("database error"),
+			expectedResult: nil,
+			expectedError:  errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockValidatorRepository()
+			service := services.NewValidatorService(mockRepo, mocks.NewMockBlockRepository(), mocks.NewMockProposalRepository())
+
+			mockRepo.On("GetValidatorDowntimeEpisodes", tt.pagination, tt.operatorAddr).Return(tt.mockEpisodes, tt.mockTotal, tt.mockError)
+
+			result, err := service.GetValidatorDowntimeEpisodes(tt.pagination, tt.operatorAddr)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidatorService_GetValidatorInfoImage(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator db.Validator
+		wantImage string
+	}{
+		{
+			name:      "hosted image URL",
+			validator: db.Validator{OperatorAddress: "initvaloper1", IdentityImage: "aW1hZ2U=", IdentityImageURL: "https://cdn.test/validators/initvaloper1.jpg"},
+			wantImage: "https://cdn.test/validators/initvaloper1.jpg",
+		},
+		{
+			name:      "base64 image until the URL is resolved",
+			validator: db.Validator{OperatorAddress: "initvaloper1", IdentityImage: "aW1hZ2U="},
+			wantImage: "aW1hZ2U=",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockValidatorRepository()
+			service := services.NewValidatorService(mockRepo, mocks.NewMockBlockRepository(), mocks.NewMockProposalRepository())
+
+			mockRepo.On("GetValidatorRow", tt.validator.OperatorAddress).Return(&tt.validator, nil)
+			mockRepo.On("GetValidatorsByPower", (*dto.PaginationQuery)(nil), true).Return([]db.Validator{tt.validator}, nil)
+
+			result, err := service.GetValidatorInfo(tt.validator.OperatorAddress)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantImage, result.Info.Image)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
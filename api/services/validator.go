@@ -19,6 +19,8 @@ type ValidatorService interface {
 	GetValidatorInfo(operatorAddr string) (*dto.ValidatorInfoResponse, error)
 	GetValidatorUptime(operatorAddr string, blocks int) (*dto.ValidatorUptimeResponse, error)
 	GetValidatorDelegationTxs(pagination dto.PaginationQuery, operatorAddr string) (*dto.ValidatorDelegationRelatedTxsResponse, error)
+	GetValidatorSlashEvents(pagination dto.PaginationQuery, operatorAddr string) (*dto.ValidatorSlashEventsResponse, error)
+	GetValidatorDowntimeEpisodes(pagination dto.PaginationQuery, operatorAddr string) (*dto.ValidatorDowntimeEpisodesResponse, error)
 	GetValidatorProposedBlocks(pagination dto.PaginationQuery, operatorAddr string) (*dto.ValidatorProposedBlocksResponse, error)
 	GetValidatorHistoricalPowers(operatorAddr string) (*dto.ValidatorHistoricalPowersResponse, error)
 	GetValidatorVotedProposals(pagination dto.PaginationQuery, operatorAddr, search, answer string) (*dto.ValidatorVotedProposalsResponse, error)
@@ -94,8 +96,8 @@ func (s *validatorService) GetValidators(pagination dto.PaginationQuery, status
 		validatorInfo.TotalBlocks = 10000
 		validatorInfo.SignedBlocks = int64(val.Last10000)
 
-		// Add image data from database (pre-fetched by cron job from Keybase, base64-encoded)
-		validatorInfo.Image = val.IdentityImage
+		// Add the identity image URL resolved by the cron job from the identity image providers
+		validatorInfo.Image = identityImage(&val.Validator)
 
 		validatorInfoItems = append(validatorInfoItems, *validatorInfo)
 	}
@@ -114,6 +116,15 @@ func (s *validatorService) GetValidators(pagination dto.PaginationQuery, status
 	}, nil
 }
 
+// identityImage returns the hosted identity image of a validator, or its base64 identity image while the URL is not
+// resolved yet
+func identityImage(validator *db.Validator) string {
+	if validator.IdentityImageURL != "" {
+		return validator.IdentityImageURL
+	}
+	return validator.IdentityImage
+}
+
 func flattenValidatorInfo(validator *dto.ValidatorWithVoteCountModel, rankMap map[string]int) *dto.ValidatorInfo {
 	validatorInfo := &dto.ValidatorInfo{
 		AccountAddress:   validator.AccountID,
@@ -179,7 +190,7 @@ func (s *validatorService) GetValidatorInfo(operatorAddr string) (*dto.Validator
 
 	totalVotingPower, rankMap, _, _ := getTotalVotingPowerAndRank(activeValidators)
 	validatorInfo := flattenValidatorInfo(&dto.ValidatorWithVoteCountModel{Validator: *validator}, rankMap)
-	validatorInfo.Image = validator.IdentityImage
+	validatorInfo.Image = identityImage(validator)
 
 	return &dto.ValidatorInfoResponse{
 		Info:             *validatorInfo,
@@ -368,6 +379,32 @@ func (s *validatorService) GetValidatorDelegationTxs(pagination dto.PaginationQu
 	}, nil
 }
 
+// GetValidatorSlashEvents retrieves the slashing history of a validator
+func (s *validatorService) GetValidatorSlashEvents(pagination dto.PaginationQuery, operatorAddr string) (*dto.ValidatorSlashEventsResponse, error) {
+	events, total, err := s.repo.GetValidatorSlashHistory(pagination, operatorAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ValidatorSlashEventsResponse{
+		SlashEvents: events,
+		Pagination:  dto.NewPaginationResponse(pagination.Offset, pagination.Limit, total),
+	}, nil
+}
+
+// GetValidatorDowntimeEpisodes retrieves the missed-block streaks and rates detected for a validator
+func (s *validatorService) GetValidatorDowntimeEpisodes(pagination dto.PaginationQuery, operatorAddr string) (*dto.ValidatorDowntimeEpisodesResponse, error) {
+	episodes, total, err := s.repo.GetValidatorDowntimeEpisodes(pagination, operatorAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ValidatorDowntimeEpisodesResponse{
+		DowntimeEpisodes: episodes,
+		Pagination:       dto.NewPaginationResponse(pagination.Offset, pagination.Limit, total),
+	}, nil
+}
+
 func (s *validatorService) GetValidatorProposedBlocks(pagination dto.PaginationQuery, operatorAddr string) (*dto.ValidatorProposedBlocksResponse, error) {
 	blocks, total, err := s.repo.GetValidatorProposedBlocks(pagination, operatorAddr)
 	if err != nil {
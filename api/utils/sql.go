@@ -0,0 +1,10 @@
+package utils
+
+import "strings"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLikePattern escapes the LIKE wildcards of s so it can be matched literally
+func EscapeLikePattern(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,54 @@
+-- Convert the partitioned event tables back into regular tables, copying the rows of every partition.
+
+-- Revert "transaction_events"
+ALTER TABLE "public"."transaction_events" RENAME TO "transaction_events_partitioned";
+ALTER INDEX "public"."ix_transaction_events_block_height_desc" RENAME TO "ix_transaction_events_partitioned_block_height_desc";
+ALTER INDEX "public"."ix_transactions_events_event_key_block_height_desc" RENAME TO "ix_transaction_events_partitioned_event_key_block_height_desc";
+ALTER TABLE "public"."transaction_events_partitioned" RENAME CONSTRAINT "transaction_events_pkey" TO "transaction_events_partitioned_pkey";
+CREATE TABLE "public"."transaction_events" (
+    "transaction_hash" character varying NOT NULL,
+    "block_height" bigint NOT NULL,
+    "event_key" character varying NOT NULL,
+    "event_value" character varying NOT NULL,
+    "event_index" integer NOT NULL,
+    PRIMARY KEY ("block_height", "transaction_hash", "event_index")
+);
+INSERT INTO "public"."transaction_events" SELECT "transaction_hash", "block_height", "event_key", "event_value", "event_index" FROM "public"."transaction_events_partitioned";
+DROP TABLE "public"."transaction_events_partitioned";
+CREATE INDEX "ix_transaction_events_block_height_desc" ON "public"."transaction_events" ("block_height" DESC);
+CREATE INDEX "ix_transactions_events_event_key_block_height_desc" ON "public"."transaction_events" ("event_key", "block_height" DESC);
+GRANT SELECT ON "public"."transaction_events" TO readonly;
+
+-- Revert "move_events"
+ALTER TABLE "public"."move_events" RENAME TO "move_events_partitioned";
+ALTER INDEX "public"."ix_move_events_type_tag_block_height_desc" RENAME TO "ix_move_events_partitioned_type_tag_block_height_desc";
+ALTER TABLE "public"."move_events_partitioned" RENAME CONSTRAINT "move_events_pkey" TO "move_events_partitioned_pkey";
+CREATE TABLE "public"."move_events" (
+    "type_tag" character varying NOT NULL,
+    "data" jsonb NOT NULL,
+    "block_height" bigint NOT NULL,
+    "transaction_hash" character varying NOT NULL,
+    "event_index" integer NOT NULL,
+    PRIMARY KEY ("block_height", "transaction_hash", "event_index")
+);
+INSERT INTO "public"."move_events" SELECT "type_tag", "data", "block_height", "transaction_hash", "event_index" FROM "public"."move_events_partitioned";
+DROP TABLE "public"."move_events_partitioned";
+CREATE INDEX "ix_move_events_type_tag_block_height_desc" ON "public"."move_events" ("type_tag", "block_height" DESC);
+GRANT SELECT ON "public"."move_events" TO readonly;
+
+-- Revert "finalize_block_events"
+ALTER TABLE "public"."finalize_block_events" RENAME TO "finalize_block_events_partitioned";
+ALTER INDEX "public"."ix_finalize_block_events_event_key_block_height_desc" RENAME TO "ix_finalize_block_events_partitioned_event_key_block_height_desc";
+ALTER TABLE "public"."finalize_block_events_partitioned" RENAME CONSTRAINT "finalize_block_events_pkey" TO "finalize_block_events_partitioned_pkey";
+CREATE TABLE "public"."finalize_block_events" (
+    "block_height" bigint NOT NULL,
+    "event_key" character varying NOT NULL,
+    "event_value" character varying NOT NULL,
+    "event_index" integer NOT NULL,
+    "mode" "public"."finalize_block_events_mode" NOT NULL,
+    PRIMARY KEY ("block_height", "event_index")
+);
+INSERT INTO "public"."finalize_block_events" SELECT "block_height", "event_key", "event_value", "event_index", "mode" FROM "public"."finalize_block_events_partitioned";
+DROP TABLE "public"."finalize_block_events_partitioned";
+CREATE INDEX "ix_finalize_block_events_event_key_block_height_desc" ON "public"."finalize_block_events" ("event_key", "block_height" DESC);
+GRANT SELECT ON "public"."finalize_block_events" TO readonly;
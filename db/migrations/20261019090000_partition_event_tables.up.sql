@@ -0,0 +1,68 @@
+-- Convert "transaction_events", "move_events" and "finalize_block_events" into tables range-partitioned by block_height.
+-- Existing rows are kept as a single "<table>_legacy" partition bounded by the current max block_height, whose rows
+-- the event-indexer prunner deletes as the pruning threshold moves, subsequent partitions are created ahead of time by
+-- the event-indexer partition maintenance job.
+
+-- Partition "transaction_events"
+ALTER TABLE "public"."transaction_events" RENAME TO "transaction_events_legacy";
+ALTER TABLE "public"."transaction_events_legacy" RENAME CONSTRAINT "transaction_events_pkey" TO "transaction_events_legacy_pkey";
+ALTER INDEX "public"."ix_transaction_events_block_height_desc" RENAME TO "ix_transaction_events_legacy_block_height_desc";
+ALTER INDEX "public"."ix_transactions_events_event_key_block_height_desc" RENAME TO "ix_transaction_events_legacy_event_key_block_height_desc";
+CREATE TABLE "public"."transaction_events" (
+    "transaction_hash" character varying NOT NULL,
+    "block_height" bigint NOT NULL,
+    "event_key" character varying NOT NULL,
+    "event_value" character varying NOT NULL,
+    "event_index" integer NOT NULL,
+    PRIMARY KEY ("block_height", "transaction_hash", "event_index")
+) PARTITION BY RANGE ("block_height");
+CREATE INDEX "ix_transaction_events_block_height_desc" ON "public"."transaction_events" ("block_height" DESC);
+CREATE INDEX "ix_transactions_events_event_key_block_height_desc" ON "public"."transaction_events" ("event_key", "block_height" DESC);
+GRANT SELECT ON "public"."transaction_events" TO readonly;
+
+-- Partition "move_events"
+ALTER TABLE "public"."move_events" RENAME TO "move_events_legacy";
+ALTER TABLE "public"."move_events_legacy" RENAME CONSTRAINT "move_events_pkey" TO "move_events_legacy_pkey";
+ALTER INDEX "public"."ix_move_events_type_tag_block_height_desc" RENAME TO "ix_move_events_legacy_type_tag_block_height_desc";
+CREATE TABLE "public"."move_events" (
+    "type_tag" character varying NOT NULL,
+    "data" jsonb NOT NULL,
+    "block_height" bigint NOT NULL,
+    "transaction_hash" character varying NOT NULL,
+    "event_index" integer NOT NULL,
+    PRIMARY KEY ("block_height", "transaction_hash", "event_index")
+) PARTITION BY RANGE ("block_height");
+CREATE INDEX "ix_move_events_type_tag_block_height_desc" ON "public"."move_events" ("type_tag", "block_height" DESC);
+GRANT SELECT ON "public"."move_events" TO readonly;
+
+-- Partition "finalize_block_events"
+ALTER TABLE "public"."finalize_block_events" RENAME TO "finalize_block_events_legacy";
+ALTER TABLE "public"."finalize_block_events_legacy" RENAME CONSTRAINT "finalize_block_events_pkey" TO "finalize_block_events_legacy_pkey";
+ALTER INDEX "public"."ix_finalize_block_events_event_key_block_height_desc" RENAME TO "ix_finalize_block_events_legacy_event_key_block_height_desc";
+CREATE TABLE "public"."finalize_block_events" (
+    "block_height" bigint NOT NULL,
+    "event_key" character varying NOT NULL,
+    "event_value" character varying NOT NULL,
+    "event_index" integer NOT NULL,
+    "mode" "public"."finalize_block_events_mode" NOT NULL,
+    PRIMARY KEY ("block_height", "event_index")
+) PARTITION BY RANGE ("block_height");
+CREATE INDEX "ix_finalize_block_events_event_key_block_height_desc" ON "public"."finalize_block_events" ("event_key", "block_height" DESC);
+GRANT SELECT ON "public"."finalize_block_events" TO readonly;
+
+-- Attach the existing rows as legacy partitions, or drop the old tables if they are empty. ATTACH PARTITION scans the
+-- rows to validate the partition bound.
+DO $$
+DECLARE
+    tbl text;
+    max_height bigint;
+BEGIN
+    FOREACH tbl IN ARRAY ARRAY['transaction_events', 'move_events', 'finalize_block_events'] LOOP
+        EXECUTE format('SELECT max(block_height) FROM public.%I', tbl || '_legacy') INTO max_height;
+        IF max_height IS NULL THEN
+            EXECUTE format('DROP TABLE public.%I', tbl || '_legacy');
+        ELSE
+            EXECUTE format('ALTER TABLE public.%I ATTACH PARTITION public.%I FOR VALUES FROM (MINVALUE) TO (%s)', tbl, tbl || '_legacy', max_height + 1);
+        END IF;
+    END LOOP;
+END $$;
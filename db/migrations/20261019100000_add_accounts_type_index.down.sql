@@ -0,0 +1,2 @@
+-- Remove the accounts type index
+DROP INDEX "public"."ix_accounts_type_address";
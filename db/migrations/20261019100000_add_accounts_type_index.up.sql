@@ -0,0 +1,2 @@
+-- Index accounts by type, so account lists can be filtered by type
+CREATE INDEX "ix_accounts_type_address" ON "public"."accounts" ("type", "address");
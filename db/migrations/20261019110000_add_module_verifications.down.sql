@@ -0,0 +1,6 @@
+-- Remove the Move package source verification tables
+ALTER TABLE "public"."module_histories" DROP COLUMN "digest";
+DROP TABLE "public"."verified_modules";
+DROP TABLE "public"."module_verification_files";
+DROP TABLE "public"."module_verifications";
+DROP TYPE "public"."verificationstatus";
@@ -0,0 +1,40 @@
+-- Add tables for Move package source verification. Submitted packages are queued in "module_verifications"
+-- with their files, and each compiled module whose digest matches the on-chain module is stored in "verified_modules".
+-- "module_histories" records the digest of every published version so verifications can be matched per history entry.
+CREATE TYPE "public"."verificationstatus" AS ENUM ('Pending', 'Compiling', 'Verified', 'Failed');
+CREATE TABLE "public"."module_verifications" (
+    "id" bigserial NOT NULL,
+    "address" character varying NOT NULL,
+    "package_name" character varying NOT NULL,
+    "status" "public"."verificationstatus" NOT NULL,
+    "error" text NOT NULL,
+    "compiler_version" character varying NOT NULL,
+    "submitted_at" timestamp NOT NULL,
+    "started_at" timestamp NULL,
+    "completed_at" timestamp NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "ix_module_verifications_address" ON "public"."module_verifications" ("address");
+CREATE INDEX "ix_module_verifications_status_id" ON "public"."module_verifications" ("status", "id");
+CREATE TABLE "public"."module_verification_files" (
+    "verification_id" bigint NOT NULL,
+    "path" character varying NOT NULL,
+    "content" text NOT NULL,
+    PRIMARY KEY ("verification_id", "path"),
+    CONSTRAINT "fk_module_verification_files_verification" FOREIGN KEY ("verification_id") REFERENCES "public"."module_verifications" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+CREATE TABLE "public"."verified_modules" (
+    "module_id" character varying NOT NULL,
+    "digest" character varying NOT NULL,
+    "verification_id" bigint NOT NULL,
+    "abi" json NOT NULL,
+    "verified_at" timestamp NOT NULL,
+    PRIMARY KEY ("module_id", "digest"),
+    CONSTRAINT "fk_verified_modules_module" FOREIGN KEY ("module_id") REFERENCES "public"."modules" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+    CONSTRAINT "fk_verified_modules_verification" FOREIGN KEY ("verification_id") REFERENCES "public"."module_verifications" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+CREATE INDEX "ix_verified_modules_verification_id" ON "public"."verified_modules" ("verification_id");
+ALTER TABLE "public"."module_histories" ADD COLUMN "digest" character varying NULL;
+GRANT SELECT ON "public"."module_verifications" TO readonly;
+GRANT SELECT ON "public"."module_verification_files" TO readonly;
+GRANT SELECT ON "public"."verified_modules" TO readonly;
@@ -0,0 +1,4 @@
+-- Remove the Move function call statistics tables
+DROP TABLE "public"."module_function_callers";
+DROP TABLE "public"."module_function_stats";
+DROP TABLE "public"."module_function_calls";
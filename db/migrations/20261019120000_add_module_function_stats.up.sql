@@ -0,0 +1,36 @@
+-- Record every MsgExecute/MsgExecuteJSON call at function level in "module_function_calls", aggregated per function
+-- and day in "module_function_stats", with the distinct callers of each day in "module_function_callers".
+CREATE TABLE "public"."module_function_calls" (
+    "tx_id" character varying NOT NULL,
+    "msg_index" integer NOT NULL,
+    "module_id" character varying NOT NULL,
+    "function_name" character varying NOT NULL,
+    "sender" character varying NOT NULL,
+    "success" boolean NOT NULL,
+    "gas_used" bigint NOT NULL,
+    "block_height" bigint NOT NULL,
+    PRIMARY KEY ("tx_id", "msg_index"),
+    CONSTRAINT "fk_module_function_calls_block" FOREIGN KEY ("block_height") REFERENCES "public"."blocks" ("height") ON UPDATE NO ACTION ON DELETE NO ACTION,
+    CONSTRAINT "fk_module_function_calls_transaction" FOREIGN KEY ("tx_id") REFERENCES "public"."transactions" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+CREATE INDEX "ix_module_function_calls_block_height" ON "public"."module_function_calls" ("block_height");
+CREATE INDEX "ix_module_function_calls_module_id_function_name_block_height" ON "public"."module_function_calls" ("module_id", "function_name", "block_height");
+CREATE TABLE "public"."module_function_stats" (
+    "module_id" character varying NOT NULL,
+    "function_name" character varying NOT NULL,
+    "date" date NOT NULL,
+    "call_count" bigint NOT NULL,
+    "failure_count" bigint NOT NULL,
+    "gas_used" bigint NOT NULL,
+    PRIMARY KEY ("module_id", "function_name", "date")
+);
+CREATE TABLE "public"."module_function_callers" (
+    "module_id" character varying NOT NULL,
+    "function_name" character varying NOT NULL,
+    "date" date NOT NULL,
+    "sender" character varying NOT NULL,
+    PRIMARY KEY ("module_id", "function_name", "date", "sender")
+);
+GRANT SELECT ON "public"."module_function_calls" TO readonly;
+GRANT SELECT ON "public"."module_function_stats" TO readonly;
+GRANT SELECT ON "public"."module_function_callers" TO readonly;
@@ -0,0 +1,7 @@
+-- Remove the validator slash event details
+DROP INDEX "public"."ix_validator_slash_events_validator_address_block_height";
+ALTER TABLE "public"."validator_slash_events"
+    DROP COLUMN "reason",
+    DROP COLUMN "power",
+    DROP COLUMN "burned_coins",
+    DROP COLUMN "infraction_height";
@@ -0,0 +1,8 @@
+-- Record the reason, slashed power, burned coins and infraction height of validator slash events,
+-- and index the events of each validator for the slashing history
+ALTER TABLE "public"."validator_slash_events"
+    ADD COLUMN "reason" character varying NULL,
+    ADD COLUMN "power" bigint NULL,
+    ADD COLUMN "burned_coins" json NULL,
+    ADD COLUMN "infraction_height" bigint NULL;
+CREATE INDEX "ix_validator_slash_events_validator_address_block_height" ON "public"."validator_slash_events" ("validator_address", "block_height");
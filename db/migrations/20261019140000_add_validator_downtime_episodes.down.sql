@@ -0,0 +1,2 @@
+-- Remove the validator downtime episodes
+DROP TABLE "public"."validator_downtime_episodes";
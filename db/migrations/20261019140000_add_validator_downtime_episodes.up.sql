@@ -0,0 +1,18 @@
+-- Store the validator downtime episodes detected by the cron from the commit signatures: missed-block streaks and
+-- missed-block rates over a window, with at most one open episode per validator and type
+CREATE TABLE "public"."validator_downtime_episodes" (
+    "id" bigserial NOT NULL,
+    "validator_address" character varying NOT NULL,
+    "type" character varying NOT NULL,
+    "start_height" bigint NOT NULL,
+    "end_height" bigint NOT NULL,
+    "missed_count" bigint NOT NULL,
+    "is_resolved" boolean NOT NULL,
+    "opened_notified" boolean NOT NULL,
+    "resolved_notified" boolean NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_validator_downtime_episodes_validator" FOREIGN KEY ("validator_address") REFERENCES "public"."validators" ("operator_address") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+CREATE INDEX "ix_validator_downtime_episodes_validator_address_start_height" ON "public"."validator_downtime_episodes" ("validator_address", "start_height");
+CREATE UNIQUE INDEX "ix_validator_downtime_episodes_open" ON "public"."validator_downtime_episodes" ("validator_address", "type") WHERE (NOT is_resolved);
+GRANT SELECT ON "public"."validator_downtime_episodes" TO readonly;
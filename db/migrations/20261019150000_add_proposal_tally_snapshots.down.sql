@@ -0,0 +1,2 @@
+-- Remove the proposal tally snapshots
+DROP TABLE "public"."proposal_tally_snapshots";
@@ -0,0 +1,19 @@
+-- Store timestamped snapshots of the live tally of the proposals in voting period, with their quorum and threshold progress
+CREATE TABLE "public"."proposal_tally_snapshots" (
+    "proposal_id" integer NOT NULL,
+    "tally_height" bigint NOT NULL,
+    "timestamp" timestamp NOT NULL,
+    "yes" bigint NOT NULL,
+    "no" bigint NOT NULL,
+    "abstain" bigint NOT NULL,
+    "no_with_veto" bigint NOT NULL,
+    "total_staking_power" bigint NOT NULL,
+    "total_vesting_power" bigint NOT NULL,
+    "quorum" double precision NOT NULL,
+    "threshold" double precision NOT NULL,
+    "quorum_progress" double precision NOT NULL,
+    "threshold_progress" double precision NOT NULL,
+    PRIMARY KEY ("proposal_id", "tally_height"),
+    CONSTRAINT "fk_proposal_tally_snapshots_proposal" FOREIGN KEY ("proposal_id") REFERENCES "public"."proposals" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+GRANT SELECT ON "public"."proposal_tally_snapshots" TO readonly;
@@ -0,0 +1,4 @@
+-- Remove the proposal minimum deposit and the proposal deposit indexes
+DROP INDEX "public"."ix_proposal_deposits_depositor";
+DROP INDEX "public"."ix_proposal_deposits_proposal_id";
+ALTER TABLE "public"."proposals" DROP COLUMN "min_deposit";
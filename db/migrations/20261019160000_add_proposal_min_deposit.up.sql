@@ -0,0 +1,4 @@
+-- Record the minimum deposit a proposal needs to enter its voting period, and index the deposits by proposal and depositor
+ALTER TABLE "public"."proposals" ADD COLUMN "min_deposit" json NULL;
+CREATE INDEX "ix_proposal_deposits_proposal_id" ON "public"."proposal_deposits" ("proposal_id");
+CREATE INDEX "ix_proposal_deposits_depositor" ON "public"."proposal_deposits" ("depositor");
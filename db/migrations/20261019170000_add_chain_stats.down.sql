@@ -0,0 +1,2 @@
+-- Remove the chain stats rollups
+DROP TABLE "public"."chain_stats";
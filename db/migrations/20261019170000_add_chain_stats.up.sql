@@ -0,0 +1,18 @@
+-- Roll up chain activity per hour and per day in "chain_stats", keyed by the granularity and the start of the period
+CREATE TABLE "public"."chain_stats" (
+    "granularity" character varying NOT NULL,
+    "timestamp" timestamp NOT NULL,
+    "block_count" bigint NOT NULL,
+    "avg_block_time" double precision NOT NULL,
+    "tx_count" bigint NOT NULL,
+    "success_tx_count" bigint NOT NULL,
+    "failed_tx_count" bigint NOT NULL,
+    "active_accounts" bigint NOT NULL,
+    "new_accounts" bigint NOT NULL,
+    "gas_used" bigint NOT NULL,
+    "fees" json NOT NULL,
+    "module_calls" bigint NOT NULL,
+    "nft_mints" bigint NOT NULL,
+    PRIMARY KEY ("granularity", "timestamp")
+);
+GRANT SELECT ON "public"."chain_stats" TO readonly;
@@ -0,0 +1,5 @@
+-- Remove the account stats
+ALTER TABLE "public"."tracking"
+    DROP COLUMN "account_stats_from_height",
+    DROP COLUMN "account_stats_backfilled_height";
+DROP TABLE "public"."account_stats";
@@ -0,0 +1,24 @@
+-- Summarize the activity of each account in "account_stats", maintained by the informative indexer for every block.
+-- The blocks indexed before are counted by the backfill-account-stats command, up to the first height counted by the
+-- indexer recorded in "tracking"
+CREATE TABLE "public"."account_stats" (
+    "account_id" character varying NOT NULL,
+    "first_seen_height" bigint NOT NULL,
+    "first_seen_timestamp" timestamp NOT NULL,
+    "last_active_height" bigint NOT NULL,
+    "last_active_timestamp" timestamp NOT NULL,
+    "tx_count" bigint NOT NULL,
+    "signer_tx_count" bigint NOT NULL,
+    "send_tx_count" bigint NOT NULL,
+    "ibc_tx_count" bigint NOT NULL,
+    "move_execute_tx_count" bigint NOT NULL,
+    "move_publish_tx_count" bigint NOT NULL,
+    "nft_tx_count" bigint NOT NULL,
+    "proposals_voted" bigint NOT NULL,
+    PRIMARY KEY ("account_id"),
+    CONSTRAINT "fk_account_stats_account" FOREIGN KEY ("account_id") REFERENCES "public"."accounts" ("address") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+ALTER TABLE "public"."tracking"
+    ADD COLUMN "account_stats_from_height" bigint NULL,
+    ADD COLUMN "account_stats_backfilled_height" bigint NOT NULL DEFAULT 0;
+GRANT SELECT ON "public"."account_stats" TO readonly;
@@ -0,0 +1,2 @@
+-- Drop index "ix_nft_histories_nft_id_block_height" from table: "nft_histories"
+DROP INDEX "public"."ix_nft_histories_nft_id_block_height";
@@ -0,0 +1,18 @@
+-- Create index "ix_nft_histories_nft_id_block_height" to table: "nft_histories"
+CREATE INDEX "ix_nft_histories_nft_id_block_height" ON "public"."nft_histories" ("nft_id", "block_height");
+-- Histories used to record the new owner as both the previous and the new one: the previous owner is the new owner
+-- of the previous entry of the Nft, and the first entry, recorded when the Nft is minted, has none
+WITH "ordered" AS (
+    SELECT "nft_histories"."ctid" AS "row_id",
+           LAG("nft_histories"."to") OVER (
+               PARTITION BY "nft_histories"."nft_id"
+               ORDER BY "nft_histories"."block_height", "transactions"."block_index"
+           ) AS "previous_owner"
+    FROM "public"."nft_histories"
+    LEFT JOIN "public"."transactions" ON "transactions"."id" = "nft_histories"."tx_id"
+)
+UPDATE "public"."nft_histories"
+SET "from" = "ordered"."previous_owner"
+FROM "ordered"
+WHERE "nft_histories"."ctid" = "ordered"."row_id"
+AND "nft_histories"."from" = "nft_histories"."to";
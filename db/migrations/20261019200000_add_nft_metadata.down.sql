@@ -0,0 +1,4 @@
+-- Remove the Nft and collection metadata
+DROP TABLE "public"."nft_attributes";
+DROP TABLE "public"."collection_metadata";
+DROP TABLE "public"."nft_metadata";
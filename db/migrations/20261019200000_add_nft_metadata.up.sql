@@ -0,0 +1,52 @@
+-- Off-chain metadata resolved from the URI of Nfts and collections, with the state of its fetch
+CREATE TABLE "public"."nft_metadata" (
+    "id" character varying NOT NULL,
+    "uri" character varying NOT NULL,
+    "status" character varying NOT NULL,
+    "attempts" integer NOT NULL,
+    "next_attempt_at" timestamp NOT NULL,
+    "last_error" character varying NULL,
+    "fetched_at" timestamp NULL,
+    "metadata" json NOT NULL,
+    "name" character varying NOT NULL,
+    "description" character varying NOT NULL,
+    "image" character varying NOT NULL,
+    "thumbnail_url" character varying NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_nft_metadata_nft" FOREIGN KEY ("id") REFERENCES "public"."nfts" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "ix_nft_metadata_status_next_attempt_at" to table: "nft_metadata"
+CREATE INDEX "ix_nft_metadata_status_next_attempt_at" ON "public"."nft_metadata" ("status", "next_attempt_at");
+GRANT SELECT ON "public"."nft_metadata" TO readonly;
+CREATE TABLE "public"."collection_metadata" (
+    "id" text NOT NULL,
+    "uri" character varying NOT NULL,
+    "status" character varying NOT NULL,
+    "attempts" integer NOT NULL,
+    "next_attempt_at" timestamp NOT NULL,
+    "last_error" character varying NULL,
+    "fetched_at" timestamp NULL,
+    "metadata" json NOT NULL,
+    "name" character varying NOT NULL,
+    "description" character varying NOT NULL,
+    "image" character varying NOT NULL,
+    "thumbnail_url" character varying NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_collection_metadata_collection" FOREIGN KEY ("id") REFERENCES "public"."collections" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "ix_collection_metadata_status_next_attempt_at" to table: "collection_metadata"
+CREATE INDEX "ix_collection_metadata_status_next_attempt_at" ON "public"."collection_metadata" ("status", "next_attempt_at");
+GRANT SELECT ON "public"."collection_metadata" TO readonly;
+-- Attributes parsed from the metadata of Nfts
+CREATE TABLE "public"."nft_attributes" (
+    "nft_id" character varying NOT NULL,
+    "trait_type" character varying NOT NULL,
+    "value" character varying NOT NULL,
+    PRIMARY KEY ("nft_id", "trait_type", "value"),
+    CONSTRAINT "fk_nft_attributes_nft" FOREIGN KEY ("nft_id") REFERENCES "public"."nfts" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "ix_nft_attributes_trait_type_value" to table: "nft_attributes"
+CREATE INDEX "ix_nft_attributes_trait_type_value" ON "public"."nft_attributes" ("trait_type", "value");
+GRANT SELECT ON "public"."nft_attributes" TO readonly;
@@ -0,0 +1,2 @@
+-- Remove the identity image URLs of the validators
+ALTER TABLE "public"."validators" DROP COLUMN "identity_image_updated_at", DROP COLUMN "identity_image_identity", DROP COLUMN "identity_image_url";
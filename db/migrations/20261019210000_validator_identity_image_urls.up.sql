@@ -0,0 +1,4 @@
+-- Add the URL of the hosted identity image of the validators, refreshed when the identity changes or the image gets
+-- stale. The base64 identity_image column is kept and served until the URL is resolved, it is dropped by a later
+-- migration once nothing reads it.
+ALTER TABLE "public"."validators" ADD COLUMN "identity_image_url" character varying NOT NULL DEFAULT '', ADD COLUMN "identity_image_identity" character varying NOT NULL DEFAULT '', ADD COLUMN "identity_image_updated_at" timestamp NULL;
@@ -0,0 +1,2 @@
+-- Remove the consumer offsets
+DROP TABLE "public"."consumer_offsets";
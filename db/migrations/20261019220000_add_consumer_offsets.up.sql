@@ -0,0 +1,11 @@
+-- Keep the offset of the last message each consumer group processed from each partition in "consumer_offsets",
+-- written in the transaction of the data indexed from the message so that a restart resumes right after it
+CREATE TABLE "public"."consumer_offsets" (
+    "consumer_group" character varying NOT NULL,
+    "topic" character varying NOT NULL,
+    "partition" integer NOT NULL,
+    "offset" bigint NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("consumer_group", "topic", "partition")
+);
+GRANT SELECT ON "public"."consumer_offsets" TO readonly;
@@ -0,0 +1,2 @@
+-- Enum values cannot be removed, revert the permanent locked and periodic vesting accounts to base accounts
+UPDATE "public"."accounts" SET "type" = 'BaseAccount' WHERE "type" IN ('PermanentLockedAccount', 'PeriodicVestingAccount');
@@ -0,0 +1,3 @@
+-- Add the permanent locked and periodic vesting accounts of the vesting module to "accounttype"
+ALTER TYPE "public"."accounttype" ADD VALUE 'PermanentLockedAccount';
+ALTER TYPE "public"."accounttype" ADD VALUE 'PeriodicVestingAccount';
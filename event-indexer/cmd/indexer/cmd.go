@@ -7,12 +7,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/initia-labs/core-indexer/event-indexer/indexer"
+	"github.com/initia-labs/core-indexer/pkg/mq"
 )
 
 const (
 	FlagID                             = "id"
 	FlagRPCEndpoints                   = "rpc-endpoints"
 	FlagRPCTimeoutInSeconds            = "rpc-timeout-in-seconds"
+	FlagMQBackend                      = "mq-backend"
+	FlagMQDir                          = "mq-dir"
 	FlagKafkaBootstrapServer           = "bootstrap-server"
 	FlagDBConnectionString             = "db"
 	FlagChain                          = "chain"
@@ -27,6 +30,8 @@ const (
 	FlagCommitSHA                      = "commit-sha"
 	FlagSentryProfilesSampleRate       = "sentry-profiles-sample-rate"
 	FlagSentryTracesSampleRate         = "sentry-traces-sample-rate"
+	FlagEventFilter                    = "event-filter"
+	FlagPartitionSize                  = "partition-size"
 )
 
 // RunCmd consumes messages from Kafka and indexes them into the database.
@@ -36,46 +41,12 @@ func RunCmd() *cobra.Command {
 		Short: "Consumes messages from Kafka and indexes them into the database.",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			rpcEndpoints, _ := cmd.Flags().GetString(FlagRPCEndpoints)
-			rpcTimeoutInSeconds, _ := cmd.Flags().GetInt64(FlagRPCTimeoutInSeconds)
-			kafkaBootstrapServer, _ := cmd.Flags().GetString(FlagKafkaBootstrapServer)
-			chain, _ := cmd.Flags().GetString(FlagChain)
-			dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
-			kafkaBlockResultsTopic, _ := cmd.Flags().GetString(KafkaBlockResultsTopic)
-			kafkaAPIKey, _ := cmd.Flags().GetString(FlagKafkaAPIKey)
-			kafkaAPISecret, _ := cmd.Flags().GetString(FlagKafkaAPISecret)
-			kafkaBlockResultsConsumerGroup, _ := cmd.Flags().GetString(FlagKafkaBlockResultsConsumerGroup)
-
-			blockResultsClaimCheckBucket, _ := cmd.Flags().GetString(FlagBlockResultsClaimCheckBucket)
-			claimCheckThresholdInMB, _ := cmd.Flags().GetUint64(FlagClaimCheckThresholdInMB)
-
-			workerID, _ := cmd.Flags().GetString(FlagID)
-
-			environment, _ := cmd.Flags().GetString(FlagEnvironment)
-			sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
-			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
-			sentryProfilesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryProfilesSampleRate)
-			sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
-
-			f, err := indexer.New(&indexer.Config{
-				RPCEndpoints:                   rpcEndpoints,
-				RPCTimeoutInSeconds:            rpcTimeoutInSeconds,
-				ID:                             workerID,
-				Chain:                          chain,
-				DBConnectionString:             dbConnectionString,
-				KafkaBootstrapServer:           kafkaBootstrapServer,
-				KafkaBlockResultsTopic:         kafkaBlockResultsTopic,
-				KafkaAPIKey:                    kafkaAPIKey,
-				KafkaAPISecret:                 kafkaAPISecret,
-				KafkaBlockResultsConsumerGroup: kafkaBlockResultsConsumerGroup,
-				ClaimCheckThresholdInMB:        int64(claimCheckThresholdInMB),
-				BlockResultsClaimCheckBucket:   blockResultsClaimCheckBucket,
-				Environment:                    environment,
-				SentryDSN:                      sentryDSN,
-				CommitSHA:                      commitSHA,
-				SentryProfilesSampleRate:       sentryProfilesSampleRate,
-				SentryTracesSampleRate:         sentryTracesSampleRate,
-			})
+			config := indexerConfig(cmd)
+			if err := mq.CheckStandalone(config.MQBackend); err != nil {
+				return err
+			}
+
+			f, err := indexer.New(config)
 			if err != nil {
 				return err
 			}
@@ -86,6 +57,65 @@ func RunCmd() *cobra.Command {
 		},
 	}
 
+	addIndexerFlags(RunCmd)
+
+	return RunCmd
+}
+
+// indexerConfig returns the indexer config set by the flags of the command
+func indexerConfig(cmd *cobra.Command) *indexer.Config {
+	rpcEndpoints, _ := cmd.Flags().GetString(FlagRPCEndpoints)
+	rpcTimeoutInSeconds, _ := cmd.Flags().GetInt64(FlagRPCTimeoutInSeconds)
+	mqBackend, _ := cmd.Flags().GetString(FlagMQBackend)
+	mqDir, _ := cmd.Flags().GetString(FlagMQDir)
+	kafkaBootstrapServer, _ := cmd.Flags().GetString(FlagKafkaBootstrapServer)
+	chain, _ := cmd.Flags().GetString(FlagChain)
+	dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
+	kafkaBlockResultsTopic, _ := cmd.Flags().GetString(KafkaBlockResultsTopic)
+	kafkaAPIKey, _ := cmd.Flags().GetString(FlagKafkaAPIKey)
+	kafkaAPISecret, _ := cmd.Flags().GetString(FlagKafkaAPISecret)
+	kafkaBlockResultsConsumerGroup, _ := cmd.Flags().GetString(FlagKafkaBlockResultsConsumerGroup)
+
+	blockResultsClaimCheckBucket, _ := cmd.Flags().GetString(FlagBlockResultsClaimCheckBucket)
+	claimCheckThresholdInMB, _ := cmd.Flags().GetUint64(FlagClaimCheckThresholdInMB)
+
+	workerID, _ := cmd.Flags().GetString(FlagID)
+	eventFilter, _ := cmd.Flags().GetString(FlagEventFilter)
+	partitionSize, _ := cmd.Flags().GetUint64(FlagPartitionSize)
+
+	environment, _ := cmd.Flags().GetString(FlagEnvironment)
+	sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
+	commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
+	sentryProfilesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryProfilesSampleRate)
+	sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
+
+	return &indexer.Config{
+		RPCEndpoints:                   rpcEndpoints,
+		RPCTimeoutInSeconds:            rpcTimeoutInSeconds,
+		ID:                             workerID,
+		Chain:                          chain,
+		DBConnectionString:             dbConnectionString,
+		MQBackend:                      mqBackend,
+		MQDir:                          mqDir,
+		KafkaBootstrapServer:           kafkaBootstrapServer,
+		KafkaBlockResultsTopic:         kafkaBlockResultsTopic,
+		KafkaAPIKey:                    kafkaAPIKey,
+		KafkaAPISecret:                 kafkaAPISecret,
+		KafkaBlockResultsConsumerGroup: kafkaBlockResultsConsumerGroup,
+		ClaimCheckThresholdInMB:        int64(claimCheckThresholdInMB),
+		BlockResultsClaimCheckBucket:   blockResultsClaimCheckBucket,
+		EventFilter:                    eventFilter,
+		PartitionSize:                  int64(partitionSize),
+		Environment:                    environment,
+		SentryDSN:                      sentryDSN,
+		CommitSHA:                      commitSHA,
+		SentryProfilesSampleRate:       sentryProfilesSampleRate,
+		SentryTracesSampleRate:         sentryTracesSampleRate,
+	}
+}
+
+// addIndexerFlags adds the flags of the indexer config to the command
+func addIndexerFlags(cmd *cobra.Command) {
 	rpcTimeOutInSeconds, err := strconv.ParseInt(os.Getenv("RPC_TIMEOUT_IN_SECONDS"), 10, 64)
 	if err != nil {
 		rpcTimeOutInSeconds = 30
@@ -96,6 +126,11 @@ func RunCmd() *cobra.Command {
 		threshold = 1
 	}
 
+	partitionSize, err := strconv.ParseInt(os.Getenv("PARTITION_SIZE"), 10, 64)
+	if err != nil {
+		partitionSize = 100000
+	}
+
 	sentryProfilesSampleRate, err := strconv.ParseFloat(os.Getenv("SENTRY_PROFILES_SAMPLE_RATE"), 64)
 	if err != nil {
 		sentryProfilesSampleRate = 0.01
@@ -106,23 +141,25 @@ func RunCmd() *cobra.Command {
 		sentryTracesSampleRate = 0.01
 	}
 
-	RunCmd.Flags().String(FlagRPCEndpoints, os.Getenv("RPC_ENDPOINTS"), "")
-	RunCmd.Flags().String(FlagKafkaBootstrapServer, os.Getenv("BOOTSTRAP_SERVER"), "<host>:<port> to Kafka bootstrap server")
-	RunCmd.Flags().Int64(FlagRPCTimeoutInSeconds, rpcTimeOutInSeconds, "RPC timeout in seconds")
-	RunCmd.Flags().String(KafkaBlockResultsTopic, os.Getenv("BLOCK_RESULTS_TOPIC"), "Kafka topic to consume block_results message")
-	RunCmd.Flags().String(FlagKafkaBlockResultsConsumerGroup, os.Getenv("BLOCK_RESULTS_CONSUMER_GROUP"), "Kafka consumer group for block_results topic")
-	RunCmd.Flags().String(FlagKafkaAPIKey, os.Getenv("KAFKA_API_KEY"), "Kafka API key")
-	RunCmd.Flags().String(FlagKafkaAPISecret, os.Getenv("KAFKA_API_SECRET"), "Kafka API secret")
-	RunCmd.Flags().String(FlagDBConnectionString, os.Getenv("DB_CONNECTION_STRING"), "Database connection string")
-	RunCmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID to sweep")
-	RunCmd.Flags().String(FlagBlockResultsClaimCheckBucket, os.Getenv("BLOCK_RESULTS_CLAIM_CHECK_BUCKET"), "Block results claim check bucket")
-	RunCmd.Flags().Uint64(FlagClaimCheckThresholdInMB, uint64(threshold), "Claim check threshold in MB")
-	RunCmd.Flags().String(FlagID, os.Getenv("ID"), "Worker ID")
-	RunCmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
-	RunCmd.Flags().String(FlagSentryDSN, os.Getenv("SENTRY_DSN"), "Sentry DSN")
-	RunCmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
-	RunCmd.Flags().Float64(FlagSentryProfilesSampleRate, sentryProfilesSampleRate, "Sentry profiles sample rate")
-	RunCmd.Flags().Float64(FlagSentryTracesSampleRate, sentryTracesSampleRate, "Sentry traces sample rate")
-
-	return RunCmd
+	cmd.Flags().String(FlagRPCEndpoints, os.Getenv("RPC_ENDPOINTS"), "")
+	cmd.Flags().String(FlagMQBackend, os.Getenv("MQ_BACKEND"), "Message bus backend: kafka (default), memory or file")
+	cmd.Flags().String(FlagMQDir, os.Getenv("MQ_DIR"), "Directory of the file message bus backend")
+	cmd.Flags().String(FlagKafkaBootstrapServer, os.Getenv("BOOTSTRAP_SERVER"), "<host>:<port> to Kafka bootstrap server")
+	cmd.Flags().Int64(FlagRPCTimeoutInSeconds, rpcTimeOutInSeconds, "RPC timeout in seconds")
+	cmd.Flags().String(KafkaBlockResultsTopic, os.Getenv("BLOCK_RESULTS_TOPIC"), "Kafka topic to consume block_results message")
+	cmd.Flags().String(FlagKafkaBlockResultsConsumerGroup, os.Getenv("BLOCK_RESULTS_CONSUMER_GROUP"), "Kafka consumer group for block_results topic")
+	cmd.Flags().String(FlagKafkaAPIKey, os.Getenv("KAFKA_API_KEY"), "Kafka API key")
+	cmd.Flags().String(FlagKafkaAPISecret, os.Getenv("KAFKA_API_SECRET"), "Kafka API secret")
+	cmd.Flags().String(FlagDBConnectionString, os.Getenv("DB_CONNECTION_STRING"), "Database connection string")
+	cmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID to sweep")
+	cmd.Flags().String(FlagBlockResultsClaimCheckBucket, os.Getenv("BLOCK_RESULTS_CLAIM_CHECK_BUCKET"), "Block results claim check bucket")
+	cmd.Flags().Uint64(FlagClaimCheckThresholdInMB, uint64(threshold), "Claim check threshold in MB")
+	cmd.Flags().String(FlagID, os.Getenv("ID"), "Worker ID")
+	cmd.Flags().String(FlagEventFilter, os.Getenv("EVENT_FILTER"), "JSON config of include/exclude rules for indexed events")
+	cmd.Flags().Uint64(FlagPartitionSize, uint64(partitionSize), "Number of blocks per event table partition")
+	cmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
+	cmd.Flags().String(FlagSentryDSN, os.Getenv("SENTRY_DSN"), "Sentry DSN")
+	cmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
+	cmd.Flags().Float64(FlagSentryProfilesSampleRate, sentryProfilesSampleRate, "Sentry profiles sample rate")
+	cmd.Flags().Float64(FlagSentryTracesSampleRate, sentryTracesSampleRate, "Sentry traces sample rate")
 }
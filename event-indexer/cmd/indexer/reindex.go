@@ -0,0 +1,76 @@
+package indexer_cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/core-indexer/event-indexer/indexer"
+	"github.com/initia-labs/core-indexer/pkg/archive"
+	"github.com/initia-labs/core-indexer/pkg/mq"
+)
+
+const (
+	FlagReindexFrom   = "from"
+	FlagReindexTo     = "to"
+	FlagReindexSource = "source"
+	FlagArchiveBucket = "archive-bucket"
+	FlagArchivePrefix = "archive-prefix"
+
+	// ReindexSourceArchive reads the bundles written by the sweeper archiver
+	ReindexSourceArchive = "archive"
+)
+
+// ReindexCmd indexes a range of heights from the block results archive into the database.
+func ReindexCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Indexes a range of heights from the block results archive into the database.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, _ := cmd.Flags().GetInt64(FlagReindexFrom)
+			to, _ := cmd.Flags().GetInt64(FlagReindexTo)
+			source, _ := cmd.Flags().GetString(FlagReindexSource)
+			if source != ReindexSourceArchive {
+				return fmt.Errorf("unsupported reindex source: %q", source)
+			}
+			if from <= 0 {
+				return fmt.Errorf("invalid reindex start height: %d", from)
+			}
+
+			config := indexerConfig(cmd)
+			config.ArchiveBucket, _ = cmd.Flags().GetString(FlagArchiveBucket)
+			config.ArchivePrefix, _ = cmd.Flags().GetString(FlagArchivePrefix)
+			// the message bus is not consumed, the messages produced while reindexing are dropped unless a backend is set
+			if !cmd.Flags().Changed(FlagMQBackend) {
+				config.MQBackend = mq.BackendMemory
+			}
+
+			f, err := indexer.New(config)
+			if err != nil {
+				return err
+			}
+
+			return f.Reindex(from, to)
+		},
+	}
+
+	addIndexerFlags(cmd)
+	addReindexFlags(cmd)
+
+	return cmd
+}
+
+func addReindexFlags(cmd *cobra.Command) {
+	prefix := os.Getenv("ARCHIVE_PREFIX")
+	if prefix == "" {
+		prefix = archive.DefaultConfig().Prefix
+	}
+
+	cmd.Flags().Int64(FlagReindexFrom, 0, "First height to reindex")
+	cmd.Flags().Int64(FlagReindexTo, 0, "Last height to reindex, the last archived height when 0")
+	cmd.Flags().String(FlagReindexSource, ReindexSourceArchive, "Source of the block results, only archive is supported")
+	cmd.Flags().String(FlagArchiveBucket, os.Getenv("ARCHIVE_BUCKET"), "Bucket of the block results archive")
+	cmd.Flags().String(FlagArchivePrefix, prefix, "Path of the bundles in the archive bucket")
+}
@@ -0,0 +1,80 @@
+package partition_cmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/core-indexer/event-indexer/partition"
+)
+
+const (
+	FlagDBConnectionString  = "db"
+	FlagPartitionSize       = "partition-size"
+	FlagPartitionsAhead     = "partitions-ahead"
+	FlagMaintenanceInterval = "maintenance-interval"
+	FlagChain               = "chain"
+	FlagEnvironment         = "environment"
+	FlagCommitSHA           = "commit-sha"
+)
+
+func MaintainPartitionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "maintain-partitions",
+		Short: "Create event table partitions ahead of the indexed height",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
+			partitionSize, _ := cmd.Flags().GetUint64(FlagPartitionSize)
+			partitionsAhead, _ := cmd.Flags().GetUint64(FlagPartitionsAhead)
+			maintenanceInterval, _ := cmd.Flags().GetUint64(FlagMaintenanceInterval)
+			chain, _ := cmd.Flags().GetString(FlagChain)
+			environment, _ := cmd.Flags().GetString(FlagEnvironment)
+			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
+
+			m, err := partition.NewMaintainer(&partition.MaintainerConfig{
+				DBConnectionString:  dbConnectionString,
+				PartitionSize:       int64(partitionSize),
+				PartitionsAhead:     int64(partitionsAhead),
+				MaintenanceInterval: int64(maintenanceInterval),
+				Chain:               chain,
+				Environment:         environment,
+				CommitSHA:           commitSHA,
+			})
+
+			if err != nil {
+				return err
+			}
+
+			m.Maintain()
+
+			return nil
+		},
+	}
+
+	partitionSize, err := strconv.ParseInt(os.Getenv("PARTITION_SIZE"), 10, 64)
+	if err != nil {
+		partitionSize = 100000
+	}
+
+	partitionsAhead, err := strconv.ParseInt(os.Getenv("PARTITIONS_AHEAD"), 10, 64)
+	if err != nil {
+		partitionsAhead = 2
+	}
+
+	maintenanceInterval, err := strconv.ParseInt(os.Getenv("PARTITION_MAINTENANCE_INTERVAL"), 10, 64)
+	if err != nil {
+		maintenanceInterval = 60
+	}
+
+	cmd.Flags().String(FlagDBConnectionString, os.Getenv("DB_CONNECTION_STRING"), "Database connection string")
+	cmd.Flags().Uint64(FlagPartitionSize, uint64(partitionSize), "Number of blocks per partition")
+	cmd.Flags().Uint64(FlagPartitionsAhead, uint64(partitionsAhead), "Number of partitions to create ahead of the latest block")
+	cmd.Flags().Uint64(FlagMaintenanceInterval, uint64(maintenanceInterval), "Maintenance interval specified in minutes")
+	cmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID")
+	cmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
+	cmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
+
+	return cmd
+}
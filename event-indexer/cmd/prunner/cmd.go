@@ -7,12 +7,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/initia-labs/core-indexer/event-indexer/prunner"
+	"github.com/initia-labs/core-indexer/pkg/export"
 )
 
 const (
 	FlagDBConnectionString = "db"
 	FlagBackupBucketName   = "backup-bucket-name"
 	FlagBackupFilePrefix   = "backup-file-prefix"
+	FlagBackupFormat       = "backup-format"
+	FlagBackupPartRows     = "backup-part-rows"
 	FlagPruningKeepBlock   = "pruning-keep-block"
 	FlagPruningInterval    = "pruning-interval"
 	FlagChain              = "chain"
@@ -29,16 +32,25 @@ func PruneCmd() *cobra.Command {
 			dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
 			backupBucketName, _ := cmd.Flags().GetString(FlagBackupBucketName)
 			filePrefix, _ := cmd.Flags().GetString(FlagBackupFilePrefix)
+			backupFormat, _ := cmd.Flags().GetString(FlagBackupFormat)
+			backupPartRows, _ := cmd.Flags().GetUint64(FlagBackupPartRows)
 			pruningKeepBlock, _ := cmd.Flags().GetUint64(FlagPruningKeepBlock)
 			pruningInterval, _ := cmd.Flags().GetUint64(FlagPruningInterval)
 			chain, _ := cmd.Flags().GetString(FlagChain)
 			environment, _ := cmd.Flags().GetString(FlagEnvironment)
 			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
 
+			format, err := export.ParseFormat(backupFormat)
+			if err != nil {
+				return err
+			}
+
 			p, err := prunner.NewPrunner(&prunner.PrunnerConfig{
 				DBConnectionString: dbConnectionString,
 				BackupBucketName:   backupBucketName,
 				BackupFilePrefix:   filePrefix,
+				BackupFormat:       format,
+				BackupPartRows:     int64(backupPartRows),
 				PruningKeepBlock:   int64(pruningKeepBlock),
 				PruningInterval:    int64(pruningInterval),
 				Chain:              chain,
@@ -61,6 +73,16 @@ func PruneCmd() *cobra.Command {
 		pruningKeepBlock = 500000
 	}
 
+	backupFormat := os.Getenv("BACKUP_FORMAT")
+	if backupFormat == "" {
+		backupFormat = string(export.FormatNDJSON)
+	}
+
+	backupPartRows, err := strconv.ParseInt(os.Getenv("BACKUP_PART_ROWS"), 10, 64)
+	if err != nil {
+		backupPartRows = 100000
+	}
+
 	pruningInterval, err := strconv.ParseInt(os.Getenv("PRUNING_INTERVAL"), 10, 64)
 	{
 		if err != nil {
@@ -71,6 +93,8 @@ func PruneCmd() *cobra.Command {
 	cmd.Flags().String(FlagDBConnectionString, os.Getenv("DB_CONNECTION_STRING"), "Database connection string")
 	cmd.Flags().String(FlagBackupBucketName, os.Getenv("BACKUP_BUCKET_NAME"), "Name of the backup bucket")
 	cmd.Flags().String(FlagBackupFilePrefix, os.Getenv("BACKUP_FILE_PREFIX"), "Prefix for backup files")
+	cmd.Flags().String(FlagBackupFormat, backupFormat, "Format of the backup parts: ndjson or parquet")
+	cmd.Flags().Uint64(FlagBackupPartRows, uint64(backupPartRows), "Maximum number of rows per backup part")
 	cmd.Flags().Uint64(FlagPruningKeepBlock, uint64(pruningKeepBlock), "Number of blocks to keep in the db")
 	cmd.Flags().Uint64(FlagPruningInterval, uint64(pruningInterval), "Pruning interval specified in days")
 	cmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID to prune")
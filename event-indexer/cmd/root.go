@@ -7,6 +7,7 @@ import (
 
 	indexer "github.com/initia-labs/core-indexer/event-indexer/cmd/indexer"
 	migrate "github.com/initia-labs/core-indexer/event-indexer/cmd/migrate"
+	partition "github.com/initia-labs/core-indexer/event-indexer/cmd/partition"
 	prunner "github.com/initia-labs/core-indexer/event-indexer/cmd/prunner"
 )
 
@@ -20,7 +21,9 @@ func Execute() {
 	rootCmd.AddCommand(
 		migrate.MigrateCmd(),
 		indexer.RunCmd(),
+		indexer.ReindexCmd(),
 		prunner.PruneCmd(),
+		partition.MaintainPartitionsCmd(),
 	)
 
 	err := rootCmd.Execute()
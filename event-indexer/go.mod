@@ -4,7 +4,8 @@ go 1.25.8
 
 require (
 	github.com/certifi/gocertifi v0.0.0-20210507211836-431795d63e8d
-	github.com/confluentinc/confluent-kafka-go/v2 v2.6.1
+	github.com/cometbft/cometbft v0.38.20
+	github.com/confluentinc/confluent-kafka-go/v2 v2.6.1 // indirect
 	github.com/getsentry/sentry-go v0.29.1
 	github.com/initia-labs/core-indexer/pkg v0.0.0-00010101000000-000000000000
 	github.com/rs/zerolog v1.33.0
@@ -17,9 +18,9 @@ require (
 	ariga.io/atlas-go-sdk v0.6.8 // indirect
 	ariga.io/atlas-provider-gorm v0.5.2 // indirect
 	github.com/agext/levenshtein v1.2.3 // indirect
+	github.com/andybalholm/brotli v1.1.0 // indirect
 	github.com/apparentlymart/go-textseg/v15 v15.0.0 // indirect
 	github.com/bmatcuk/doublestar v1.3.4 // indirect
-	github.com/cometbft/cometbft v0.38.20 // indirect
 	github.com/envoyproxy/go-control-plane/envoy v1.36.0 // indirect
 	github.com/gabriel-vasile/mimetype v1.4.3 // indirect
 	github.com/go-jose/go-jose/v4 v4.1.3 // indirect
@@ -40,6 +41,8 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.22 // indirect
 	github.com/microsoft/go-mssqldb v1.7.2 // indirect
 	github.com/mitchellh/go-wordwrap v1.0.1 // indirect
+	github.com/parquet-go/parquet-go v0.25.1 // indirect
+	github.com/pierrec/lz4/v4 v4.1.21 // indirect
 	github.com/spiffe/go-spiffe/v2 v2.6.0 // indirect
 	github.com/ugorji/go/codec v1.2.11 // indirect
 	github.com/zclconf/go-cty v1.14.4 // indirect
@@ -191,7 +194,7 @@ require (
 	github.com/spf13/cast v1.7.1 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
 	github.com/spf13/viper v1.19.0 // indirect
-	github.com/stretchr/testify v1.11.1 // indirect
+	github.com/stretchr/testify v1.11.1
 	github.com/subosito/gotenv v1.6.0 // indirect
 	github.com/syndtr/goleveldb v1.0.1-0.20220721030215-126854af5e6d // indirect
 	github.com/tendermint/go-amino v0.16.0 // indirect
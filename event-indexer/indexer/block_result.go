@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/getsentry/sentry-go"
 	movetypes "github.com/initia-labs/initia/x/move/types"
 	"gorm.io/gorm"
 
@@ -15,6 +17,9 @@ import (
 	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
 )
 
+// filterStatsReportInterval is the number of blocks between two reports of the event filter counters.
+const filterStatsReportInterval = 1000
+
 func (f *Indexer) parseAndInsertTransactionEvents(parentCtx context.Context, dbTx *gorm.DB, blockResults *mq.BlockResultMsg) error {
 	span, ctx := sentry_integration.StartSentrySpan(parentCtx, "parseAndInsertTransactionEvents", "Parse block_results message and insert transaction_events into the database")
 	defer span.Finish()
@@ -26,16 +31,20 @@ func (f *Indexer) parseAndInsertTransactionEvents(parentCtx context.Context, dbT
 		}
 
 		// idx ensures EventIndex is unique within each transaction.
+		// Filtered attributes still consume an index so EventIndex does not depend on the filter config.
 		idx := 0
 		for _, event := range tx.ExecTxResults.Events {
+			typeTag := getMoveEventTypeTag(event)
 			for _, attr := range event.Attributes {
-				txEvents = append(txEvents, &db.TransactionEvent{
-					TransactionHash: tx.Hash,
-					BlockHeight:     blockResults.Height,
-					EventKey:        fmt.Sprintf("%s.%s", event.Type, attr.Key),
-					EventValue:      attr.Value,
-					EventIndex:      idx,
-				})
+				if f.eventFilter.KeepAttribute(db.TableNameTransactionEvent, event.Type, attr.Key, typeTag) {
+					txEvents = append(txEvents, &db.TransactionEvent{
+						TransactionHash: tx.Hash,
+						BlockHeight:     blockResults.Height,
+						EventKey:        fmt.Sprintf("%s.%s", event.Type, attr.Key),
+						EventValue:      attr.Value,
+						EventIndex:      idx,
+					})
+				}
 				idx++
 			}
 		}
@@ -76,7 +85,9 @@ func (f *Indexer) parseAndInsertMoveEvents(parentCtx context.Context, dbTx *gorm
 						moveEvent.Data = []byte(attr.Value)
 					}
 				}
-				moveEvents = append(moveEvents, moveEvent)
+				if f.eventFilter.KeepMoveEvent(event.Type, moveEvent.TypeTag) {
+					moveEvents = append(moveEvents, moveEvent)
+				}
 				idx++
 			}
 		}
@@ -131,14 +142,17 @@ func (f *Indexer) parseAndInsertFinalizeBlockEvents(parentCtx context.Context, d
 			mode := attrs[len(attrs)-1].Value
 
 			// Process all attributes except the last one (which should be "mode")
+			typeTag := getMoveEventTypeTag(event)
 			for _, attr := range attrs[:len(attrs)-1] {
-				finalizeBlockEvents = append(finalizeBlockEvents, &db.FinalizeBlockEvent{
-					BlockHeight: blockResults.Height,
-					EventKey:    fmt.Sprintf("%s.%s", event.Type, attr.Key),
-					EventValue:  attr.Value,
-					EventIndex:  idx,
-					Mode:        mode,
-				})
+				if f.eventFilter.KeepAttribute(db.TableNameFinalizeBlockEvent, event.Type, attr.Key, typeTag) {
+					finalizeBlockEvents = append(finalizeBlockEvents, &db.FinalizeBlockEvent{
+						BlockHeight: blockResults.Height,
+						EventKey:    fmt.Sprintf("%s.%s", event.Type, attr.Key),
+						EventValue:  attr.Value,
+						EventIndex:  idx,
+						Mode:        mode,
+					})
+				}
 				idx++
 			}
 		}
@@ -152,12 +166,17 @@ func (f *Indexer) parseAndInsertFinalizeBlockEvents(parentCtx context.Context, d
 	return nil
 }
 
-func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *mq.BlockResultMsg) error {
+func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *mq.BlockResultMsg, offset db.ConsumerOffset) error {
 	span, ctx := sentry_integration.StartSentrySpan(parentCtx, "processBlockResults", "Parse block_results message and insert tx events into the database")
 	defer span.Finish()
 
 	logger.Info().Msgf("Processing block_results at height: %d", blockResults.Height)
 
+	if err := f.ensurePartitions(ctx, blockResults.Height); err != nil {
+		logger.Error().Int64("height", blockResults.Height).Msgf("Error ensuring event table partitions: %v", err)
+		return err
+	}
+
 	if err := f.dbClient.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
 		if err := f.parseAndInsertTransactionEvents(ctx, dbTx, blockResults); err != nil {
 			logger.Error().Int64("height", blockResults.Height).Msgf("Error inserting transaction_events: %v", err)
@@ -173,6 +192,11 @@ func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *m
 			logger.Error().Int64("height", blockResults.Height).Msgf("Error inserting finalize_block_events: %v", err)
 			return err
 		}
+
+		if err := db.UpsertConsumerOffset(ctx, dbTx, offset); err != nil {
+			logger.Error().Int64("height", blockResults.Height).Msgf("Error recording consumer offset: %v", err)
+			return err
+		}
 		return nil
 	}); err != nil {
 		logger.Error().Int64("height", blockResults.Height).Msgf("Error processing block: %v", err)
@@ -181,5 +205,46 @@ func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *m
 
 	logger.Info().Int64("height", blockResults.Height).Msgf("Successfully flushed block: %d", blockResults.Height)
 
+	if f.eventFilter.IsEnabled() {
+		reportEventFilterDropped(sentry.CurrentHub(), span, f.eventFilter, blockResults.Height)
+		if blockResults.Height%filterStatsReportInterval == 0 {
+			logger.Info().Int64("height", blockResults.Height).Msgf("Event filter dropped rows: %s", f.eventFilter.DroppedSummary())
+		}
+	}
+
 	return nil
 }
+
+// reportEventFilterDropped attaches the rows dropped by each rule of the filter to the span and to the scope of the
+// hub, so that every Sentry event carries them, and captures them every filterStatsReportInterval blocks
+func reportEventFilterDropped(hub *sentry.Hub, span *sentry.Span, filter *EventFilter, height int64) {
+	counts := filter.DroppedCounts()
+	dropped := make(sentry.Context, len(counts))
+	for name, count := range counts {
+		dropped[name] = count
+		span.SetData("event_filter.dropped."+name, count)
+	}
+	hub.ConfigureScope(func(scope *sentry.Scope) {
+		scope.SetContext("event_filter_dropped", dropped)
+	})
+
+	if height%filterStatsReportInterval == 0 {
+		hub.WithScope(func(scope *sentry.Scope) {
+			scope.SetLevel(sentry.LevelInfo)
+			hub.CaptureMessage("Event filter dropped rows")
+		})
+	}
+}
+
+// getMoveEventTypeTag returns the type_tag attribute of a Move event, or an empty string for other events.
+func getMoveEventTypeTag(event abci.Event) string {
+	if event.Type != movetypes.EventTypeMove {
+		return ""
+	}
+	for _, attr := range event.Attributes {
+		if attr.Key == movetypes.AttributeKeyTypeTag {
+			return attr.Value
+		}
+	}
+	return ""
+}
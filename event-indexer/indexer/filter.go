@@ -0,0 +1,224 @@
+package indexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+type FilterAction string
+
+const (
+	FilterActionInclude FilterAction = "include"
+	FilterActionExclude FilterAction = "exclude"
+)
+
+// EventFilterRule matches event data by event type, "type.key" pattern and Move type_tag.
+// Every non-empty field has to match for the rule to apply. Patterns support '*' as a wildcard.
+type EventFilterRule struct {
+	Name      string       `json:"name"`
+	Action    FilterAction `json:"action"`
+	EventType string       `json:"event_type,omitempty"`
+	EventKey  string       `json:"event_key,omitempty"`
+	TypeTag   string       `json:"type_tag,omitempty"`
+	// Tables restricts the rule to the given tables. Empty means every event table.
+	Tables []string `json:"tables,omitempty"`
+
+	dropped atomic.Int64
+}
+
+// EventFilterConfig is the JSON configuration of the event filter.
+// Rules are evaluated in order and the first matching rule decides; unmatched data falls back to DefaultAction.
+type EventFilterConfig struct {
+	DefaultAction FilterAction       `json:"default_action"`
+	Rules         []*EventFilterRule `json:"rules"`
+}
+
+type EventFilter struct {
+	defaultAction FilterAction
+	rules         []*EventFilterRule
+
+	defaultDropped atomic.Int64
+}
+
+// NewEventFilter parses the JSON filter config. An empty config keeps every event.
+func NewEventFilter(rawConfig string) (*EventFilter, error) {
+	filter := &EventFilter{defaultAction: FilterActionInclude}
+	if strings.TrimSpace(rawConfig) == "" {
+		return filter, nil
+	}
+
+	var config EventFilterConfig
+	if err := json.Unmarshal([]byte(rawConfig), &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event filter config: %w", err)
+	}
+
+	if config.DefaultAction != "" {
+		if err := validateFilterAction(config.DefaultAction); err != nil {
+			return nil, err
+		}
+		filter.defaultAction = config.DefaultAction
+	}
+
+	for idx, rule := range config.Rules {
+		if err := validateFilterAction(rule.Action); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", idx, err)
+		}
+		if rule.EventType == "" && rule.EventKey == "" && rule.TypeTag == "" {
+			return nil, fmt.Errorf("rule %d: at least one of event_type, event_key or type_tag is required", idx)
+		}
+		for _, table := range rule.Tables {
+			if !isEventTable(table) {
+				return nil, fmt.Errorf("rule %d: invalid table %s", idx, table)
+			}
+		}
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", idx)
+		}
+	}
+	filter.rules = config.Rules
+
+	return filter, nil
+}
+
+func validateFilterAction(action FilterAction) error {
+	switch action {
+	case FilterActionInclude, FilterActionExclude:
+		return nil
+	}
+	return fmt.Errorf("invalid filter action: %q", action)
+}
+
+func isEventTable(table string) bool {
+	switch table {
+	case db.TableNameTransactionEvent, db.TableNameMoveEvent, db.TableNameFinalizeBlockEvent:
+		return true
+	}
+	return false
+}
+
+// IsEnabled reports whether any rule could drop data.
+func (f *EventFilter) IsEnabled() bool {
+	return len(f.rules) > 0 || f.defaultAction == FilterActionExclude
+}
+
+// KeepAttribute decides whether a single event attribute row is written to transaction_events or finalize_block_events.
+// typeTag is the Move type_tag of the event, if any.
+func (f *EventFilter) KeepAttribute(table, eventType, attrKey, typeTag string) bool {
+	return f.keep(table, eventType, attrKey, typeTag)
+}
+
+// KeepMoveEvent decides whether a Move event is written to move_events.
+// Rules matching on event_key never apply here since a move_events row covers the whole event.
+func (f *EventFilter) KeepMoveEvent(eventType, typeTag string) bool {
+	return f.keep(db.TableNameMoveEvent, eventType, "", typeTag)
+}
+
+// keep applies the first rule matching the row, or the default action, without evaluating anything when no rule
+// could drop it
+func (f *EventFilter) keep(table, eventType, attrKey, typeTag string) bool {
+	if !f.IsEnabled() {
+		return true
+	}
+
+	for _, rule := range f.rules {
+		if rule.matches(table, eventType, attrKey, typeTag) {
+			return f.apply(rule.Action, &rule.dropped)
+		}
+	}
+	return f.apply(f.defaultAction, &f.defaultDropped)
+}
+
+func (f *EventFilter) apply(action FilterAction, dropped *atomic.Int64) bool {
+	if action == FilterActionExclude {
+		dropped.Add(1)
+		return false
+	}
+	return true
+}
+
+// DroppedCounts returns the number of rows dropped by each rule since startup.
+// Rows dropped by the default action are reported under "default".
+func (f *EventFilter) DroppedCounts() map[string]int64 {
+	counts := make(map[string]int64, len(f.rules)+1)
+	for _, rule := range f.rules {
+		counts[rule.Name] += rule.dropped.Load()
+	}
+	counts["default"] += f.defaultDropped.Load()
+	return counts
+}
+
+// DroppedSummary formats the dropped counters in a stable order for logging.
+func (f *EventFilter) DroppedSummary() string {
+	counts := f.DroppedCounts()
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%d", name, counts[name]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// matches reports whether the rule applies to a row of the table. attrKey is empty for a row covering the whole event,
+// which the rules matching on event_key never apply to, and typeTag for an event without Move type_tag, which the rules
+// matching on type_tag never apply to.
+func (r *EventFilterRule) matches(table, eventType, attrKey, typeTag string) bool {
+	if !r.appliesTo(table) {
+		return false
+	}
+	if r.EventType != "" && !matchPattern(r.EventType, eventType) {
+		return false
+	}
+	if r.EventKey != "" && (attrKey == "" || !matchPattern(r.EventKey, eventType+"."+attrKey)) {
+		return false
+	}
+	if r.TypeTag != "" && (typeTag == "" || !matchPattern(r.TypeTag, typeTag)) {
+		return false
+	}
+	return true
+}
+
+func (r *EventFilterRule) appliesTo(table string) bool {
+	if len(r.Tables) == 0 {
+		return true
+	}
+	for _, t := range r.Tables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern matches s against a pattern where '*' matches any sequence of characters.
+func matchPattern(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(s, part)
+		if idx < 0 {
+			return false
+		}
+		s = s[idx+len(part):]
+	}
+
+	return len(s) >= len(last) && strings.HasSuffix(s, last)
+}
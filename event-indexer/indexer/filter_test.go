@@ -0,0 +1,150 @@
+package indexer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    bool
+	}{
+		{"tx.fee", "tx.fee", true},
+		{"tx.fee", "tx.fee_payer", false},
+		{"tx.*", "tx.fee", true},
+		{"*.module", "message.module", true},
+		{"0x1::coin::*", "0x1::coin::CoinStore<0x1::native_uinit::Coin>", true},
+		{"0x1::*::Deposit*", "0x1::fungible_asset::DepositEvent", true},
+		{"0x1::*::Deposit*", "0x1::fungible_asset::WithdrawEvent", false},
+		{"a*a", "a", false},
+		{"*", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchPattern(tt.pattern, tt.input))
+		})
+	}
+}
+
+func TestEventFilter(t *testing.T) {
+	filter, err := NewEventFilter(`{
+		"rules": [
+			{"name": "keep-bank-move", "action": "include", "type_tag": "0x1::fungible_asset::*"},
+			{"name": "drop-move", "action": "exclude", "event_type": "move"},
+			{"name": "drop-fee", "action": "exclude", "event_key": "tx.fee*"},
+			{"name": "drop-message-module", "action": "exclude", "event_key": "message.module", "tables": ["transaction_events"]}
+		]
+	}`)
+	require.NoError(t, err)
+	assert.True(t, filter.IsEnabled())
+
+	assert.False(t, filter.KeepAttribute(db.TableNameTransactionEvent, "tx", "fee", ""))
+	assert.False(t, filter.KeepAttribute(db.TableNameTransactionEvent, "tx", "fee_payer", ""))
+	assert.True(t, filter.KeepAttribute(db.TableNameTransactionEvent, "tx", "acc_seq", ""))
+	assert.False(t, filter.KeepAttribute(db.TableNameTransactionEvent, "message", "module", ""))
+	assert.True(t, filter.KeepAttribute(db.TableNameFinalizeBlockEvent, "message", "module", ""))
+
+	assert.True(t, filter.KeepMoveEvent("move", "0x1::fungible_asset::DepositEvent"))
+	assert.False(t, filter.KeepMoveEvent("move", "0x1::dex::SwapEvent"))
+	assert.True(t, filter.KeepAttribute(db.TableNameTransactionEvent, "move", "data", "0x1::fungible_asset::DepositEvent"))
+	assert.False(t, filter.KeepAttribute(db.TableNameTransactionEvent, "move", "data", "0x1::dex::SwapEvent"))
+
+	// the type_tag rules match a Move event and its attribute rows alike
+	for _, typeTag := range []string{"0x1::fungible_asset::DepositEvent", ""} {
+		assert.Equal(t, filter.KeepMoveEvent("transfer", typeTag), filter.KeepAttribute(db.TableNameTransactionEvent, "transfer", "amount", typeTag), typeTag)
+	}
+
+	counts := filter.DroppedCounts()
+	assert.Equal(t, int64(2), counts["drop-fee"])
+	assert.Equal(t, int64(1), counts["drop-message-module"])
+	assert.Equal(t, int64(2), counts["drop-move"])
+	assert.Equal(t, int64(0), counts["keep-bank-move"])
+	assert.Equal(t, int64(0), counts["default"])
+}
+
+type eventsTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *eventsTransport) Configure(sentry.ClientOptions) {}
+
+func (t *eventsTransport) Flush(time.Duration) bool { return true }
+
+func (t *eventsTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func TestReportEventFilterDropped(t *testing.T) {
+	filter, err := NewEventFilter(`{"rules": [{"name": "drop-fee", "action": "exclude", "event_key": "tx.fee"}]}`)
+	require.NoError(t, err)
+	assert.False(t, filter.KeepAttribute(db.TableNameTransactionEvent, "tx", "fee", ""))
+
+	transport := &eventsTransport{}
+	client, err := sentry.NewClient(sentry.ClientOptions{Transport: transport, EnableTracing: true, TracesSampleRate: 1})
+	require.NoError(t, err)
+	hub := sentry.NewHub(client, sentry.NewScope())
+	span := sentry.StartSpan(sentry.SetHubOnContext(context.Background(), hub), "processBlockResults")
+
+	// the counters are attached to the span and the scope on every block, and only captured at the interval
+	reportEventFilterDropped(hub, span, filter, filterStatsReportInterval+1)
+	assert.Equal(t, int64(1), span.Data["event_filter.dropped.drop-fee"])
+	assert.Equal(t, int64(0), span.Data["event_filter.dropped.default"])
+	assert.Empty(t, transport.events)
+
+	reportEventFilterDropped(hub, span, filter, filterStatsReportInterval)
+	require.Len(t, transport.events, 1)
+	assert.Equal(t, sentry.LevelInfo, transport.events[0].Level)
+	assert.Equal(t, sentry.Context{"drop-fee": int64(1), "default": int64(0)}, transport.events[0].Contexts["event_filter_dropped"])
+}
+
+func TestEventFilterDefaultExclude(t *testing.T) {
+	filter, err := NewEventFilter(`{"default_action": "exclude", "rules": [{"action": "include", "event_type": "transfer"}]}`)
+	require.NoError(t, err)
+
+	assert.True(t, filter.KeepAttribute(db.TableNameTransactionEvent, "transfer", "amount", ""))
+	assert.False(t, filter.KeepAttribute(db.TableNameTransactionEvent, "coin_spent", "amount", ""))
+	assert.Equal(t, int64(1), filter.DroppedCounts()["default"])
+}
+
+func TestEventFilterDisabled(t *testing.T) {
+	filter, err := NewEventFilter("")
+	require.NoError(t, err)
+	assert.False(t, filter.IsEnabled())
+
+	assert.True(t, filter.KeepAttribute(db.TableNameTransactionEvent, "tx", "fee", ""))
+	assert.True(t, filter.KeepMoveEvent("move", "0x1::dex::SwapEvent"))
+	assert.Equal(t, map[string]int64{"default": 0}, filter.DroppedCounts())
+}
+
+func TestNewEventFilterErrors(t *testing.T) {
+	filter, err := NewEventFilter("")
+	require.NoError(t, err)
+	assert.False(t, filter.IsEnabled())
+	assert.True(t, filter.KeepAttribute(db.TableNameTransactionEvent, "tx", "fee", ""))
+
+	_, err = NewEventFilter(`{"rules": [{"action": "drop", "event_type": "tx"}]}`)
+	assert.Error(t, err)
+
+	_, err = NewEventFilter(`{"rules": [{"action": "exclude"}]}`)
+	assert.Error(t, err)
+
+	_, err = NewEventFilter(`{"rules": [{"action": "exclude", "event_type": "tx", "tables": ["blocks"]}]}`)
+	assert.Error(t, err)
+
+	_, err = NewEventFilter(`{"default_action": "skip"}`)
+	assert.Error(t, err)
+}
@@ -11,7 +11,6 @@ import (
 	"time"
 
 	"github.com/certifi/gocertifi"
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/getsentry/sentry-go"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -28,11 +27,15 @@ import (
 var logger *zerolog.Logger
 
 type Indexer struct {
-	consumer      *mq.Consumer
-	producer      *mq.Producer
+	consumer      mq.Consumer
+	producer      mq.Producer
 	dbClient      *gorm.DB
 	storageClient storage.Client
 	config        *Config
+	eventFilter   *EventFilter
+
+	// partitionUpperBounds caches the exclusive upper bound covered by the partitions of each partitioned event table
+	partitionUpperBounds map[string]int64
 
 	rpcClient cosmosrpc.CosmosJSONRPCHub
 }
@@ -49,6 +52,12 @@ type Config struct {
 	RPCEndpoints        string
 	RPCTimeoutInSeconds int64
 
+	// MQBackend selects the message bus, kafka when empty, see mq.Config
+	MQBackend string
+	MQDir     string
+	// MQMemoryBus is the bus of the memory backend, shared with the services run in the same process
+	MQMemoryBus *mq.MemoryBus
+
 	// Kafka config
 	KafkaBootstrapServer           string
 	KafkaBlockResultsTopic         string
@@ -60,6 +69,16 @@ type Config struct {
 	ClaimCheckThresholdInMB      int64
 	BlockResultsClaimCheckBucket string
 
+	// Archive config, the block results bundles read by reindex
+	ArchiveBucket string
+	ArchivePrefix string
+
+	// EventFilter is the JSON config of include/exclude rules applied before inserting events
+	EventFilter string
+
+	// PartitionSize is the number of blocks per event table partition, it has to match the partition maintainer
+	PartitionSize int64
+
 	Environment              string
 	SentryDSN                string
 	CommitSHA                string
@@ -105,69 +124,29 @@ func New(config *Config) (*Indexer, error) {
 		return nil, err
 	}
 
-	var consumer *mq.Consumer
-
-	if config.Environment == "local" {
-		consumer, err = mq.NewConsumer(&kafka.ConfigMap{
-			"bootstrap.servers":    config.KafkaBootstrapServer,
-			"group.id":             config.KafkaBlockResultsConsumerGroup,
-			"client.id":            config.KafkaBlockResultsConsumerGroup + "-" + config.ID,
-			"enable.auto.commit":   false,
-			"auto.offset.reset":    "earliest",
-			"security.protocol":    "PLAINTEXT",
-			"max.poll.interval.ms": 600000,
-		})
-	} else {
-		consumer, err = mq.NewConsumer(&kafka.ConfigMap{
-			"bootstrap.servers":    config.KafkaBootstrapServer,
-			"group.id":             config.KafkaBlockResultsConsumerGroup,
-			"client.id":            config.KafkaBlockResultsConsumerGroup + "-" + config.ID,
-			"enable.auto.commit":   false,
-			"auto.offset.reset":    "earliest",
-			"security.protocol":    "SASL_SSL",
-			"sasl.mechanisms":      "PLAIN",
-			"sasl.username":        config.KafkaAPIKey,
-			"sasl.password":        config.KafkaAPISecret,
-			"max.poll.interval.ms": 600000,
-		})
+	mqConfig := mq.Config{
+		Backend: config.MQBackend,
+		Dir:     config.MQDir,
+		Memory:  config.MQMemoryBus,
+		Kafka: mq.KafkaConfig{
+			BootstrapServer: config.KafkaBootstrapServer,
+			ClientID:        config.KafkaBlockResultsConsumerGroup + "-" + config.ID,
+			APIKey:          config.KafkaAPIKey,
+			APISecret:       config.KafkaAPISecret,
+			Plaintext:       config.Environment == "local",
+		},
 	}
-
+	consumer, err := mq.NewConsumer(mqConfig, config.KafkaBlockResultsConsumerGroup)
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
-		logger.Fatal().Msgf("Kafka: Error creating consumer: %v\n", err)
+		logger.Fatal().Msgf("MQ: Error creating consumer: %v\n", err)
 		return nil, err
 	}
 
-	var producer *mq.Producer
-
-	if config.Environment == "local" {
-		producer, err = mq.NewProducer(&kafka.ConfigMap{
-			"bootstrap.servers": config.KafkaBootstrapServer,
-			"client.id":         config.KafkaBlockResultsConsumerGroup + "-" + config.ID,
-			"acks":              "all",
-			"linger.ms":         200,
-			"security.protocol": "PLAINTEXT",
-			"message.max.bytes": 7340032,
-			"compression.codec": "lz4",
-		})
-	} else {
-		producer, err = mq.NewProducer(&kafka.ConfigMap{
-			"bootstrap.servers": config.KafkaBootstrapServer,
-			"client.id":         config.KafkaBlockResultsConsumerGroup + "-" + config.ID,
-			"acks":              "all",
-			"linger.ms":         200,
-			"security.protocol": "SASL_SSL",
-			"sasl.mechanisms":   "PLAIN",
-			"sasl.username":     config.KafkaAPIKey,
-			"sasl.password":     config.KafkaAPISecret,
-			"message.max.bytes": 7340032,
-			"compression.codec": "lz4",
-		})
-	}
-
+	producer, err := mq.NewProducer(mqConfig, logger)
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
-		logger.Fatal().Msgf("Kafka: Error creating producer: %v\n", err)
+		logger.Fatal().Msgf("MQ: Error creating producer: %v\n", err)
 		return nil, err
 	}
 
@@ -225,34 +204,49 @@ func New(config *Config) (*Indexer, error) {
 		return nil, err
 	}
 
+	eventFilter, err := NewEventFilter(config.EventFilter)
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+		logger.Fatal().Msgf("Filter: Error parsing event filter config: %v\n", err)
+		return nil, err
+	}
+
+	partitionUpperBounds, err := loadPartitionUpperBounds(context.Background(), dbClient)
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+		logger.Fatal().Msgf("DB: Error loading event table partitions: %v\n", err)
+		return nil, err
+	}
+
 	return &Indexer{
-		consumer:      consumer,
-		producer:      producer,
-		dbClient:      dbClient,
-		storageClient: storageClient,
-		config:        config,
-		rpcClient:     rpcClient,
+		consumer:             consumer,
+		producer:             producer,
+		dbClient:             dbClient,
+		storageClient:        storageClient,
+		config:               config,
+		eventFilter:          eventFilter,
+		partitionUpperBounds: partitionUpperBounds,
+		rpcClient:            rpcClient,
 	}, nil
 }
 
-func (f *Indexer) parseBlockResults(parentCtx context.Context, blockResultsBytes []byte) (mq.BlockResultMsg, error) {
+func (f *Indexer) parseBlockResults(parentCtx context.Context, encoding string, blockResultsBytes []byte) (mq.BlockResultMsg, error) {
 	span, _ := sentry_integration.StartSentrySpan(parentCtx, "parseBlockResults", "Parsing block_results")
 	defer span.Finish()
 
-	var blockResultsMsg mq.BlockResultMsg
-	err := json.Unmarshal(blockResultsBytes, &blockResultsMsg)
+	blockResultsMsg, err := mq.DecodeBlockResultMsg(encoding, blockResultsBytes)
 	if err != nil {
-		logger.Error().Msgf("Error unmarshalling message: %v", err)
+		logger.Error().Msgf("Error decoding message: %v", err)
 		return blockResultsMsg, err
 	}
 
 	return blockResultsMsg, err
 }
 
-func (f *Indexer) processUntilSucceeds(ctx context.Context, blockResults mq.BlockResultMsg) error {
+func (f *Indexer) processUntilSucceeds(ctx context.Context, blockResults mq.BlockResultMsg, offset db.ConsumerOffset) error {
 	//	// Process the block_results until success
 	for {
-		err := f.processBlockResults(ctx, &blockResults)
+		err := f.processBlockResults(ctx, &blockResults, offset)
 		if err != nil {
 			if errors.Is(err, indexererrors.ErrorNonRetryable) {
 				return err
@@ -292,13 +286,13 @@ func (f *Indexer) processClaimCheckMessage(key []byte, messageValue []byte) ([]b
 	return messageValue, nil
 }
 
-func (f *Indexer) processKafkaMessage(ctx context.Context, message *kafka.Message) error {
+func (f *Indexer) processKafkaMessage(ctx context.Context, message *mq.Message) error {
 	messageValue, err := f.processClaimCheckMessage(message.Key, message.Value)
 	if err != nil {
 		logger.Error().Msgf("Error processing claim check message: %v", err)
 		return err
 	}
-	blockResultsMsg, err := f.parseBlockResults(ctx, messageValue)
+	blockResultsMsg, err := f.parseBlockResults(ctx, mq.MessageEncoding(message), messageValue)
 	if err != nil {
 		logger.Error().Msgf("Error processing block_results message: %v", err)
 		return err
@@ -308,7 +302,7 @@ func (f *Indexer) processKafkaMessage(ctx context.Context, message *kafka.Messag
 		scope.SetTag("height", fmt.Sprint(blockResultsMsg.Height))
 	})
 
-	err = f.processUntilSucceeds(ctx, blockResultsMsg)
+	err = f.processUntilSucceeds(ctx, blockResultsMsg, f.consumerOffset(message))
 	if err != nil {
 		logger.Error().Msgf("Error processing block_results: %v", err)
 		return err
@@ -317,13 +311,28 @@ func (f *Indexer) processKafkaMessage(ctx context.Context, message *kafka.Messag
 	return nil
 }
 
+// consumerOffset is the offset recorded along the data indexed from the message
+func (f *Indexer) consumerOffset(message *mq.Message) db.ConsumerOffset {
+	return db.ConsumerOffset{
+		ConsumerGroup: f.config.KafkaBlockResultsConsumerGroup,
+		Topic:         message.Topic,
+		Partition:     message.Partition,
+		Offset:        message.Offset,
+	}
+}
+
+// loadConsumerOffset resumes a partition assigned to the indexer after the last message whose data was committed
+func (f *Indexer) loadConsumerOffset(topic string, partition int32) (int64, bool, error) {
+	return db.QueryConsumerOffset(context.Background(), f.dbClient, f.config.KafkaBlockResultsConsumerGroup, topic, partition)
+}
+
 func (f *Indexer) close() {
 	sqlDB, err := f.dbClient.DB()
 	if err == nil {
 		sqlDB.Close()
 	}
 
-	f.producer.Flush(30000)
+	f.producer.Flush(30 * time.Second)
 	f.producer.Close()
 
 	f.consumer.Close()
@@ -332,9 +341,7 @@ func (f *Indexer) close() {
 func (f *Indexer) StartIndexing(stopCtx context.Context) {
 	logger.Info().Msgf("Starting indexer...")
 
-	f.producer.ListenToKafkaProduceEvents(logger)
-
-	err := f.consumer.SubscribeTopics([]string{f.config.KafkaBlockResultsTopic}, nil)
+	err := f.consumer.SubscribeTopicsFrom([]string{f.config.KafkaBlockResultsTopic}, f.loadConsumerOffset)
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
 		logger.Fatal().Msgf("Failed to subscribe to topic: %s\n", err)
@@ -351,9 +358,14 @@ func (f *Indexer) StartIndexing(stopCtx context.Context) {
 
 			message, err := f.consumer.ReadMessage(10 * time.Second)
 			if err != nil {
-				if err.(kafka.Error).IsTimeout() {
+				if errors.Is(err, mq.ErrTimeout) {
 					continue
 				}
+				if errors.Is(err, mq.ErrOffsetsNotLoaded) {
+					// the partitions are not read until the consumer is restarted
+					sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+					logger.Fatal().Msgf("Error reading message: %v", err)
+				}
 
 				sentry_integration.CaptureCurrentHubException(err, sentry.LevelWarning)
 				logger.Error().Msgf("Error reading message: %v", err)
@@ -362,19 +374,26 @@ func (f *Indexer) StartIndexing(stopCtx context.Context) {
 
 			sentry.ConfigureScope(func(scope *sentry.Scope) {
 				scope.SetTag("id", f.config.ID)
-				scope.SetTag("partition", fmt.Sprint(message.TopicPartition.Partition))
-				scope.SetTag("offset", message.TopicPartition.Offset.String())
+				scope.SetTag("partition", fmt.Sprint(message.Partition))
+				scope.SetTag("offset", fmt.Sprint(message.Offset))
 			})
 
 			transaction, ctx := sentry_integration.StartSentryTransaction(ctx, "Index", "Process and index event block_results messages")
 			err = f.processKafkaMessage(ctx, message)
 			if err != nil {
 				sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
-				logger.Warn().Msgf("Producing message to DLQ: %d, %d, %v", message.TopicPartition.Partition, message.TopicPartition.Offset, err)
-				f.producer.ProduceToDLQ(f.config.Chain, "event-indexer-block-results", message, err, logger)
+				logger.Warn().Msgf("Producing message to DLQ: %d, %d, %v", message.Partition, message.Offset, err)
+				mq.ProduceToDLQ(f.producer, f.storageClient, f.config.Chain, "event-indexer-block-results", message, err, logger)
+				// the message is not processed again after a restart. The offset is recorded before the message is
+				// committed, and a failure stops the indexer so that the offsets in the database and in the consumer
+				// group never disagree: the message is processed again once restarted.
+				if err := db.UpsertConsumerOffset(ctx, f.dbClient, f.consumerOffset(message)); err != nil {
+					sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+					logger.Fatal().Msgf("Error recording consumer offset: %v", err)
+				}
 			}
 
-			_, err = f.consumer.CommitMessage(message)
+			err = f.consumer.CommitMessage(message)
 			if err != nil {
 				sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
 				logger.Error().Msgf("Non-retryable Error committing message: %v", err)
@@ -388,6 +407,12 @@ func (f *Indexer) Run() {
 	// graceful shutdown
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
+
+	f.Index(ctx)
+}
+
+// Index indexes the messages until the context is cancelled
+func (f *Indexer) Index(ctx context.Context) {
 	defer sentry.Flush(2 * time.Second)
 
 	f.StartIndexing(ctx)
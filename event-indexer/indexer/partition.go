@@ -0,0 +1,56 @@
+package indexer
+
+import (
+	"context"
+	"math"
+
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+// loadPartitionUpperBounds returns the current upper bound of every partitioned event table.
+// Tables which are not partitioned are left out, so no partition is ever created for them.
+func loadPartitionUpperBounds(ctx context.Context, dbClient *gorm.DB) (map[string]int64, error) {
+	upperBounds := make(map[string]int64)
+	for _, table := range db.GetValidTableNames() {
+		partitioned, err := db.IsPartitionedTable(ctx, dbClient, table)
+		if err != nil {
+			return nil, err
+		}
+		if !partitioned {
+			continue
+		}
+
+		partitions, err := db.ListPartitions(ctx, dbClient, table)
+		if err != nil {
+			return nil, err
+		}
+
+		upperBounds[table] = math.MinInt64
+		if len(partitions) > 0 {
+			upperBounds[table] = partitions[len(partitions)-1].To
+		}
+	}
+
+	return upperBounds, nil
+}
+
+// ensurePartitions makes sure the partitions for the given height exist before inserting.
+// The cached bounds are refreshed once the height reaches them; partitions are only created here if the maintainer falls behind.
+func (f *Indexer) ensurePartitions(parentCtx context.Context, height int64) error {
+	for table, upper := range f.partitionUpperBounds {
+		if height < upper {
+			continue
+		}
+
+		newUpper, err := db.EnsurePartitions(parentCtx, f.dbClient, table, height, f.config.PartitionSize)
+		if err != nil {
+			return err
+		}
+		logger.Info().Int64("height", height).Msgf("Partitions of table %s cover block_height below: %d", table, newUpper)
+		f.partitionUpperBounds[table] = newUpper
+	}
+
+	return nil
+}
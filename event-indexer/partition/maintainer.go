@@ -0,0 +1,118 @@
+package partition
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+var logger *zerolog.Logger
+
+type Maintainer struct {
+	dbClient *gorm.DB
+	config   *MaintainerConfig
+}
+
+type MaintainerConfig struct {
+	DBConnectionString string
+	// PartitionSize is the number of blocks covered by a single partition
+	PartitionSize int64
+	// PartitionsAhead is the number of partitions to keep created above the latest indexed height
+	PartitionsAhead int64
+	// MaintenanceInterval is specified in minutes
+	MaintenanceInterval int64
+	Chain               string
+	Environment         string
+	CommitSHA           string
+}
+
+func NewMaintainer(config *MaintainerConfig) (*Maintainer, error) {
+	logger = zerolog.Ctx(log.With().Str("component", "event-indexer-partition-maintainer").Str("chain", config.Chain).Str("environment", config.Environment).Str("commit_sha", config.CommitSHA).Logger().WithContext(context.Background()))
+
+	if config.PartitionSize <= 0 {
+		return nil, fmt.Errorf("invalid partition size: %d", config.PartitionSize)
+	}
+
+	dbClient, err := db.NewClient(config.DBConnectionString)
+	if err != nil {
+		logger.Fatal().Msgf("DB: Error creating DB client: %v", err)
+		return nil, err
+	}
+
+	return &Maintainer{
+		dbClient: dbClient,
+		config:   config,
+	}, nil
+}
+
+func (m *Maintainer) StartMaintaining(signalCtx context.Context) {
+	logger.Info().Msg("Maintainer: Starting partition maintenance ...")
+
+	for {
+		select {
+		case <-signalCtx.Done():
+			logger.Info().Msg("Maintainer: Received stop signal. Exiting maintenance loop ...")
+			return
+		default:
+			for _, table := range db.GetValidTableNames() {
+				if err := m.maintainTable(signalCtx, table); err != nil {
+					logger.Error().Msgf("Error during partition maintenance for table %s: %v", table, err)
+				}
+			}
+
+			time.Sleep(time.Duration(m.config.MaintenanceInterval) * time.Minute)
+		}
+	}
+}
+
+func (m *Maintainer) maintainTable(ctx context.Context, tableName string) error {
+	partitioned, err := db.IsPartitionedTable(ctx, m.dbClient, tableName)
+	if err != nil {
+		return fmt.Errorf("DB: failed to check partitioning of table %s: %w", tableName, err)
+	}
+	if !partitioned {
+		logger.Info().Msgf("Partition maintenance not required for table %s: table is not partitioned", tableName)
+		return nil
+	}
+
+	height, err := db.GetLatestInformativeBlockHeight(ctx, m.dbClient)
+	if err != nil {
+		return fmt.Errorf("DB: failed to get latest block height: %w", err)
+	}
+
+	target := height + m.config.PartitionsAhead*m.config.PartitionSize
+	upper, err := db.EnsurePartitions(ctx, m.dbClient, tableName, target, m.config.PartitionSize)
+	if err != nil {
+		return fmt.Errorf("DB: failed to create partitions for table %s: %w", tableName, err)
+	}
+
+	logger.Info().Msgf("Partitions of table %s cover block_height below: %d", tableName, upper)
+	return nil
+}
+
+func (m *Maintainer) Maintain() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go m.StartMaintaining(ctx)
+
+	<-ctx.Done()
+
+	logger.Info().Msgf("Stopping partition maintainer ...")
+	m.close()
+}
+
+func (m *Maintainer) close() {
+	sqlDB, err := m.dbClient.DB()
+	if err == nil {
+		sqlDB.Close()
+	}
+}
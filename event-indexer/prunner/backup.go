@@ -0,0 +1,176 @@
+package prunner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/pkg/export"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+const backupManifestFileName = "manifest.json"
+
+// BackupManifest lists the parts of a backup, it is uploaded last so a backup without manifest is incomplete.
+type BackupManifest struct {
+	Table     string                `json:"table"`
+	Format    export.Format         `json:"format"`
+	CreatedAt time.Time             `json:"created_at"`
+	Rows      int64                 `json:"rows"`
+	Parts     []export.FileManifest `json:"parts"`
+}
+
+// backupWriter streams the rows of a query into compressed parts of at most partRows rows each,
+// so only a single part is ever held in memory.
+type backupWriter struct {
+	storageClient storage.Client
+	bucket        string
+	dir           string
+	table         *export.Table
+	format        export.Format
+	partRows      int64
+
+	manifest *BackupManifest
+	buffer   bytes.Buffer
+	encoder  export.Encoder
+	part     export.FileManifest
+}
+
+func newBackupWriter(storageClient storage.Client, bucket, dir string, table *export.Table, format export.Format, partRows int64) *backupWriter {
+	return &backupWriter{
+		storageClient: storageClient,
+		bucket:        bucket,
+		dir:           dir,
+		table:         table,
+		format:        format,
+		partRows:      partRows,
+		manifest: &BackupManifest{
+			Table:     table.Name,
+			Format:    format,
+			CreatedAt: time.Now().UTC(),
+			Parts:     []export.FileManifest{},
+		},
+	}
+}
+
+func (w *backupWriter) write(row reflect.Value, blockHeight int64) error {
+	if w.encoder == nil {
+		encoder, err := export.NewEncoder(w.format, &w.buffer, w.table)
+		if err != nil {
+			return err
+		}
+		w.encoder = encoder
+		w.part = export.FileManifest{FromHeight: blockHeight}
+	}
+
+	if err := w.encoder.Encode(row); err != nil {
+		return fmt.Errorf("failed to encode row: %w", err)
+	}
+	w.part.ToHeight = blockHeight
+	w.part.Rows++
+
+	if w.part.Rows >= w.partRows {
+		return w.flush()
+	}
+	return nil
+}
+
+// flush uploads the current part, if any.
+func (w *backupWriter) flush() error {
+	if w.encoder == nil {
+		return nil
+	}
+
+	if err := w.encoder.Close(); err != nil {
+		return fmt.Errorf("failed to close encoder: %w", err)
+	}
+
+	w.part.Path = fmt.Sprintf("%s/part-%05d%s", w.dir, len(w.manifest.Parts), w.format.Extension())
+	w.part.SHA256 = export.Checksum(w.buffer.Bytes())
+	if err := w.storageClient.UploadFile(w.bucket, w.part.Path, w.buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", w.part.Path, err)
+	}
+
+	w.manifest.Parts = append(w.manifest.Parts, w.part)
+	w.manifest.Rows += w.part.Rows
+	w.buffer.Reset()
+	w.encoder = nil
+	return nil
+}
+
+// close uploads the last part and the manifest.
+func (w *backupWriter) close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	return w.storageClient.UploadFile(w.bucket, fmt.Sprintf("%s/%s", w.dir, backupManifestFileName), data)
+}
+
+// streamBackup streams the rows of the query, ordered by block_height, as parts under <table>/<name>
+// and returns the manifest of the backup.
+func (p *Prunner) streamBackup(ctx context.Context, query *gorm.DB, tableName, name string) (*BackupManifest, error) {
+	tables, err := export.LookupTables([]string{tableName})
+	if err != nil {
+		return nil, err
+	}
+	table := tables[0]
+
+	rows, err := query.Order("block_height").Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rows: %w", err)
+	}
+	defer rows.Close()
+
+	writer := newBackupWriter(p.storageClient, p.config.BackupBucketName, fmt.Sprintf("%s/%s", tableName, name),
+		table, p.config.BackupFormat, p.config.BackupPartRows)
+	heightField := table.Schema.LookUpField("block_height")
+
+	for rows.Next() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		row := reflect.New(table.Schema.ModelType)
+		if err := p.dbClient.ScanRows(rows, row.Interface()); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := writer.write(row, heightField.ReflectValueOf(ctx, row).Int()); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	if err := writer.close(); err != nil {
+		return nil, err
+	}
+
+	return writer.manifest, nil
+}
+
+// verifyBackup reads back every uploaded part and checks its checksum, as well as the total row count,
+// before anything is deleted from the database.
+func (p *Prunner) verifyBackup(manifest *BackupManifest, expectedRows int64) error {
+	if manifest.Rows != expectedRows {
+		return fmt.Errorf("backup of table %s has %d rows, expected %d", manifest.Table, manifest.Rows, expectedRows)
+	}
+
+	for _, part := range manifest.Parts {
+		if err := export.VerifyFile(p.storageClient, p.config.BackupBucketName, part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
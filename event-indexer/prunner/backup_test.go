@@ -0,0 +1,71 @@
+package prunner
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/export"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+type memoryStorage struct {
+	storage.Client
+	objects map[string][]byte
+}
+
+func (m *memoryStorage) UploadFile(bucket string, objectPath string, message []byte) error {
+	m.objects[bucket+"/"+objectPath] = bytes.Clone(message)
+	return nil
+}
+
+func (m *memoryStorage) ReadFile(bucket string, objectPath string) ([]byte, error) {
+	data, ok := m.objects[bucket+"/"+objectPath]
+	if !ok {
+		return nil, fmt.Errorf("object %s not found", objectPath)
+	}
+	return data, nil
+}
+
+func TestBackupWriter(t *testing.T) {
+	tables, err := export.LookupTables([]string{db.TableNameMoveEvent})
+	require.NoError(t, err)
+
+	storageClient := &memoryStorage{objects: map[string][]byte{}}
+	writer := newBackupWriter(storageClient, "bucket", "move_events/events-1", tables[0], export.FormatNDJSON, 2)
+	for height := int64(1); height <= 5; height++ {
+		row := &db.MoveEvent{TypeTag: "0x1::coin::Deposit", Data: db.JSONB(`{}`), BlockHeight: height, TransactionHash: "hash"}
+		require.NoError(t, writer.write(reflect.ValueOf(row), height))
+	}
+	require.NoError(t, writer.close())
+
+	manifest := writer.manifest
+	assert.Equal(t, int64(5), manifest.Rows)
+	require.Len(t, manifest.Parts, 3)
+	assert.Equal(t, "move_events/events-1/part-00002.ndjson.gz", manifest.Parts[2].Path)
+	assert.Equal(t, int64(3), manifest.Parts[1].FromHeight)
+	assert.Equal(t, int64(4), manifest.Parts[1].ToHeight)
+	assert.Equal(t, int64(1), manifest.Parts[2].Rows)
+	assert.Contains(t, storageClient.objects, "bucket/move_events/events-1/manifest.json")
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(storageClient.objects["bucket/"+manifest.Parts[0].Path]))
+	require.NoError(t, err)
+	data, err := io.ReadAll(gzipReader)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 2)
+
+	p := &Prunner{storageClient: storageClient, config: &PrunnerConfig{BackupBucketName: "bucket"}}
+	assert.NoError(t, p.verifyBackup(manifest, 5))
+	assert.Error(t, p.verifyBackup(manifest, 6))
+
+	storageClient.objects["bucket/"+manifest.Parts[1].Path] = []byte("corrupted")
+	assert.Error(t, p.verifyBackup(manifest, 5))
+}
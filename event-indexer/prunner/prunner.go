@@ -1,13 +1,10 @@
 package prunner
 
 import (
-	"archive/zip"
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"math"
 	"os/signal"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -17,6 +14,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/export"
 	"github.com/initia-labs/core-indexer/pkg/storage"
 )
 
@@ -32,6 +30,8 @@ type PrunnerConfig struct {
 	DBConnectionString string
 	BackupBucketName   string
 	BackupFilePrefix   string
+	BackupFormat       export.Format
+	BackupPartRows     int64
 	PruningKeepBlock   int64
 	PruningInterval    int64
 	Chain              string
@@ -42,6 +42,10 @@ type PrunnerConfig struct {
 func NewPrunner(config *PrunnerConfig) (*Prunner, error) {
 	logger = zerolog.Ctx(log.With().Str("component", "event-indexer-prunner").Str("chain", config.Chain).Str("environment", config.Environment).Str("commit_sha", config.CommitSHA).Logger().WithContext(context.Background()))
 
+	if config.BackupPartRows <= 0 {
+		return nil, fmt.Errorf("invalid backup part rows: %d", config.BackupPartRows)
+	}
+
 	dbClient, err := db.NewClient(config.DBConnectionString)
 	if err != nil {
 		logger.Fatal().Msgf("DB: Error creating DB client: %v", err)
@@ -106,6 +110,14 @@ func (p *Prunner) pruningTable(ctx context.Context, tableName string) error {
 		return nil
 	}
 
+	partitioned, err := db.IsPartitionedTable(ctx, p.dbClient, tableName)
+	if err != nil {
+		return fmt.Errorf("DB: failed to check partitioning of table %s: %w", tableName, err)
+	}
+	if partitioned {
+		return p.pruningPartitions(ctx, tableName, pruningThreshold)
+	}
+
 	logger.Info().Msgf("Pruning rows from table %s with block_height below: %d", tableName, pruningThreshold)
 
 	query, err := db.BuildPruneQuery(ctx, p.dbClient, tableName, pruningThreshold)
@@ -124,9 +136,13 @@ func (p *Prunner) pruningTable(ctx context.Context, tableName string) error {
 		return nil
 	}
 
-	backupFileName := fmt.Sprintf("%s-%d.zip", p.config.BackupFilePrefix, time.Now().Unix())
-	if err := streamBackupToGCS(ctx, query, p.storageClient, p.config.BackupBucketName,
-		tableName, backupFileName); err != nil {
+	backupQuery, err := db.BuildPruneQuery(ctx, p.dbClient, tableName, pruningThreshold)
+	if err != nil {
+		return fmt.Errorf("DB: Failed to prepare query for table %s: %w", tableName, err)
+	}
+
+	backupName := fmt.Sprintf("%s-%d", p.config.BackupFilePrefix, time.Now().Unix())
+	if err := p.backupAndVerify(ctx, backupQuery, tableName, backupName, count); err != nil {
 		return fmt.Errorf("GCS: Failed to backup data from table %s to GCS: %w", tableName, err)
 	}
 
@@ -138,84 +154,93 @@ func (p *Prunner) pruningTable(ctx context.Context, tableName string) error {
 	return nil
 }
 
-// streamBackupToGCS streams query results directly to a zip file and uploads to GCS
-func streamBackupToGCS(ctx context.Context, query *gorm.DB, storageClient storage.Client, bucketName, tableName, fileName string) error {
-	// create zip
-	var buffer bytes.Buffer
-	zipWriter := zip.NewWriter(&buffer)
-
-	// create a file inside the zip
-	zipFile, err := zipWriter.Create(fmt.Sprintf("%s.json", strings.Split(fileName, ".")[0]))
+// pruningPartitions backs up and drops every partition whose rows are all at or below the pruning threshold.
+// A partition straddling the threshold is kept until the threshold moves past its upper bound, except the legacy
+// partition holding the rows from before the table was partitioned, whose rows are pruned as the threshold moves.
+func (p *Prunner) pruningPartitions(ctx context.Context, tableName string, pruningThreshold int64) error {
+	partitions, err := db.ListPartitions(ctx, p.dbClient, tableName)
 	if err != nil {
-		return fmt.Errorf("failed to create zip entry: %w", err)
+		return fmt.Errorf("DB: Failed to list partitions for table %s: %w", tableName, err)
 	}
 
-	// start writing the JSON array opening bracket
-	if _, err := zipFile.Write([]byte("[")); err != nil {
-		return fmt.Errorf("failed to write opening bracket: %w", err)
-	}
+	pruned := 0
+	for _, partition := range partitions {
+		if partition.To > pruningThreshold+1 {
+			if partition.From == math.MinInt64 {
+				if err := p.pruningLegacyPartition(ctx, tableName, partition, pruningThreshold); err != nil {
+					return err
+				}
+			}
+			break
+		}
 
-	// process data in chunks
-	const chunkSize = 1000
-	offset := 0
-	isFirstRow := true
+		logger.Info().Msgf("Pruning partition %s of table %s with block_height below: %d", partition.Name, tableName, partition.To)
 
-	for {
-		// check if context is canceled
-		if ctx.Err() != nil {
-			return ctx.Err()
+		query := db.BuildPartitionQuery(ctx, p.dbClient, partition)
+		var count int64
+		if err := query.Count(&count).Error; err != nil {
+			return fmt.Errorf("DB: Failed to count rows for partition %s: %w", partition.Name, err)
 		}
 
-		var chunk []map[string]any
-		if err := query.Limit(chunkSize).Offset(offset).Find(&chunk).Error; err != nil {
-			return fmt.Errorf("failed to fetch chunk: %w", err)
+		if count > 0 {
+			backupName := fmt.Sprintf("%s-%s-%d", p.config.BackupFilePrefix, partition.Name, time.Now().Unix())
+			if err := p.backupAndVerify(ctx, db.BuildPartitionQuery(ctx, p.dbClient, partition), tableName, backupName, count); err != nil {
+				return fmt.Errorf("GCS: Failed to backup data from partition %s to GCS: %w", partition.Name, err)
+			}
 		}
 
-		// no more data, break the loop
-		if len(chunk) == 0 {
-			break
+		if err := db.DropPartition(ctx, p.dbClient, tableName, partition); err != nil {
+			return fmt.Errorf("DB: Failed to drop partition %s: %w", partition.Name, err)
 		}
+		pruned++
+	}
 
-		for _, rowData := range chunk {
-			if !isFirstRow {
-				if _, err := zipFile.Write([]byte(",")); err != nil {
-					return fmt.Errorf("failed to write comma: %w", err)
-				}
-			} else {
-				isFirstRow = false
-			}
+	if pruned == 0 {
+		logger.Info().Msgf("Pruning not required for table %s: no partition below threshold %d", tableName, pruningThreshold)
+		return nil
+	}
 
-			rowJSON, err := json.Marshal(rowData)
-			if err != nil {
-				return fmt.Errorf("failed to marshal row: %w", err)
-			}
+	logger.Info().Msgf("Pruning completed, dropped %d partitions of table %s ...", pruned, tableName)
+	return nil
+}
 
-			if _, err := zipFile.Write(rowJSON); err != nil {
-				return fmt.Errorf("failed to write row JSON: %w", err)
-			}
-		}
+// pruningLegacyPartition backs up and deletes the rows of the legacy partition at or below the pruning threshold, the
+// partition spanning the whole history indexed before the table was partitioned.
+func (p *Prunner) pruningLegacyPartition(ctx context.Context, tableName string, partition db.Partition, pruningThreshold int64) error {
+	var count int64
+	if err := db.BuildPartitionQuery(ctx, p.dbClient, partition).Where("block_height <= ?", pruningThreshold).Count(&count).Error; err != nil {
+		return fmt.Errorf("DB: Failed to count rows for partition %s: %w", partition.Name, err)
+	}
+	if count == 0 {
+		return nil
+	}
 
-		offset += len(chunk)
+	logger.Info().Msgf("Pruning rows from partition %s of table %s with block_height below: %d", partition.Name, tableName, pruningThreshold)
 
-		if len(chunk) < chunkSize {
-			break
-		}
+	backupName := fmt.Sprintf("%s-%s-%d", p.config.BackupFilePrefix, partition.Name, time.Now().Unix())
+	backupQuery := db.BuildPartitionQuery(ctx, p.dbClient, partition).Where("block_height <= ?", pruningThreshold)
+	if err := p.backupAndVerify(ctx, backupQuery, tableName, backupName, count); err != nil {
+		return fmt.Errorf("GCS: Failed to backup data from partition %s to GCS: %w", partition.Name, err)
 	}
 
-	if _, err := zipFile.Write([]byte("]")); err != nil {
-		return fmt.Errorf("failed to write closing bracket: %w", err)
+	if err := db.DeletePartitionRowsToPrune(ctx, p.dbClient, partition, pruningThreshold); err != nil {
+		return fmt.Errorf("DB: Failed to prune rows from partition %s: %w", partition.Name, err)
 	}
+	return nil
+}
 
-	if err := zipWriter.Close(); err != nil {
-		return fmt.Errorf("failed to close zip writer: %w", err)
+// backupAndVerify streams a backup of the query and verifies the uploaded parts against its manifest.
+func (p *Prunner) backupAndVerify(ctx context.Context, query *gorm.DB, tableName, backupName string, expectedRows int64) error {
+	manifest, err := p.streamBackup(ctx, query, tableName, backupName)
+	if err != nil {
+		return err
 	}
 
-	objectName := fmt.Sprintf("%s/%s", tableName, fileName)
-	err = storageClient.UploadFile(bucketName, objectName, buffer.Bytes())
-	if err != nil {
-		return fmt.Errorf("failed to upload file to GCS: %w", err)
+	if err := p.verifyBackup(manifest, expectedRows); err != nil {
+		return fmt.Errorf("backup verification failed: %w", err)
 	}
 
+	logger.Info().Msgf("Backed up %d rows of table %s in %d parts to %s/%s", manifest.Rows, tableName, len(manifest.Parts), tableName, backupName)
 	return nil
 }
 
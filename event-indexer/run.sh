@@ -49,11 +49,33 @@ task__prune() {
   ./event-indexer prune --db $DB_CONNECTION_STRING \
     --backup-bucket-name ${chain}-local-core-event-data-backup \
     --backup-file-prefix events \
+    --backup-format ndjson \
+    --backup-part-rows 100000 \
     --pruning-keep-block 10 \
     --pruning-interval 1 \
     --chain $chain
 }
 
+help__maintain_partitions="maintain_partitions <..args> : run partition maintenance"
+task__maintain_partitions() {
+  local chain=$1
+
+  if [ -z "$chain" ]; then
+    echo "usage: $0 maintain_partitions <chain>"
+    exit
+  fi
+
+  go build -o event-indexer .
+
+  source .env
+
+  ./event-indexer maintain-partitions --db $DB_CONNECTION_STRING \
+    --partition-size 100000 \
+    --partitions-ahead 2 \
+    --maintenance-interval 1 \
+    --chain $chain
+}
+
 list_all_helps() {
   compgen -v | egrep "^help__.*"
 }
@@ -7,12 +7,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/initia-labs/core-indexer/generic-indexer/indexer"
+	"github.com/initia-labs/core-indexer/pkg/mq"
 )
 
 // List of CLI flags
 const (
 	FlagID                            = "id"
 	FlagRPCEndpoints                  = "rpcs"
+	FlagMQBackend                     = "mq-backend"
+	FlagMQDir                         = "mq-dir"
 	FlagKafkaBootstrapServer          = "bootstrap-server"
 	FlagDBConnectionString            = "db"
 	FlagNumWorkers                    = "workers"
@@ -44,62 +47,12 @@ func IndexerCmd() *cobra.Command {
 		Short: "Consumes from Kafka and indexes the blockchain for data",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			rpcEndpoints, _ := cmd.Flags().GetString(FlagRPCEndpoints)
-			kafkaBootstrapServer, _ := cmd.Flags().GetString(FlagKafkaBootstrapServer)
-			chain, _ := cmd.Flags().GetString(FlagChain)
-			dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
-			kafkaBlockTopic, _ := cmd.Flags().GetString(KafkaBlockTopic)
-			kafkaTxResponseTopic, _ := cmd.Flags().GetString(KafkaTxResponseTopic)
-			kafkaAPIKey, _ := cmd.Flags().GetString(FlagKafkaAPIKey)
-			kafkaAPISecret, _ := cmd.Flags().GetString(FlagKafkaAPISecret)
-			kafkaBlockConsumerGroup, _ := cmd.Flags().GetString(FlagKafkaBlockConsumerGroup)
-
-			numWorkers, _ := cmd.Flags().GetUint64(FlagNumWorkers)
-
-			awsAccessKey, _ := cmd.Flags().GetString(FlagAWSAccessKey)
-			awsSecretKey, _ := cmd.Flags().GetString(FlagAWSSecretKey)
-			blockClaimCheckBucket, _ := cmd.Flags().GetString(FlagBlockClaimCheckBucket)
-			claimCheckThresholdInMB, _ := cmd.Flags().GetUint64(FlagClaimCheckThresholdInMB)
-			lcdTxResponseClaimCheckBucket, _ := cmd.Flags().GetString(FlagLCDTxResponseClaimCheckBucket)
-
-			workerID, _ := cmd.Flags().GetString(FlagID)
-
-			environment, _ := cmd.Flags().GetString(FlagEnvironment)
-			rebalanceInterval, _ := cmd.Flags().GetInt64(FlagRebalanceInterval)
-			rpcTimeOutInSeconds, _ := cmd.Flags().GetInt64(FlagRPCTimeoutInSeconds)
-
-			sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
-			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
-			sentryProfilesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryProfilesSampleRate)
-			sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
-			blockResultsClaimCheckBucket, _ := cmd.Flags().GetString(FlagBlockResultsClaimCheckBucket)
-
-			f, err := indexer.New(&indexer.IndexerConfig{
-				ID:                            workerID,
-				RPCEndpoints:                  rpcEndpoints,
-				KafkaBootstrapServer:          kafkaBootstrapServer,
-				KafkaBlockTopic:               kafkaBlockTopic,
-				KafkaTxResponseTopic:          kafkaTxResponseTopic,
-				KafkaAPIKey:                   kafkaAPIKey,
-				KafkaAPISecret:                kafkaAPISecret,
-				KafkaBlockConsumerGroup:       kafkaBlockConsumerGroup,
-				NumWorkers:                    int64(numWorkers),
-				Chain:                         chain,
-				DBConnectionString:            dbConnectionString,
-				AWSAccessKey:                  awsAccessKey,
-				AWSSecretKey:                  awsSecretKey,
-				BlockClaimCheckBucket:         blockClaimCheckBucket,
-				ClaimCheckThresholdInMB:       int64(claimCheckThresholdInMB),
-				LCDTxResponseClaimCheckBucket: lcdTxResponseClaimCheckBucket,
-				Environment:                   environment,
-				RebalanceInterval:             rebalanceInterval,
-				RPCTimeOutInSeconds:           rpcTimeOutInSeconds,
-				SentryDSN:                     sentryDSN,
-				CommitSHA:                     commitSHA,
-				SentryProfilesSampleRate:      sentryProfilesSampleRate,
-				SentryTracesSampleRate:        sentryTracesSampleRate,
-				BlockResultsClaimCheckBucket:  blockResultsClaimCheckBucket,
-			})
+			config := indexerConfig(cmd)
+			if err := mq.CheckStandalone(config.MQBackend); err != nil {
+				return err
+			}
+
+			f, err := indexer.New(config)
 
 			if err != nil {
 				return err
@@ -111,6 +64,77 @@ func IndexerCmd() *cobra.Command {
 		},
 	}
 
+	addIndexerFlags(cmd)
+
+	return cmd
+}
+
+// indexerConfig returns the indexer config set by the flags of the command
+func indexerConfig(cmd *cobra.Command) *indexer.IndexerConfig {
+	rpcEndpoints, _ := cmd.Flags().GetString(FlagRPCEndpoints)
+	mqBackend, _ := cmd.Flags().GetString(FlagMQBackend)
+	mqDir, _ := cmd.Flags().GetString(FlagMQDir)
+	kafkaBootstrapServer, _ := cmd.Flags().GetString(FlagKafkaBootstrapServer)
+	chain, _ := cmd.Flags().GetString(FlagChain)
+	dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
+	kafkaBlockTopic, _ := cmd.Flags().GetString(KafkaBlockTopic)
+	kafkaTxResponseTopic, _ := cmd.Flags().GetString(KafkaTxResponseTopic)
+	kafkaAPIKey, _ := cmd.Flags().GetString(FlagKafkaAPIKey)
+	kafkaAPISecret, _ := cmd.Flags().GetString(FlagKafkaAPISecret)
+	kafkaBlockConsumerGroup, _ := cmd.Flags().GetString(FlagKafkaBlockConsumerGroup)
+
+	numWorkers, _ := cmd.Flags().GetUint64(FlagNumWorkers)
+
+	awsAccessKey, _ := cmd.Flags().GetString(FlagAWSAccessKey)
+	awsSecretKey, _ := cmd.Flags().GetString(FlagAWSSecretKey)
+	blockClaimCheckBucket, _ := cmd.Flags().GetString(FlagBlockClaimCheckBucket)
+	claimCheckThresholdInMB, _ := cmd.Flags().GetUint64(FlagClaimCheckThresholdInMB)
+	lcdTxResponseClaimCheckBucket, _ := cmd.Flags().GetString(FlagLCDTxResponseClaimCheckBucket)
+
+	workerID, _ := cmd.Flags().GetString(FlagID)
+
+	environment, _ := cmd.Flags().GetString(FlagEnvironment)
+	rebalanceInterval, _ := cmd.Flags().GetInt64(FlagRebalanceInterval)
+	rpcTimeOutInSeconds, _ := cmd.Flags().GetInt64(FlagRPCTimeoutInSeconds)
+
+	sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
+	commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
+	sentryProfilesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryProfilesSampleRate)
+	sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
+	blockResultsClaimCheckBucket, _ := cmd.Flags().GetString(FlagBlockResultsClaimCheckBucket)
+
+	return &indexer.IndexerConfig{
+		ID:                            workerID,
+		RPCEndpoints:                  rpcEndpoints,
+		MQBackend:                     mqBackend,
+		MQDir:                         mqDir,
+		KafkaBootstrapServer:          kafkaBootstrapServer,
+		KafkaBlockTopic:               kafkaBlockTopic,
+		KafkaTxResponseTopic:          kafkaTxResponseTopic,
+		KafkaAPIKey:                   kafkaAPIKey,
+		KafkaAPISecret:                kafkaAPISecret,
+		KafkaBlockConsumerGroup:       kafkaBlockConsumerGroup,
+		NumWorkers:                    int64(numWorkers),
+		Chain:                         chain,
+		DBConnectionString:            dbConnectionString,
+		AWSAccessKey:                  awsAccessKey,
+		AWSSecretKey:                  awsSecretKey,
+		BlockClaimCheckBucket:         blockClaimCheckBucket,
+		ClaimCheckThresholdInMB:       int64(claimCheckThresholdInMB),
+		LCDTxResponseClaimCheckBucket: lcdTxResponseClaimCheckBucket,
+		Environment:                   environment,
+		RebalanceInterval:             rebalanceInterval,
+		RPCTimeOutInSeconds:           rpcTimeOutInSeconds,
+		SentryDSN:                     sentryDSN,
+		CommitSHA:                     commitSHA,
+		SentryProfilesSampleRate:      sentryProfilesSampleRate,
+		SentryTracesSampleRate:        sentryTracesSampleRate,
+		BlockResultsClaimCheckBucket:  blockResultsClaimCheckBucket,
+	}
+}
+
+// addIndexerFlags adds the flags of the indexer config to the command
+func addIndexerFlags(cmd *cobra.Command) {
 	threshold, err := strconv.ParseInt(os.Getenv("CLAIM_CHECK_THRESHOLD_IN_MB"), 10, 64)
 	if err != nil {
 		threshold = 1
@@ -136,6 +160,8 @@ func IndexerCmd() *cobra.Command {
 	}
 
 	cmd.Flags().String(FlagRPCEndpoints, os.Getenv("RPC_ENDPOINTS"), "")
+	cmd.Flags().String(FlagMQBackend, os.Getenv("MQ_BACKEND"), "Message bus backend: kafka (default), memory or file")
+	cmd.Flags().String(FlagMQDir, os.Getenv("MQ_DIR"), "Directory of the file message bus backend")
 	cmd.Flags().String(FlagKafkaBootstrapServer, os.Getenv("BOOTSTRAP_SERVER"), "<host>:<port> to Kafka bootstrap server")
 	cmd.Flags().String(KafkaBlockTopic, os.Getenv("BLOCK_TOPIC"), "Kafka topic about Blocks to consume")
 	cmd.Flags().String(KafkaTxResponseTopic, os.Getenv("TX_TOPIC"), "Kafka topic about TxResponses to produce")
@@ -159,6 +185,4 @@ func IndexerCmd() *cobra.Command {
 	cmd.Flags().Float64(FlagSentryProfilesSampleRate, sentryProfilesSampleRate, "Sentry profiles sample rate")
 	cmd.Flags().Float64(FlagSentryTracesSampleRate, sentryTracesSampleRate, "Sentry traces sample rate")
 	cmd.Flags().String(FlagBlockResultsClaimCheckBucket, os.Getenv("BLOCK_RESULTS_CLAIM_CHECK_BUCKET"), "Block results claim check bucket")
-
-	return cmd
 }
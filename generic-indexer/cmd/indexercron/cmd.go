@@ -1,8 +1,10 @@
 package indexercron_cmd
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	indexercron "github.com/initia-labs/core-indexer/generic-indexer/indexer-cron"
 	"github.com/spf13/cobra"
@@ -16,6 +18,24 @@ const (
 	FlagValidatorUpdateInterval              = "validator-update-interval"
 	FlagValidatorUptimeUpdateInterval        = "validator-uptime-update-interval"
 	FlagValidatorIdentityImageUpdateInterval = "validator-identity-image-update-interval"
+	FlagValidatorIdentityImageTTL            = "validator-identity-image-ttl"
+	FlagValidatorIdentityImageProviders      = "validator-identity-image-providers"
+	FlagValidatorIdentityImageRegistry       = "validator-identity-image-registry"
+	FlagValidatorIdentityImageChainRegistry  = "validator-identity-image-chain-registry"
+	FlagValidatorIdentityImageBucket         = "validator-identity-image-bucket"
+	FlagValidatorIdentityImagePublicURL      = "validator-identity-image-public-url"
+	FlagValidatorAlertInterval               = "validator-alert-interval"
+	FlagValidatorAlertMissStreak             = "validator-alert-miss-streak"
+	FlagValidatorAlertWindow                 = "validator-alert-window"
+	FlagValidatorAlertMissRate               = "validator-alert-miss-rate"
+	FlagValidatorAlertNotifier               = "validator-alert-notifier"
+	FlagValidatorAlertWebhookURL             = "validator-alert-webhook-url"
+	FlagProposalTallyInterval                = "proposal-tally-interval"
+	FlagChainStatsInterval                   = "chain-stats-interval"
+	FlagNftMetadataInterval                  = "nft-metadata-interval"
+	FlagNftMetadataIPFSGateway               = "nft-metadata-ipfs-gateway"
+	FlagNftMetadataBucket                    = "nft-metadata-bucket"
+	FlagNftMetadataPublicURL                 = "nft-metadata-public-url"
 	FlagEnvironment                          = "environment"
 	FlagKeepLatestCommitSignatures           = "keep-latest-commit-signatures"
 	FlagRPCTimeoutInSeconds                  = "rpc-timeout-in-seconds"
@@ -42,6 +62,33 @@ func IndexerCronCmd() *cobra.Command {
 			validatorUpdateInterval, _ := cmd.Flags().GetInt64(FlagValidatorUpdateInterval)
 			validatorUptimeUpdateInterval, _ := cmd.Flags().GetInt64(FlagValidatorUptimeUpdateInterval)
 			validatorIdentityImageUpdateInterval, _ := cmd.Flags().GetInt64(FlagValidatorIdentityImageUpdateInterval)
+			validatorIdentityImageTTL, _ := cmd.Flags().GetInt64(FlagValidatorIdentityImageTTL)
+			validatorIdentityImageProviders, _ := cmd.Flags().GetStringSlice(FlagValidatorIdentityImageProviders)
+			validatorIdentityImageRegistry, _ := cmd.Flags().GetString(FlagValidatorIdentityImageRegistry)
+			validatorIdentityImageChainRegistry, _ := cmd.Flags().GetString(FlagValidatorIdentityImageChainRegistry)
+			validatorIdentityImageBucket, _ := cmd.Flags().GetString(FlagValidatorIdentityImageBucket)
+			validatorIdentityImagePublicURL, _ := cmd.Flags().GetString(FlagValidatorIdentityImagePublicURL)
+			if validatorIdentityImagePublicURL == "" {
+				validatorIdentityImagePublicURL = "https://storage.googleapis.com/" + validatorIdentityImageBucket
+			}
+			validatorAlertInterval, _ := cmd.Flags().GetInt64(FlagValidatorAlertInterval)
+			validatorAlertMissStreak, _ := cmd.Flags().GetInt64(FlagValidatorAlertMissStreak)
+			validatorAlertWindow, _ := cmd.Flags().GetInt64(FlagValidatorAlertWindow)
+			validatorAlertMissRate, _ := cmd.Flags().GetFloat64(FlagValidatorAlertMissRate)
+			validatorAlertNotifier, _ := cmd.Flags().GetString(FlagValidatorAlertNotifier)
+			validatorAlertWebhookURL, _ := cmd.Flags().GetString(FlagValidatorAlertWebhookURL)
+			if validatorAlertMissStreak <= 0 || validatorAlertWindow <= 0 || validatorAlertMissRate <= 0 || validatorAlertMissRate > 1 {
+				return fmt.Errorf("validator alert miss streak and window must be positive, and miss rate between 0 and 1")
+			}
+			proposalTallyInterval, _ := cmd.Flags().GetInt64(FlagProposalTallyInterval)
+			chainStatsInterval, _ := cmd.Flags().GetInt64(FlagChainStatsInterval)
+			nftMetadataInterval, _ := cmd.Flags().GetInt64(FlagNftMetadataInterval)
+			nftMetadataIPFSGateway, _ := cmd.Flags().GetString(FlagNftMetadataIPFSGateway)
+			nftMetadataBucket, _ := cmd.Flags().GetString(FlagNftMetadataBucket)
+			nftMetadataPublicURL, _ := cmd.Flags().GetString(FlagNftMetadataPublicURL)
+			if nftMetadataPublicURL == "" {
+				nftMetadataPublicURL = "https://storage.googleapis.com/" + nftMetadataBucket
+			}
 			environment, _ := cmd.Flags().GetString(FlagEnvironment)
 			keepLatestCommitSignatures, _ := cmd.Flags().GetInt64(FlagKeepLatestCommitSignatures)
 			rpcTimeOutInSeconds, _ := cmd.Flags().GetInt64(FlagRPCTimeoutInSeconds)
@@ -58,13 +105,31 @@ func IndexerCronCmd() *cobra.Command {
 				ValidatorUpdateIntervalInSeconds:       int64(validatorUpdateInterval),
 				ValidatorUptimeUpdateIntervalInSeconds: int64(validatorUptimeUpdateInterval),
 				ValidatorIdentityImageUpdateIntervalInSeconds: int64(validatorIdentityImageUpdateInterval),
-				Environment:                environment,
-				KeepLatestCommitSignatures: keepLatestCommitSignatures,
-				RPCTimeOutInSeconds:        rpcTimeOutInSeconds,
-				SentryDSN:                  sentryDSN,
-				CommitSHA:                  commitSHA,
-				SentryProfilesSampleRate:   sentryProfilesSampleRate,
-				SentryTracesSampleRate:     sentryTracesSampleRate,
+				ValidatorIdentityImageTTLInSeconds:            validatorIdentityImageTTL,
+				ValidatorIdentityImageProviders:               validatorIdentityImageProviders,
+				ValidatorIdentityImageRegistry:                validatorIdentityImageRegistry,
+				ValidatorIdentityImageChainRegistryURL:        validatorIdentityImageChainRegistry,
+				ValidatorIdentityImageBucket:                  validatorIdentityImageBucket,
+				ValidatorIdentityImagePublicURL:               validatorIdentityImagePublicURL,
+				ValidatorAlertIntervalInSeconds:               validatorAlertInterval,
+				ValidatorAlertMissStreak:                      validatorAlertMissStreak,
+				ValidatorAlertWindow:                          validatorAlertWindow,
+				ValidatorAlertMissRate:                        validatorAlertMissRate,
+				ValidatorAlertNotifier:                        validatorAlertNotifier,
+				ValidatorAlertWebhookURL:                      validatorAlertWebhookURL,
+				ProposalTallyIntervalInSeconds:                proposalTallyInterval,
+				ChainStatsIntervalInSeconds:                   chainStatsInterval,
+				NftMetadataIntervalInSeconds:                  nftMetadataInterval,
+				NftMetadataIPFSGateway:                        nftMetadataIPFSGateway,
+				NftMetadataBucket:                             nftMetadataBucket,
+				NftMetadataPublicURL:                          nftMetadataPublicURL,
+				Environment:                                   environment,
+				KeepLatestCommitSignatures:                    keepLatestCommitSignatures,
+				RPCTimeOutInSeconds:                           rpcTimeOutInSeconds,
+				SentryDSN:                                     sentryDSN,
+				CommitSHA:                                     commitSHA,
+				SentryProfilesSampleRate:                      sentryProfilesSampleRate,
+				SentryTracesSampleRate:                        sentryTracesSampleRate,
 			})
 
 			if err != nil {
@@ -92,6 +157,61 @@ func IndexerCronCmd() *cobra.Command {
 		validatorIdentityImageUpdateInterval = 600 // 10 minutes default
 	}
 
+	validatorIdentityImageTTL, err := strconv.ParseInt(os.Getenv("VALIDATOR_IDENTITY_IMAGE_TTL"), 10, 64)
+	if err != nil {
+		validatorIdentityImageTTL = 86400
+	}
+
+	validatorIdentityImageProviders := []string{"keybase"}
+	if providers := os.Getenv("VALIDATOR_IDENTITY_IMAGE_PROVIDERS"); providers != "" {
+		validatorIdentityImageProviders = strings.Split(providers, ",")
+	}
+
+	validatorAlertInterval, err := strconv.ParseInt(os.Getenv("VALIDATOR_ALERT_INTERVAL"), 10, 64)
+	if err != nil {
+		validatorAlertInterval = 60
+	}
+
+	validatorAlertMissStreak, err := strconv.ParseInt(os.Getenv("VALIDATOR_ALERT_MISS_STREAK"), 10, 64)
+	if err != nil {
+		validatorAlertMissStreak = 10
+	}
+
+	validatorAlertWindow, err := strconv.ParseInt(os.Getenv("VALIDATOR_ALERT_WINDOW"), 10, 64)
+	if err != nil {
+		validatorAlertWindow = 100
+	}
+
+	validatorAlertMissRate, err := strconv.ParseFloat(os.Getenv("VALIDATOR_ALERT_MISS_RATE"), 64)
+	if err != nil {
+		validatorAlertMissRate = 0.5
+	}
+
+	validatorAlertNotifier := os.Getenv("VALIDATOR_ALERT_NOTIFIER")
+	if validatorAlertNotifier == "" {
+		validatorAlertNotifier = "stdout"
+	}
+
+	proposalTallyInterval, err := strconv.ParseInt(os.Getenv("PROPOSAL_TALLY_INTERVAL"), 10, 64)
+	if err != nil {
+		proposalTallyInterval = 300
+	}
+
+	chainStatsInterval, err := strconv.ParseInt(os.Getenv("CHAIN_STATS_INTERVAL"), 10, 64)
+	if err != nil {
+		chainStatsInterval = 600
+	}
+
+	nftMetadataInterval, err := strconv.ParseInt(os.Getenv("NFT_METADATA_INTERVAL"), 10, 64)
+	if err != nil {
+		nftMetadataInterval = 300
+	}
+
+	nftMetadataIPFSGateway := os.Getenv("IPFS_GATEWAY")
+	if nftMetadataIPFSGateway == "" {
+		nftMetadataIPFSGateway = "https://ipfs.io/ipfs/"
+	}
+
 	keepLatestCommitSignatures, err := strconv.Atoi(os.Getenv("KEEP_LATEST_COMMIT_SIGNATURES"))
 	if err != nil {
 		keepLatestCommitSignatures = 11000
@@ -117,7 +237,25 @@ func IndexerCronCmd() *cobra.Command {
 	cmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID to run validator crons")
 	cmd.Flags().Int64(FlagValidatorUpdateInterval, int64(validatorUpdateInterval), "Interval to update validators")
 	cmd.Flags().Int64(FlagValidatorUptimeUpdateInterval, int64(validatorUptimeUpdateInterval), "Interval to update validators")
-	cmd.Flags().Int64(FlagValidatorIdentityImageUpdateInterval, int64(validatorIdentityImageUpdateInterval), "Interval to update validator identity images from Keybase")
+	cmd.Flags().Int64(FlagValidatorIdentityImageUpdateInterval, int64(validatorIdentityImageUpdateInterval), "Interval to refresh the validator identity images")
+	cmd.Flags().Int64(FlagValidatorIdentityImageTTL, validatorIdentityImageTTL, "Age in seconds after which a validator identity image is refreshed")
+	cmd.Flags().StringSlice(FlagValidatorIdentityImageProviders, validatorIdentityImageProviders, "Identity image providers in fallback order: keybase, registry or chain-registry")
+	cmd.Flags().String(FlagValidatorIdentityImageRegistry, os.Getenv("VALIDATOR_IDENTITY_IMAGE_REGISTRY"), "Path or URL of the JSON registry file mapping validator operator addresses or identities to image URLs")
+	cmd.Flags().String(FlagValidatorIdentityImageChainRegistry, os.Getenv("VALIDATOR_IDENTITY_IMAGE_CHAIN_REGISTRY"), "URL of the chain registry style document listing the validator logos")
+	cmd.Flags().String(FlagValidatorIdentityImageBucket, os.Getenv("VALIDATOR_IDENTITY_IMAGE_BUCKET"), "Bucket hosting the validator identity images, empty keeps the provider URLs")
+	cmd.Flags().String(FlagValidatorIdentityImagePublicURL, os.Getenv("VALIDATOR_IDENTITY_IMAGE_PUBLIC_URL"), "Public URL of the identity image bucket, defaults to its Google Cloud Storage URL")
+	cmd.Flags().Int64(FlagValidatorAlertInterval, validatorAlertInterval, "Interval to check validator missed blocks, 0 disables the alerts")
+	cmd.Flags().Int64(FlagValidatorAlertMissStreak, validatorAlertMissStreak, "Consecutive missed blocks opening a downtime episode")
+	cmd.Flags().Int64(FlagValidatorAlertWindow, validatorAlertWindow, "Number of latest blocks the missed-block rate is computed on")
+	cmd.Flags().Float64(FlagValidatorAlertMissRate, validatorAlertMissRate, "Missed-block rate over the window opening a downtime episode")
+	cmd.Flags().String(FlagValidatorAlertNotifier, validatorAlertNotifier, "Notifier of the downtime episodes: stdout or webhook")
+	cmd.Flags().String(FlagValidatorAlertWebhookURL, os.Getenv("VALIDATOR_ALERT_WEBHOOK_URL"), "Webhook URL of the webhook notifier")
+	cmd.Flags().Int64(FlagProposalTallyInterval, proposalTallyInterval, "Interval to snapshot the live tally of the proposals in voting period, 0 disables the snapshots")
+	cmd.Flags().Int64(FlagChainStatsInterval, chainStatsInterval, "Interval to roll up the chain activity per hour and per day, 0 disables the rollups")
+	cmd.Flags().Int64(FlagNftMetadataInterval, nftMetadataInterval, "Interval to fetch the off-chain metadata of the Nfts and the collections, 0 disables the fetches")
+	cmd.Flags().String(FlagNftMetadataIPFSGateway, nftMetadataIPFSGateway, "Gateway the ipfs:// metadata and images are fetched through")
+	cmd.Flags().String(FlagNftMetadataBucket, os.Getenv("NFT_METADATA_BUCKET"), "Bucket storing the Nft and collection thumbnails, empty disables the thumbnails")
+	cmd.Flags().String(FlagNftMetadataPublicURL, os.Getenv("NFT_METADATA_PUBLIC_URL"), "Public URL of the thumbnail bucket, defaults to its Google Cloud Storage URL")
 	cmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
 	cmd.Flags().Int64(FlagKeepLatestCommitSignatures, int64(keepLatestCommitSignatures), "Keep latest commit signatures")
 	cmd.Flags().Int64(FlagRPCTimeoutInSeconds, rpcTimeOutInSeconds, "RPC timeout in seconds")
@@ -20,6 +20,7 @@ func Execute() {
 
 	rootCmd.AddCommand(
 		indexer.IndexerCmd(),
+		indexer.ReindexCmd(),
 		indexercron.IndexerCronCmd(),
 	)
 
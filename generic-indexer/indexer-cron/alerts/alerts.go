@@ -0,0 +1,104 @@
+package alerts
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+// CheckMissedBlocks updates the downtime episodes from the commit signatures of the latest window,
+// then notifies the openings and resolutions not notified yet. An episode is notified once per status,
+// a failed notification is retried on the next check.
+func CheckMissedBlocks(ctx context.Context, dbClient *gorm.DB, notifier Notifier, chain string, config DetectorConfig, logger *zerolog.Logger) error {
+	if err := dbClient.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+		height, err := db.QueryLatestInformativeBlockHeight(ctx, dbTx)
+		if err != nil {
+			logger.Error().Msgf("Error querying latest block height: %v", err)
+			return err
+		}
+
+		votes, err := db.QueryValidatorCommitSignaturesFrom(ctx, dbTx, height-config.Window+1)
+		if err != nil {
+			logger.Error().Msgf("Error fetching validator commit signatures: %v", err)
+			return err
+		}
+
+		openEpisodes, err := db.QueryOpenValidatorDowntimeEpisodes(ctx, dbTx)
+		if err != nil {
+			logger.Error().Msgf("Error fetching open downtime episodes: %v", err)
+			return err
+		}
+
+		changes := Detect(config, votes, openEpisodes)
+		if err := db.SaveValidatorDowntimeEpisodes(ctx, dbTx, changes); err != nil {
+			logger.Error().Msgf("Error saving downtime episodes: %v", err)
+			return err
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	episodes, err := db.QueryUnnotifiedValidatorDowntimeEpisodes(ctx, dbClient)
+	if err != nil {
+		logger.Error().Msgf("Error fetching unnotified downtime episodes: %v", err)
+		return err
+	}
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	monikers, err := queryMonikers(ctx, dbClient, episodes)
+	if err != nil {
+		logger.Error().Msgf("Error fetching validator monikers: %v", err)
+		return err
+	}
+
+	for _, episode := range episodes {
+		if !episode.OpenedNotified {
+			if err := notifier.Notify(ctx, NewAlert(chain, AlertOpened, episode, monikers[episode.ValidatorAddress])); err != nil {
+				logger.Error().Msgf("Error notifying downtime episode %d: %v", episode.ID, err)
+				return err
+			}
+		}
+		if episode.IsResolved {
+			if err := notifier.Notify(ctx, NewAlert(chain, AlertResolved, episode, monikers[episode.ValidatorAddress])); err != nil {
+				logger.Error().Msgf("Error notifying resolved downtime episode %d: %v", episode.ID, err)
+				return err
+			}
+		}
+
+		if err := db.MarkValidatorDowntimeEpisodeNotified(ctx, dbClient, episode); err != nil {
+			logger.Error().Msgf("Error marking downtime episode %d as notified: %v", episode.ID, err)
+			return err
+		}
+	}
+
+	logger.Info().Msgf("Notified %d downtime episodes", len(episodes))
+	return nil
+}
+
+func queryMonikers(ctx context.Context, dbClient *gorm.DB, episodes []db.ValidatorDowntimeEpisode) (map[string]string, error) {
+	addresses := make([]string, 0, len(episodes))
+	for _, episode := range episodes {
+		addresses = append(addresses, episode.ValidatorAddress)
+	}
+
+	var validators []db.Validator
+	if err := dbClient.WithContext(ctx).
+		Model(&db.Validator{}).
+		Select("operator_address, moniker").
+		Where("operator_address IN ?", addresses).
+		Find(&validators).Error; err != nil {
+		return nil, err
+	}
+
+	monikers := make(map[string]string, len(validators))
+	for _, validator := range validators {
+		monikers[validator.OperatorAddress] = validator.Moniker
+	}
+	return monikers, nil
+}
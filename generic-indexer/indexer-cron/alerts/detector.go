@@ -0,0 +1,135 @@
+package alerts
+
+import (
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+// DetectorConfig holds the thresholds of the missed-block alerts
+type DetectorConfig struct {
+	// MissStreak is the number of consecutive missed blocks opening a streak episode
+	MissStreak int64
+	// Window is the number of latest blocks the missed-block rate is computed on
+	Window int64
+	// MissRate is the missed-block rate over the window opening a rate episode, between 0 and 1
+	MissRate float64
+}
+
+// Detect compares the commit signatures of the window, ordered by validator and height, with the open episodes.
+// It returns the episodes to save: new episodes, open episodes that grew and resolved episodes.
+func Detect(config DetectorConfig, votes []db.ValidatorCommitSignature, openEpisodes []db.ValidatorDowntimeEpisode) []db.ValidatorDowntimeEpisode {
+	open := make(map[string]map[db.DowntimeType]db.ValidatorDowntimeEpisode)
+	for _, episode := range openEpisodes {
+		if open[episode.ValidatorAddress] == nil {
+			open[episode.ValidatorAddress] = make(map[db.DowntimeType]db.ValidatorDowntimeEpisode)
+		}
+		open[episode.ValidatorAddress][db.DowntimeType(episode.Type)] = episode
+	}
+
+	changes := make([]db.ValidatorDowntimeEpisode, 0)
+	for start := 0; start < len(votes); {
+		end := start
+		for end < len(votes) && votes[end].ValidatorAddress == votes[start].ValidatorAddress {
+			end++
+		}
+
+		address := votes[start].ValidatorAddress
+		validatorVotes := votes[start:end]
+		if episode, ok := open[address][db.MissedBlockStreak]; ok {
+			changes = append(changes, detectStreak(config, address, validatorVotes, &episode)...)
+		} else {
+			changes = append(changes, detectStreak(config, address, validatorVotes, nil)...)
+		}
+		if episode, ok := open[address][db.MissedBlockRate]; ok {
+			changes = append(changes, detectRate(config, address, validatorVotes, &episode)...)
+		} else {
+			changes = append(changes, detectRate(config, address, validatorVotes, nil)...)
+		}
+
+		start = end
+	}
+
+	return changes
+}
+
+// detectStreak follows the run of missed blocks ending at the latest signature of the validator
+func detectStreak(config DetectorConfig, address string, votes []db.ValidatorCommitSignature, episode *db.ValidatorDowntimeEpisode) []db.ValidatorDowntimeEpisode {
+	streakStart := len(votes)
+	for streakStart > 0 && votes[streakStart-1].Vote == string(db.Absent) {
+		streakStart--
+	}
+	streak := votes[streakStart:]
+
+	changes := make([]db.ValidatorDowntimeEpisode, 0)
+	if episode != nil {
+		// the open episode goes on while the current streak continues it
+		if len(streak) > 0 && streak[0].BlockHeight <= episode.EndHeight+1 {
+			if missed := countMissedAfter(streak, episode.EndHeight); missed > 0 {
+				episode.EndHeight = streak[len(streak)-1].BlockHeight
+				episode.MissedCount += missed
+				changes = append(changes, *episode)
+			}
+			return changes
+		}
+
+		episode.IsResolved = true
+		changes = append(changes, *episode)
+	}
+
+	if int64(len(streak)) >= config.MissStreak {
+		changes = append(changes, db.ValidatorDowntimeEpisode{
+			ValidatorAddress: address,
+			Type:             string(db.MissedBlockStreak),
+			StartHeight:      streak[0].BlockHeight,
+			EndHeight:        streak[len(streak)-1].BlockHeight,
+			MissedCount:      int64(len(streak)),
+		})
+	}
+	return changes
+}
+
+// detectRate follows the missed blocks of the validator over the window
+func detectRate(config DetectorConfig, address string, votes []db.ValidatorCommitSignature, episode *db.ValidatorDowntimeEpisode) []db.ValidatorDowntimeEpisode {
+	missed := make([]db.ValidatorCommitSignature, 0)
+	for _, vote := range votes {
+		if vote.Vote == string(db.Absent) {
+			missed = append(missed, vote)
+		}
+	}
+	aboveRate := config.Window > 0 && float64(len(missed))/float64(config.Window) >= config.MissRate
+
+	if episode != nil {
+		if !aboveRate {
+			episode.IsResolved = true
+			return []db.ValidatorDowntimeEpisode{*episode}
+		}
+
+		if count := countMissedAfter(missed, episode.EndHeight); count > 0 {
+			episode.EndHeight = missed[len(missed)-1].BlockHeight
+			episode.MissedCount += count
+			return []db.ValidatorDowntimeEpisode{*episode}
+		}
+		return nil
+	}
+
+	if !aboveRate || len(missed) == 0 {
+		return nil
+	}
+	return []db.ValidatorDowntimeEpisode{{
+		ValidatorAddress: address,
+		Type:             string(db.MissedBlockRate),
+		StartHeight:      missed[0].BlockHeight,
+		EndHeight:        missed[len(missed)-1].BlockHeight,
+		MissedCount:      int64(len(missed)),
+	}}
+}
+
+// countMissedAfter counts the missed blocks above the given height
+func countMissedAfter(votes []db.ValidatorCommitSignature, height int64) int64 {
+	var count int64
+	for _, vote := range votes {
+		if vote.BlockHeight > height && vote.Vote == string(db.Absent) {
+			count++
+		}
+	}
+	return count
+}
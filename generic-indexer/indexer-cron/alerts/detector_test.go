@@ -0,0 +1,166 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+// signatures builds the commit signatures of a validator from the given height, x is a missed block
+func signatures(address string, from int64, votes string) []db.ValidatorCommitSignature {
+	out := make([]db.ValidatorCommitSignature, len(votes))
+	for idx, vote := range votes {
+		out[idx] = db.ValidatorCommitSignature{ValidatorAddress: address, BlockHeight: from + int64(idx), Vote: string(db.Vote)}
+		if vote == 'x' {
+			out[idx].Vote = string(db.Absent)
+		}
+	}
+	return out
+}
+
+func TestDetect(t *testing.T) {
+	config := DetectorConfig{MissStreak: 3, Window: 10, MissRate: 0.5}
+
+	tests := []struct {
+		name         string
+		votes        []db.ValidatorCommitSignature
+		openEpisodes []db.ValidatorDowntimeEpisode
+		expected     []db.ValidatorDowntimeEpisode
+	}{
+		{
+			name:     "signing validator",
+			votes:    signatures("val", 1, "..........."),
+			expected: []db.ValidatorDowntimeEpisode{},
+		},
+		{
+			name:  "opens a streak",
+			votes: signatures("val", 1, "..x....xxx"),
+			expected: []db.ValidatorDowntimeEpisode{
+				{ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 8, EndHeight: 10, MissedCount: 3},
+			},
+		},
+		{
+			name:     "streak below the threshold",
+			votes:    signatures("val", 1, ".......x.xx"),
+			expected: []db.ValidatorDowntimeEpisode{},
+		},
+		{
+			name:  "opens a streak and a rate episode",
+			votes: signatures("val", 1, "x.x.x.xxxx"),
+			expected: []db.ValidatorDowntimeEpisode{
+				{ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 7, EndHeight: 10, MissedCount: 4},
+				{ValidatorAddress: "val", Type: string(db.MissedBlockRate), StartHeight: 1, EndHeight: 10, MissedCount: 7},
+			},
+		},
+		{
+			name:  "extends an open streak",
+			votes: signatures("val", 5, "..xxxxxx"),
+			openEpisodes: []db.ValidatorDowntimeEpisode{
+				{ID: 1, ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 7, EndHeight: 9, MissedCount: 3, OpenedNotified: true},
+			},
+			expected: []db.ValidatorDowntimeEpisode{
+				{ID: 1, ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 7, EndHeight: 12, MissedCount: 6, OpenedNotified: true},
+				{ValidatorAddress: "val", Type: string(db.MissedBlockRate), StartHeight: 7, EndHeight: 12, MissedCount: 6},
+			},
+		},
+		{
+			name:  "open streak without new blocks",
+			votes: signatures("val", 5, "..xxx"),
+			openEpisodes: []db.ValidatorDowntimeEpisode{
+				{ID: 1, ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 7, EndHeight: 9, MissedCount: 3},
+			},
+			expected: []db.ValidatorDowntimeEpisode{},
+		},
+		{
+			name:  "resolves a streak once the validator signs",
+			votes: signatures("val", 5, "..xxx."),
+			openEpisodes: []db.ValidatorDowntimeEpisode{
+				{ID: 1, ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 7, EndHeight: 9, MissedCount: 3},
+			},
+			expected: []db.ValidatorDowntimeEpisode{
+				{ID: 1, ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 7, EndHeight: 9, MissedCount: 3, IsResolved: true},
+			},
+		},
+		{
+			name:  "replaces a streak broken between two checks",
+			votes: signatures("val", 5, "..xxx.xxx"),
+			openEpisodes: []db.ValidatorDowntimeEpisode{
+				{ID: 1, ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 7, EndHeight: 9, MissedCount: 3},
+			},
+			expected: []db.ValidatorDowntimeEpisode{
+				{ID: 1, ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 7, EndHeight: 9, MissedCount: 3, IsResolved: true},
+				{ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 11, EndHeight: 13, MissedCount: 3},
+				{ValidatorAddress: "val", Type: string(db.MissedBlockRate), StartHeight: 7, EndHeight: 13, MissedCount: 6},
+			},
+		},
+		{
+			name:  "resolves a rate episode below the rate",
+			votes: signatures("val", 11, "x.x......."),
+			openEpisodes: []db.ValidatorDowntimeEpisode{
+				{ID: 2, ValidatorAddress: "val", Type: string(db.MissedBlockRate), StartHeight: 1, EndHeight: 9, MissedCount: 6},
+			},
+			expected: []db.ValidatorDowntimeEpisode{
+				{ID: 2, ValidatorAddress: "val", Type: string(db.MissedBlockRate), StartHeight: 1, EndHeight: 9, MissedCount: 6, IsResolved: true},
+			},
+		},
+		{
+			name: "validators are independent",
+			votes: append(
+				signatures("val1", 1, ".........."),
+				signatures("val2", 1, ".......xxx")...,
+			),
+			expected: []db.ValidatorDowntimeEpisode{
+				{ValidatorAddress: "val2", Type: string(db.MissedBlockStreak), StartHeight: 8, EndHeight: 10, MissedCount: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Detect(config, tt.votes, tt.openEpisodes))
+		})
+	}
+}
+
+func TestStdoutNotifier(t *testing.T) {
+	var out bytes.Buffer
+	notifier := &StdoutNotifier{out: &out}
+
+	err := notifier.Notify(t.Context(), NewAlert("chain", AlertOpened, db.ValidatorDowntimeEpisode{
+		ID: 1, ValidatorAddress: "val", Type: string(db.MissedBlockStreak), StartHeight: 8, EndHeight: 10, MissedCount: 3,
+	}, "moniker"))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"chain":"chain","status":"opened","episode_id":1,"validator_address":"val","moniker":"moniker","type":"MissedBlockStreak","start_height":8,"end_height":10,"missed_count":3}`, out.String())
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	notifier, err := NewNotifier("webhook", server.URL)
+	assert.NoError(t, err)
+
+	alert := NewAlert("chain", AlertResolved, db.ValidatorDowntimeEpisode{ID: 2, ValidatorAddress: "val", Type: string(db.MissedBlockRate), IsResolved: true}, "")
+	assert.NoError(t, notifier.Notify(t.Context(), alert))
+	assert.Equal(t, alert, received)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	notifier, err = NewNotifier("webhook", failing.URL)
+	assert.NoError(t, err)
+	assert.Error(t, notifier.Notify(t.Context(), alert))
+}
@@ -0,0 +1,107 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+type AlertStatus string
+
+const (
+	AlertOpened   AlertStatus = "opened"
+	AlertResolved AlertStatus = "resolved"
+)
+
+// Alert is the notification of the opening or the resolution of a downtime episode
+type Alert struct {
+	Chain            string      `json:"chain"`
+	Status           AlertStatus `json:"status"`
+	EpisodeID        int64       `json:"episode_id"`
+	ValidatorAddress string      `json:"validator_address"`
+	Moniker          string      `json:"moniker"`
+	Type             string      `json:"type"`
+	StartHeight      int64       `json:"start_height"`
+	EndHeight        int64       `json:"end_height"`
+	MissedCount      int64       `json:"missed_count"`
+}
+
+func NewAlert(chain string, status AlertStatus, episode db.ValidatorDowntimeEpisode, moniker string) Alert {
+	return Alert{
+		Chain:            chain,
+		Status:           status,
+		EpisodeID:        episode.ID,
+		ValidatorAddress: episode.ValidatorAddress,
+		Moniker:          moniker,
+		Type:             episode.Type,
+		StartHeight:      episode.StartHeight,
+		EndHeight:        episode.EndHeight,
+		MissedCount:      episode.MissedCount,
+	}
+}
+
+// Notifier delivers alerts, an alert is retried until Notify succeeds
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// NewNotifier creates the notifier of the given kind: stdout or webhook
+func NewNotifier(kind, webhookURL string) (Notifier, error) {
+	switch kind {
+	case "stdout":
+		return &StdoutNotifier{out: os.Stdout}, nil
+	case "webhook":
+		if webhookURL == "" {
+			return nil, fmt.Errorf("webhook notifier requires a webhook URL")
+		}
+		return &WebhookNotifier{url: webhookURL, client: &http.Client{Timeout: 10 * time.Second}}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier: %s", kind)
+	}
+}
+
+// StdoutNotifier writes each alert as a JSON line
+type StdoutNotifier struct {
+	out io.Writer
+}
+
+func (n *StdoutNotifier) Notify(_ context.Context, alert Alert) error {
+	return json.NewEncoder(n.out).Encode(alert)
+}
+
+// WebhookNotifier posts each alert as JSON to a URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+type staticProvider struct {
+	name   string
+	images map[string]string
+	err    error
+}
+
+func (p *staticProvider) Name() string {
+	return p.name
+}
+
+func (p *staticProvider) ImageURL(_ context.Context, validator Validator) (string, error) {
+	return p.images[validator.OperatorAddress], p.err
+}
+
+func TestNewProviders(t *testing.T) {
+	providers, err := NewProviders([]string{"registry", " keybase", "chain-registry"}, http.DefaultClient, ProviderConfig{Registry: "registry.json", ChainRegistryURL: "https://registry.test/chain.json"})
+	require.NoError(t, err)
+	names := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		names = append(names, provider.Name())
+	}
+	assert.Equal(t, []string{ProviderRegistry, ProviderKeybase, ProviderChainRegistry}, names)
+
+	_, err = NewProviders([]string{"registry"}, http.DefaultClient, ProviderConfig{})
+	assert.Error(t, err)
+
+	_, err = NewProviders([]string{"gravatar"}, http.DefaultClient, ProviderConfig{})
+	assert.Error(t, err)
+
+	_, err = NewProviders(nil, http.DefaultClient, ProviderConfig{})
+	assert.Error(t, err)
+}
+
+func TestKeybaseProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key_suffix") == "ABCDEF0123456789" {
+			_, _ = w.Write([]byte(`{"them":[{"pictures":{"primary":{"url":"https://s3.test/avatar.jpg"}}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"them":[]}`))
+	}))
+	defer server.Close()
+	provider := NewKeybaseProvider(server.Client(), server.URL)
+	ctx := context.Background()
+
+	image, err := provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1a", Identity: "ABCDEF0123456789"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://s3.test/avatar.jpg", image)
+
+	image, err = provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1b", Identity: "0000000000000000"})
+	require.NoError(t, err)
+	assert.Empty(t, image)
+
+	image, err = provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1c"})
+	require.NoError(t, err)
+	assert.Empty(t, image)
+}
+
+func TestRegistryProvider(t *testing.T) {
+	registry := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, os.WriteFile(registry, []byte(`{"initvaloper1a": "https://cdn.test/a.png", "ABCDEF0123456789": "https://cdn.test/identity.png"}`), 0o600))
+	provider := NewRegistryProvider(http.DefaultClient, registry)
+	ctx := context.Background()
+
+	// not loaded yet
+	_, err := provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1a"})
+	assert.Error(t, err)
+
+	require.NoError(t, provider.Load(ctx))
+	image, err := provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1a", Identity: "ABCDEF0123456789"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.test/a.png", image)
+
+	image, err = provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1b", Identity: "ABCDEF0123456789"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.test/identity.png", image)
+
+	image, err = provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1c"})
+	require.NoError(t, err)
+	assert.Empty(t, image)
+
+	require.NoError(t, os.Remove(registry))
+	assert.Error(t, provider.Load(ctx))
+	_, err = provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1a"})
+	assert.Error(t, err)
+}
+
+func TestChainRegistryProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"validators": [
+			{"operator_address": "initvaloper1a", "logo_URIs": {"svg": "https://cdn.test/a.svg", "png": "https://cdn.test/a.png"}},
+			{"identity": "ABCDEF0123456789", "logo_URIs": {"svg": "https://cdn.test/b.svg"}},
+			{"operator_address": "initvaloper1c", "logo_URIs": {}}
+		]}`))
+	}))
+	defer server.Close()
+	provider := NewChainRegistryProvider(server.Client(), server.URL)
+	ctx := context.Background()
+
+	require.NoError(t, provider.Load(ctx))
+	image, err := provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1a"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.test/a.png", image)
+
+	image, err = provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1b", Identity: "ABCDEF0123456789"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.test/b.svg", image)
+
+	image, err = provider.ImageURL(ctx, Validator{OperatorAddress: "initvaloper1c"})
+	require.NoError(t, err)
+	assert.Empty(t, image)
+}
+
+type fakeStorage struct {
+	storage.Client
+	uploads map[string][]byte
+}
+
+func (s *fakeStorage) UploadFile(bucket string, objectPath string, message []byte) error {
+	s.uploads[bucket+"/"+objectPath] = message
+	return nil
+}
+
+func (s *fakeStorage) ReadFile(bucket string, objectPath string) ([]byte, error) {
+	return s.uploads[bucket+"/"+objectPath], nil
+}
+
+func TestRefresherResolve(t *testing.T) {
+	failing := &staticProvider{name: "failing", err: errors.New("unavailable")}
+	first := &staticProvider{name: "first", images: map[string]string{"initvaloper1a": "https://first.test/a.png"}}
+	second := &staticProvider{name: "second", images: map[string]string{"initvaloper1a": "https://second.test/a.png", "initvaloper1b": "https://second.test/b.png"}}
+	ctx := context.Background()
+
+	refresher := NewRefresher(nil, nil, nil, nil, []Provider{failing, first, second}, Config{})
+
+	// the first provider having an image wins, a failing provider is skipped
+	image, err := refresher.resolve(ctx, Validator{OperatorAddress: "initvaloper1a"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://first.test/a.png", image)
+
+	image, err = refresher.resolve(ctx, Validator{OperatorAddress: "initvaloper1b"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://second.test/b.png", image)
+
+	// no image, but a provider failed: the validator is retried rather than losing its image
+	_, err = refresher.resolve(ctx, Validator{OperatorAddress: "initvaloper1c"})
+	assert.ErrorContains(t, err, "failing: unavailable")
+
+	refresher = NewRefresher(nil, nil, nil, nil, []Provider{first, second}, Config{})
+	image, err = refresher.resolve(ctx, Validator{OperatorAddress: "initvaloper1c"})
+	require.NoError(t, err)
+	assert.Empty(t, image)
+}
+
+func TestRefresherRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("image " + r.URL.Path))
+	}))
+	defer server.Close()
+	provider := &staticProvider{name: "static", images: map[string]string{"initvaloper1a": server.URL + "/a.png", "initvaloper1b": server.URL + "/b.png"}}
+	normalize := func(data []byte) []byte {
+		if string(data) == "image /b.png" {
+			return nil
+		}
+		return append([]byte("jpeg "), data...)
+	}
+	storageClient := &fakeStorage{uploads: make(map[string][]byte)}
+	ctx := context.Background()
+
+	refresher := NewRefresher(nil, server.Client(), storageClient, normalize, []Provider{provider}, Config{Bucket: "images", PublicURL: "https://cdn.test/images/"})
+	hosted, err := refresher.refresh(ctx, Validator{OperatorAddress: "initvaloper1a"}, "")
+	require.NoError(t, err)
+	assert.Regexp(t, `^https://cdn\.test/images/validators/initvaloper1a/[0-9a-f]{16}\.jpg$`, hosted)
+	assert.Len(t, storageClient.uploads, 1)
+
+	// an unchanged image is not uploaded again
+	storageClient.uploads = make(map[string][]byte)
+	again, err := refresher.refresh(ctx, Validator{OperatorAddress: "initvaloper1a"}, hosted)
+	require.NoError(t, err)
+	assert.Equal(t, hosted, again)
+	assert.Empty(t, storageClient.uploads)
+
+	_, err = refresher.refresh(ctx, Validator{OperatorAddress: "initvaloper1b"}, "")
+	assert.ErrorContains(t, err, "unsupported image")
+
+	hosted, err = refresher.refresh(ctx, Validator{OperatorAddress: "initvaloper1c"}, "")
+	require.NoError(t, err)
+	assert.Empty(t, hosted)
+
+	// without a bucket the provider URL is kept
+	refresher = NewRefresher(nil, server.Client(), nil, normalize, []Provider{provider}, Config{})
+	hosted, err = refresher.refresh(ctx, Validator{OperatorAddress: "initvaloper1a"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/a.png", hosted)
+}
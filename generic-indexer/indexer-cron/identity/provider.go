@@ -0,0 +1,280 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	ProviderKeybase       = "keybase"
+	ProviderRegistry      = "registry"
+	ProviderChainRegistry = "chain-registry"
+
+	defaultKeybaseURL = "https://keybase.io"
+	maxDocumentSize   = 10 << 20
+)
+
+// Validator is the validator whose identity image is resolved
+type Validator struct {
+	OperatorAddress string
+	Identity        string
+}
+
+// Provider resolves the image URL of a validator, returning an empty URL when it has none
+type Provider interface {
+	Name() string
+	ImageURL(ctx context.Context, validator Validator) (string, error)
+}
+
+// Loader is implemented by the providers reading a registry document, which is loaded again before every refresh
+type Loader interface {
+	Load(ctx context.Context) error
+}
+
+// ProviderConfig configures the providers built by NewProviders
+type ProviderConfig struct {
+	// Registry is the path or the https URL of the registry file
+	Registry string
+	// ChainRegistryURL is the https URL of the chain registry document
+	ChainRegistryURL string
+}
+
+// NewProviders builds the providers named in order, the first one resolving an image wins
+func NewProviders(names []string, httpClient *http.Client, config ProviderConfig) ([]Provider, error) {
+	providers := make([]Provider, 0, len(names))
+	for _, name := range names {
+		switch strings.TrimSpace(name) {
+		case ProviderKeybase:
+			providers = append(providers, NewKeybaseProvider(httpClient, defaultKeybaseURL))
+		case ProviderRegistry:
+			if config.Registry == "" {
+				return nil, fmt.Errorf("the %s provider requires a registry file", ProviderRegistry)
+			}
+			providers = append(providers, NewRegistryProvider(httpClient, config.Registry))
+		case ProviderChainRegistry:
+			if config.ChainRegistryURL == "" {
+				return nil, fmt.Errorf("the %s provider requires a chain registry URL", ProviderChainRegistry)
+			}
+			providers = append(providers, NewChainRegistryProvider(httpClient, config.ChainRegistryURL))
+		default:
+			return nil, fmt.Errorf("unknown identity image provider %q", name)
+		}
+	}
+
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no identity image provider")
+	}
+	return providers, nil
+}
+
+// KeybaseProvider resolves the Keybase profile picture of the validator identity
+type KeybaseProvider struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+func NewKeybaseProvider(httpClient *http.Client, baseURL string) *KeybaseProvider {
+	return &KeybaseProvider{httpClient: httpClient, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (p *KeybaseProvider) Name() string {
+	return ProviderKeybase
+}
+
+func (p *KeybaseProvider) ImageURL(ctx context.Context, validator Validator) (string, error) {
+	if validator.Identity == "" {
+		return "", nil
+	}
+
+	var response struct {
+		Them []struct {
+			Pictures struct {
+				Primary struct {
+					URL string `json:"url"`
+				} `json:"primary"`
+			} `json:"pictures"`
+		} `json:"them"`
+	}
+	lookupURL := fmt.Sprintf("%s/_/api/1.0/user/lookup.json?key_suffix=%s&fields=pictures", p.baseURL, url.QueryEscape(validator.Identity))
+	if err := readJSON(ctx, p.httpClient, lookupURL, &response); err != nil {
+		return "", err
+	}
+
+	if len(response.Them) == 0 {
+		return "", nil
+	}
+	return response.Them[0].Pictures.Primary.URL, nil
+}
+
+// RegistryProvider resolves the image URLs listed in a registry file, a JSON object mapping validator operator
+// addresses or identities to image URLs
+type RegistryProvider struct {
+	registryImages
+	httpClient *http.Client
+	source     string
+}
+
+func NewRegistryProvider(httpClient *http.Client, source string) *RegistryProvider {
+	return &RegistryProvider{httpClient: httpClient, source: source}
+}
+
+func (p *RegistryProvider) Name() string {
+	return ProviderRegistry
+}
+
+func (p *RegistryProvider) Load(ctx context.Context) error {
+	images := make(map[string]string)
+	err := readJSON(ctx, p.httpClient, p.source, &images)
+	p.set(images, err)
+	return err
+}
+
+// ChainRegistryProvider resolves the logos of a chain registry style document listing the validators of the chain:
+// {"validators": [{"operator_address": "...", "identity": "...", "logo_URIs": {"png": "...", "jpeg": "...", "svg": "..."}}]}
+type ChainRegistryProvider struct {
+	registryImages
+	httpClient *http.Client
+	source     string
+}
+
+func NewChainRegistryProvider(httpClient *http.Client, source string) *ChainRegistryProvider {
+	return &ChainRegistryProvider{httpClient: httpClient, source: source}
+}
+
+func (p *ChainRegistryProvider) Name() string {
+	return ProviderChainRegistry
+}
+
+func (p *ChainRegistryProvider) Load(ctx context.Context) error {
+	var document struct {
+		Validators []struct {
+			OperatorAddress string `json:"operator_address"`
+			Identity        string `json:"identity"`
+			LogoURIs        struct {
+				PNG  string `json:"png"`
+				JPEG string `json:"jpeg"`
+				SVG  string `json:"svg"`
+			} `json:"logo_URIs"`
+		} `json:"validators"`
+	}
+	err := readJSON(ctx, p.httpClient, p.source, &document)
+
+	// raster logos are preferred, the svg ones need libvips to be built with svg support
+	images := make(map[string]string)
+	for _, validator := range document.Validators {
+		image := validator.LogoURIs.PNG
+		if image == "" {
+			image = validator.LogoURIs.JPEG
+		}
+		if image == "" {
+			image = validator.LogoURIs.SVG
+		}
+		if image == "" {
+			continue
+		}
+
+		if validator.OperatorAddress != "" {
+			images[validator.OperatorAddress] = image
+		}
+		if validator.Identity != "" {
+			images[validator.Identity] = image
+		}
+	}
+
+	p.set(images, err)
+	return err
+}
+
+// registryImages holds the image URLs of a loaded registry document, by validator operator address and identity
+type registryImages struct {
+	mu     sync.RWMutex
+	images map[string]string
+	err    error
+}
+
+func (r *registryImages) set(images map[string]string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.images, r.err = images, err
+}
+
+// ImageURL returns the image listed for the operator address of the validator, or else for its identity. It fails
+// when the document could not be loaded.
+func (r *registryImages) ImageURL(_ context.Context, validator Validator) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.err != nil {
+		return "", r.err
+	}
+	if r.images == nil {
+		return "", fmt.Errorf("registry not loaded")
+	}
+
+	if image, ok := r.images[validator.OperatorAddress]; ok {
+		return image, nil
+	}
+	if validator.Identity == "" {
+		return "", nil
+	}
+	return r.images[validator.Identity], nil
+}
+
+// readJSON decodes a JSON document read from an http(s) URL or from a file
+func readJSON(ctx context.Context, httpClient *http.Client, source string, out any) error {
+	var data []byte
+	var err error
+	if isHTTPURL(source) {
+		data, err = fetch(ctx, httpClient, source, maxDocumentSize)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid document %s: %w", source, err)
+	}
+	return nil
+}
+
+func isHTTPURL(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
+
+// fetch reads up to maxSize bytes from an http(s) URL
+func fetch(ctx context.Context, httpClient *http.Client, source string, maxSize int64) ([]byte, error) {
+	if !isHTTPURL(source) {
+		return nil, fmt.Errorf("unsupported url %s", source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s returned status %d", source, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%s exceeds %d bytes", source, maxSize)
+	}
+	return data, nil
+}
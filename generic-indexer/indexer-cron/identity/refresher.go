@@ -0,0 +1,153 @@
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+const maxImageSize = 5 << 20
+
+type Config struct {
+	// Bucket hosts the images, the provider URLs are stored as they are when it is empty
+	Bucket string
+	// PublicURL is the URL the objects of the bucket are served from
+	PublicURL string
+	// TTL is the age after which an image is resolved again
+	TTL time.Duration
+}
+
+// Refresher resolves the identity images of the validators through the providers, in order, and hosts them
+type Refresher struct {
+	dbClient      *gorm.DB
+	httpClient    *http.Client
+	storageClient storage.Client
+	normalize     func([]byte) []byte
+	providers     []Provider
+	config        Config
+}
+
+// NewRefresher creates a Refresher, normalize converts an image to the JPEG stored in the bucket and returns nil when
+// the image cannot be decoded
+func NewRefresher(dbClient *gorm.DB, httpClient *http.Client, storageClient storage.Client, normalize func([]byte) []byte, providers []Provider, config Config) *Refresher {
+	return &Refresher{
+		dbClient:      dbClient,
+		httpClient:    httpClient,
+		storageClient: storageClient,
+		normalize:     normalize,
+		providers:     providers,
+		config:        config,
+	}
+}
+
+// Run resolves the images of the validators whose identity changed or whose image is older than the TTL. A validator
+// failing to resolve keeps its image and is retried on the next run.
+func (r *Refresher) Run(ctx context.Context, logger *zerolog.Logger) error {
+	now := time.Now().UTC()
+
+	validators, err := db.QueryValidatorsDueIdentityImage(ctx, r.dbClient, now.Add(-r.config.TTL))
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to query validators for image update")
+		return err
+	}
+	if len(validators) == 0 {
+		logger.Debug().Msg("No validator identity image to refresh, skipping")
+		return nil
+	}
+
+	for _, provider := range r.providers {
+		if loader, ok := provider.(Loader); ok {
+			if err := loader.Load(ctx); err != nil {
+				logger.Warn().Err(err).Msgf("Failed to load the %s identity image provider", provider.Name())
+			}
+		}
+	}
+
+	updates := make([]db.Validator, 0, len(validators))
+	failed := 0
+	for _, validator := range validators {
+		imageURL, err := r.refresh(ctx, Validator{OperatorAddress: validator.OperatorAddress, Identity: validator.Identity}, validator.IdentityImageURL)
+		if err != nil {
+			logger.Warn().Err(err).Msgf("Failed to refresh identity image of validator %s", validator.OperatorAddress)
+			failed++
+			continue
+		}
+
+		updates = append(updates, db.Validator{
+			OperatorAddress:       validator.OperatorAddress,
+			IdentityImageURL:      imageURL,
+			IdentityImageIdentity: validator.Identity,
+		})
+	}
+
+	if err := db.UpdateValidatorIdentityImages(ctx, r.dbClient, updates, now); err != nil {
+		logger.Error().Err(err).Msg("Failed to update validator identity images")
+		return err
+	}
+
+	logger.Info().Msgf("Refreshed identity images of %d validators, %d failed", len(updates), failed)
+	return nil
+}
+
+// refresh resolves the image of a validator and returns its hosted URL, or an empty URL when no provider has one
+func (r *Refresher) refresh(ctx context.Context, validator Validator, currentURL string) (string, error) {
+	imageURL, err := r.resolve(ctx, validator)
+	if err != nil || imageURL == "" {
+		return "", err
+	}
+
+	if r.config.Bucket == "" || r.storageClient == nil {
+		return imageURL, nil
+	}
+
+	data, err := fetch(ctx, r.httpClient, imageURL, maxImageSize)
+	if err != nil {
+		return "", err
+	}
+	normalized := r.normalize(data)
+	if normalized == nil {
+		return "", fmt.Errorf("unsupported image %s", imageURL)
+	}
+
+	// the object is named after its content, so a changed image gets a new URL and an unchanged one is not uploaded again
+	hash := sha256.Sum256(normalized)
+	objectPath := fmt.Sprintf("validators/%s/%s.jpg", validator.OperatorAddress, hex.EncodeToString(hash[:8]))
+	hostedURL := strings.TrimSuffix(r.config.PublicURL, "/") + "/" + objectPath
+	if hostedURL == currentURL {
+		return hostedURL, nil
+	}
+
+	if err := r.storageClient.UploadFile(r.config.Bucket, objectPath, normalized); err != nil {
+		return "", err
+	}
+	return hostedURL, nil
+}
+
+// resolve returns the image URL of the first provider having one. Providers failing are skipped, but their error is
+// returned when no later provider has an image, so that the validator is retried instead of losing its image.
+func (r *Refresher) resolve(ctx context.Context, validator Validator) (string, error) {
+	var errs []error
+	for _, provider := range r.providers {
+		imageURL, err := provider.ImageURL(ctx, validator)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+			continue
+		}
+		if imageURL != "" {
+			return imageURL, nil
+		}
+	}
+
+	return "", errors.Join(errs...)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os/signal"
 	"syscall"
 	"time"
@@ -18,16 +19,30 @@ import (
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 
+	"github.com/initia-labs/core-indexer/generic-indexer/indexer-cron/alerts"
+	"github.com/initia-labs/core-indexer/generic-indexer/indexer-cron/identity"
+	"github.com/initia-labs/core-indexer/generic-indexer/indexer-cron/nftmetadata"
 	"github.com/initia-labs/core-indexer/pkg/cosmosrpc"
 	"github.com/initia-labs/core-indexer/pkg/db"
 	"github.com/initia-labs/core-indexer/pkg/mq"
 	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+const (
+	// nftMetadataBatchSize bounds the Nfts and the collections whose metadata is fetched by a single updateNftMetadata run
+	nftMetadataBatchSize = 100
+	// httpTimeout bounds the requests of the jobs fetching off-chain data
+	httpTimeout = 15 * time.Second
 )
 
 type IndexerCron struct {
 	dbClient           *gorm.DB
 	rpcClient          cosmosrpc.CosmosJSONRPCHub
 	interfaceRegistry  codectypes.InterfaceRegistry
+	notifier           alerts.Notifier
+	nftMetadataFetcher *nftmetadata.Fetcher
+	identityRefresher  *identity.Refresher
 	DBConnectionString string
 	config             *IndexerCronConfig
 }
@@ -39,6 +54,24 @@ type IndexerCronConfig struct {
 	ValidatorUpdateIntervalInSeconds              int64
 	ValidatorUptimeUpdateIntervalInSeconds        int64
 	ValidatorIdentityImageUpdateIntervalInSeconds int64
+	ValidatorIdentityImageTTLInSeconds            int64
+	ValidatorIdentityImageProviders               []string
+	ValidatorIdentityImageRegistry                string
+	ValidatorIdentityImageChainRegistryURL        string
+	ValidatorIdentityImageBucket                  string
+	ValidatorIdentityImagePublicURL               string
+	ValidatorAlertIntervalInSeconds               int64
+	ValidatorAlertMissStreak                      int64
+	ValidatorAlertWindow                          int64
+	ValidatorAlertMissRate                        float64
+	ValidatorAlertNotifier                        string
+	ValidatorAlertWebhookURL                      string
+	ProposalTallyIntervalInSeconds                int64
+	ChainStatsIntervalInSeconds                   int64
+	NftMetadataIntervalInSeconds                  int64
+	NftMetadataIPFSGateway                        string
+	NftMetadataBucket                             string
+	NftMetadataPublicURL                          string
 	Environment                                   string
 	KeepLatestCommitSignatures                    int64
 	RPCTimeOutInSeconds                           int64
@@ -111,6 +144,56 @@ func New(config *IndexerCronConfig) (*IndexerCron, error) {
 		return nil, err
 	}
 
+	notifier, err := alerts.NewNotifier(config.ValidatorAlertNotifier, config.ValidatorAlertWebhookURL)
+	if err != nil {
+		logger.Fatal().Msgf("Alerts: Error creating notifier: %v", err)
+		return nil, err
+	}
+
+	var storageClient storage.Client
+	if config.ValidatorIdentityImageBucket != "" || (config.NftMetadataIntervalInSeconds > 0 && config.NftMetadataBucket != "") {
+		if config.Environment == "local" {
+			storageClient, err = storage.NewGCSFakeClient()
+		} else {
+			storageClient, err = storage.NewGCSClient()
+		}
+		if err != nil {
+			sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+			logger.Fatal().Msgf("Storage: Error creating storage client: %v", err)
+			return nil, err
+		}
+	}
+
+	httpClient := &http.Client{Timeout: httpTimeout}
+
+	var nftMetadataFetcher *nftmetadata.Fetcher
+	if config.NftMetadataIntervalInSeconds > 0 {
+		nftMetadataFetcher = nftmetadata.NewFetcher(dbClient, nftmetadata.NewClient(httpTimeout), storageClient, func(data []byte) []byte {
+			return normalizeImageToJPEG(data, nftThumbnailSize)
+		}, nftmetadata.Config{
+			IPFSGateway: config.NftMetadataIPFSGateway,
+			Bucket:      config.NftMetadataBucket,
+			PublicURL:   config.NftMetadataPublicURL,
+			BatchSize:   nftMetadataBatchSize,
+		})
+	}
+
+	identityProviders, err := identity.NewProviders(config.ValidatorIdentityImageProviders, httpClient, identity.ProviderConfig{
+		Registry:         config.ValidatorIdentityImageRegistry,
+		ChainRegistryURL: config.ValidatorIdentityImageChainRegistryURL,
+	})
+	if err != nil {
+		logger.Fatal().Msgf("Identity: Error creating identity image providers: %v", err)
+		return nil, err
+	}
+	identityRefresher := identity.NewRefresher(dbClient, httpClient, storageClient, func(data []byte) []byte {
+		return normalizeImageToJPEG(data, avatarSize)
+	}, identityProviders, identity.Config{
+		Bucket:    config.ValidatorIdentityImageBucket,
+		PublicURL: config.ValidatorIdentityImagePublicURL,
+		TTL:       time.Duration(config.ValidatorIdentityImageTTLInSeconds) * time.Second,
+	})
+
 	sdkConfig := types.GetConfig()
 	sdkConfig.SetCoinType(initiaapp.CoinType)
 
@@ -127,10 +210,13 @@ func New(config *IndexerCronConfig) (*IndexerCron, error) {
 	sdkConfig.Seal()
 
 	return &IndexerCron{
-		rpcClient:         rpcClient,
-		dbClient:          dbClient,
-		config:            config,
-		interfaceRegistry: initiaapp.MakeEncodingConfig().InterfaceRegistry,
+		rpcClient:          rpcClient,
+		dbClient:           dbClient,
+		config:             config,
+		interfaceRegistry:  initiaapp.MakeEncodingConfig().InterfaceRegistry,
+		notifier:           notifier,
+		nftMetadataFetcher: nftMetadataFetcher,
+		identityRefresher:  identityRefresher,
 	}, nil
 }
 
@@ -194,13 +280,7 @@ func (v *IndexerCron) Run() {
 
 	updateValidatorIdentityImagesHub, updateValidatorIdentityImagesCtx := createCronHubAndContext("updateValidatorIdentityImages")
 	if _, err := c.AddFunc(fmt.Sprintf("@every %ds", v.config.ValidatorIdentityImageUpdateIntervalInSeconds), func() {
-		logger := zerolog.Ctx(log.With().
-			Str("component", "indexer-cron").
-			Str("function_name", "updateValidatorIdentityImages").
-			Str("chain", v.config.Chain).
-			Str("environment", v.config.Environment).
-			Logger().WithContext(updateValidatorIdentityImagesCtx))
-		err := updateValidatorImages(updateValidatorIdentityImagesCtx, v.dbClient, logger)
+		err := updateValidatorIdentityImages(updateValidatorIdentityImagesCtx, v.identityRefresher, v.config)
 		if err != nil {
 			sentry_integration.CaptureException(updateValidatorIdentityImagesHub, err, sentry.LevelError)
 		}
@@ -208,6 +288,54 @@ func (v *IndexerCron) Run() {
 		log.Error().Err(err).Msg("cron: failed to schedule updateValidatorIdentityImages")
 	}
 
+	if v.config.ValidatorAlertIntervalInSeconds > 0 {
+		checkValidatorMissedBlocksHub, checkValidatorMissedBlocksCtx := createCronHubAndContext("checkValidatorMissedBlocks")
+		if _, err := c.AddFunc(fmt.Sprintf("@every %ds", v.config.ValidatorAlertIntervalInSeconds), func() {
+			err := checkValidatorMissedBlocks(checkValidatorMissedBlocksCtx, v.dbClient, v.notifier, v.config)
+			if err != nil {
+				sentry_integration.CaptureException(checkValidatorMissedBlocksHub, err, sentry.LevelError)
+			}
+		}); err != nil {
+			log.Error().Err(err).Msg("cron: failed to schedule checkValidatorMissedBlocks")
+		}
+	}
+
+	if v.config.ProposalTallyIntervalInSeconds > 0 {
+		updateProposalTalliesHub, updateProposalTalliesCtx := createCronHubAndContext("updateProposalTallies")
+		if _, err := c.AddFunc(fmt.Sprintf("@every %ds", v.config.ProposalTallyIntervalInSeconds), func() {
+			err := updateProposalTallies(updateProposalTalliesCtx, v.dbClient, v.rpcClient, v.interfaceRegistry, v.config)
+			if err != nil {
+				sentry_integration.CaptureException(updateProposalTalliesHub, err, sentry.LevelError)
+			}
+		}); err != nil {
+			log.Error().Err(err).Msg("cron: failed to schedule updateProposalTallies")
+		}
+	}
+
+	if v.config.ChainStatsIntervalInSeconds > 0 {
+		updateChainStatsHub, updateChainStatsCtx := createCronHubAndContext("updateChainStats")
+		if _, err := c.AddFunc(fmt.Sprintf("@every %ds", v.config.ChainStatsIntervalInSeconds), func() {
+			err := updateChainStats(updateChainStatsCtx, v.dbClient, v.config)
+			if err != nil {
+				sentry_integration.CaptureException(updateChainStatsHub, err, sentry.LevelError)
+			}
+		}); err != nil {
+			log.Error().Err(err).Msg("cron: failed to schedule updateChainStats")
+		}
+	}
+
+	if v.nftMetadataFetcher != nil {
+		updateNftMetadataHub, updateNftMetadataCtx := createCronHubAndContext("updateNftMetadata")
+		if _, err := c.AddFunc(fmt.Sprintf("@every %ds", v.config.NftMetadataIntervalInSeconds), func() {
+			err := updateNftMetadata(updateNftMetadataCtx, v.nftMetadataFetcher, v.config)
+			if err != nil {
+				sentry_integration.CaptureException(updateNftMetadataHub, err, sentry.LevelError)
+			}
+		}); err != nil {
+			log.Error().Err(err).Msg("cron: failed to schedule updateNftMetadata")
+		}
+	}
+
 	// Start the Cron job scheduler
 	c.Start()
 
@@ -2,24 +2,23 @@ package indexercron
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 	"sort"
-	"sync"
 	"time"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 	"github.com/h2non/bimg"
+	govkeeper "github.com/initia-labs/initia/x/gov/keeper"
 	mstakingtypes "github.com/initia-labs/initia/x/mstaking/types"
 	vmtypes "github.com/initia-labs/movevm/types"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 
+	"github.com/initia-labs/core-indexer/generic-indexer/indexer-cron/alerts"
+	"github.com/initia-labs/core-indexer/generic-indexer/indexer-cron/identity"
+	"github.com/initia-labs/core-indexer/generic-indexer/indexer-cron/nftmetadata"
 	"github.com/initia-labs/core-indexer/pkg/cosmosrpc"
 	"github.com/initia-labs/core-indexer/pkg/db"
 	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
@@ -142,6 +141,209 @@ func updateValidatorUptimeLast10000(parentCtx context.Context, dbClient *gorm.DB
 	return nil
 }
 
+// checkValidatorMissedBlocks records the missed-block streaks and rates of the validators as downtime episodes and notifies them.
+func checkValidatorMissedBlocks(parentCtx context.Context, dbClient *gorm.DB, notifier alerts.Notifier, config *IndexerCronConfig) error {
+	transaction, ctx := sentry_integration.StartSentryTransaction(parentCtx, "checkValidatorMissedBlocks", "Detect and notify validator downtime episodes")
+	defer transaction.Finish()
+	logger := zerolog.Ctx(log.With().
+		Str("component", "indexer-cron").
+		Str("function_name", "checkValidatorMissedBlocks").
+		Str("chain", config.Chain).
+		Str("environment", config.Environment).
+		Logger().
+		WithContext(ctx))
+
+	logger.Info().Msg("Starting checkValidatorMissedBlocks task ...")
+
+	if err := alerts.CheckMissedBlocks(ctx, dbClient, notifier, config.Chain, alerts.DetectorConfig{
+		MissStreak: config.ValidatorAlertMissStreak,
+		Window:     config.ValidatorAlertWindow,
+		MissRate:   config.ValidatorAlertMissRate,
+	}, logger); err != nil {
+		return err
+	}
+
+	logger.Info().Msg("Successfully checked validator missed blocks")
+	return nil
+}
+
+// updateProposalTallies stores a snapshot of the live tally of every proposal in voting period.
+func updateProposalTallies(parentCtx context.Context, dbClient *gorm.DB, rpcClient cosmosrpc.CosmosJSONRPCHub, interfaceRegistry codectypes.InterfaceRegistry, config *IndexerCronConfig) error {
+	transaction, ctx := sentry_integration.StartSentryTransaction(parentCtx, "updateProposalTallies", "Snapshot the live tally of the proposals in voting period")
+	defer transaction.Finish()
+	logger := zerolog.Ctx(log.With().
+		Str("component", "indexer-cron").
+		Str("function_name", "updateProposalTallies").
+		Str("chain", config.Chain).
+		Str("environment", config.Environment).
+		Logger().
+		WithContext(ctx))
+
+	logger.Info().Msg("Starting updateProposalTallies task ...")
+
+	proposalIDs, err := db.QueryVotingProposalIDs(ctx, dbClient)
+	if err != nil {
+		logger.Error().Msgf("Error querying proposals in voting period: %v", err)
+		return err
+	}
+	if len(proposalIDs) == 0 {
+		logger.Info().Msg("No proposal in voting period, skipping")
+		return nil
+	}
+
+	// total staking power of the validators, for tallies not reporting one
+	historicalStakingPower, err := db.QueryLatestTotalVotingPower(ctx, dbClient)
+	if err != nil {
+		logger.Error().Msgf("Error querying latest validator historical powers: %v", err)
+		return err
+	}
+
+	if err := rpcClient.Rebalance(ctx); err != nil {
+		logger.Error().Msgf("Error rebalancing clients: %v", err)
+		return err
+	}
+
+	params, err := rpcClient.GovParams(ctx, nil)
+	if err != nil {
+		logger.Error().Msgf("Error getting gov params: %v", err)
+		return err
+	}
+
+	timestamp := time.Now()
+	snapshots := make([]db.ProposalTallySnapshot, 0, len(proposalIDs))
+	for _, proposalID := range proposalIDs {
+		proposal, err := rpcClient.Proposal(ctx, proposalID, nil)
+		if err != nil {
+			logger.Error().Msgf("Error getting proposal %d: %v", proposalID, err)
+			return err
+		}
+		// the proposal may have been resolved since it was indexed
+		if proposal.Proposal.Status != govv1.StatusVotingPeriod {
+			continue
+		}
+		if err := proposal.Proposal.UnpackInterfaces(interfaceRegistry); err != nil {
+			logger.Error().Msgf("Error unpacking proposal %d messages: %v", proposalID, err)
+			return err
+		}
+
+		tally, err := rpcClient.TallyResult(ctx, proposalID, nil)
+		if err != nil {
+			logger.Error().Msgf("Error getting tally of proposal %d: %v", proposalID, err)
+			return err
+		}
+
+		// expedited and emergency proposals need the expedited threshold, unless they only call low threshold functions
+		threshold := params.Params.Threshold
+		if (proposal.Proposal.Expedited || proposal.Proposal.Emergency) && !govkeeper.IsLowThresholdProposal(params.Params, *proposal.Proposal) {
+			threshold = params.Params.ExpeditedThreshold
+		}
+
+		snapshot, err := db.NewProposalTallySnapshot(proposalID, tally.TallyResult, params.Params.Quorum, threshold, historicalStakingPower, timestamp)
+		if err != nil {
+			logger.Error().Msgf("Error building tally snapshot of proposal %d: %v", proposalID, err)
+			return err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := db.InsertProposalTallySnapshots(ctx, dbClient, snapshots); err != nil {
+		logger.Error().Msgf("Error inserting proposal tally snapshots: %v", err)
+		return err
+	}
+
+	logger.Info().Msgf("Successfully stored %d proposal tally snapshots", len(snapshots))
+	return nil
+}
+
+// maxChainStatHoursPerRun bounds the hours rolled up by a single updateChainStats run while catching up with the chain
+const maxChainStatHoursPerRun = 24 * 7
+
+// updateChainStats rolls up the chain activity per hour and per day, from the latest hourly rollup up to the latest indexed block.
+func updateChainStats(parentCtx context.Context, dbClient *gorm.DB, config *IndexerCronConfig) error {
+	transaction, ctx := sentry_integration.StartSentryTransaction(parentCtx, "updateChainStats", "Roll up the chain activity per hour and per day")
+	defer transaction.Finish()
+	logger := zerolog.Ctx(log.With().
+		Str("component", "indexer-cron").
+		Str("function_name", "updateChainStats").
+		Str("chain", config.Chain).
+		Str("environment", config.Environment).
+		Logger().
+		WithContext(ctx))
+
+	logger.Info().Msg("Starting updateChainStats task ...")
+
+	resume, err := db.QueryChainStatsResumeTime(ctx, dbClient)
+	if err != nil {
+		logger.Error().Msgf("Error querying chain stats resume time: %v", err)
+		return err
+	}
+	if resume == nil {
+		logger.Info().Msg("No block indexed yet, skipping")
+		return nil
+	}
+
+	height, err := db.QueryLatestInformativeBlockHeight(ctx, dbClient)
+	if err != nil {
+		logger.Error().Msgf("Error querying latest block height: %v", err)
+		return err
+	}
+	latest, err := db.QueryBlockTimestamp(ctx, dbClient, height)
+	if err != nil {
+		logger.Error().Msgf("Error querying timestamp of block %d: %v", height, err)
+		return err
+	}
+
+	stats := make([]db.ChainStat, 0)
+	days := make([]time.Time, 0)
+	for hour := *resume; !hour.After(latest) && len(stats) < maxChainStatHoursPerRun; hour = hour.Add(time.Hour) {
+		stat, err := db.ComputeChainStat(ctx, dbClient, db.ChainStatGranularityHour, hour, height)
+		if err != nil {
+			logger.Error().Msgf("Error computing chain stats of hour %s: %v", hour, err)
+			return err
+		}
+		stats = append(stats, stat)
+
+		if day, _ := db.ChainStatPeriod(db.ChainStatGranularityDay, hour); len(days) == 0 || !days[len(days)-1].Equal(day) {
+			days = append(days, day)
+		}
+	}
+
+	// distinct accounts do not add up over hours, so the days are computed from their blocks as well
+	for _, day := range days {
+		stat, err := db.ComputeChainStat(ctx, dbClient, db.ChainStatGranularityDay, day, height)
+		if err != nil {
+			logger.Error().Msgf("Error computing chain stats of day %s: %v", day, err)
+			return err
+		}
+		stats = append(stats, stat)
+	}
+
+	if err := db.UpsertChainStats(ctx, dbClient, stats); err != nil {
+		logger.Error().Msgf("Error upserting chain stats: %v", err)
+		return err
+	}
+
+	logger.Info().Msgf("Successfully rolled up chain stats of %d hours and %d days up to block %d", len(stats)-len(days), len(days), height)
+	return nil
+}
+
+// updateNftMetadata fetches the off-chain metadata of the Nfts and the collections, and stores their thumbnails.
+func updateNftMetadata(parentCtx context.Context, fetcher *nftmetadata.Fetcher, config *IndexerCronConfig) error {
+	transaction, ctx := sentry_integration.StartSentryTransaction(parentCtx, "updateNftMetadata", "Fetch the off-chain metadata of the Nfts and the collections")
+	defer transaction.Finish()
+	logger := zerolog.Ctx(log.With().
+		Str("component", "indexer-cron").
+		Str("function_name", "updateNftMetadata").
+		Str("chain", config.Chain).
+		Str("environment", config.Environment).
+		Logger().
+		WithContext(ctx))
+
+	logger.Info().Msg("Starting updateNftMetadata task ...")
+
+	return fetcher.Run(ctx, logger)
+}
+
 func updateValidators(parentCtx context.Context, dbClient *gorm.DB, rpcClient cosmosrpc.CosmosJSONRPCHub, interfaceRegistry codectypes.InterfaceRegistry, config *IndexerCronConfig) error {
 	transaction, ctx := sentry_integration.StartSentryTransaction(parentCtx, "updateValidators", "Update all validator details in the database")
 	defer transaction.Finish()
@@ -247,29 +449,21 @@ func updateValidators(parentCtx context.Context, dbClient *gorm.DB, rpcClient co
 	return nil
 }
 
-// keybaseResponse represents the structure of Keybase API response
-type keybaseResponse struct {
-	Them []struct {
-		Pictures struct {
-			Primary struct {
-				URL string `json:"url"`
-			} `json:"primary"`
-		} `json:"pictures"`
-	} `json:"them"`
-}
-
-const avatarSize = 36
+const (
+	avatarSize       = 36
+	nftThumbnailSize = 256
+)
 
-// normalizeImageToJPEG uses bimg (libvips) to convert the image to JPEG and resize to avatarSize×avatarSize.
-// Returns nil on failure so caller can keep the original bytes.
+// normalizeImageToJPEG uses bimg (libvips) to convert the image to JPEG and resize to size×size.
+// Returns nil on failure.
 // Requires libvips to be installed on the system (e.g. apt install libvips-dev, brew install vips).
-func normalizeImageToJPEG(data []byte) []byte {
+func normalizeImageToJPEG(data []byte, size int) []byte {
 	opts := bimg.Options{
-		Width:   avatarSize,
-		Height:  avatarSize,
+		Width:   size,
+		Height:  size,
 		Type:    bimg.JPEG,
 		Quality: 80,
-		Crop:    false, // fit whole image within 36×36
+		Crop:    false, // fit whole image within size×size
 	}
 	out, err := bimg.NewImage(data).Process(opts)
 	if err != nil {
@@ -278,125 +472,19 @@ func normalizeImageToJPEG(data []byte) []byte {
 	return out
 }
 
-// keybaseImageCache caches Keybase identity -> base64 image in memory to avoid repeated API calls.
-var (
-	keybaseImageCache   = make(map[string]string)
-	keybaseImageCacheMu sync.RWMutex
-)
-
-// fetchImageDataFromKeybase fetches the validator image from Keybase API and returns it as base64.
-// Results are cached in memory by identity so each identity is only fetched once per process.
-// Second return is true if the result was from cache (no Keybase call).
-func fetchImageDataFromKeybase(identity string) (string, bool) {
-	if identity == "" {
-		return "", false
-	}
-	keybaseImageCacheMu.RLock()
-	cached, ok := keybaseImageCache[identity]
-	keybaseImageCacheMu.RUnlock()
-	if ok {
-		return cached, true
-	}
-
-	// First, get the image URL from Keybase API
-	client := &http.Client{Timeout: 10 * time.Second}
-	url := fmt.Sprintf("https://keybase.io/_/api/1.0/user/lookup.json?key_suffix=%s&fields=pictures", identity)
-
-	resp, err := client.Get(url)
-	if err != nil {
-		return "", false
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", false
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", false
-	}
-
-	var keybaseResp keybaseResponse
-	if err := json.Unmarshal(body, &keybaseResp); err != nil {
-		return "", false
-	}
-
-	imageURL := ""
-	if len(keybaseResp.Them) > 0 && keybaseResp.Them[0].Pictures.Primary.URL != "" {
-		imageURL = keybaseResp.Them[0].Pictures.Primary.URL
-	}
-
-	if imageURL == "" {
-		return "", false
-	}
-
-	// Now fetch the actual image data from the URL
-	imageResp, err := client.Get(imageURL)
-	if err != nil {
-		return "", false
-	}
-	defer imageResp.Body.Close()
-
-	if imageResp.StatusCode != http.StatusOK {
-		return "", false
-	}
-
-	imageData, err := io.ReadAll(imageResp.Body)
-	if err != nil {
-		return "", false
-	}
-
-	// Normalize to JPEG to reduce stored size (avatars from Keybase may be PNG or large JPEGs)
-	if normalized := normalizeImageToJPEG(imageData); normalized != nil {
-		imageData = normalized
-	}
-
-	// Convert to base64 and cache
-	base64Image := base64.StdEncoding.EncodeToString(imageData)
-	keybaseImageCacheMu.Lock()
-	keybaseImageCache[identity] = base64Image
-	keybaseImageCacheMu.Unlock()
-	return base64Image, false
-}
-
-// updateValidatorImages fetches and updates image data (base64-encoded) only for validators that have
-// identity but no cached image in DB. Keybase results are cached in memory by identity (one fetch per identity per process).
-func updateValidatorImages(ctx context.Context, dbClient *gorm.DB, logger *zerolog.Logger) error {
-	// Only validators with identity and no image yet — DB is source of truth; memory cache avoids duplicate Keybase calls
-	var validators []db.Validator
-	if err := dbClient.WithContext(ctx).
-		Model(&db.Validator{}).
-		Where("identity != ''").
-		Find(&validators).Error; err != nil {
-		logger.Error().Err(err).Msg("Failed to query validators for image update")
-		return err
-	}
-
-	if len(validators) == 0 {
-		logger.Debug().Msg("No validators missing cached images, skipping")
-		return nil
-	}
-
-	logger.Info().Msgf("Updating images for %d validators (in-memory cache used per identity)", len(validators))
-
-	var toUpsert []db.Validator
-	for _, val := range validators {
-		imageData, _ := fetchImageDataFromKeybase(val.Identity)
-		if imageData == "" {
-			continue
-		}
-		toUpsert = append(toUpsert, db.Validator{OperatorAddress: val.OperatorAddress, IdentityImage: imageData})
-	}
-
-	if len(toUpsert) > 0 {
-		if err := db.UpsertValidatorIdentityImages(ctx, dbClient, toUpsert); err != nil {
-			logger.Warn().Err(err).Msg("Failed to upsert validator identity images")
-		}
-	}
+// updateValidatorIdentityImages resolves the identity images of the validators whose identity changed or whose image is stale, and hosts them.
+func updateValidatorIdentityImages(parentCtx context.Context, refresher *identity.Refresher, config *IndexerCronConfig) error {
+	transaction, ctx := sentry_integration.StartSentryTransaction(parentCtx, "updateValidatorIdentityImages", "Refresh the validator identity images")
+	defer transaction.Finish()
+	logger := zerolog.Ctx(log.With().
+		Str("component", "indexer-cron").
+		Str("function_name", "updateValidatorIdentityImages").
+		Str("chain", config.Chain).
+		Str("environment", config.Environment).
+		Logger().
+		WithContext(ctx))
 
-	logger.Info().Msg("Validator image update completed")
-	return nil
+	return refresher.Run(ctx, logger)
 }
 
 func pruneCommitSignatures(parenCtx context.Context, dbClient *gorm.DB, config *IndexerCronConfig) error {
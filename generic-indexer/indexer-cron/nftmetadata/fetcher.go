@@ -0,0 +1,240 @@
+package nftmetadata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+const (
+	// MaxAttempts is the number of failed fetches after which an Nft or a collection is no longer retried, until
+	// its URI changes
+	MaxAttempts = 8
+
+	maxDocumentSize = 1 << 20
+	maxImageSize    = 10 << 20
+	minBackoff      = time.Minute
+	maxBackoff      = 24 * time.Hour
+	httpTimeout     = 15 * time.Second
+)
+
+// Backoff returns the delay before retrying a fetch that failed attempts times, doubling from a minute up to a day
+func Backoff(attempts int32) time.Duration {
+	backoff := minBackoff
+	for i := int32(1); i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+type Config struct {
+	// IPFSGateway is the gateway ipfs:// URIs are fetched through
+	IPFSGateway string
+	// Bucket stores the thumbnails, they are not generated when it is empty
+	Bucket string
+	// PublicURL is the URL the objects of the bucket are served from
+	PublicURL string
+	// BatchSize bounds the Nfts and the collections fetched by a single run
+	BatchSize int
+}
+
+// Fetcher fetches the off-chain metadata of the Nfts and the collections from their URIs
+type Fetcher struct {
+	dbClient      *gorm.DB
+	httpClient    *http.Client
+	storageClient storage.Client
+	thumbnail     func([]byte) []byte
+	config        Config
+}
+
+// NewFetcher creates a Fetcher, thumbnail converts an image to the JPEG thumbnail stored in the bucket and returns
+// nil when the image cannot be decoded. httpClient defaults to the client of NewClient, which only reaches public
+// addresses.
+func NewFetcher(dbClient *gorm.DB, httpClient *http.Client, storageClient storage.Client, thumbnail func([]byte) []byte, config Config) *Fetcher {
+	if httpClient == nil {
+		httpClient = NewClient(httpTimeout)
+	}
+	return &Fetcher{
+		dbClient:      dbClient,
+		httpClient:    httpClient,
+		storageClient: storageClient,
+		thumbnail:     thumbnail,
+		config:        config,
+	}
+}
+
+// Run queues the Nfts and the collections whose metadata is missing or whose URI changed, then fetches the ones due.
+// A failed fetch is recorded and retried with backoff, only database errors stop the run.
+func (f *Fetcher) Run(ctx context.Context, logger *zerolog.Logger) error {
+	now := time.Now().UTC()
+
+	queuedNfts, err := db.QueueNftMetadataFetches(ctx, f.dbClient, now, f.config.BatchSize)
+	if err != nil {
+		logger.Error().Msgf("Error queueing Nft metadata fetches: %v", err)
+		return err
+	}
+	queuedCollections, err := db.QueueCollectionMetadataFetches(ctx, f.dbClient, now, f.config.BatchSize)
+	if err != nil {
+		logger.Error().Msgf("Error queueing collection metadata fetches: %v", err)
+		return err
+	}
+
+	nfts, err := db.QueryDueNftMetadataFetches(ctx, f.dbClient, now, MaxAttempts, f.config.BatchSize)
+	if err != nil {
+		logger.Error().Msgf("Error querying due Nft metadata fetches: %v", err)
+		return err
+	}
+	failedNfts := 0
+	for _, fetch := range nfts {
+		failed, err := f.fetchNft(ctx, fetch)
+		if err != nil {
+			logger.Error().Msgf("Error saving metadata of Nft %s: %v", fetch.ID, err)
+			return err
+		}
+		if failed {
+			failedNfts++
+		}
+	}
+
+	collections, err := db.QueryDueCollectionMetadataFetches(ctx, f.dbClient, now, MaxAttempts, f.config.BatchSize)
+	if err != nil {
+		logger.Error().Msgf("Error querying due collection metadata fetches: %v", err)
+		return err
+	}
+	failedCollections := 0
+	for _, fetch := range collections {
+		failed, err := f.fetchCollection(ctx, fetch)
+		if err != nil {
+			logger.Error().Msgf("Error saving metadata of collection %s: %v", fetch.ID, err)
+			return err
+		}
+		if failed {
+			failedCollections++
+		}
+	}
+
+	logger.Info().Msgf("Queued %d Nfts and %d collections, fetched metadata of %d Nfts (%d failed) and %d collections (%d failed)",
+		queuedNfts, queuedCollections, len(nfts), failedNfts, len(collections), failedCollections)
+	return nil
+}
+
+// fetchNft fetches and saves the metadata of an Nft, and returns whether the fetch failed
+func (f *Fetcher) fetchNft(ctx context.Context, fetch db.MetadataFetch) (bool, error) {
+	document, metadata, err := f.fetchMetadata(ctx, fetch.URI)
+	now := time.Now().UTC()
+	if err != nil {
+		attempts := fetch.Attempts + 1
+		return true, db.UpdateNftMetadataFetchFailure(ctx, f.dbClient, fetch.ID, attempts, now.Add(Backoff(attempts)), err.Error(), now)
+	}
+
+	thumbnailURL, err := f.storeThumbnail(ctx, "nfts/"+fetch.ID+".jpg", metadata.Image)
+	record := db.NftMetadata{
+		ID:           fetch.ID,
+		URI:          fetch.URI,
+		FetchedAt:    &now,
+		Metadata:     document,
+		Name:         metadata.Name,
+		Description:  metadata.Description,
+		Image:        metadata.Image,
+		ThumbnailURL: thumbnailURL,
+		UpdatedAt:    now,
+	}
+	record.Status, record.Attempts, record.NextAttemptAt, record.LastError = fetchOutcome(fetch, err, now)
+
+	attributes := make([]db.NftAttribute, 0, len(metadata.Attributes))
+	for _, attribute := range metadata.Attributes {
+		attributes = append(attributes, db.NftAttribute{NftID: fetch.ID, TraitType: attribute.TraitType, Value: attribute.Value})
+	}
+
+	return err != nil, db.SaveNftMetadata(ctx, f.dbClient, record, attributes)
+}
+
+// fetchCollection fetches and saves the metadata of a collection, and returns whether the fetch failed
+func (f *Fetcher) fetchCollection(ctx context.Context, fetch db.MetadataFetch) (bool, error) {
+	document, metadata, err := f.fetchMetadata(ctx, fetch.URI)
+	now := time.Now().UTC()
+	if err != nil {
+		attempts := fetch.Attempts + 1
+		return true, db.UpdateCollectionMetadataFetchFailure(ctx, f.dbClient, fetch.ID, attempts, now.Add(Backoff(attempts)), err.Error(), now)
+	}
+
+	thumbnailURL, err := f.storeThumbnail(ctx, "collections/"+fetch.ID+".jpg", metadata.Image)
+	record := db.CollectionMetadata{
+		ID:           fetch.ID,
+		URI:          fetch.URI,
+		FetchedAt:    &now,
+		Metadata:     document,
+		Name:         metadata.Name,
+		Description:  metadata.Description,
+		Image:        metadata.Image,
+		ThumbnailURL: thumbnailURL,
+		UpdatedAt:    now,
+	}
+	record.Status, record.Attempts, record.NextAttemptAt, record.LastError = fetchOutcome(fetch, err, now)
+
+	return err != nil, db.SaveCollectionMetadata(ctx, f.dbClient, record)
+}
+
+// fetchOutcome returns the status, the attempts, the next attempt time and the error of a parsed metadata, which is
+// retried when its thumbnail could not be stored
+func fetchOutcome(fetch db.MetadataFetch, thumbnailErr error, now time.Time) (string, int32, time.Time, *string) {
+	if thumbnailErr == nil {
+		return db.MetadataStatusFetched, 0, now, nil
+	}
+
+	attempts := fetch.Attempts + 1
+	lastError := thumbnailErr.Error()
+	return db.MetadataStatusFailed, attempts, now.Add(Backoff(attempts)), &lastError
+}
+
+// fetchMetadata fetches and parses a metadata document, and returns it compacted along with the parsed metadata
+func (f *Fetcher) fetchMetadata(ctx context.Context, uri string) (db.JSON, Metadata, error) {
+	data, err := fetchURI(ctx, f.httpClient, uri, f.config.IPFSGateway, maxDocumentSize)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	metadata, err := ParseMetadata(data)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	var document bytes.Buffer
+	if err := json.Compact(&document, data); err != nil {
+		return nil, Metadata{}, err
+	}
+	return db.JSON(document.Bytes()), metadata, nil
+}
+
+// storeThumbnail uploads the thumbnail of an image to the bucket and returns its public URL, or an empty URL when
+// there is no image or no bucket
+func (f *Fetcher) storeThumbnail(ctx context.Context, objectPath string, image string) (string, error) {
+	if image == "" || f.config.Bucket == "" || f.storageClient == nil {
+		return "", nil
+	}
+
+	data, err := fetchURI(ctx, f.httpClient, image, f.config.IPFSGateway, maxImageSize)
+	if err != nil {
+		return "", fmt.Errorf("fetching image: %w", err)
+	}
+
+	thumbnail := f.thumbnail(data)
+	if thumbnail == nil {
+		return "", fmt.Errorf("unsupported image %s", image)
+	}
+
+	if err := f.storageClient.UploadFile(f.config.Bucket, objectPath, thumbnail); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(f.config.PublicURL, "/") + "/" + objectPath, nil
+}
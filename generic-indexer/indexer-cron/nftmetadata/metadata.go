@@ -0,0 +1,91 @@
+package nftmetadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Metadata is the standard metadata document of an Nft or a collection
+type Metadata struct {
+	Name        string
+	Description string
+	Image       string
+	Attributes  []Attribute
+}
+
+type Attribute struct {
+	TraitType string
+	Value     string
+}
+
+// ParseMetadata parses a metadata document, taking the image from image_url when image is missing. Attribute values
+// are kept as strings, attributes without a value are left out and the ones repeated are only kept once.
+func ParseMetadata(data []byte) (Metadata, error) {
+	var document struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Image       string `json:"image"`
+		ImageURL    string `json:"image_url"`
+		Attributes  []struct {
+			TraitType string          `json:"trait_type"`
+			Value     json.RawMessage `json:"value"`
+		} `json:"attributes"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return Metadata{}, fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	metadata := Metadata{
+		Name:        document.Name,
+		Description: document.Description,
+		Image:       document.Image,
+		Attributes:  make([]Attribute, 0, len(document.Attributes)),
+	}
+	if metadata.Image == "" {
+		metadata.Image = document.ImageURL
+	}
+
+	seen := make(map[Attribute]bool)
+	for _, attribute := range document.Attributes {
+		value, ok := attributeValue(attribute.Value)
+		if !ok {
+			continue
+		}
+
+		parsed := Attribute{TraitType: attribute.TraitType, Value: value}
+		if seen[parsed] {
+			continue
+		}
+		seen[parsed] = true
+		metadata.Attributes = append(metadata.Attributes, parsed)
+	}
+	sort.Slice(metadata.Attributes, func(i, j int) bool {
+		if metadata.Attributes[i].TraitType != metadata.Attributes[j].TraitType {
+			return metadata.Attributes[i].TraitType < metadata.Attributes[j].TraitType
+		}
+		return metadata.Attributes[i].Value < metadata.Attributes[j].Value
+	})
+
+	return metadata, nil
+}
+
+// attributeValue returns strings as they are and other values as JSON, and false for missing or null values
+func attributeValue(raw json.RawMessage) (string, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return "", false
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, true
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, raw); err != nil {
+		return "", false
+	}
+	return compacted.String(), true
+}
@@ -0,0 +1,224 @@
+package nftmetadata
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+func TestResolveURI(t *testing.T) {
+	tests := []struct {
+		name     string
+		uri      string
+		expected string
+		err      bool
+	}{
+		{name: "https", uri: "https://example.com/1.json", expected: "https://example.com/1.json"},
+		{name: "ipfs", uri: "ipfs://bafy/1.json", expected: "https://gateway.test/ipfs/bafy/1.json"},
+		{name: "ipfs with ipfs prefix", uri: "ipfs://ipfs/bafy/1.json", expected: "https://gateway.test/ipfs/bafy/1.json"},
+		{name: "data", uri: "data:application/json,{}", expected: "data:application/json,{}"},
+		{name: "empty ipfs", uri: "ipfs://", err: true},
+		{name: "http", uri: "http://example.com/1.json", err: true},
+		{name: "unknown scheme", uri: "ar://abc", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved, err := ResolveURI(tc.uri, "https://gateway.test/ipfs/")
+			if tc.err {
+				assert.ErrorIs(t, err, ErrUnsupportedURI)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, resolved)
+		})
+	}
+}
+
+func TestDecodeDataURI(t *testing.T) {
+	document := `{"name":"Nft #1"}`
+
+	decoded, err := DecodeDataURI("data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(document)))
+	require.NoError(t, err)
+	assert.Equal(t, document, string(decoded))
+
+	decoded, err = DecodeDataURI("data:application/json,%7B%22name%22%3A%22Nft%20%231%22%7D")
+	require.NoError(t, err)
+	assert.Equal(t, document, string(decoded))
+
+	_, err = DecodeDataURI("data:application/json")
+	assert.Error(t, err)
+}
+
+func TestParseMetadata(t *testing.T) {
+	metadata, err := ParseMetadata([]byte(`{
+		"name": "Nft #1",
+		"description": "The first one",
+		"image_url": "ipfs://bafy/1.png",
+		"attributes": [
+			{"trait_type": "Level", "value": 5},
+			{"trait_type": "Background", "value": "Blue"},
+			{"trait_type": "Background", "value": "Blue"},
+			{"trait_type": "Shiny", "value": true},
+			{"trait_type": "Empty", "value": null},
+			{"value": "Untyped"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, Metadata{
+		Name:        "Nft #1",
+		Description: "The first one",
+		Image:       "ipfs://bafy/1.png",
+		Attributes: []Attribute{
+			{TraitType: "", Value: "Untyped"},
+			{TraitType: "Background", Value: "Blue"},
+			{TraitType: "Level", Value: "5"},
+			{TraitType: "Shiny", Value: "true"},
+		},
+	}, metadata)
+
+	metadata, err = ParseMetadata([]byte(`{"image": "https://example.com/1.png", "image_url": "https://example.com/other.png"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/1.png", metadata.Image)
+
+	_, err = ParseMetadata([]byte(`["not", "an", "object"]`))
+	assert.Error(t, err)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, Backoff(1))
+	assert.Equal(t, 2*time.Minute, Backoff(2))
+	assert.Equal(t, 64*time.Minute, Backoff(7))
+	assert.Equal(t, 24*time.Hour, Backoff(12))
+	assert.Equal(t, 24*time.Hour, Backoff(100))
+}
+
+func TestFetchURI(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/bafy/1.json":
+			_, _ = w.Write([]byte(`{"name":"Nft #1"}`))
+		case "/large.json":
+			_, _ = w.Write(make([]byte, 64))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	data, err := fetchURI(ctx, server.Client(), "ipfs://bafy/1.json", server.URL+"/ipfs", 32)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"Nft #1"}`, string(data))
+
+	_, err = fetchURI(ctx, server.Client(), server.URL+"/large.json", server.URL+"/ipfs", 32)
+	assert.ErrorContains(t, err, "exceeds 32 bytes")
+
+	_, err = fetchURI(ctx, server.Client(), server.URL+"/missing.json", server.URL+"/ipfs", 32)
+	assert.ErrorContains(t, err, "status 404")
+
+	_, err = fetchURI(ctx, server.Client(), "data:application/json,"+string(make([]byte, 64)), server.URL+"/ipfs", 32)
+	assert.ErrorContains(t, err, "exceeds 32 bytes")
+}
+
+func TestFetchURIPrivateAddress(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"Nft #1"}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	client := NewClient(time.Second)
+
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	for _, uri := range []string{server.URL + "/1.json", "https://localhost:" + port + "/1.json"} {
+		_, err = fetchURI(ctx, client, uri, "", 32)
+		assert.ErrorIs(t, err, ErrForbiddenAddress, uri)
+	}
+
+	_, err = fetchURI(ctx, client, "ipfs://bafy/1.json", server.URL+"/ipfs", 32)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+
+	redirect, err := http.NewRequest(http.MethodGet, "http://169.254.169.254/latest/meta-data", nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, client.CheckRedirect(redirect, nil), ErrUnsupportedURI)
+}
+
+func TestCheckAddress(t *testing.T) {
+	for _, address := range []string{
+		"127.0.0.1:443",
+		"10.0.0.1:443",
+		"172.16.0.1:443",
+		"192.168.1.1:443",
+		"169.254.169.254:80",
+		"0.0.0.0:443",
+		"[::1]:443",
+		"[fe80::1]:443",
+		"[fd00::1]:443",
+		"[::ffff:127.0.0.1]:443",
+		"[::ffff:169.254.169.254]:80",
+	} {
+		assert.ErrorIs(t, checkAddress(address), ErrForbiddenAddress, address)
+	}
+
+	for _, address := range []string{"8.8.8.8:443", "[2606:4700::1111]:443"} {
+		assert.NoError(t, checkAddress(address), address)
+	}
+}
+
+type fakeStorage struct {
+	storage.Client
+	uploaded map[string][]byte
+}
+
+func (s *fakeStorage) UploadFile(bucket string, objectPath string, message []byte) error {
+	s.uploaded[bucket+"/"+objectPath] = message
+	return nil
+}
+
+func (s *fakeStorage) ReadFile(bucket string, objectPath string) ([]byte, error) {
+	return s.uploaded[bucket+"/"+objectPath], nil
+}
+
+func TestStoreThumbnail(t *testing.T) {
+	storageClient := &fakeStorage{uploaded: make(map[string][]byte)}
+	thumbnail := func(data []byte) []byte {
+		if string(data) != "image" {
+			return nil
+		}
+		return []byte("thumbnail")
+	}
+	image := func(data string) string {
+		return "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte(data))
+	}
+	ctx := context.Background()
+
+	fetcher := NewFetcher(nil, nil, storageClient, thumbnail, Config{Bucket: "bucket", PublicURL: "https://cdn.test/bucket/"})
+	url, err := fetcher.storeThumbnail(ctx, "nfts/0x1.jpg", image("image"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.test/bucket/nfts/0x1.jpg", url)
+	assert.Equal(t, []byte("thumbnail"), storageClient.uploaded["bucket/nfts/0x1.jpg"])
+
+	_, err = fetcher.storeThumbnail(ctx, "nfts/0x2.jpg", image("not an image"))
+	assert.ErrorContains(t, err, "unsupported image")
+
+	url, err = fetcher.storeThumbnail(ctx, "nfts/0x3.jpg", "")
+	require.NoError(t, err)
+	assert.Empty(t, url)
+
+	fetcher = NewFetcher(nil, nil, storageClient, thumbnail, Config{})
+	url, err = fetcher.storeThumbnail(ctx, "nfts/0x4.jpg", image("image"))
+	require.NoError(t, err)
+	assert.Empty(t, url)
+	assert.Len(t, storageClient.uploaded, 1)
+}
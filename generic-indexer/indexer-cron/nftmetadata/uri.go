@@ -0,0 +1,95 @@
+package nftmetadata
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var ErrUnsupportedURI = errors.New("unsupported uri")
+
+// ResolveURI returns the URL a metadata or an image URI is fetched from: ipfs:// URIs go through the gateway, https
+// URLs and data: URIs are kept as they are
+func ResolveURI(uri string, ipfsGateway string) (string, error) {
+	uri = strings.TrimSpace(uri)
+	switch {
+	case strings.HasPrefix(uri, "ipfs://"):
+		path := strings.TrimPrefix(strings.TrimPrefix(uri, "ipfs://"), "ipfs/")
+		if path == "" {
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedURI, uri)
+		}
+		return strings.TrimSuffix(ipfsGateway, "/") + "/" + path, nil
+	case strings.HasPrefix(uri, "https://"), strings.HasPrefix(uri, "data:"):
+		return uri, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedURI, uri)
+	}
+}
+
+// DecodeDataURI returns the content of a data: URI, either base64 or percent encoded
+func DecodeDataURI(uri string) ([]byte, error) {
+	header, data, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return nil, fmt.Errorf("invalid data uri")
+	}
+
+	if strings.HasSuffix(header, ";base64") {
+		return base64.StdEncoding.DecodeString(data)
+	}
+
+	decoded, err := url.PathUnescape(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(decoded), nil
+}
+
+// fetchURI reads the content of a metadata or an image URI, failing when it exceeds maxSize bytes
+func fetchURI(ctx context.Context, client *http.Client, uri string, ipfsGateway string, maxSize int64) ([]byte, error) {
+	resolved, err := ResolveURI(uri, ipfsGateway)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(resolved, "data:") {
+		data, err := DecodeDataURI(resolved)
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(data)) > maxSize {
+			return nil, fmt.Errorf("content of %s exceeds %d bytes", uri, maxSize)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resolved, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s returned status %d", resolved, resp.StatusCode)
+	}
+	if resp.ContentLength > maxSize {
+		return nil, fmt.Errorf("content of %s exceeds %d bytes", uri, maxSize)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("content of %s exceeds %d bytes", uri, maxSize)
+	}
+	return data, nil
+}
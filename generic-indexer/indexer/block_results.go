@@ -8,7 +8,6 @@ import (
 	"maps"
 	"strings"
 
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 
@@ -21,9 +20,9 @@ import (
 
 func (f *Indexer) produceTxResultMessage(txHash string, height int64, txResultJsonBytes []byte, logger *zerolog.Logger) {
 	messageKey := mq.NEW_LCD_TX_RESPONSE_KAFKA_MESSAGE_KEY + fmt.Sprintf("_%s", txHash)
-	kafkaMessage := kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &f.config.KafkaTxResponseTopic, Partition: int32(kafka.PartitionAny)},
-		Headers: []kafka.Header{
+	message := mq.Message{
+		Topic: f.config.KafkaTxResponseTopic,
+		Headers: []mq.Header{
 			{Key: "height", Value: fmt.Append(nil, height)},
 			{Key: "tx_hash", Value: fmt.Append(nil, txHash)},
 			{Key: "service", Value: fmt.Append(nil, "generic-indexer")},
@@ -32,10 +31,11 @@ func (f *Indexer) produceTxResultMessage(txHash string, height int64, txResultJs
 		Value: txResultJsonBytes,
 	}
 
-	f.producer.ProduceWithClaimCheck(&mq.ProduceWithClaimCheckInput{
+	mq.ProduceWithClaimCheck(f.producer, &mq.ProduceWithClaimCheckInput{
 		Topic:          f.config.KafkaTxResponseTopic,
-		Key:            kafkaMessage.Key,
-		MessageInBytes: kafkaMessage.Value,
+		Key:            message.Key,
+		MessageInBytes: message.Value,
+		Producer:       "generic-indexer",
 
 		ClaimCheckKey:           []byte(mq.NEW_LCD_TX_RESPONSE_CLAIM_CHECK_KAFKA_MESSAGE_KEY + fmt.Sprintf("_%s", txHash)),
 		ClaimCheckThresholdInMB: f.config.ClaimCheckThresholdInMB,
@@ -44,7 +44,7 @@ func (f *Indexer) produceTxResultMessage(txHash string, height int64, txResultJs
 
 		StorageClient: f.storageClient,
 
-		Headers: kafkaMessage.Headers,
+		Headers: message.Headers,
 	}, logger)
 }
 
@@ -102,7 +102,7 @@ func (f *Indexer) decodeAndInsertTxs(parentCtx context.Context, dbTx *gorm.DB, b
 	return nil
 }
 
-func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *mq.BlockResultMsg) error {
+func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *mq.BlockResultMsg, offset db.ConsumerOffset) error {
 	span, ctx := sentry_integration.StartSentrySpan(parentCtx, "processBlock", "Parse Block and insert blocks & transactions into DB")
 	defer span.Finish()
 
@@ -135,6 +135,11 @@ func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *m
 			logger.Error().Int64("height", blockResults.Height).Msgf("Error inserting transactions: %v", err)
 			return errors.Join(indexererrors.ErrorNonRetryable, err)
 		}
+
+		if err := db.UpsertConsumerOffset(ctx, dbTx, offset); err != nil {
+			logger.Error().Int64("height", blockResults.Height).Msgf("Error recording consumer offset: %v", err)
+			return err
+		}
 		return nil
 	}); err != nil {
 		logger.Error().Int64("height", blockResults.Height).Msgf("Error processing block: %v", err)
@@ -11,7 +11,6 @@ import (
 	"time"
 
 	"github.com/certifi/gocertifi"
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/getsentry/sentry-go"
 	initiaapp "github.com/initia-labs/initia/app"
@@ -31,8 +30,8 @@ import (
 var logger *zerolog.Logger
 
 type Indexer struct {
-	consumer      *mq.Consumer
-	producer      *mq.Producer
+	consumer      mq.Consumer
+	producer      mq.Producer
 	rpcClient     cosmosrpc.CosmosJSONRPCHub
 	dbClient      *gorm.DB
 	storageClient storage.Client
@@ -53,6 +52,12 @@ type IndexerConfig struct {
 
 	DBConnectionString string
 
+	// MQBackend selects the message bus, kafka when empty, see mq.Config
+	MQBackend string
+	MQDir     string
+	// MQMemoryBus is the bus of the memory backend, shared with the services run in the same process
+	MQMemoryBus *mq.MemoryBus
+
 	// Kafka config
 	KafkaBootstrapServer    string
 	KafkaBlockTopic         string
@@ -67,6 +72,10 @@ type IndexerConfig struct {
 	LCDTxResponseClaimCheckBucket string
 	BlockResultsClaimCheckBucket  string
 
+	// Archive config, the block results bundles read by reindex
+	ArchiveBucket string
+	ArchivePrefix string
+
 	// AWS
 	AWSAccessKey string
 	AWSSecretKey string
@@ -153,39 +162,29 @@ func New(config *IndexerConfig) (*Indexer, error) {
 		return nil, err
 	}
 
-	consumer, err := mq.NewConsumer(&kafka.ConfigMap{
-		"bootstrap.servers":    config.KafkaBootstrapServer,
-		"group.id":             config.KafkaBlockConsumerGroup,
-		"client.id":            config.KafkaBlockConsumerGroup + "-" + config.ID,
-		"enable.auto.commit":   false,
-		"auto.offset.reset":    "earliest",
-		"security.protocol":    "SASL_SSL",
-		"sasl.mechanisms":      "PLAIN",
-		"sasl.username":        config.KafkaAPIKey,
-		"sasl.password":        config.KafkaAPISecret,
-		"max.poll.interval.ms": 600000,
-	})
+	mqConfig := mq.Config{
+		Backend: config.MQBackend,
+		Dir:     config.MQDir,
+		Memory:  config.MQMemoryBus,
+		Kafka: mq.KafkaConfig{
+			BootstrapServer: config.KafkaBootstrapServer,
+			ClientID:        config.KafkaBlockConsumerGroup + "-" + config.ID,
+			APIKey:          config.KafkaAPIKey,
+			APISecret:       config.KafkaAPISecret,
+			Plaintext:       config.Environment == "local",
+		},
+	}
+	consumer, err := mq.NewConsumer(mqConfig, config.KafkaBlockConsumerGroup)
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
-		logger.Fatal().Msgf("Kafka: Error creating consumer. Error: %v\n", err)
+		logger.Fatal().Msgf("MQ: Error creating consumer. Error: %v\n", err)
 		return nil, err
 	}
 
-	producer, err := mq.NewProducer(&kafka.ConfigMap{
-		"bootstrap.servers": config.KafkaBootstrapServer,
-		"client.id":         config.KafkaBlockConsumerGroup + "-" + config.ID,
-		"acks":              "all",
-		"linger.ms":         200,
-		"security.protocol": "SASL_SSL",
-		"sasl.mechanisms":   "PLAIN",
-		"sasl.username":     config.KafkaAPIKey,
-		"sasl.password":     config.KafkaAPISecret,
-		"message.max.bytes": 7340032,
-		"compression.codec": "lz4",
-	})
+	producer, err := mq.NewProducer(mqConfig, logger)
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
-		logger.Fatal().Msgf("Kafka: Error creating producer. Error: %v\n", err)
+		logger.Fatal().Msgf("MQ: Error creating producer. Error: %v\n", err)
 		return nil, err
 	}
 
@@ -234,39 +233,33 @@ func (f Indexer) WithCustomRPCClient(rpcClient cosmosrpc.CosmosJSONRPCHub) *Inde
 	return &f
 }
 
-func (f *Indexer) parseBlockAndRebalanceRPCClient(parentCtx context.Context, blockBytes []byte) (mq.BlockResultMsg, error) {
+func (f *Indexer) parseBlockAndRebalanceRPCClient(parentCtx context.Context, encoding string, blockBytes []byte) (mq.BlockResultMsg, error) {
 	span, ctx := sentry_integration.StartSentrySpan(parentCtx, "parseBlockAndRebalanceRPCClient", "Parsing block and rebalancing RPC clients")
 	defer span.Finish()
 
-	var blockMsg mq.BlockResultMsg
-	err := json.Unmarshal(blockBytes, &blockMsg)
+	blockMsg, err := mq.DecodeBlockResultMsg(encoding, blockBytes)
 	if err != nil {
-		logger.Error().Msgf("Error unmarshalling message: %v", err)
+		logger.Error().Msgf("Error decoding message: %v", err)
 		return blockMsg, err
 	}
 
-	if blockMsg.Version != 0 {
-		return blockMsg, fmt.Errorf("invalid version: %d", blockMsg.Version)
-	}
-
 	if f.config.RebalanceInterval != 0 && blockMsg.Height%f.config.RebalanceInterval == 0 {
 		err := f.rpcClient.Rebalance(ctx)
 		if err != nil {
 			logger.Error().Msgf("Error rebalancing clients: %v", err)
 			return blockMsg, err
 		}
-		actives := f.rpcClient.GetActiveClients()
-		for _, active := range actives {
-			logger.Info().Msgf("Active client url: %s, latest height: %d", active.Client.GetIdentifier(), active.Height)
+		for _, health := range f.rpcClient.Health() {
+			logger.Info().Msgf("Client url: %s, latest height: %d, breaker: %s, latency: %s, error rate: %.2f", health.Identifier, health.Height, health.State, health.Latency, health.ErrorRate)
 		}
 	}
 	return blockMsg, err
 }
 
-func (f *Indexer) processUntilSucceeds(ctx context.Context, blockResults mq.BlockResultMsg) error {
+func (f *Indexer) processUntilSucceeds(ctx context.Context, blockResults mq.BlockResultMsg, offset db.ConsumerOffset) error {
 	// Process the block until success
 	for {
-		err := f.processBlockResults(ctx, &blockResults)
+		err := f.processBlockResults(ctx, &blockResults, offset)
 		if err != nil {
 			if errors.Is(err, indexererrors.ErrorNonRetryable) {
 				return err
@@ -306,13 +299,13 @@ func (f *Indexer) processClaimCheckMessage(key []byte, messageValue []byte) ([]b
 	return messageValue, nil
 }
 
-func (f *Indexer) processKafkaMessage(ctx context.Context, message *kafka.Message) error {
+func (f *Indexer) processKafkaMessage(ctx context.Context, message *mq.Message) error {
 	messageValue, err := f.processClaimCheckMessage(message.Key, message.Value)
 	if err != nil {
 		logger.Error().Msgf("Error processing claim check message: %v", err)
 		return err
 	}
-	blockMsg, err := f.parseBlockAndRebalanceRPCClient(ctx, messageValue)
+	blockMsg, err := f.parseBlockAndRebalanceRPCClient(ctx, mq.MessageEncoding(message), messageValue)
 	if err != nil {
 		logger.Error().Msgf("Error processing block message: %v", err)
 		return err
@@ -322,7 +315,7 @@ func (f *Indexer) processKafkaMessage(ctx context.Context, message *kafka.Messag
 		scope.SetTag("height", fmt.Sprint(blockMsg.Height))
 	})
 
-	err = f.processUntilSucceeds(ctx, blockMsg)
+	err = f.processUntilSucceeds(ctx, blockMsg, f.consumerOffset(message))
 	if err != nil {
 		logger.Error().Msgf("Error processing block: %v", err)
 		return err
@@ -331,11 +324,26 @@ func (f *Indexer) processKafkaMessage(ctx context.Context, message *kafka.Messag
 	return nil
 }
 
+// consumerOffset is the offset recorded along the data indexed from the message
+func (f *Indexer) consumerOffset(message *mq.Message) db.ConsumerOffset {
+	return db.ConsumerOffset{
+		ConsumerGroup: f.config.KafkaBlockConsumerGroup,
+		Topic:         message.Topic,
+		Partition:     message.Partition,
+		Offset:        message.Offset,
+	}
+}
+
+// loadConsumerOffset resumes a partition assigned to the indexer after the last message whose data was committed
+func (f *Indexer) loadConsumerOffset(topic string, partition int32) (int64, bool, error) {
+	return db.QueryConsumerOffset(context.Background(), f.dbClient, f.config.KafkaBlockConsumerGroup, topic, partition)
+}
+
 func (f *Indexer) close() {
 	if sqlDB, err := f.dbClient.DB(); err == nil {
 		sqlDB.Close()
 	}
-	f.producer.Flush(30000)
+	f.producer.Flush(30 * time.Second)
 	f.producer.Close()
 
 	f.consumer.Close()
@@ -344,9 +352,7 @@ func (f *Indexer) close() {
 func (f *Indexer) StartIndexing(stopCtx context.Context) {
 	logger.Info().Msgf("Starting indexer...")
 
-	f.producer.ListenToKafkaProduceEvents(logger)
-
-	err := f.consumer.SubscribeTopics([]string{f.config.KafkaBlockTopic}, nil)
+	err := f.consumer.SubscribeTopicsFrom([]string{f.config.KafkaBlockTopic}, f.loadConsumerOffset)
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
 		logger.Fatal().Msgf("Failed to subscribe to topic: %s\n", err)
@@ -363,9 +369,14 @@ func (f *Indexer) StartIndexing(stopCtx context.Context) {
 
 			message, err := f.consumer.ReadMessage(10 * time.Second)
 			if err != nil {
-				if err.(kafka.Error).IsTimeout() {
+				if errors.Is(err, mq.ErrTimeout) {
 					continue
 				}
+				if errors.Is(err, mq.ErrOffsetsNotLoaded) {
+					// the partitions are not read until the consumer is restarted
+					sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+					logger.Fatal().Msgf("Error reading message: %v", err)
+				}
 
 				sentry_integration.CaptureCurrentHubException(err, sentry.LevelWarning)
 				logger.Error().Msgf("Error reading message: %v", err)
@@ -374,19 +385,26 @@ func (f *Indexer) StartIndexing(stopCtx context.Context) {
 
 			sentry.ConfigureScope(func(scope *sentry.Scope) {
 				scope.SetTag("id", f.config.ID)
-				scope.SetTag("partition", fmt.Sprint(message.TopicPartition.Partition))
-				scope.SetTag("offset", message.TopicPartition.Offset.String())
+				scope.SetTag("partition", fmt.Sprint(message.Partition))
+				scope.SetTag("offset", fmt.Sprint(message.Offset))
 			})
 
 			transaction, ctx := sentry_integration.StartSentryTransaction(ctx, "Index", "Process and index generic block messages")
 			err = f.processKafkaMessage(ctx, message)
 			if err != nil {
 				sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
-				logger.Warn().Msgf("Producing message to DLQ: %d, %d, %v", message.TopicPartition.Partition, message.TopicPartition.Offset, err)
-				f.producer.ProduceToDLQ(f.config.Chain, "generic-indexer-block-results", message, err, logger)
+				logger.Warn().Msgf("Producing message to DLQ: %d, %d, %v", message.Partition, message.Offset, err)
+				mq.ProduceToDLQ(f.producer, f.storageClient, f.config.Chain, "generic-indexer-block-results", message, err, logger)
+				// the message is not processed again after a restart. The offset is recorded before the message is
+				// committed, and a failure stops the indexer so that the offsets in the database and in the consumer
+				// group never disagree: the message is processed again once restarted.
+				if err := db.UpsertConsumerOffset(ctx, f.dbClient, f.consumerOffset(message)); err != nil {
+					sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+					logger.Fatal().Msgf("Error recording consumer offset: %v", err)
+				}
 			}
 
-			_, err = f.consumer.CommitMessage(message)
+			err = f.consumer.CommitMessage(message)
 			if err != nil {
 				sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
 				logger.Error().Msgf("Non-retryable Error committing message: %v", err)
@@ -400,6 +418,12 @@ func (f *Indexer) Run() {
 	// graceful shutdown
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
+
+	f.Index(ctx)
+}
+
+// Index indexes the messages until the context is cancelled
+func (f *Indexer) Index(ctx context.Context) {
 	defer sentry.Flush(2 * time.Second)
 
 	f.StartIndexing(ctx)
@@ -0,0 +1,40 @@
+package indexer
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+
+	"github.com/initia-labs/core-indexer/pkg/archive"
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
+)
+
+// Reindex indexes the archived block results of the heights from to to, both included, without consuming the message
+// bus. The offsets of the consumer group are left as they are.
+func (f *Indexer) Reindex(from, to int64) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	defer sentry.Flush(2 * time.Second)
+	defer f.close()
+
+	logger.Info().Msgf("Reindexing heights %d to %d from the archive", from, to)
+	err := archive.Replay(ctx, f.storageClient, f.config.ArchiveBucket, f.config.ArchivePrefix, from, to, func(blockResults *mq.BlockResultMsg) error {
+		transaction, ctx := sentry_integration.StartSentryTransaction(ctx, "Reindex", "Reindex archived blocks")
+		defer transaction.Finish()
+
+		return f.processUntilSucceeds(ctx, *blockResults, db.ConsumerOffset{})
+	})
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
+		logger.Error().Msgf("Error reindexing: %v", err)
+		return err
+	}
+
+	logger.Info().Msgf("Reindexed heights %d to %d", from, to)
+	return nil
+}
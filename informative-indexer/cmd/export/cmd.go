@@ -0,0 +1,135 @@
+package export_cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/export"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+const (
+	FlagDBConnectionString = "db"
+	FlagTables             = "tables"
+	FlagFromHeight         = "from-height"
+	FlagToHeight           = "to-height"
+	FlagChunkSize          = "chunk-size"
+	FlagFormat             = "format"
+	FlagBucketName         = "bucket-name"
+	FlagPrefix             = "prefix"
+	FlagChain              = "chain"
+	FlagEnvironment        = "environment"
+	FlagCommitSHA          = "commit-sha"
+)
+
+// ExportCmd exports indexed tables for a height range to Parquet or gzipped NDJSON files.
+func ExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export indexed data for a height range to Parquet or NDJSON files",
+		Long:  "Export indexed data for a height range to Parquet or gzipped NDJSON files partitioned by height, with a manifest used to resume interrupted exports",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
+			tables, _ := cmd.Flags().GetStringSlice(FlagTables)
+			fromHeight, _ := cmd.Flags().GetInt64(FlagFromHeight)
+			toHeight, _ := cmd.Flags().GetInt64(FlagToHeight)
+			chunkSize, _ := cmd.Flags().GetInt64(FlagChunkSize)
+			format, _ := cmd.Flags().GetString(FlagFormat)
+			bucketName, _ := cmd.Flags().GetString(FlagBucketName)
+			prefix, _ := cmd.Flags().GetString(FlagPrefix)
+			chain, _ := cmd.Flags().GetString(FlagChain)
+			environment, _ := cmd.Flags().GetString(FlagEnvironment)
+			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
+
+			logger := zerolog.Ctx(log.With().Str("component", "informative-indexer-export").Str("chain", chain).Str("environment", environment).Str("commit_sha", commitSHA).Logger().WithContext(context.Background()))
+
+			exportFormat, err := export.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			dbClient, err := db.NewClient(dbConnectionString)
+			if err != nil {
+				logger.Error().Msgf("DB: Error creating DB client: %v", err)
+				return err
+			}
+
+			var storageClient storage.Client
+			if environment == "local" {
+				storageClient, err = storage.NewGCSFakeClient()
+			} else {
+				storageClient, err = storage.NewGCSClient()
+			}
+			if err != nil {
+				logger.Error().Msgf("Storage: Error creating storage client: %v", err)
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			// export up to the latest indexed block by default
+			if toHeight <= 0 {
+				toHeight, err = db.GetLatestInformativeBlockHeight(ctx, dbClient)
+				if err != nil {
+					logger.Error().Msgf("DB: Error getting latest block height: %v", err)
+					return err
+				}
+			}
+
+			exporter, err := export.NewExporter(dbClient, storageClient, &export.Config{
+				Tables:     tables,
+				FromHeight: fromHeight,
+				ToHeight:   toHeight,
+				ChunkSize:  chunkSize,
+				Format:     exportFormat,
+				Bucket:     bucketName,
+				Prefix:     prefix,
+			}, logger)
+			if err != nil {
+				return err
+			}
+
+			return exporter.Run(ctx)
+		},
+	}
+
+	chunkSize, err := strconv.ParseInt(os.Getenv("EXPORT_CHUNK_SIZE"), 10, 64)
+	if err != nil {
+		chunkSize = 10000
+	}
+
+	format := os.Getenv("EXPORT_FORMAT")
+	if format == "" {
+		format = string(export.FormatParquet)
+	}
+
+	var tables []string
+	if envTables := os.Getenv("EXPORT_TABLES"); envTables != "" {
+		tables = strings.Split(envTables, ",")
+	}
+
+	cmd.Flags().String(FlagDBConnectionString, os.Getenv("DB_CONNECTION_STRING"), "Database connection string")
+	cmd.Flags().StringSlice(FlagTables, tables, "Tables to export, all exportable tables if empty")
+	cmd.Flags().Int64(FlagFromHeight, 0, "First block height to export")
+	cmd.Flags().Int64(FlagToHeight, 0, "Last block height to export, the latest indexed block if not set")
+	cmd.Flags().Int64(FlagChunkSize, chunkSize, "Number of blocks per exported file")
+	cmd.Flags().String(FlagFormat, format, "Export format: parquet or ndjson")
+	cmd.Flags().String(FlagBucketName, os.Getenv("EXPORT_BUCKET_NAME"), "Name of the export bucket")
+	cmd.Flags().String(FlagPrefix, os.Getenv("EXPORT_PREFIX"), "Object prefix of the export, also locates the manifest to resume from")
+	cmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID")
+	cmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
+	cmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
+
+	return cmd
+}
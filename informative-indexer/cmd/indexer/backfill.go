@@ -0,0 +1,101 @@
+package indexer_cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/core-indexer/informative-indexer/indexer"
+	"github.com/initia-labs/core-indexer/pkg/mq"
+)
+
+const FlagBackfillBatchSize = "batch-size"
+
+// BackfillAccountTypesCmd resolves again the type of the accounts stored as base accounts.
+func BackfillAccountTypesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill-account-types",
+		Short: "Resolves again the type of the accounts stored as base accounts.",
+		Long:  "Resolves again the type of the accounts stored as base accounts, batch after batch from the latest state. The accounts indexed before their type was mapped, vesting accounts among others, were stored as base accounts. It runs once, along the indexer.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			batchSize, _ := cmd.Flags().GetInt(FlagBackfillBatchSize)
+			if batchSize <= 0 {
+				return fmt.Errorf("invalid backfill batch size: %d", batchSize)
+			}
+
+			config := indexerConfig(cmd)
+			// the message bus is neither consumed nor produced to
+			if !cmd.Flags().Changed(FlagMQBackend) {
+				config.MQBackend = mq.BackendMemory
+			}
+
+			f, err := indexer.NewIndexer(config)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			return f.BackfillAccountTypes(ctx, batchSize)
+		},
+	}
+
+	batchSize, err := strconv.Atoi(os.Getenv("BACKFILL_BATCH_SIZE"))
+	if err != nil {
+		batchSize = 1000
+	}
+
+	addIndexerFlags(cmd)
+	cmd.Flags().Int(FlagBackfillBatchSize, batchSize, "Number of accounts resolved per batch")
+
+	return cmd
+}
+
+// BackfillAccountStatsCmd counts the activity of the blocks indexed before the account stats.
+func BackfillAccountStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill-account-stats",
+		Short: "Counts the activity of the blocks indexed before the account stats.",
+		Long:  "Counts the activity of the blocks indexed before the account stats, batch after batch of heights up to the first height counted by the indexer, then counts again the proposals voted on by each account. It resumes from the last backfilled height and runs once, along the indexer, after the indexer counted a block.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			batchSize, _ := cmd.Flags().GetInt(FlagBackfillBatchSize)
+			if batchSize <= 0 {
+				return fmt.Errorf("invalid backfill batch size: %d", batchSize)
+			}
+
+			config := indexerConfig(cmd)
+			// the message bus is neither consumed nor produced to
+			if !cmd.Flags().Changed(FlagMQBackend) {
+				config.MQBackend = mq.BackendMemory
+			}
+
+			f, err := indexer.NewIndexer(config)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			return f.BackfillAccountStats(ctx, batchSize)
+		},
+	}
+
+	batchSize, err := strconv.Atoi(os.Getenv("BACKFILL_BATCH_SIZE"))
+	if err != nil {
+		batchSize = 1000
+	}
+
+	addIndexerFlags(cmd)
+	cmd.Flags().Int(FlagBackfillBatchSize, batchSize, "Number of heights, then of accounts, counted per batch")
+
+	return cmd
+}
@@ -10,12 +10,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/initia-labs/core-indexer/informative-indexer/indexer"
+	"github.com/initia-labs/core-indexer/pkg/mq"
 )
 
 const (
 	FlagID                             = "id"
 	FlagRPCEndpoints                   = "rpc-endpoints"
 	FlagRPCTimeoutInSeconds            = "rpc-timeout-in-seconds"
+	FlagMQBackend                      = "mq-backend"
+	FlagMQDir                          = "mq-dir"
 	FlagKafkaBootstrapServer           = "bootstrap-server"
 	FlagDBConnectionString             = "db"
 	FlagChain                          = "chain"
@@ -41,50 +44,12 @@ func RunCmd() *cobra.Command {
 		Short: "Consumes messages from Kafka and indexes them into the database.",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			rpcEndpoints, _ := cmd.Flags().GetString(FlagRPCEndpoints)
-			rpcTimeoutInSeconds, _ := cmd.Flags().GetInt64(FlagRPCTimeoutInSeconds)
-			kafkaBootstrapServer, _ := cmd.Flags().GetString(FlagKafkaBootstrapServer)
-			chain, _ := cmd.Flags().GetString(FlagChain)
-			dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
-			kafkaAPIKey, _ := cmd.Flags().GetString(FlagKafkaAPIKey)
-			kafkaAPISecret, _ := cmd.Flags().GetString(FlagKafkaAPISecret)
-			kafkaBlockResultsTopic, _ := cmd.Flags().GetString(FlagKafkaBlockResultsTopic)
-			kafkaTxResponseTopic, _ := cmd.Flags().GetString(FlagKafkaTxResponseTopic)
-			kafkaBlockResultsConsumerGroup, _ := cmd.Flags().GetString(FlagKafkaBlockResultsConsumerGroup)
-
-			blockResultsClaimCheckBucket, _ := cmd.Flags().GetString(FlagBlockResultsClaimCheckBucket)
-			lcdTxResponseClaimCheckBucket, _ := cmd.Flags().GetString(FlagLCDTxResponseClaimCheckBucket)
-			claimCheckThresholdInMB, _ := cmd.Flags().GetUint64(FlagClaimCheckThresholdInMB)
-
-			workerID, _ := cmd.Flags().GetString(FlagID)
-
-			environment, _ := cmd.Flags().GetString(FlagEnvironment)
-			sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
-			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
-			sentryProfilesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryProfilesSampleRate)
-			sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
-
-			f, err := indexer.NewIndexer(&indexer.Config{
-				RPCEndpoints:                   rpcEndpoints,
-				RPCTimeoutInSeconds:            rpcTimeoutInSeconds,
-				ID:                             workerID,
-				Chain:                          chain,
-				DBConnectionString:             dbConnectionString,
-				KafkaBootstrapServer:           kafkaBootstrapServer,
-				KafkaAPIKey:                    kafkaAPIKey,
-				KafkaAPISecret:                 kafkaAPISecret,
-				KafkaBlockResultsTopic:         kafkaBlockResultsTopic,
-				KafkaTxResponseTopic:           kafkaTxResponseTopic,
-				KafkaBlockResultsConsumerGroup: kafkaBlockResultsConsumerGroup,
-				BlockResultsClaimCheckBucket:   blockResultsClaimCheckBucket,
-				LCDTxResponseClaimCheckBucket:  lcdTxResponseClaimCheckBucket,
-				ClaimCheckThresholdInMB:        int64(claimCheckThresholdInMB),
-				Environment:                    environment,
-				SentryDSN:                      sentryDSN,
-				CommitSHA:                      commitSHA,
-				SentryProfilesSampleRate:       sentryProfilesSampleRate,
-				SentryTracesSampleRate:         sentryTracesSampleRate,
-			})
+			config := indexerConfig(cmd)
+			if err := mq.CheckStandalone(config.MQBackend); err != nil {
+				return err
+			}
+
+			f, err := indexer.NewIndexer(config)
 			if err != nil {
 				return err
 			}
@@ -100,6 +65,65 @@ func RunCmd() *cobra.Command {
 		},
 	}
 
+	addIndexerFlags(runCmd)
+
+	return runCmd
+}
+
+// indexerConfig returns the indexer config set by the flags of the command
+func indexerConfig(cmd *cobra.Command) *indexer.Config {
+	rpcEndpoints, _ := cmd.Flags().GetString(FlagRPCEndpoints)
+	rpcTimeoutInSeconds, _ := cmd.Flags().GetInt64(FlagRPCTimeoutInSeconds)
+	mqBackend, _ := cmd.Flags().GetString(FlagMQBackend)
+	mqDir, _ := cmd.Flags().GetString(FlagMQDir)
+	kafkaBootstrapServer, _ := cmd.Flags().GetString(FlagKafkaBootstrapServer)
+	chain, _ := cmd.Flags().GetString(FlagChain)
+	dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
+	kafkaAPIKey, _ := cmd.Flags().GetString(FlagKafkaAPIKey)
+	kafkaAPISecret, _ := cmd.Flags().GetString(FlagKafkaAPISecret)
+	kafkaBlockResultsTopic, _ := cmd.Flags().GetString(FlagKafkaBlockResultsTopic)
+	kafkaTxResponseTopic, _ := cmd.Flags().GetString(FlagKafkaTxResponseTopic)
+	kafkaBlockResultsConsumerGroup, _ := cmd.Flags().GetString(FlagKafkaBlockResultsConsumerGroup)
+
+	blockResultsClaimCheckBucket, _ := cmd.Flags().GetString(FlagBlockResultsClaimCheckBucket)
+	lcdTxResponseClaimCheckBucket, _ := cmd.Flags().GetString(FlagLCDTxResponseClaimCheckBucket)
+	claimCheckThresholdInMB, _ := cmd.Flags().GetUint64(FlagClaimCheckThresholdInMB)
+
+	workerID, _ := cmd.Flags().GetString(FlagID)
+
+	environment, _ := cmd.Flags().GetString(FlagEnvironment)
+	sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
+	commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
+	sentryProfilesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryProfilesSampleRate)
+	sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
+
+	return &indexer.Config{
+		RPCEndpoints:                   rpcEndpoints,
+		RPCTimeoutInSeconds:            rpcTimeoutInSeconds,
+		ID:                             workerID,
+		Chain:                          chain,
+		DBConnectionString:             dbConnectionString,
+		MQBackend:                      mqBackend,
+		MQDir:                          mqDir,
+		KafkaBootstrapServer:           kafkaBootstrapServer,
+		KafkaAPIKey:                    kafkaAPIKey,
+		KafkaAPISecret:                 kafkaAPISecret,
+		KafkaBlockResultsTopic:         kafkaBlockResultsTopic,
+		KafkaTxResponseTopic:           kafkaTxResponseTopic,
+		KafkaBlockResultsConsumerGroup: kafkaBlockResultsConsumerGroup,
+		BlockResultsClaimCheckBucket:   blockResultsClaimCheckBucket,
+		LCDTxResponseClaimCheckBucket:  lcdTxResponseClaimCheckBucket,
+		ClaimCheckThresholdInMB:        int64(claimCheckThresholdInMB),
+		Environment:                    environment,
+		SentryDSN:                      sentryDSN,
+		CommitSHA:                      commitSHA,
+		SentryProfilesSampleRate:       sentryProfilesSampleRate,
+		SentryTracesSampleRate:         sentryTracesSampleRate,
+	}
+}
+
+// addIndexerFlags adds the flags of the indexer config to the command
+func addIndexerFlags(cmd *cobra.Command) {
 	rpcTimeOutInSeconds, err := strconv.ParseInt(os.Getenv("RPC_TIMEOUT_IN_SECONDS"), 10, 64)
 	if err != nil {
 		rpcTimeOutInSeconds = 30
@@ -120,25 +144,25 @@ func RunCmd() *cobra.Command {
 		sentryTracesSampleRate = 0.01
 	}
 
-	runCmd.Flags().String(FlagRPCEndpoints, os.Getenv("RPC_ENDPOINTS"), "")
-	runCmd.Flags().String(FlagKafkaBootstrapServer, os.Getenv("BOOTSTRAP_SERVER"), "<host>:<port> to Kafka bootstrap server")
-	runCmd.Flags().Int64(FlagRPCTimeoutInSeconds, rpcTimeOutInSeconds, "RPC timeout in seconds")
-	runCmd.Flags().String(FlagKafkaBlockResultsTopic, os.Getenv("BLOCK_RESULTS_TOPIC"), "Kafka topic to consume block_results message")
-	runCmd.Flags().String(FlagKafkaTxResponseTopic, os.Getenv("TX_RESPONSE_TOPIC"), "Kafka topic about TxResponses to produce")
-	runCmd.Flags().String(FlagKafkaBlockResultsConsumerGroup, os.Getenv("BLOCK_RESULTS_CONSUMER_GROUP"), "Kafka consumer group for block_results topic")
-	runCmd.Flags().String(FlagKafkaAPIKey, os.Getenv("KAFKA_API_KEY"), "Kafka API key")
-	runCmd.Flags().String(FlagKafkaAPISecret, os.Getenv("KAFKA_API_SECRET"), "Kafka API secret")
-	runCmd.Flags().String(FlagDBConnectionString, os.Getenv("DB_CONNECTION_STRING"), "Database connection string")
-	runCmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID to sweep")
-	runCmd.Flags().String(FlagBlockResultsClaimCheckBucket, os.Getenv("BLOCK_RESULTS_CLAIM_CHECK_BUCKET"), "Block results claim check bucket")
-	runCmd.Flags().String(FlagLCDTxResponseClaimCheckBucket, os.Getenv("TX_CLAIM_CHECK_BUCKET"), "LCD TxResponse claim check bucket")
-	runCmd.Flags().Uint64(FlagClaimCheckThresholdInMB, uint64(threshold), "Claim check threshold in MB")
-	runCmd.Flags().String(FlagID, os.Getenv("ID"), "Worker ID")
-	runCmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
-	runCmd.Flags().String(FlagSentryDSN, os.Getenv("SENTRY_DSN"), "Sentry DSN")
-	runCmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
-	runCmd.Flags().Float64(FlagSentryProfilesSampleRate, sentryProfilesSampleRate, "Sentry profiles sample rate")
-	runCmd.Flags().Float64(FlagSentryTracesSampleRate, sentryTracesSampleRate, "Sentry traces sample rate")
-
-	return runCmd
+	cmd.Flags().String(FlagRPCEndpoints, os.Getenv("RPC_ENDPOINTS"), "")
+	cmd.Flags().String(FlagMQBackend, os.Getenv("MQ_BACKEND"), "Message bus backend: kafka (default), memory or file")
+	cmd.Flags().String(FlagMQDir, os.Getenv("MQ_DIR"), "Directory of the file message bus backend")
+	cmd.Flags().String(FlagKafkaBootstrapServer, os.Getenv("BOOTSTRAP_SERVER"), "<host>:<port> to Kafka bootstrap server")
+	cmd.Flags().Int64(FlagRPCTimeoutInSeconds, rpcTimeOutInSeconds, "RPC timeout in seconds")
+	cmd.Flags().String(FlagKafkaBlockResultsTopic, os.Getenv("BLOCK_RESULTS_TOPIC"), "Kafka topic to consume block_results message")
+	cmd.Flags().String(FlagKafkaTxResponseTopic, os.Getenv("TX_RESPONSE_TOPIC"), "Kafka topic about TxResponses to produce")
+	cmd.Flags().String(FlagKafkaBlockResultsConsumerGroup, os.Getenv("BLOCK_RESULTS_CONSUMER_GROUP"), "Kafka consumer group for block_results topic")
+	cmd.Flags().String(FlagKafkaAPIKey, os.Getenv("KAFKA_API_KEY"), "Kafka API key")
+	cmd.Flags().String(FlagKafkaAPISecret, os.Getenv("KAFKA_API_SECRET"), "Kafka API secret")
+	cmd.Flags().String(FlagDBConnectionString, os.Getenv("DB_CONNECTION_STRING"), "Database connection string")
+	cmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID to sweep")
+	cmd.Flags().String(FlagBlockResultsClaimCheckBucket, os.Getenv("BLOCK_RESULTS_CLAIM_CHECK_BUCKET"), "Block results claim check bucket")
+	cmd.Flags().String(FlagLCDTxResponseClaimCheckBucket, os.Getenv("TX_CLAIM_CHECK_BUCKET"), "LCD TxResponse claim check bucket")
+	cmd.Flags().Uint64(FlagClaimCheckThresholdInMB, uint64(threshold), "Claim check threshold in MB")
+	cmd.Flags().String(FlagID, os.Getenv("ID"), "Worker ID")
+	cmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
+	cmd.Flags().String(FlagSentryDSN, os.Getenv("SENTRY_DSN"), "Sentry DSN")
+	cmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
+	cmd.Flags().Float64(FlagSentryProfilesSampleRate, sentryProfilesSampleRate, "Sentry profiles sample rate")
+	cmd.Flags().Float64(FlagSentryTracesSampleRate, sentryTracesSampleRate, "Sentry traces sample rate")
 }
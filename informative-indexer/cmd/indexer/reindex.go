@@ -0,0 +1,83 @@
+package indexer_cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/core-indexer/informative-indexer/indexer"
+	"github.com/initia-labs/core-indexer/pkg/archive"
+	"github.com/initia-labs/core-indexer/pkg/mq"
+)
+
+const (
+	FlagReindexFrom   = "from"
+	FlagReindexTo     = "to"
+	FlagReindexSource = "source"
+	FlagArchiveBucket = "archive-bucket"
+	FlagArchivePrefix = "archive-prefix"
+
+	// ReindexSourceArchive reads the bundles written by the sweeper archiver
+	ReindexSourceArchive = "archive"
+)
+
+// ReindexCmd indexes a range of heights from the block results archive into the database.
+func ReindexCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Indexes a range of heights from the block results archive into the database.",
+		Long:  "Indexes a range of heights from the block results archive into the database. The informative state is built block after block, so the reindex only rolls forward: the heights up to the latest indexed one are skipped, --from must not be past the height following it and --to must reach it. Rebuilding a range already indexed requires restoring the database to the height preceding it.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, _ := cmd.Flags().GetInt64(FlagReindexFrom)
+			to, _ := cmd.Flags().GetInt64(FlagReindexTo)
+			source, _ := cmd.Flags().GetString(FlagReindexSource)
+			if source != ReindexSourceArchive {
+				return fmt.Errorf("unsupported reindex source: %q", source)
+			}
+			if from <= 0 {
+				return fmt.Errorf("invalid reindex start height: %d", from)
+			}
+
+			config := indexerConfig(cmd)
+			config.ArchiveBucket, _ = cmd.Flags().GetString(FlagArchiveBucket)
+			config.ArchivePrefix, _ = cmd.Flags().GetString(FlagArchivePrefix)
+			// the message bus is not consumed, the messages produced while reindexing are dropped unless a backend is set
+			if !cmd.Flags().Changed(FlagMQBackend) {
+				config.MQBackend = mq.BackendMemory
+			}
+
+			f, err := indexer.NewIndexer(config)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			return f.Reindex(ctx, from, to)
+		},
+	}
+
+	addIndexerFlags(cmd)
+	addReindexFlags(cmd)
+
+	return cmd
+}
+
+func addReindexFlags(cmd *cobra.Command) {
+	prefix := os.Getenv("ARCHIVE_PREFIX")
+	if prefix == "" {
+		prefix = archive.DefaultConfig().Prefix
+	}
+
+	cmd.Flags().Int64(FlagReindexFrom, 0, "First height to reindex, at most the height following the latest indexed one")
+	cmd.Flags().Int64(FlagReindexTo, 0, "Last height to reindex, the last archived height when 0")
+	cmd.Flags().String(FlagReindexSource, ReindexSourceArchive, "Source of the block results, only archive is supported")
+	cmd.Flags().String(FlagArchiveBucket, os.Getenv("ARCHIVE_BUCKET"), "Bucket of the block results archive")
+	cmd.Flags().String(FlagArchivePrefix, prefix, "Path of the bundles in the archive bucket")
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	export "github.com/initia-labs/core-indexer/informative-indexer/cmd/export"
 	indexer "github.com/initia-labs/core-indexer/informative-indexer/cmd/indexer"
 	migrate "github.com/initia-labs/core-indexer/informative-indexer/cmd/migrate"
 )
@@ -19,6 +20,10 @@ func Execute() {
 	rootCmd.AddCommand(
 		migrate.MigrateCmd(),
 		indexer.RunCmd(),
+		indexer.ReindexCmd(),
+		indexer.BackfillAccountTypesCmd(),
+		indexer.BackfillAccountStatsCmd(),
+		export.ExportCmd(),
 	)
 
 	err := rootCmd.Execute()
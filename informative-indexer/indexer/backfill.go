@@ -0,0 +1,109 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"gorm.io/gorm"
+
+	statetracker "github.com/initia-labs/core-indexer/informative-indexer/indexer/state-tracker"
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+// BackfillAccountTypes resolves again the type of the accounts stored as base accounts, batchSize accounts at a time
+// from the latest state. The accounts indexed before their type was mapped, vesting accounts among others, were stored
+// as base accounts. It can run along the indexer, which only stores the types it resolves itself.
+func (f *Indexer) BackfillAccountTypes(ctx context.Context, batchSize int) error {
+	defer sentry.Flush(2 * time.Second)
+	defer f.close()
+
+	after := ""
+	checked, updated := 0, 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		addresses, err := db.QueryAccountAddressesByType(ctx, f.dbClient, db.BaseAccount, after, batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to query base accounts: %w", err)
+		}
+		if len(addresses) == 0 {
+			break
+		}
+
+		accountTypes, err := statetracker.FetchAccountTypes(ctx, f.rpcClient, addresses, nil)
+		if err != nil {
+			return err
+		}
+		changed := make(map[string]db.AccountType)
+		for idx, address := range addresses {
+			if accountTypes[idx] != db.BaseAccount {
+				changed[address] = accountTypes[idx]
+			}
+		}
+		if err := db.UpdateAccountTypes(ctx, f.dbClient, changed); err != nil {
+			return fmt.Errorf("failed to update account types: %w", err)
+		}
+
+		checked += len(addresses)
+		updated += len(changed)
+		after = addresses[len(addresses)-1]
+		logger.Info().Msgf("Backfilled account types up to %s: %d accounts checked, %d updated", after, checked, updated)
+	}
+
+	logger.Info().Msgf("Backfilled account types: %d accounts checked, %d updated", checked, updated)
+	return nil
+}
+
+// BackfillAccountStats counts the activity of the blocks indexed before the account stats, batchSize heights at a time
+// up to the first height counted by the indexer, then counts again the proposals voted on by each account. Every batch
+// is committed along the backfilled height, so that it resumes where it stopped. It can run along the indexer once
+// the indexer counted a block.
+func (f *Indexer) BackfillAccountStats(ctx context.Context, batchSize int) error {
+	defer sentry.Flush(2 * time.Second)
+	defer f.close()
+
+	for left := true; left; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var height int64
+		if err := f.dbClient.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+			var err error
+			height, left, err = db.BackfillAccountStats(ctx, dbTx, int64(batchSize))
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to backfill account stats: %w", err)
+		}
+		logger.Info().Msgf("Backfilled account stats up to height %d", height)
+	}
+
+	after := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var last string
+		if err := f.dbClient.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+			var err error
+			last, err = db.RecountAccountProposalsVoted(ctx, dbTx, after, batchSize)
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to recount proposals voted: %w", err)
+		}
+		if last == "" {
+			break
+		}
+
+		after = last
+		logger.Info().Msgf("Recounted the proposals voted on up to %s", after)
+	}
+
+	logger.Info().Msg("Backfilled account stats")
+	return nil
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 
@@ -43,9 +42,9 @@ func (f *Indexer) parseAndInsertBlock(parentCtx context.Context, dbTx *gorm.DB,
 
 func (f *Indexer) produceTxResponseMessage(txHash string, height int64, txResultJsonBytes []byte, logger *zerolog.Logger) {
 	messageKey := mq.NEW_LCD_TX_RESPONSE_KAFKA_MESSAGE_KEY + fmt.Sprintf("_%s", txHash)
-	kafkaMessage := kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &f.config.KafkaTxResponseTopic, Partition: int32(kafka.PartitionAny)},
-		Headers: []kafka.Header{
+	message := mq.Message{
+		Topic: f.config.KafkaTxResponseTopic,
+		Headers: []mq.Header{
 			{Key: "height", Value: fmt.Append(nil, height)},
 			{Key: "tx_hash", Value: fmt.Append(nil, txHash)},
 			{Key: "service", Value: fmt.Append(nil, "informative-indexer")},
@@ -54,10 +53,11 @@ func (f *Indexer) produceTxResponseMessage(txHash string, height int64, txResult
 		Value: txResultJsonBytes,
 	}
 
-	f.producer.ProduceWithClaimCheck(&mq.ProduceWithClaimCheckInput{
+	mq.ProduceWithClaimCheck(f.producer, &mq.ProduceWithClaimCheckInput{
 		Topic:          f.config.KafkaTxResponseTopic,
-		Key:            kafkaMessage.Key,
-		MessageInBytes: kafkaMessage.Value,
+		Key:            message.Key,
+		MessageInBytes: message.Value,
+		Producer:       "informative-indexer",
 
 		ClaimCheckKey:           []byte(mq.NEW_LCD_TX_RESPONSE_CLAIM_CHECK_KAFKA_MESSAGE_KEY + fmt.Sprintf("_%s", txHash)),
 		ClaimCheckThresholdInMB: f.config.ClaimCheckThresholdInMB,
@@ -66,7 +66,7 @@ func (f *Indexer) produceTxResponseMessage(txHash string, height int64, txResult
 
 		StorageClient: f.storageClient,
 
-		Headers: kafkaMessage.Headers,
+		Headers: message.Headers,
 	}, logger)
 }
 
@@ -108,7 +108,7 @@ func (f *Indexer) processTransactions(parentCtx context.Context, blockResults *m
 	return nil
 }
 
-func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *mq.BlockResultMsg, proposer *db.ValidatorAddress) error {
+func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *mq.BlockResultMsg, proposer *db.ValidatorAddress, offset db.ConsumerOffset) error {
 	span, ctx := sentry_integration.StartSentrySpan(parentCtx, "processBlockResults", "Parse block_results message and insert tx events into the database")
 	defer span.Finish()
 
@@ -126,6 +126,11 @@ func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *m
 		for _, processor := range f.processors {
 			processor.InitProcessor(blockResults.Height, f.cacher)
 
+			if err := processor.ProcessMisbehaviors(blockResults.Misbehaviors); err != nil {
+				logger.Error().Msgf("Error processing %s misbehaviors: %v", processor.Name(), err)
+				return err
+			}
+
 			if err := processor.ProcessBeginBlockEvents(&blockResults.FinalizeBlockEvents); err != nil {
 				logger.Error().Msgf("Error processing %s messages: %v", processor.Name(), err)
 				return err
@@ -149,7 +154,7 @@ func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *m
 			}
 		}
 
-		if err := f.stateUpdateManager.UpdateState(ctx, f.rpcClient); err != nil {
+		if err := f.stateUpdateManager.UpdateState(ctx, dbTx, f.rpcClient); err != nil {
 			logger.Error().Msgf("Error updating state: %v", err)
 			return err
 		}
@@ -158,6 +163,16 @@ func (f *Indexer) processBlockResults(parentCtx context.Context, blockResults *m
 			logger.Error().Msgf("Error flushing batch insert: %v", err)
 			return err
 		}
+
+		if err := f.processValidator(ctx, dbTx, blockResults, proposer); err != nil {
+			logger.Error().Int64("height", blockResults.Height).Msgf("Error validating block validators: %v", err)
+			return err
+		}
+
+		if err := db.UpsertConsumerOffset(ctx, dbTx, offset); err != nil {
+			logger.Error().Int64("height", blockResults.Height).Msgf("Error recording consumer offset: %v", err)
+			return err
+		}
 		return nil
 	}); err != nil {
 		logger.Error().Int64("height", blockResults.Height).Msgf("Error processing block: %v", err)
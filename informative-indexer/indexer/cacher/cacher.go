@@ -1,18 +1,28 @@
 package cacher
 
 import (
+	lru "github.com/hashicorp/golang-lru/v2"
+
 	"github.com/initia-labs/core-indexer/pkg/db"
 )
 
+// accountTypesCacheSize bounds the account types cached, the least recently used ones are evicted and read from the
+// database again
+const accountTypesCacheSize = 100_000
+
 type Cacher struct {
 	valAccAddrToOperator  map[string]db.ValidatorAddress
 	valConsAddrToOperator map[string]db.ValidatorAddress
+	accountTypes          *lru.Cache[string, db.AccountType]
 }
 
 func NewCacher() *Cacher {
+	// the size is positive, which is the only error of lru.New
+	accountTypes, _ := lru.New[string, db.AccountType](accountTypesCacheSize)
 	return &Cacher{
 		valAccAddrToOperator:  make(map[string]db.ValidatorAddress),
 		valConsAddrToOperator: make(map[string]db.ValidatorAddress),
+		accountTypes:          accountTypes,
 	}
 }
 
@@ -37,3 +47,11 @@ func (c *Cacher) GetValidatorByConsAddr(consAddress string) (db.ValidatorAddress
 	validator, ok := c.valConsAddrToOperator[consAddress]
 	return validator, ok
 }
+
+func (c *Cacher) SetAccountType(address string, accountType db.AccountType) {
+	c.accountTypes.Add(address, accountType)
+}
+
+func (c *Cacher) GetAccountType(address string) (db.AccountType, bool) {
+	return c.accountTypes.Get(address)
+}
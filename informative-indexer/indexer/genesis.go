@@ -61,10 +61,12 @@ func (f *Indexer) StartFromGenesis(ctx context.Context, logger *zerolog.Logger)
 
 		accAddr := a.GetAddress()
 		vmAddr, _ := vmtypes.NewAccountAddressFromBytes(accAddr)
+		accountType := db.AccountTypeFromTypeURL(account.GetTypeUrl())
+		f.cacher.SetAccountType(accAddr.String(), accountType)
 		dbBatchInsert.AddAccounts(db.Account{
 			Address:   sdk.AccAddress(accAddr).String(),
 			VMAddress: db.VMAddress{VMAddress: vmAddr.String()},
-			Type:      string(db.BaseAccount),
+			Type:      string(accountType),
 		})
 	}
 
@@ -99,10 +101,15 @@ func (f *Indexer) StartFromGenesis(ctx context.Context, logger *zerolog.Logger)
 				if err != nil {
 					return err
 				}
+				// keep the type of the validator's auth genesis account, if any
+				accountType, ok := f.cacher.GetAccountType(accAddr.String())
+				if !ok {
+					accountType = db.BaseAccount
+				}
 				dbBatchInsert.AddAccounts(db.Account{
 					Address:   accAddr.String(),
 					VMAddress: db.VMAddress{VMAddress: vmAddr.String()},
-					Type:      string(db.BaseAccount),
+					Type:      string(accountType),
 				})
 
 				validator, err := db.NewGenesisValidator(accAddr.String(), msg)
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/certifi/gocertifi"
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/getsentry/sentry-go"
 	initiaapp "github.com/initia-labs/initia/app"
 	"github.com/initia-labs/initia/app/params"
@@ -38,8 +37,8 @@ import (
 var logger *zerolog.Logger
 
 type Indexer struct {
-	consumer      *mq.Consumer
-	producer      *mq.Producer
+	consumer      mq.Consumer
+	producer      mq.Producer
 	dbClient      *gorm.DB
 	storageClient storage.Client
 	config        *Config
@@ -66,6 +65,12 @@ type Config struct {
 	RPCEndpoints        string
 	RPCTimeoutInSeconds int64
 
+	// MQBackend selects the message bus, kafka when empty, see mq.Config
+	MQBackend string
+	MQDir     string
+	// MQMemoryBus is the bus of the memory backend, shared with the services run in the same process
+	MQMemoryBus *mq.MemoryBus
+
 	// Kafka config
 	KafkaBootstrapServer           string
 	KafkaBlockResultsTopic         string
@@ -79,6 +84,10 @@ type Config struct {
 	BlockResultsClaimCheckBucket  string
 	LCDTxResponseClaimCheckBucket string
 
+	// Archive config, the block results bundles read by reindex
+	ArchiveBucket string
+	ArchivePrefix string
+
 	Environment              string
 	SentryDSN                string
 	CommitSHA                string
@@ -124,69 +133,29 @@ func NewIndexer(config *Config) (*Indexer, error) {
 		return nil, err
 	}
 
-	var consumer *mq.Consumer
-
-	if config.Environment == "local" {
-		consumer, err = mq.NewConsumer(&kafka.ConfigMap{
-			"bootstrap.servers":    config.KafkaBootstrapServer,
-			"group.id":             config.KafkaBlockResultsConsumerGroup,
-			"client.id":            config.KafkaBlockResultsConsumerGroup + "-" + config.ID,
-			"enable.auto.commit":   false,
-			"auto.offset.reset":    "earliest",
-			"security.protocol":    "PLAINTEXT",
-			"max.poll.interval.ms": 600000,
-		})
-	} else {
-		consumer, err = mq.NewConsumer(&kafka.ConfigMap{
-			"bootstrap.servers":    config.KafkaBootstrapServer,
-			"group.id":             config.KafkaBlockResultsConsumerGroup,
-			"client.id":            config.KafkaBlockResultsConsumerGroup + "-" + config.ID,
-			"enable.auto.commit":   false,
-			"auto.offset.reset":    "earliest",
-			"security.protocol":    "SASL_SSL",
-			"sasl.mechanisms":      "PLAIN",
-			"sasl.username":        config.KafkaAPIKey,
-			"sasl.password":        config.KafkaAPISecret,
-			"max.poll.interval.ms": 600000,
-		})
+	mqConfig := mq.Config{
+		Backend: config.MQBackend,
+		Dir:     config.MQDir,
+		Memory:  config.MQMemoryBus,
+		Kafka: mq.KafkaConfig{
+			BootstrapServer: config.KafkaBootstrapServer,
+			ClientID:        config.KafkaBlockResultsConsumerGroup + "-" + config.ID,
+			APIKey:          config.KafkaAPIKey,
+			APISecret:       config.KafkaAPISecret,
+			Plaintext:       config.Environment == "local",
+		},
 	}
-
+	consumer, err := mq.NewConsumer(mqConfig, config.KafkaBlockResultsConsumerGroup)
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
-		logger.Fatal().Msgf("Kafka: Error creating consumer: %v\n", err)
+		logger.Fatal().Msgf("MQ: Error creating consumer: %v\n", err)
 		return nil, err
 	}
 
-	var producer *mq.Producer
-
-	if config.Environment == "local" {
-		producer, err = mq.NewProducer(&kafka.ConfigMap{
-			"bootstrap.servers": config.KafkaBootstrapServer,
-			"client.id":         config.KafkaBlockResultsConsumerGroup + "-" + config.ID,
-			"acks":              "all",
-			"linger.ms":         200,
-			"security.protocol": "PLAINTEXT",
-			"message.max.bytes": 7340032,
-			"compression.codec": "lz4",
-		})
-	} else {
-		producer, err = mq.NewProducer(&kafka.ConfigMap{
-			"bootstrap.servers": config.KafkaBootstrapServer,
-			"client.id":         config.KafkaBlockResultsConsumerGroup + "-" + config.ID,
-			"acks":              "all",
-			"linger.ms":         200,
-			"security.protocol": "SASL_SSL",
-			"sasl.mechanisms":   "PLAIN",
-			"sasl.username":     config.KafkaAPIKey,
-			"sasl.password":     config.KafkaAPISecret,
-			"message.max.bytes": 7340032,
-			"compression.codec": "lz4",
-		})
-	}
-
+	producer, err := mq.NewProducer(mqConfig, logger)
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
-		logger.Fatal().Msgf("Kafka: Error creating producer: %v\n", err)
+		logger.Fatal().Msgf("MQ: Error creating producer: %v\n", err)
 		return nil, err
 	}
 
@@ -273,21 +242,20 @@ func (f *Indexer) RunPatcher(ctx context.Context) {
 	}
 }
 
-func (f *Indexer) parseBlockResults(parentCtx context.Context, blockResultsBytes []byte) (mq.BlockResultMsg, error) {
+func (f *Indexer) parseBlockResults(parentCtx context.Context, encoding string, blockResultsBytes []byte) (mq.BlockResultMsg, error) {
 	span, _ := sentry_integration.StartSentrySpan(parentCtx, "parseBlockResults", "Parsing block_results")
 	defer span.Finish()
 
-	var blockResultsMsg mq.BlockResultMsg
-	err := json.Unmarshal(blockResultsBytes, &blockResultsMsg)
+	blockResultsMsg, err := mq.DecodeBlockResultMsg(encoding, blockResultsBytes)
 	if err != nil {
-		logger.Error().Msgf("Error unmarshalling message: %v", err)
+		logger.Error().Msgf("Error decoding message: %v", err)
 		return blockResultsMsg, err
 	}
 
 	return blockResultsMsg, err
 }
 
-func (f *Indexer) processUntilSucceeds(ctx context.Context, blockResults mq.BlockResultMsg) error {
+func (f *Indexer) processUntilSucceeds(ctx context.Context, blockResults mq.BlockResultMsg, offset db.ConsumerOffset) error {
 	proposer, ok := f.cacher.GetValidatorByConsAddr(blockResults.ProposerConsensusAddress)
 	if !ok {
 		logger.Error().Msgf("Failed to get proposer operator address")
@@ -295,7 +263,7 @@ func (f *Indexer) processUntilSucceeds(ctx context.Context, blockResults mq.Bloc
 
 	// Process the block_results until success
 	for {
-		err := f.processBlockResults(ctx, &blockResults, &proposer)
+		err := f.processBlockResults(ctx, &blockResults, &proposer, offset)
 		if err != nil {
 			if errors.Is(err, indexererrors.ErrorNonRetryable) {
 				return err
@@ -308,22 +276,6 @@ func (f *Indexer) processUntilSucceeds(ctx context.Context, blockResults mq.Bloc
 		break
 	}
 
-	for {
-		err := f.processValidator(ctx, &blockResults, &proposer)
-		if err != nil {
-			if errors.Is(err, indexererrors.ErrorNonRetryable) {
-				return err
-			}
-
-			sentry_integration.CaptureCurrentHubException(err, sentry.LevelWarning)
-			logger.Error().Msgf("Error validating block validators: %v, retrying...", err)
-			time.Sleep(10 * time.Second)
-
-			continue
-		}
-		break
-	}
-
 	return nil
 }
 
@@ -351,13 +303,13 @@ func (f *Indexer) processClaimCheckMessage(key []byte, messageValue []byte) ([]b
 	return messageValue, nil
 }
 
-func (f *Indexer) processKafkaMessage(ctx context.Context, message *kafka.Message) error {
+func (f *Indexer) processKafkaMessage(ctx context.Context, message *mq.Message) error {
 	messageValue, err := f.processClaimCheckMessage(message.Key, message.Value)
 	if err != nil {
 		logger.Error().Msgf("Error processing claim check message: %v", err)
 		return err
 	}
-	blockResultsMsg, err := f.parseBlockResults(ctx, messageValue)
+	blockResultsMsg, err := f.parseBlockResults(ctx, mq.MessageEncoding(message), messageValue)
 	if err != nil {
 		logger.Error().Msgf("Error processing block_results message: %v", err)
 		return err
@@ -373,7 +325,7 @@ func (f *Indexer) processKafkaMessage(ctx context.Context, message *kafka.Messag
 		scope.SetTag("height", fmt.Sprint(blockResultsMsg.Height))
 	})
 
-	err = f.processUntilSucceeds(ctx, blockResultsMsg)
+	err = f.processUntilSucceeds(ctx, blockResultsMsg, f.consumerOffset(message))
 	if err != nil {
 		logger.Error().Msgf("Error processing block_results: %v", err)
 		return err
@@ -382,13 +334,28 @@ func (f *Indexer) processKafkaMessage(ctx context.Context, message *kafka.Messag
 	return nil
 }
 
+// consumerOffset is the offset recorded along the data indexed from the message
+func (f *Indexer) consumerOffset(message *mq.Message) db.ConsumerOffset {
+	return db.ConsumerOffset{
+		ConsumerGroup: f.config.KafkaBlockResultsConsumerGroup,
+		Topic:         message.Topic,
+		Partition:     message.Partition,
+		Offset:        message.Offset,
+	}
+}
+
+// loadConsumerOffset resumes a partition assigned to the indexer after the last message whose data was committed
+func (f *Indexer) loadConsumerOffset(topic string, partition int32) (int64, bool, error) {
+	return db.QueryConsumerOffset(context.Background(), f.dbClient, f.config.KafkaBlockResultsConsumerGroup, topic, partition)
+}
+
 func (f *Indexer) close() {
 	sqlDB, err := f.dbClient.DB()
 	if err == nil {
 		sqlDB.Close()
 	}
 
-	f.producer.Flush(30000)
+	f.producer.Flush(30 * time.Second)
 	f.producer.Close()
 
 	f.consumer.Close()
@@ -411,9 +378,8 @@ func (f *Indexer) StartIndexing(stopCtx context.Context) {
 			panic(err)
 		}
 	}
-	f.producer.ListenToKafkaProduceEvents(logger)
 
-	err = f.consumer.SubscribeTopics([]string{f.config.KafkaBlockResultsTopic}, nil)
+	err = f.consumer.SubscribeTopicsFrom([]string{f.config.KafkaBlockResultsTopic}, f.loadConsumerOffset)
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
 		logger.Fatal().Msgf("Failed to subscribe to topic: %s\n", err)
@@ -437,9 +403,14 @@ func (f *Indexer) StartIndexing(stopCtx context.Context) {
 
 			message, err := f.consumer.ReadMessage(10 * time.Second)
 			if err != nil {
-				if err.(kafka.Error).IsTimeout() {
+				if errors.Is(err, mq.ErrTimeout) {
 					continue
 				}
+				if errors.Is(err, mq.ErrOffsetsNotLoaded) {
+					// the partitions are not read until the consumer is restarted
+					sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+					logger.Fatal().Msgf("Error reading message: %v", err)
+				}
 
 				sentry_integration.CaptureCurrentHubException(err, sentry.LevelWarning)
 				logger.Error().Msgf("Error reading message: %v", err)
@@ -448,8 +419,8 @@ func (f *Indexer) StartIndexing(stopCtx context.Context) {
 
 			sentry.ConfigureScope(func(scope *sentry.Scope) {
 				scope.SetTag("id", f.config.ID)
-				scope.SetTag("partition", fmt.Sprint(message.TopicPartition.Partition))
-				scope.SetTag("offset", message.TopicPartition.Offset.String())
+				scope.SetTag("partition", fmt.Sprint(message.Partition))
+				scope.SetTag("offset", fmt.Sprint(message.Offset))
 			})
 
 			transaction, ctx := sentry_integration.StartSentryTransaction(ctx, "Index", "Process and index informative block_results messages")
@@ -459,7 +430,7 @@ func (f *Indexer) StartIndexing(stopCtx context.Context) {
 				logger.Fatal().Msgf("Error process kafka message: %v", err)
 			}
 
-			_, err = f.consumer.CommitMessage(message)
+			err = f.consumer.CommitMessage(message)
 			if err != nil {
 				sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
 				logger.Error().Msgf("Non-retryable Error committing message: %v", err)
@@ -1,10 +1,18 @@
 package account
 
 import (
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/initia-labs/initia/app/params"
+	movetypes "github.com/initia-labs/initia/x/move/types"
 
 	"github.com/initia-labs/core-indexer/informative-indexer/indexer/cacher"
 	statetracker "github.com/initia-labs/core-indexer/informative-indexer/indexer/state-tracker"
+	"github.com/initia-labs/core-indexer/informative-indexer/indexer/types"
+	"github.com/initia-labs/core-indexer/informative-indexer/indexer/utils"
 	"github.com/initia-labs/core-indexer/pkg/db"
 	"github.com/initia-labs/core-indexer/pkg/mq"
 	"github.com/initia-labs/core-indexer/pkg/parser"
@@ -15,6 +23,7 @@ func (p *Processor) InitProcessor(height int64, cacher *cacher.Cacher) {
 	p.Cacher = cacher
 	p.accounts = make(map[string]db.Account)
 	p.accountsInTx = make(map[statetracker.AccountTxKey]db.AccountTransaction)
+	p.accountsToRecheck = make(map[string]bool)
 }
 
 func (p *Processor) Name() string {
@@ -28,6 +37,31 @@ func (p *Processor) NewTxProcessor(txData *db.Transaction) {
 	}
 }
 
+// ProcessSDKMessages marks the accounts created by vesting messages to have their type rechecked
+func (p *Processor) ProcessSDKMessages(tx *mq.TxResult, encodingConfig *params.EncodingConfig) error {
+	if !tx.ExecTxResults.IsOK() {
+		return nil
+	}
+
+	sdkTx, err := encodingConfig.TxConfig.TxDecoder()(tx.Tx)
+	if err != nil {
+		return fmt.Errorf("failed to decode SDK transaction: %w", err)
+	}
+
+	for _, msg := range sdkTx.GetMsgs() {
+		switch msg := msg.(type) {
+		case *vestingtypes.MsgCreateVestingAccount:
+			p.accountsToRecheck[msg.ToAddress] = true
+		case *vestingtypes.MsgCreatePermanentLockedAccount:
+			p.accountsToRecheck[msg.ToAddress] = true
+		case *vestingtypes.MsgCreatePeriodicVestingAccount:
+			p.accountsToRecheck[msg.ToAddress] = true
+		}
+	}
+
+	return nil
+}
+
 func (p *Processor) ProcessTransactionEvents(tx *mq.TxResult) error {
 	relatedAccs, err := parser.GrepAddressesFromEvents(tx.ExecTxResults.Events)
 	if err != nil {
@@ -35,9 +69,35 @@ func (p *Processor) ProcessTransactionEvents(tx *mq.TxResult) error {
 	}
 	p.txProcessor.relatedAccs = relatedAccs
 
+	for _, event := range tx.ExecTxResults.Events {
+		if err := p.handleObjectCreateEvent(event); err != nil {
+			return fmt.Errorf("failed to handle tx event %s: %w", event.Type, err)
+		}
+	}
+
 	return nil
 }
 
+// handleObjectCreateEvent marks created objects to have their type rechecked, since
+// object creation overwrites an existing account with an object account
+func (p *Processor) handleObjectCreateEvent(event abci.Event) error {
+	if event.Type != movetypes.EventTypeMove {
+		return nil
+	}
+	if value, found := utils.FindAttribute(event.Attributes, movetypes.AttributeKeyTypeTag); !found || value != types.ObjectCreateEventKey {
+		return nil
+	}
+
+	return utils.HandleEventWithKey(event, movetypes.AttributeKeyData, nil, func(e types.ObjectCreateEvent) error {
+		addr, err := parser.AccAddressFromString(e.Object)
+		if err != nil {
+			return err
+		}
+		p.accountsToRecheck[addr.String()] = true
+		return nil
+	})
+}
+
 func (p *Processor) ResolveTxProcessor() error {
 	for _, acc := range p.txProcessor.relatedAccs {
 		account := db.NewAccountFromSDKAddress(acc)
@@ -57,5 +117,8 @@ func (p *Processor) ResolveTxProcessor() error {
 
 func (p *Processor) TrackState(stateUpdateManager *statetracker.StateUpdateManager, dbBatchInsert *statetracker.DBBatchInsert) error {
 	dbBatchInsert.AddAccountsInTx(p.accounts, p.accountsInTx)
+	for address := range p.accountsToRecheck {
+		stateUpdateManager.AccountsToRecheck[address] = true
+	}
 	return nil
 }
@@ -19,6 +19,8 @@ type Processor struct {
 	processors.BaseProcessor
 	accounts     map[string]db.Account
 	accountsInTx map[statetracker.AccountTxKey]db.AccountTransaction
+	// accountsToRecheck holds accounts whose type may have changed in the block
+	accountsToRecheck map[string]bool
 
 	txProcessor *TxProcessor
 }
@@ -23,6 +23,8 @@ func (p *Processor) InitProcessor(height int64, cacher *cacher.Cacher) {
 	p.mintedNftTransactions = make([]db.NftTransaction, 0)
 	p.burnedNftTransactions = make([]db.NftTransaction, 0)
 	p.objectOwners = make(map[string]string)
+	p.objectOwnerChanges = make([]db.NftHistory, 0)
+	p.functionCalls = make([]db.ModuleFunctionCall, 0)
 
 	p.modulePublishedEvents = make([]db.ModuleHistory, 0)
 	p.collectionMutationEvents = make([]db.CollectionMutationEvent, 0)
@@ -35,25 +37,29 @@ func (p *Processor) Name() string {
 
 func (p *Processor) NewTxProcessor(txData *db.Transaction) {
 	p.txProcessor = &TxProcessor{
-		txData:      txData,
-		modulesInTx: make(map[vmapi.ModuleInfoResponse]bool),
-		nftsMap:     make(map[string]string),
+		txData:             txData,
+		modulesInTx:        make(map[vmapi.ModuleInfoResponse]bool),
+		nftsMap:            make(map[string]string),
+		objectOwnerChanges: make(map[string]int),
 	}
 }
 
-// ProcessSDKMessages processes SDK transaction messages to identify entry points
+// ProcessSDKMessages processes SDK transaction messages to identify entry points,
+// and records the function calls of both successful and failed transactions
 func (p *Processor) ProcessSDKMessages(tx *mq.TxResult, encodingConfig *params.EncodingConfig) error {
-	if !tx.ExecTxResults.IsOK() {
-		return nil
-	}
-
 	sdkTx, err := encodingConfig.TxConfig.TxDecoder()(tx.Tx)
 	if err != nil {
+		if !tx.ExecTxResults.IsOK() {
+			return nil
+		}
 		return fmt.Errorf("failed to decode SDK transaction: %w", err)
 	}
 
-	for _, msg := range sdkTx.GetMsgs() {
-		p.handleMsg(msg, tx.ExecTxResults.IsOK())
+	for idx, msg := range sdkTx.GetMsgs() {
+		p.recordFunctionCall(int32(idx), msg, tx.ExecTxResults)
+		if tx.ExecTxResults.IsOK() {
+			p.handleMsg(msg, true)
+		}
 	}
 
 	return nil
@@ -89,6 +95,7 @@ func (p *Processor) TrackState(stateUpdateManager *statetracker.StateUpdateManag
 	}
 
 	dbBatchInsert.ModuleTransactions = append(dbBatchInsert.ModuleTransactions, p.moduleTransactions...)
+	dbBatchInsert.ModuleFunctionCalls = append(dbBatchInsert.ModuleFunctionCalls, p.functionCalls...)
 
 	// Update collections
 	for _, collection := range p.newCollections {
@@ -112,11 +119,17 @@ func (p *Processor) TrackState(stateUpdateManager *statetracker.StateUpdateManag
 	// Update object transfers
 	for object, owner := range p.objectOwners {
 		dbBatchInsert.ObjectNewOwners[object] = owner
-		dbBatchInsert.TransferredNftTransactions = append(
-			dbBatchInsert.TransferredNftTransactions,
-			db.NewNftTransferTransaction(object, p.txProcessor.txData.ID, p.Height),
-		)
 	}
+	for _, change := range p.objectOwnerChanges {
+		// objects created in the transaction are minted rather than transferred
+		if change.From != nil {
+			dbBatchInsert.TransferredNftTransactions = append(
+				dbBatchInsert.TransferredNftTransactions,
+				db.NewNftTransferTransaction(change.NftID, change.TxID, change.BlockHeight),
+			)
+		}
+	}
+	dbBatchInsert.ObjectOwnerChanges = append(dbBatchInsert.ObjectOwnerChanges, p.objectOwnerChanges...)
 
 	dbBatchInsert.ModulePublishedEvents = append(dbBatchInsert.ModulePublishedEvents, p.modulePublishedEvents...)
 	dbBatchInsert.CollectionMutationEvents = append(dbBatchInsert.CollectionMutationEvents, p.collectionMutationEvents...)
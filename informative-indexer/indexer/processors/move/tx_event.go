@@ -219,6 +219,7 @@ func (p *Processor) handleNftMutationEvent(event abci.Event) error {
 func (p *Processor) handleObjectCreateEvent(event abci.Event) error {
 	return utils.HandleEventWithKey(event, movetypes.AttributeKeyData, nil, func(e types.ObjectCreateEvent) error {
 		p.objectOwners[e.Object] = e.Owner
+		p.recordObjectOwnerChange(e.Object, nil, e.Owner)
 		return nil
 	})
 }
@@ -227,6 +228,26 @@ func (p *Processor) handleObjectCreateEvent(event abci.Event) error {
 func (p *Processor) handleObjectTransferEvent(event abci.Event) error {
 	return utils.HandleEventWithKey(event, movetypes.AttributeKeyData, &p.txProcessor.txData.IsNftTransfer, func(e types.ObjectTransferEvent) error {
 		p.objectOwners[e.Object] = e.To
+		p.recordObjectOwnerChange(e.Object, &e.From, e.To)
 		return nil
 	})
 }
+
+// recordObjectOwnerChange records the owner change of an object in the transaction, from the owner before the
+// transaction, or nil when the object is created in it, to the owner after it
+func (p *Processor) recordObjectOwnerChange(object string, from *string, to string) {
+	if idx, ok := p.txProcessor.objectOwnerChanges[object]; ok {
+		p.objectOwnerChanges[idx].To = to
+		return
+	}
+
+	p.txProcessor.objectOwnerChanges[object] = len(p.objectOwnerChanges)
+	p.objectOwnerChanges = append(p.objectOwnerChanges, db.NftHistory{
+		NftID:       object,
+		TxID:        p.txProcessor.txData.ID,
+		BlockHeight: p.Height,
+		From:        from,
+		To:          to,
+		Remark:      db.JSON("{}"),
+	})
+}
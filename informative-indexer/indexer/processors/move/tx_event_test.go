@@ -0,0 +1,32 @@
+package move
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+func TestRecordObjectOwnerChange(t *testing.T) {
+	p := &Processor{}
+	p.InitProcessor(10, nil)
+	owner := func(address string) *string { return &address }
+
+	// created and transferred in the same transaction
+	p.NewTxProcessor(&db.Transaction{ID: "tx1/10"})
+	p.recordObjectOwnerChange("0xnft", nil, "0xminter")
+	p.recordObjectOwnerChange("0xnft", owner("0xminter"), "0xalice")
+
+	// transferred twice in a later transaction of the block
+	p.NewTxProcessor(&db.Transaction{ID: "tx2/10"})
+	p.recordObjectOwnerChange("0xnft", owner("0xalice"), "0xbob")
+	p.recordObjectOwnerChange("0xother", owner("0xcarol"), "0xdave")
+	p.recordObjectOwnerChange("0xnft", owner("0xbob"), "0xerin")
+
+	assert.Equal(t, []db.NftHistory{
+		{NftID: "0xnft", TxID: "tx1/10", BlockHeight: 10, From: nil, To: "0xalice", Remark: db.JSON("{}")},
+		{NftID: "0xnft", TxID: "tx2/10", BlockHeight: 10, From: owner("0xalice"), To: "0xerin", Remark: db.JSON("{}")},
+		{NftID: "0xother", TxID: "tx2/10", BlockHeight: 10, From: owner("0xcarol"), To: "0xdave", Remark: db.JSON("{}")},
+	}, p.objectOwnerChanges)
+}
@@ -3,10 +3,13 @@ package move
 import (
 	"fmt"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	movetypes "github.com/initia-labs/initia/x/move/types"
 	vmapi "github.com/initia-labs/movevm/api"
 	vmtypes "github.com/initia-labs/movevm/types"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
 )
 
 func (p *Processor) handleMsg(msg sdk.Msg, isTxOk bool) error {
@@ -41,3 +44,33 @@ func (p *Processor) handleMoveExecuteEventIsEntry(moduleAddress, moduleName stri
 	p.txProcessor.modulesInTx[vmapi.ModuleInfoResponse{Address: vmAddr, Name: moduleName}] = true
 	return nil
 }
+
+// recordFunctionCall records a MsgExecute or MsgExecuteJSON call, the gas used is the one of the whole transaction
+func (p *Processor) recordFunctionCall(msgIndex int32, msg sdk.Msg, result *abci.ExecTxResult) {
+	var sender, moduleAddress, moduleName, functionName string
+	switch msg := msg.(type) {
+	case *movetypes.MsgExecute:
+		sender, moduleAddress, moduleName, functionName = msg.Sender, msg.ModuleAddress, msg.ModuleName, msg.FunctionName
+	case *movetypes.MsgExecuteJSON:
+		sender, moduleAddress, moduleName, functionName = msg.Sender, msg.ModuleAddress, msg.ModuleName, msg.FunctionName
+	default:
+		return
+	}
+
+	vmAddr, err := vmtypes.NewAccountAddress(moduleAddress)
+	if err != nil {
+		// only failed transactions can carry an invalid module address
+		return
+	}
+
+	p.functionCalls = append(p.functionCalls, db.ModuleFunctionCall{
+		TxID:         p.txProcessor.txData.ID,
+		MsgIndex:     msgIndex,
+		ModuleID:     db.GetModuleID(vmapi.ModuleInfoResponse{Address: vmAddr, Name: moduleName}),
+		FunctionName: functionName,
+		Sender:       sender,
+		Success:      result.IsOK(),
+		GasUsed:      result.GasUsed,
+		BlockHeight:  p.Height,
+	})
+}
@@ -0,0 +1,40 @@
+package move
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	movetypes "github.com/initia-labs/initia/x/move/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+func TestRecordFunctionCall(t *testing.T) {
+	p := &Processor{}
+	p.InitProcessor(10, nil)
+	p.NewTxProcessor(&db.Transaction{ID: "tx/10"})
+
+	p.recordFunctionCall(0, &movetypes.MsgExecute{
+		Sender:        "init1sender",
+		ModuleAddress: "0x0000000000000000000000000000000000000001",
+		ModuleName:    "coin",
+		FunctionName:  "transfer",
+	}, &abci.ExecTxResult{GasUsed: 100})
+	p.recordFunctionCall(1, &movetypes.MsgExecuteJSON{
+		Sender:        "init1sender",
+		ModuleAddress: "0xcafe",
+		ModuleName:    "token",
+		FunctionName:  "mint",
+	}, &abci.ExecTxResult{Code: 5, GasUsed: 200})
+	// not a function call
+	p.recordFunctionCall(2, &banktypes.MsgSend{}, &abci.ExecTxResult{})
+	// invalid module addresses are skipped
+	p.recordFunctionCall(3, &movetypes.MsgExecute{ModuleAddress: "not an address"}, &abci.ExecTxResult{Code: 5})
+
+	assert.Equal(t, []db.ModuleFunctionCall{
+		{TxID: "tx/10", MsgIndex: 0, ModuleID: "0x1::coin", FunctionName: "transfer", Sender: "init1sender", Success: true, GasUsed: 100, BlockHeight: 10},
+		{TxID: "tx/10", MsgIndex: 1, ModuleID: "0xcafe::token", FunctionName: "mint", Sender: "init1sender", Success: false, GasUsed: 200, BlockHeight: 10},
+	}, p.functionCalls)
+}
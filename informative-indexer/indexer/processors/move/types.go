@@ -15,6 +15,8 @@ type TxProcessor struct {
 	modulesInTx map[vmapi.ModuleInfoResponse]bool
 	// Mapping for collection_addr::token_id to object address
 	nftsMap map[string]string
+	// Index of the owner change of each object in the transaction
+	objectOwnerChanges map[string]int
 }
 
 type Processor struct {
@@ -28,6 +30,8 @@ type Processor struct {
 	mintedNftTransactions  []db.NftTransaction
 	burnedNftTransactions  []db.NftTransaction
 	objectOwners           map[string]string
+	objectOwnerChanges     []db.NftHistory
+	functionCalls          []db.ModuleFunctionCall
 
 	modulePublishedEvents    []db.ModuleHistory
 	collectionMutationEvents []db.CollectionMutationEvent
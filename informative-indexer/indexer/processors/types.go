@@ -13,6 +13,7 @@ import (
 type Processor interface {
 	InitProcessor(height int64, cacher *cacher.Cacher)
 	Name() string
+	ProcessMisbehaviors(misbehaviors []abci.Misbehavior) error
 	ProcessBeginBlockEvents(finalizeBlockEvents *[]abci.Event) error
 	ProcessEndBlockEvents(finalizeBlockEvents *[]abci.Event) error
 	NewTxProcessor(txData *db.Transaction)
@@ -38,6 +39,10 @@ func (p *BaseProcessor) Name() string {
 	return "base"
 }
 
+func (p *BaseProcessor) ProcessMisbehaviors(misbehaviors []abci.Misbehavior) error {
+	return nil
+}
+
 func (p *BaseProcessor) ProcessBeginBlockEvents(finalizeBlockEvents *[]abci.Event) error {
 	return nil
 }
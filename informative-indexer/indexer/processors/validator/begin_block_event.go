@@ -1,9 +1,12 @@
 package validator
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 
 	"github.com/initia-labs/core-indexer/informative-indexer/indexer/utils"
@@ -20,19 +23,90 @@ func (p *Processor) handleBeginBlockEvent(event abci.Event) error {
 	}
 }
 
+// handleSlashEvent records the slash and the jail of a validator. A downtime emits a single event carrying both,
+// while a double sign emits the slash first and then a jail event holding only the jailed address.
 func (p *Processor) handleSlashEvent(event abci.Event) error {
-	if value, found := utils.FindAttribute(event.Attributes, slashingtypes.AttributeKeyJailed); found {
+	if value, found := utils.FindAttribute(event.Attributes, slashingtypes.AttributeKeyAddress); found {
 		validator, ok := p.Cacher.GetValidatorByConsAddr(value)
 		if !ok {
 			return fmt.Errorf("failed to map validator address: %s", value)
 		}
 
+		slashEvent, err := p.parseSlashEvent(event, validator.OperatorAddress, value)
+		if err != nil {
+			return err
+		}
+
 		p.validators[validator.OperatorAddress] = true
-		p.slashEvents = append(p.slashEvents, db.ValidatorSlashEvent{
+		p.slashEvents = append(p.slashEvents, slashEvent)
+		p.lastSlashes[validator.OperatorAddress] = slashEvent
+	}
+
+	if value, found := utils.FindAttribute(event.Attributes, slashingtypes.AttributeKeyJailed); found {
+		validator, ok := p.Cacher.GetValidatorByConsAddr(value)
+		if !ok {
+			return fmt.Errorf("failed to map validator address: %s", value)
+		}
+
+		jailEvent := db.ValidatorSlashEvent{
 			ValidatorAddress: validator.OperatorAddress,
 			BlockHeight:      p.Height,
 			Type:             string(db.Jailed),
-		})
+		}
+		// the jail follows the slash of the validator in the same block
+		if slashEvent, ok := p.lastSlashes[validator.OperatorAddress]; ok {
+			jailEvent.Reason = slashEvent.Reason
+			jailEvent.InfractionHeight = slashEvent.InfractionHeight
+		}
+
+		p.validators[validator.OperatorAddress] = true
+		p.slashEvents = append(p.slashEvents, jailEvent)
 	}
 	return nil
 }
+
+func (p *Processor) parseSlashEvent(event abci.Event, operatorAddress, consAddress string) (db.ValidatorSlashEvent, error) {
+	slashEvent := db.ValidatorSlashEvent{
+		ValidatorAddress: operatorAddress,
+		BlockHeight:      p.Height,
+		Type:             string(db.Slashed),
+	}
+
+	if value, found := utils.FindAttribute(event.Attributes, slashingtypes.AttributeKeyReason); found {
+		slashEvent.Reason = &value
+
+		switch value {
+		case slashingtypes.AttributeValueDoubleSign:
+			if height, ok := p.infractionHeights[consAddress]; ok {
+				slashEvent.InfractionHeight = &height
+			}
+		case slashingtypes.AttributeValueMissingSignature:
+			// the downtime is detected on the signatures of the last commit
+			height := p.Height - 1
+			slashEvent.InfractionHeight = &height
+		}
+	}
+
+	if value, found := utils.FindAttribute(event.Attributes, slashingtypes.AttributeKeyPower); found {
+		power, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return slashEvent, fmt.Errorf("failed to parse slashed power: %w", err)
+		}
+		slashEvent.Power = &power
+	}
+
+	if value, found := utils.FindAttribute(event.Attributes, slashingtypes.AttributeKeyBurnedCoins); found {
+		coins, err := sdk.ParseCoinsNormalized(value)
+		if err != nil {
+			return slashEvent, fmt.Errorf("failed to parse burned coins: %w", err)
+		}
+
+		coinsJSON, err := json.Marshal(coins)
+		if err != nil {
+			return slashEvent, fmt.Errorf("failed to marshal burned coins to JSON: %w", err)
+		}
+		slashEvent.BurnedCoins = db.JSON(coinsJSON)
+	}
+
+	return slashEvent, nil
+}
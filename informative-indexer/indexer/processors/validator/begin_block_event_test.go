@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/core-indexer/informative-indexer/indexer/cacher"
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+func slashEvent(attributes ...string) abci.Event {
+	event := abci.Event{Type: slashingtypes.EventTypeSlash}
+	for i := 0; i < len(attributes); i += 2 {
+		event.Attributes = append(event.Attributes, abci.EventAttribute{Key: attributes[i], Value: attributes[i+1]})
+	}
+	return event
+}
+
+func TestHandleSlashEvent(t *testing.T) {
+	consAddrBytes := []byte("validator-consensus-address-")
+	consAddr := sdk.ConsAddress(consAddrBytes).String()
+	operatorAddr := "initvaloper1validator"
+
+	newProcessor := func() *Processor {
+		c := cacher.NewCacher()
+		c.SetValidator(db.ValidatorAddress{OperatorAddress: operatorAddr, ConsensusAddress: consAddr})
+
+		p := &Processor{}
+		p.InitProcessor(100, c)
+		return p
+	}
+	ptr := func(v int64) *int64 { return &v }
+	str := func(v string) *string { return &v }
+
+	t.Run("downtime", func(t *testing.T) {
+		p := newProcessor()
+		require.NoError(t, p.ProcessBeginBlockEvents(&[]abci.Event{
+			slashEvent(
+				slashingtypes.AttributeKeyAddress, consAddr,
+				slashingtypes.AttributeKeyPower, "1000",
+				slashingtypes.AttributeKeyReason, slashingtypes.AttributeValueMissingSignature,
+				slashingtypes.AttributeKeyJailed, consAddr,
+				slashingtypes.AttributeKeyBurnedCoins, "10uinit",
+			),
+		}))
+
+		assert.Equal(t, []db.ValidatorSlashEvent{
+			{
+				ValidatorAddress: operatorAddr,
+				BlockHeight:      100,
+				Type:             string(db.Slashed),
+				Reason:           str(slashingtypes.AttributeValueMissingSignature),
+				Power:            ptr(1000),
+				BurnedCoins:      db.JSON(`[{"denom":"uinit","amount":"10"}]`),
+				InfractionHeight: ptr(99),
+			},
+			{
+				ValidatorAddress: operatorAddr,
+				BlockHeight:      100,
+				Type:             string(db.Jailed),
+				Reason:           str(slashingtypes.AttributeValueMissingSignature),
+				InfractionHeight: ptr(99),
+			},
+		}, p.slashEvents)
+	})
+
+	t.Run("double sign", func(t *testing.T) {
+		p := newProcessor()
+		require.NoError(t, p.ProcessMisbehaviors([]abci.Misbehavior{
+			{Type: abci.MisbehaviorType_DUPLICATE_VOTE, Validator: abci.Validator{Address: consAddrBytes, Power: 1000}, Height: 95},
+		}))
+		require.NoError(t, p.ProcessBeginBlockEvents(&[]abci.Event{
+			slashEvent(
+				slashingtypes.AttributeKeyAddress, consAddr,
+				slashingtypes.AttributeKeyPower, "1000",
+				slashingtypes.AttributeKeyReason, slashingtypes.AttributeValueDoubleSign,
+				slashingtypes.AttributeKeyBurnedCoins, "50uinit",
+			),
+			slashEvent(slashingtypes.AttributeKeyJailed, consAddr),
+		}))
+
+		assert.Equal(t, []db.ValidatorSlashEvent{
+			{
+				ValidatorAddress: operatorAddr,
+				BlockHeight:      100,
+				Type:             string(db.Slashed),
+				Reason:           str(slashingtypes.AttributeValueDoubleSign),
+				Power:            ptr(1000),
+				BurnedCoins:      db.JSON(`[{"denom":"uinit","amount":"50"}]`),
+				InfractionHeight: ptr(95),
+			},
+			{
+				ValidatorAddress: operatorAddr,
+				BlockHeight:      100,
+				Type:             string(db.Jailed),
+				Reason:           str(slashingtypes.AttributeValueDoubleSign),
+				InfractionHeight: ptr(95),
+			},
+		}, p.slashEvents)
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		p := newProcessor()
+		err := p.ProcessBeginBlockEvents(&[]abci.Event{
+			slashEvent(slashingtypes.AttributeKeyJailed, "unknown"),
+		})
+		assert.Error(t, err)
+	})
+}
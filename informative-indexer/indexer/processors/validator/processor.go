@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/initia-labs/initia/app/params"
 
 	"github.com/initia-labs/core-indexer/informative-indexer/indexer/cacher"
@@ -18,6 +19,8 @@ func (p *Processor) InitProcessor(height int64, cacher *cacher.Cacher) {
 	p.stakeChanges = make([]db.ValidatorBondedTokenChange, 0)
 	p.validators = make(map[string]bool)
 	p.slashEvents = make([]db.ValidatorSlashEvent, 0)
+	p.infractionHeights = make(map[string]int64)
+	p.lastSlashes = make(map[string]db.ValidatorSlashEvent)
 
 	p.txProcessor = nil
 }
@@ -26,6 +29,14 @@ func (p *Processor) Name() string {
 	return "validator"
 }
 
+// ProcessMisbehaviors keeps the heights of the block evidences, the infraction heights of the double sign slashes
+func (p *Processor) ProcessMisbehaviors(misbehaviors []abci.Misbehavior) error {
+	for _, misbehavior := range misbehaviors {
+		p.infractionHeights[sdk.ConsAddress(misbehavior.Validator.Address).String()] = misbehavior.Height
+	}
+	return nil
+}
+
 func (p *Processor) ProcessBeginBlockEvents(finalizeBlockEvents *[]abci.Event) error {
 	for _, event := range *finalizeBlockEvents {
 		if err := p.handleBeginBlockEvent(event); err != nil {
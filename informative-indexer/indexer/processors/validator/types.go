@@ -18,5 +18,10 @@ type Processor struct {
 	validators   map[string]bool
 	slashEvents  []db.ValidatorSlashEvent
 
+	// infractionHeights maps the consensus addresses of the block misbehaviors to their heights
+	infractionHeights map[string]int64
+	// lastSlashes is the last slash of each validator in the block, by operator address
+	lastSlashes map[string]db.ValidatorSlashEvent
+
 	txProcessor *TxProcessor
 }
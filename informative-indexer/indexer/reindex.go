@@ -0,0 +1,56 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+
+	"github.com/initia-labs/core-indexer/pkg/archive"
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
+)
+
+// Reindex indexes the archived block results of the heights from to to, both included, without consuming the message
+// bus. As the state is built block after block, it only rolls forward: the heights up to the latest indexed one are
+// skipped, and the range must reach the height following it. The offsets of the consumer group are left as they are.
+func (f *Indexer) Reindex(ctx context.Context, from, to int64) error {
+	defer sentry.Flush(2 * time.Second)
+	defer f.close()
+
+	latestInformativeBlockHeight, err := db.GetLatestInformativeBlockHeight(ctx, f.dbClient)
+	if err != nil {
+		logger.Error().Msgf("DB: Error getting latest block height: %v", err)
+		return err
+	}
+
+	if from > latestInformativeBlockHeight+1 {
+		return fmt.Errorf("reindex must start at height %d at the latest, the one following the latest indexed height, got %d", latestInformativeBlockHeight+1, from)
+	}
+	if to != 0 && to <= latestInformativeBlockHeight {
+		return fmt.Errorf("heights %d to %d are already indexed, the latest indexed height being %d", from, to, latestInformativeBlockHeight)
+	}
+	if from <= latestInformativeBlockHeight {
+		logger.Info().Msgf("Skipping heights %d to %d because they're already processed", from, latestInformativeBlockHeight)
+		from = latestInformativeBlockHeight + 1
+	}
+
+	logger.Info().Msgf("Reindexing heights %d to %d from the archive", from, to)
+	err = archive.Replay(ctx, f.storageClient, f.config.ArchiveBucket, f.config.ArchivePrefix, from, to, func(blockResults *mq.BlockResultMsg) error {
+
+		transaction, ctx := sentry_integration.StartSentryTransaction(ctx, "Reindex", "Reindex archived informative block_results")
+		defer transaction.Finish()
+
+		return f.processUntilSucceeds(ctx, *blockResults, db.ConsumerOffset{})
+	})
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
+		logger.Error().Msgf("Error reindexing: %v", err)
+		return err
+	}
+
+	logger.Info().Msgf("Reindexed heights %d to %d", from, to)
+	return nil
+}
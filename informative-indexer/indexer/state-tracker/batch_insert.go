@@ -26,6 +26,7 @@ type DBBatchInsert struct {
 	transactions []db.Transaction
 
 	accounts                   map[string]db.Account
+	accountTypeUpdates         map[string]db.AccountType
 	accountsInTx               map[AccountTxKey]db.AccountTransaction
 	proposals                  map[int32]db.Proposal
 	ProposalStatusChanges      map[int32]db.Proposal
@@ -45,7 +46,9 @@ type DBBatchInsert struct {
 	TransferredNftTransactions []db.NftTransaction
 	Nfts                       map[string]db.Nft
 	ObjectNewOwners            map[string]string
+	ObjectOwnerChanges         []db.NftHistory
 	ModuleTransactions         []db.ModuleTransaction
+	ModuleFunctionCalls        []db.ModuleFunctionCall
 	BurnedNft                  map[string]bool
 	BurnedNftTransactions      []db.NftTransaction
 	ProposalDeposits           []db.ProposalDeposit
@@ -62,6 +65,7 @@ func NewDBBatchInsert(cacher *cacher.Cacher, logger *zerolog.Logger) *DBBatchIns
 		transactions:               make([]db.Transaction, 0),
 		accountsInTx:               make(map[AccountTxKey]db.AccountTransaction),
 		accounts:                   make(map[string]db.Account),
+		accountTypeUpdates:         make(map[string]db.AccountType),
 		proposals:                  make(map[int32]db.Proposal),
 		ProposalStatusChanges:      make(map[int32]db.Proposal),
 		PrunedProposals:            make(map[int32]db.Proposal),
@@ -78,7 +82,9 @@ func NewDBBatchInsert(cacher *cacher.Cacher, logger *zerolog.Logger) *DBBatchIns
 		TransferredNftTransactions: make([]db.NftTransaction, 0),
 		Nfts:                       make(map[string]db.Nft),
 		ObjectNewOwners:            make(map[string]string),
+		ObjectOwnerChanges:         make([]db.NftHistory, 0),
 		ModuleTransactions:         make([]db.ModuleTransaction, 0),
+		ModuleFunctionCalls:        make([]db.ModuleFunctionCall, 0),
 		BurnedNft:                  make(map[string]bool),
 		BurnedNftTransactions:      make([]db.NftTransaction, 0),
 		ProposalDeposits:           make([]db.ProposalDeposit, 0),
@@ -104,6 +110,20 @@ func (b *DBBatchInsert) AddAccounts(accounts ...db.Account) {
 	}
 }
 
+// SetAccountType sets the type of an account to be inserted in the batch
+func (b *DBBatchInsert) SetAccountType(address string, accountType db.AccountType) {
+	if account, ok := b.accounts[address]; ok {
+		account.Type = string(accountType)
+		b.accounts[address] = account
+	}
+}
+
+// UpdateAccountType sets the type of an account and also updates it if the account already exists
+func (b *DBBatchInsert) UpdateAccountType(address string, accountType db.AccountType) {
+	b.SetAccountType(address, accountType)
+	b.accountTypeUpdates[address] = accountType
+}
+
 func (b *DBBatchInsert) AddValidatorBondedTokenTxs(txs ...db.ValidatorBondedTokenChange) {
 	b.validatorBondedTokenTxs = append(b.validatorBondedTokenTxs, txs...)
 }
@@ -126,6 +146,10 @@ func (b *DBBatchInsert) Flush(ctx context.Context, dbTx *gorm.DB, height int64)
 		b.logger.Error().Msgf("Error updating tracking table: %v", err)
 		return err
 	}
+	if err := db.MarkAccountStatsFromHeight(ctx, dbTx, height); err != nil {
+		b.logger.Error().Msgf("Error updating tracking table: %v", err)
+		return err
+	}
 
 	if len(b.accounts) > 0 {
 		if err := db.InsertVMAddressesAndAccountsIgnoreConflict(ctx, dbTx, b.accounts); err != nil {
@@ -133,6 +157,13 @@ func (b *DBBatchInsert) Flush(ctx context.Context, dbTx *gorm.DB, height int64)
 		}
 	}
 
+	if len(b.accountTypeUpdates) > 0 {
+		if err := db.UpdateAccountTypes(ctx, dbTx, b.accountTypeUpdates); err != nil {
+			b.logger.Error().Msgf("Error updating account types: %v", err)
+			return err
+		}
+	}
+
 	if len(b.transactions) > 0 {
 		if err := db.UpsertTransactions(ctx, dbTx, b.transactions); err != nil {
 			b.logger.Error().Msgf("Error inserting transactions: %v", err)
@@ -155,6 +186,16 @@ func (b *DBBatchInsert) Flush(ctx context.Context, dbTx *gorm.DB, height int64)
 		if err := db.InsertAccountTxsIgnoreConflict(ctx, dbTx, txs); err != nil {
 			return err
 		}
+
+		timestamp, err := db.QueryBlockTimestamp(ctx, dbTx, height)
+		if err != nil {
+			b.logger.Error().Msgf("Error querying block timestamp: %v", err)
+			return err
+		}
+		if err := db.UpsertAccountStats(ctx, dbTx, db.NewAccountStats(txs, b.transactions, timestamp)); err != nil {
+			b.logger.Error().Msgf("Error upserting account stats: %v", err)
+			return err
+		}
 	}
 
 	if len(b.validators) > 0 {
@@ -252,6 +293,14 @@ func (b *DBBatchInsert) Flush(ctx context.Context, dbTx *gorm.DB, height int64)
 	}
 
 	if len(b.ModulePublishedEvents) > 0 {
+		// Record the published digest, so verifications can be matched per history entry
+		for idx, history := range b.ModulePublishedEvents {
+			if module, ok := b.modules[history.ModuleID]; ok && module.Digest != "" {
+				digest := module.Digest
+				b.ModulePublishedEvents[idx].Digest = &digest
+			}
+		}
+
 		if err := db.InsertModuleHistories(ctx, dbTx, b.ModulePublishedEvents); err != nil {
 			return err
 		}
@@ -263,6 +312,12 @@ func (b *DBBatchInsert) Flush(ctx context.Context, dbTx *gorm.DB, height int64)
 		}
 	}
 
+	if len(b.ModuleFunctionCalls) > 0 {
+		if err := db.InsertModuleFunctionCalls(ctx, dbTx, height, b.ModuleFunctionCalls); err != nil {
+			return err
+		}
+	}
+
 	if len(b.ModuleProposals) > 0 {
 		if err := db.InsertModuleProposalsIgnoreConflict(ctx, dbTx, b.ModuleProposals); err != nil {
 			return err
@@ -381,7 +436,6 @@ func (b *DBBatchInsert) FlushTransferredNft(ctx context.Context, dbTx *gorm.DB)
 		}
 
 		nftTxs := make([]db.NftTransaction, 0)
-		nftHistories := make([]db.NftHistory, 0)
 		collectionTransactions := make([]db.CollectionTransaction, 0)
 		for _, tx := range b.TransferredNftTransactions {
 			if nft, ok := existingNfts[tx.NftID]; ok {
@@ -393,28 +447,18 @@ func (b *DBBatchInsert) FlushTransferredNft(ctx context.Context, dbTx *gorm.DB)
 					CollectionID:  nft.Collection,
 					BlockHeight:   tx.BlockHeight,
 				})
-				nftHistories = append(nftHistories, db.NftHistory{
-					NftID:       tx.NftID,
-					TxID:        tx.TxID,
-					BlockHeight: tx.BlockHeight,
-					From:        nft.Owner,
-					To:          b.ObjectNewOwners[tx.NftID],
-					Remark:      db.JSON("{}"),
-				})
-				existingNfts[tx.NftID].Owner = b.ObjectNewOwners[tx.NftID]
 			}
 		}
 
-		if err := db.InsertCollectionTransactions(ctx, dbTx, collectionTransactions); err != nil {
-			return err
-		}
-
-		nfts = make([]*db.Nft, 0, len(existingNfts))
-		for _, nft := range existingNfts {
-			nfts = append(nfts, nft)
+		// Objects that are not Nfts are left out of the ownership history
+		nftHistories := make([]db.NftHistory, 0)
+		for _, change := range b.ObjectOwnerChanges {
+			if _, ok := existingNfts[change.NftID]; ok {
+				nftHistories = append(nftHistories, change)
+			}
 		}
 
-		if err := db.InsertNftsOnConflictDoUpdate(ctx, dbTx, nfts); err != nil {
+		if err := db.InsertCollectionTransactions(ctx, dbTx, collectionTransactions); err != nil {
 			return err
 		}
 
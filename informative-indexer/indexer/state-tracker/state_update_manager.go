@@ -6,13 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	cosmosgovtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/initia-labs/initia/app/params"
+	initiagovtypes "github.com/initia-labs/initia/x/gov/types"
 	vmapi "github.com/initia-labs/movevm/api"
-	vmtypes "github.com/initia-labs/movevm/types"
+	"gorm.io/gorm"
 
 	"github.com/initia-labs/core-indexer/informative-indexer/indexer/types"
 	"github.com/initia-labs/core-indexer/informative-indexer/indexer/utils"
@@ -22,6 +24,9 @@ import (
 	"github.com/initia-labs/core-indexer/pkg/parser"
 )
 
+// maxConcurrentAccountQueries is the number of account queries run at the same time to resolve the account types
+const maxConcurrentAccountQueries = 16
+
 // StateUpdateManager tracks entities that need to be synchronized with the blockchain state
 // through RPC queries. It maintains sets of validators and modules that have been modified
 // and need their latest state to be fetched from the chain.
@@ -44,6 +49,10 @@ type StateUpdateManager struct {
 	// height is the height of the block to be used for RPC queries
 	height *int64
 
+	// AccountsToRecheck tracks accounts whose type may have changed in the block, such as
+	// accounts overwritten by object creation, so the type is queried even if already known
+	AccountsToRecheck map[string]bool
+
 	ProposalsToUpdate     map[int32]string
 	CollectionsToUpdate   map[string]bool
 	NftsToUpdate          map[string]bool
@@ -61,6 +70,7 @@ func NewStateUpdateManager(
 		dbBatchInsert:         dbBatchInsert,
 		encodingConfig:        encodingConfig,
 		height:                height,
+		AccountsToRecheck:     make(map[string]bool),
 		ProposalsToUpdate:     make(map[int32]string),
 		CollectionsToUpdate:   make(map[string]bool),
 		NftsToUpdate:          make(map[string]bool),
@@ -68,7 +78,7 @@ func NewStateUpdateManager(
 	}
 }
 
-func (s *StateUpdateManager) UpdateState(ctx context.Context, rpcClient cosmosrpc.CosmosJSONRPCHub) error {
+func (s *StateUpdateManager) UpdateState(ctx context.Context, dbTx *gorm.DB, rpcClient cosmosrpc.CosmosJSONRPCHub) error {
 	// TODO: add retry logic
 	if err := s.updateValidators(ctx, rpcClient); err != nil {
 		return err
@@ -90,10 +100,114 @@ func (s *StateUpdateManager) UpdateState(ctx context.Context, rpcClient cosmosrp
 		return err
 	}
 
+	// Accounts are resolved last since syncing validators may add accounts
+	if err := s.updateAccountTypes(ctx, dbTx, rpcClient); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateAccountTypes resolves the types of the accounts in the batch, from the cache, the database
+// or, for new and rechecked accounts, the auth account query
+func (s *StateUpdateManager) updateAccountTypes(ctx context.Context, dbTx *gorm.DB, rpcClient cosmosrpc.CosmosJSONRPCHub) error {
+	for address := range s.AccountsToRecheck {
+		if _, ok := s.dbBatchInsert.accounts[address]; ok {
+			continue
+		}
+		accAddr, err := sdk.AccAddressFromBech32(address)
+		if err != nil {
+			return fmt.Errorf("failed to convert account address: %w", err)
+		}
+		s.dbBatchInsert.AddAccounts(db.NewAccountFromSDKAddress(accAddr))
+	}
+
+	uncached := make([]string, 0)
+	for address := range s.dbBatchInsert.accounts {
+		if s.AccountsToRecheck[address] {
+			continue
+		}
+		if accountType, ok := s.dbBatchInsert.cacher.GetAccountType(address); ok {
+			s.dbBatchInsert.SetAccountType(address, accountType)
+			continue
+		}
+		uncached = append(uncached, address)
+	}
+
+	storedTypes, err := db.QueryAccountTypes(ctx, dbTx, uncached)
+	if err != nil {
+		return fmt.Errorf("failed to query account types: %w", err)
+	}
+
+	toFetch := make([]string, 0)
+	for address := range s.dbBatchInsert.accounts {
+		if _, ok := s.dbBatchInsert.cacher.GetAccountType(address); ok && !s.AccountsToRecheck[address] {
+			continue
+		}
+
+		if accountType, ok := storedTypes[address]; ok {
+			s.dbBatchInsert.cacher.SetAccountType(address, accountType)
+			s.dbBatchInsert.SetAccountType(address, accountType)
+			continue
+		}
+		toFetch = append(toFetch, address)
+	}
+
+	accountTypes, err := FetchAccountTypes(ctx, rpcClient, toFetch, s.height)
+	if err != nil {
+		return err
+	}
+	for idx, address := range toFetch {
+		s.dbBatchInsert.cacher.SetAccountType(address, accountTypes[idx])
+		s.dbBatchInsert.UpdateAccountType(address, accountTypes[idx])
+	}
+
 	return nil
 }
 
+// FetchAccountTypes queries the types of the accounts at the height, maxConcurrentAccountQueries at a time, in the
+// order of the addresses
+func FetchAccountTypes(ctx context.Context, rpcClient cosmosrpc.CosmosJSONRPCHub, addresses []string, height *int64) ([]db.AccountType, error) {
+	accountTypes := make([]db.AccountType, len(addresses))
+	errs := make([]error, len(addresses))
+	sem := make(chan struct{}, maxConcurrentAccountQueries)
+	var wg sync.WaitGroup
+	for idx, address := range addresses {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res, err := rpcClient.Account(ctx, address, height)
+			if err != nil {
+				errs[idx] = fmt.Errorf("failed to fetch account: %w", err)
+				return
+			}
+			accountTypes[idx] = db.BaseAccount
+			if res.Account != nil {
+				accountTypes[idx] = db.AccountTypeFromTypeURL(res.Account.TypeUrl)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return accountTypes, nil
+}
+
 func (s *StateUpdateManager) updateProposals(ctx context.Context, rpcClient cosmosrpc.CosmosJSONRPCHub) error {
+	var govParams initiagovtypes.Params
+	if len(s.ProposalsToUpdate) > 0 {
+		res, err := rpcClient.GovParams(ctx, s.height)
+		if err != nil {
+			return fmt.Errorf("failed to query gov params: %w", err)
+		}
+		govParams = res.Params
+	}
+
 	for proposalID, txID := range s.ProposalsToUpdate {
 		proposal, err := rpcClient.Proposal(ctx, proposalID, s.height)
 		if err != nil {
@@ -151,6 +265,15 @@ func (s *StateUpdateManager) updateProposals(ctx context.Context, rpcClient cosm
 			return fmt.Errorf("failed to marshal proposal types: %w", err)
 		}
 
+		minDeposit := govParams.MinDeposit
+		if proposalInfo.GetExpedited() {
+			minDeposit = govParams.ExpeditedMinDeposit
+		}
+		minDepositJSON, err := json.Marshal(sdk.NewCoins(minDeposit...))
+		if err != nil {
+			return fmt.Errorf("failed to marshal min deposit: %w", err)
+		}
+
 		s.dbBatchInsert.proposals[proposalID] = db.Proposal{
 			ID:                     proposalID,
 			Title:                  proposalInfo.GetTitle(),
@@ -161,6 +284,7 @@ func (s *StateUpdateManager) updateProposals(ctx context.Context, rpcClient cosm
 			VotingTime:             proposalInfo.GetVotingStartTime(),
 			VotingEndTime:          proposalInfo.GetVotingEndTime(),
 			TotalDeposit:           db.JSON("[]"),
+			MinDeposit:             db.JSON(minDepositJSON),
 			Messages:               db.JSON(msgsJson),
 			Content:                db.JSON(contentJSON),
 			Metadata:               proposalInfo.GetMetadata(),
@@ -270,13 +394,7 @@ func (s *StateUpdateManager) syncValidators(ctx context.Context, rpcClient cosmo
 		}
 
 		accAddr := sdk.AccAddress(valAcc)
-		vmAddr, _ := vmtypes.NewAccountAddressFromBytes(accAddr)
-
-		s.dbBatchInsert.AddAccounts(db.Account{
-			Address:   accAddr.String(),
-			VMAddress: db.VMAddress{VMAddress: vmAddr.String()},
-			Type:      string(db.BaseAccount),
-		})
+		s.dbBatchInsert.AddAccounts(db.NewAccountFromSDKAddress(accAddr))
 
 		validator, err := rpcClient.Validator(ctx, validatorAddr, s.height)
 		if err != nil {
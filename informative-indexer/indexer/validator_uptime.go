@@ -48,7 +48,9 @@ func (f *Indexer) ProcessCommitSignatureVote(sigs []types.CommitSig) (map[string
 	return commitSigs, nil
 }
 
-func (f *Indexer) processValidator(parentCtx context.Context, blockResults *mq.BlockResultMsg, proposer *db.ValidatorAddress) error {
+// processValidator inserts the validator commit signatures of the block in the transaction of the block, so that they
+// are recorded along with the consumer offset of its message
+func (f *Indexer) processValidator(parentCtx context.Context, dbTx *gorm.DB, blockResults *mq.BlockResultMsg, proposer *db.ValidatorAddress) error {
 	span, ctx := sentry_integration.StartSentrySpan(parentCtx, "processValidator", "Parse validator signatures from block and insert into DB")
 	defer span.Finish()
 
@@ -69,36 +71,29 @@ func (f *Indexer) processValidator(parentCtx context.Context, blockResults *mq.B
 	}
 
 	logger.Info().Msgf("Proposer for this round is: %v => %v", blockResults.ProposerConsensusAddress, proposer.OperatorAddress)
-	if err := f.dbClient.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
-		err = db.InsertValidatorCommitSignatureForProposer(ctx, dbTx, proposer.OperatorAddress, blockResults.Height)
-		if err != nil {
-			logger.Error().Int64("height", blockResults.Height).Msgf("Error inserting commmit signature for block proposer: %v", err)
-			return err
-		}
-		dbSigs := make([]db.ValidatorCommitSignature, 0)
-		for consAddr, vote := range sigs {
-			val, ok := f.cacher.GetValidatorByConsAddr(consAddr)
-			if !ok {
-				err := fmt.Errorf("validator not found - %s", consAddr)
-				logger.Error().Msgf("Error getting validator for a commit signature: %v", err)
-				return err
-			}
-
-			dbSigs = append(dbSigs, db.ValidatorCommitSignature{
-				ValidatorAddress: val.OperatorAddress,
-				BlockHeight:      blockResults.LastCommit.Height,
-				Vote:             string(vote),
-			})
-		}
-		err = db.InsertValidatorCommitSignatures(ctx, dbTx, &dbSigs)
-		if err != nil {
-			logger.Error().Int64("height", blockResults.Height).Msgf("Error inserting validator commit signatures: %v", err)
+	err = db.InsertValidatorCommitSignatureForProposer(ctx, dbTx, proposer.OperatorAddress, blockResults.Height)
+	if err != nil {
+		logger.Error().Int64("height", blockResults.Height).Msgf("Error inserting commmit signature for block proposer: %v", err)
+		return err
+	}
+	dbSigs := make([]db.ValidatorCommitSignature, 0)
+	for consAddr, vote := range sigs {
+		val, ok := f.cacher.GetValidatorByConsAddr(consAddr)
+		if !ok {
+			err := fmt.Errorf("validator not found - %s", consAddr)
+			logger.Error().Msgf("Error getting validator for a commit signature: %v", err)
 			return err
 		}
 
-		return nil
-	}); err != nil {
-		logger.Error().Int64("height", blockResults.Height).Msgf("Error committing transaction: %v", err)
+		dbSigs = append(dbSigs, db.ValidatorCommitSignature{
+			ValidatorAddress: val.OperatorAddress,
+			BlockHeight:      blockResults.LastCommit.Height,
+			Vote:             string(vote),
+		})
+	}
+	err = db.InsertValidatorCommitSignatures(ctx, dbTx, &dbSigs)
+	if err != nil {
+		logger.Error().Int64("height", blockResults.Height).Msgf("Error inserting validator commit signatures: %v", err)
 		return err
 	}
 
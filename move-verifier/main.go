@@ -0,0 +1,7 @@
+package main
+
+import "github.com/initia-labs/core-indexer/move-verifier/verifier"
+
+func main() {
+	verifier.Execute()
+}
@@ -0,0 +1,51 @@
+#!/bin/bash
+
+set -e
+
+SCRIPT_DIR=$(cd $(dirname $0) ; pwd -P)
+
+TASK=$1
+ARGS=${@:2}
+
+help__verify="verify <..args> : run verify"
+task__verify() {
+  local chain=$1
+
+  if [ -z "$chain" ]; then
+    echo "usage: $0 verify <chain>"
+    exit
+  fi
+
+  go build -o move-verifier .
+
+  source .env
+
+  ./move-verifier verify --db $DB_CONNECTION_STRING \
+    --chain $chain \
+    --poll-interval-in-seconds 5 \
+    --compile-timeout-in-seconds 120 \
+    --insecure-no-sandbox
+}
+
+
+list_all_helps() {
+  compgen -v | egrep "^help__.*"
+}
+
+NEW_LINE=$'\n'
+if type -t "task__$TASK" &>/dev/null; then
+  task__$TASK $ARGS
+else
+  echo "usage: $0 <task> [<..args>]"
+  echo "task:"
+
+  HELPS=""
+  for help in $(list_all_helps)
+  do
+
+    HELPS="$HELPS    ${help/help__/} |-- ${!help}$NEW_LINE"
+  done
+
+  echo "$HELPS" | column -t -s "|"
+  exit
+fi
@@ -0,0 +1,170 @@
+package verifier
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func Execute() {
+	var rootCmd = &cobra.Command{
+		Use:   "move-verifier",
+		Short: "Move Verifier",
+		Long:  "Move Verifier - Compiles submitted Move packages and verifies them against on-chain modules",
+	}
+
+	rootCmd.AddCommand(
+		VerifyCmd(),
+		CompileCmd(),
+	)
+
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+const (
+	FlagChain                    = "chain"
+	FlagDBConnectionString       = "db"
+	FlagPollIntervalInSeconds    = "poll-interval-in-seconds"
+	FlagCompileTimeoutInSeconds  = "compile-timeout-in-seconds"
+	FlagSandboxCommand           = "sandbox-command"
+	FlagInsecureNoSandbox        = "insecure-no-sandbox"
+	FlagMoveHome                 = "move-home"
+	FlagMemoryLimitInMB          = "memory-limit-in-mb"
+	FlagProcessLimit             = "process-limit"
+	FlagWorkDir                  = "work-dir"
+	FlagEnvironment              = "environment"
+	FlagSentryDSN                = "sentry-dsn"
+	FlagCommitSHA                = "commit-sha"
+	FlagSentryProfilesSampleRate = "sentry-profiles-sample-rate"
+	FlagSentryTracesSampleRate   = "sentry-traces-sample-rate"
+)
+
+func VerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify submitted Move packages",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, _ := cmd.Flags().GetString(FlagChain)
+			dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
+			pollIntervalInSeconds, _ := cmd.Flags().GetInt64(FlagPollIntervalInSeconds)
+			compileTimeoutInSeconds, _ := cmd.Flags().GetInt64(FlagCompileTimeoutInSeconds)
+			sandboxCommand, _ := cmd.Flags().GetString(FlagSandboxCommand)
+			insecureNoSandbox, _ := cmd.Flags().GetBool(FlagInsecureNoSandbox)
+			moveHome, _ := cmd.Flags().GetString(FlagMoveHome)
+			memoryLimitInMB, _ := cmd.Flags().GetInt64(FlagMemoryLimitInMB)
+			processLimit, _ := cmd.Flags().GetInt64(FlagProcessLimit)
+			workDir, _ := cmd.Flags().GetString(FlagWorkDir)
+			environment, _ := cmd.Flags().GetString(FlagEnvironment)
+			sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
+			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
+			sentryProfilesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryProfilesSampleRate)
+			sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
+
+			v, err := NewVerifier(&VerifierConfig{
+				Chain:                    chain,
+				DBConnectionString:       dbConnectionString,
+				PollIntervalInSeconds:    pollIntervalInSeconds,
+				CompileTimeoutInSeconds:  compileTimeoutInSeconds,
+				SandboxCommand:           sandboxCommand,
+				InsecureNoSandbox:        insecureNoSandbox,
+				MoveHome:                 moveHome,
+				MemoryLimitInMB:          memoryLimitInMB,
+				ProcessLimit:             processLimit,
+				WorkDir:                  workDir,
+				Environment:              environment,
+				SentryDSN:                sentryDSN,
+				CommitSHA:                commitSHA,
+				SentryProfilesSampleRate: sentryProfilesSampleRate,
+				SentryTracesSampleRate:   sentryTracesSampleRate,
+			})
+			if err != nil {
+				return err
+			}
+
+			v.Verify()
+
+			return nil
+		},
+	}
+
+	pollIntervalInSeconds, err := strconv.ParseInt(os.Getenv("POLL_INTERVAL_IN_SECONDS"), 10, 64)
+	if err != nil {
+		pollIntervalInSeconds = 5
+	}
+
+	compileTimeoutInSeconds, err := strconv.ParseInt(os.Getenv("COMPILE_TIMEOUT_IN_SECONDS"), 10, 64)
+	if err != nil {
+		compileTimeoutInSeconds = 120
+	}
+
+	insecureNoSandbox, err := strconv.ParseBool(os.Getenv("INSECURE_NO_SANDBOX"))
+	if err != nil {
+		insecureNoSandbox = false
+	}
+
+	memoryLimitInMB, err := strconv.ParseInt(os.Getenv("MEMORY_LIMIT_IN_MB"), 10, 64)
+	if err != nil {
+		memoryLimitInMB = 2048
+	}
+
+	processLimit, err := strconv.ParseInt(os.Getenv("PROCESS_LIMIT"), 10, 64)
+	if err != nil {
+		processLimit = 256
+	}
+
+	sentryProfilesSampleRate, err := strconv.ParseFloat(os.Getenv("SENTRY_PROFILES_SAMPLE_RATE"), 64)
+	if err != nil {
+		sentryProfilesSampleRate = 0.01
+	}
+
+	sentryTracesSampleRate, err := strconv.ParseFloat(os.Getenv("SENTRY_TRACES_SAMPLE_RATE"), 64)
+	if err != nil {
+		sentryTracesSampleRate = 0.01
+	}
+
+	cmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID")
+	cmd.Flags().String(FlagDBConnectionString, os.Getenv("DB_CONNECTION_STRING"), "Database connection string")
+	cmd.Flags().Int64(FlagPollIntervalInSeconds, pollIntervalInSeconds, "Interval between polls for pending verifications")
+	cmd.Flags().Int64(FlagCompileTimeoutInSeconds, compileTimeoutInSeconds, "Timeout of a package compilation")
+	cmd.Flags().String(FlagSandboxCommand, os.Getenv("SANDBOX_COMMAND"), "Command prefix wrapping the compiler process, e.g. a bwrap or nsjail invocation, required unless --"+FlagInsecureNoSandbox+" is set")
+	cmd.Flags().Bool(FlagInsecureNoSandbox, insecureNoSandbox, "Run the compiler process without sandbox, for local development only")
+	cmd.Flags().String(FlagMoveHome, os.Getenv("MOVE_HOME"), "Move home directory holding the cached package dependencies, which are never fetched")
+	cmd.Flags().Int64(FlagMemoryLimitInMB, memoryLimitInMB, "Address space limit of the compiler process, unlimited when 0")
+	cmd.Flags().Int64(FlagProcessLimit, processLimit, "Limit of the processes of the user running the compiler process, unlimited when 0")
+	cmd.Flags().String(FlagWorkDir, os.TempDir(), "Directory in which packages are compiled")
+	cmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
+	cmd.Flags().String(FlagSentryDSN, os.Getenv("SENTRY_DSN"), "Sentry DSN")
+	cmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
+	cmd.Flags().Float64(FlagSentryProfilesSampleRate, sentryProfilesSampleRate, "Sentry profiles sample rate")
+	cmd.Flags().Float64(FlagSentryTracesSampleRate, sentryTracesSampleRate, "Sentry traces sample rate")
+
+	return cmd
+}
+
+// CompileCmd builds a package with the pinned compiler, it is run by the verifier as a sandboxed child process.
+func CompileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "compile [package-path]",
+		Short:  "Compile a Move package",
+		Args:   cobra.ExactArgs(1),
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			memoryLimitInMB, _ := cmd.Flags().GetInt64(FlagMemoryLimitInMB)
+			processLimit, _ := cmd.Flags().GetInt64(FlagProcessLimit)
+			if err := setLimits(memoryLimitInMB, processLimit); err != nil {
+				return err
+			}
+			return BuildPackage(args[0])
+		},
+	}
+
+	cmd.Flags().Int64(FlagMemoryLimitInMB, 0, "Address space limit, unlimited when 0")
+	cmd.Flags().Int64(FlagProcessLimit, 0, "Limit of the processes of the user, unlimited when 0")
+
+	return cmd
+}
@@ -0,0 +1,248 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	vmapi "github.com/initia-labs/movevm/api"
+	compiler "github.com/initia-labs/movevm/types/compiler"
+	buildtypes "github.com/initia-labs/movevm/types/compiler/build"
+	"golang.org/x/sys/unix"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+const (
+	movevmModulePath = "github.com/initia-labs/movevm"
+	buildDir         = "build"
+	bytecodeDir      = "bytecode_modules"
+	maxOutputLength  = 4096
+)
+
+// VerificationError is an error caused by the submitted package, it fails the verification
+// instead of leaving it to be retried.
+type VerificationError struct {
+	Reason string
+}
+
+func (e *VerificationError) Error() string {
+	return e.Reason
+}
+
+// CompiledPackage is the result of building a package
+type CompiledPackage struct {
+	Name    string
+	Modules [][]byte
+}
+
+// Compiler builds packages by running the compile command of this binary as a child process wrapped by a sandbox
+// command. The child gets no other environment than its home, PATH and MOVE_HOME, so that secrets such as the database
+// connection string are not exposed to it, and its memory and number of processes are limited.
+type Compiler struct {
+	executable string
+	config     CompilerConfig
+	sandbox    []string
+}
+
+type CompilerConfig struct {
+	// SandboxCommand is the command prefix wrapping the child process, e.g. a bwrap or nsjail invocation
+	SandboxCommand string
+	// InsecureNoSandbox allows running the child process without sandbox command, for local development only
+	InsecureNoSandbox bool
+	// MoveHome is the directory holding the cached package dependencies, which are never fetched
+	MoveHome        string
+	WorkDir         string
+	Timeout         time.Duration
+	MemoryLimitInMB int64
+	ProcessLimit    int64
+}
+
+func NewCompiler(config CompilerConfig) (*Compiler, error) {
+	sandbox := strings.Fields(config.SandboxCommand)
+	if len(sandbox) == 0 && !config.InsecureNoSandbox {
+		return nil, fmt.Errorf("no sandbox command to run the compiler, set --%s or --%s", FlagSandboxCommand, FlagInsecureNoSandbox)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executable: %w", err)
+	}
+
+	return &Compiler{
+		executable: executable,
+		config:     config,
+		sandbox:    sandbox,
+	}, nil
+}
+
+// CompilerVersion returns the version of the movevm library providing the compiler.
+func CompilerVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == movevmModulePath {
+			if dep.Replace != nil {
+				dep = dep.Replace
+			}
+			return fmt.Sprintf("movevm %s", dep.Version)
+		}
+	}
+	return "unknown"
+}
+
+// Compile writes the package files into a temporary directory and builds it.
+func (c *Compiler) Compile(ctx context.Context, files []db.ModuleVerificationFile) (*CompiledPackage, error) {
+	dir, err := os.MkdirTemp(c.config.WorkDir, "package-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create package directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := writePackage(dir, files); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	args := append([]string{}, c.sandbox...)
+	args = append(args, c.executable, "compile", dir,
+		"--"+FlagMemoryLimitInMB, fmt.Sprint(c.config.MemoryLimitInMB),
+		"--"+FlagProcessLimit, fmt.Sprint(c.config.ProcessLimit),
+	)
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = []string{
+		"HOME=" + dir,
+		"PATH=" + os.Getenv("PATH"),
+		// the dependencies missing from the cache are not cloned from remote repositories
+		"GIT_ALLOW_PROTOCOL=file",
+	}
+	if c.config.MoveHome != "" {
+		cmd.Env = append(cmd.Env, "MOVE_HOME="+c.config.MoveHome)
+	}
+
+	output, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return nil, ctx.Err()
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, &VerificationError{Reason: fmt.Sprintf("compilation timed out after %s", c.config.Timeout)}
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to run compiler: %w", err)
+		}
+		return nil, &VerificationError{Reason: "compilation failed: " + truncate(string(output), maxOutputLength)}
+	}
+
+	return readCompiledPackage(dir)
+}
+
+// BuildPackage builds the package with the pinned compiler into its build directory, using the cached dependencies
+// without fetching their latest version.
+func BuildPackage(packagePath string) error {
+	_, err := vmapi.BuildContract(compiler.NewCompilerArgumentWithBuildOption(packagePath, false,
+		buildtypes.WithInstallDir(filepath.Join(packagePath, buildDir)),
+		buildtypes.WithSkipFetchLatestGitDeps(),
+	))
+	return err
+}
+
+// setLimits limits the memory and the number of processes of the current process and its children
+func setLimits(memoryLimitInMB, processLimit int64) error {
+	if memoryLimitInMB > 0 {
+		limit := uint64(memoryLimitInMB) << 20
+		if err := unix.Setrlimit(unix.RLIMIT_AS, &unix.Rlimit{Cur: limit, Max: limit}); err != nil {
+			return fmt.Errorf("failed to limit the memory: %w", err)
+		}
+	}
+	if processLimit > 0 {
+		limit := uint64(processLimit)
+		if err := unix.Setrlimit(unix.RLIMIT_NPROC, &unix.Rlimit{Cur: limit, Max: limit}); err != nil {
+			return fmt.Errorf("failed to limit the processes: %w", err)
+		}
+	}
+	return nil
+}
+
+// writePackage writes the files into dir, rejecting paths that would escape it.
+func writePackage(dir string, files []db.ModuleVerificationFile) error {
+	for _, file := range files {
+		if !filepath.IsLocal(file.Path) {
+			return &VerificationError{Reason: fmt.Sprintf("invalid file path: %s", file.Path)}
+		}
+
+		path := filepath.Join(dir, file.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+		}
+		if err := os.WriteFile(path, []byte(file.Content), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// readCompiledPackage reads the bytecode of the root package modules, skipping its dependencies.
+func readCompiledPackage(dir string) (*CompiledPackage, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, buildDir))
+	if err != nil {
+		return nil, &VerificationError{Reason: "compilation produced no build output"}
+	}
+
+	var packageNames []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, buildDir, entry.Name(), bytecodeDir)); err == nil {
+			packageNames = append(packageNames, entry.Name())
+		}
+	}
+	if len(packageNames) != 1 {
+		return nil, &VerificationError{Reason: fmt.Sprintf("expected a single compiled package, found %d", len(packageNames))}
+	}
+
+	compiled := &CompiledPackage{Name: packageNames[0]}
+	modulesDir := filepath.Join(dir, buildDir, compiled.Name, bytecodeDir)
+	modules, err := filepath.Glob(filepath.Join(modulesDir, "*.mv"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(modules)
+
+	for _, module := range modules {
+		bytes, err := os.ReadFile(module)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", module, err)
+		}
+		compiled.Modules = append(compiled.Modules, bytes)
+	}
+	if len(compiled.Modules) == 0 {
+		return nil, &VerificationError{Reason: "package has no modules"}
+	}
+
+	return compiled, nil
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return s[:length] + "..."
+}
@@ -0,0 +1,82 @@
+package verifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+func TestNewCompilerRequiresSandbox(t *testing.T) {
+	_, err := NewCompiler(CompilerConfig{})
+	assert.ErrorContains(t, err, "no sandbox command")
+
+	_, err = NewCompiler(CompilerConfig{SandboxCommand: "bwrap --unshare-all"})
+	assert.NoError(t, err)
+	_, err = NewCompiler(CompilerConfig{InsecureNoSandbox: true})
+	assert.NoError(t, err)
+}
+
+func TestWritePackage(t *testing.T) {
+	t.Run("writes nested files", func(t *testing.T) {
+		dir := t.TempDir()
+		err := writePackage(dir, []db.ModuleVerificationFile{
+			{Path: "Move.toml", Content: "[package]"},
+			{Path: "sources/coin.move", Content: "module 0x1::coin {}"},
+		})
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "sources", "coin.move"))
+		require.NoError(t, err)
+		assert.Equal(t, "module 0x1::coin {}", string(content))
+	})
+
+	for _, path := range []string{"../Move.toml", "/etc/passwd", "sources/../../x.move"} {
+		t.Run("rejects "+path, func(t *testing.T) {
+			err := writePackage(t.TempDir(), []db.ModuleVerificationFile{{Path: path}})
+			var verificationErr *VerificationError
+			assert.ErrorAs(t, err, &verificationErr)
+		})
+	}
+}
+
+func TestReadCompiledPackage(t *testing.T) {
+	writeModule := func(t *testing.T, dir, pkg, name string, content []byte) {
+		modulesDir := filepath.Join(dir, buildDir, pkg, bytecodeDir)
+		require.NoError(t, os.MkdirAll(modulesDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(modulesDir, name), content, 0o644))
+	}
+
+	t.Run("reads the root package modules in order", func(t *testing.T) {
+		dir := t.TempDir()
+		writeModule(t, dir, "token", "b.mv", []byte{2})
+		writeModule(t, dir, "token", "a.mv", []byte{1})
+		// dependencies are built without bytecode_modules at the top level
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, buildDir, "token", "dependencies"), 0o755))
+
+		compiled, err := readCompiledPackage(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "token", compiled.Name)
+		assert.Equal(t, [][]byte{{1}, {2}}, compiled.Modules)
+	})
+
+	t.Run("fails without build output", func(t *testing.T) {
+		_, err := readCompiledPackage(t.TempDir())
+		var verificationErr *VerificationError
+		assert.ErrorAs(t, err, &verificationErr)
+	})
+
+	t.Run("fails with several packages", func(t *testing.T) {
+		dir := t.TempDir()
+		writeModule(t, dir, "a", "a.mv", []byte{1})
+		writeModule(t, dir, "b", "b.mv", []byte{1})
+
+		_, err := readCompiledPackage(dir)
+		var verificationErr *VerificationError
+		assert.ErrorAs(t, err, &verificationErr)
+	})
+}
@@ -0,0 +1,299 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/certifi/gocertifi"
+	"github.com/getsentry/sentry-go"
+	vmapi "github.com/initia-labs/movevm/api"
+	vmtypes "github.com/initia-labs/movevm/types"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/parser"
+	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
+)
+
+var logger *zerolog.Logger
+
+type Verifier struct {
+	dbClient *gorm.DB
+	compiler *Compiler
+	config   *VerifierConfig
+}
+
+type VerifierConfig struct {
+	Chain                    string
+	DBConnectionString       string
+	PollIntervalInSeconds    int64
+	CompileTimeoutInSeconds  int64
+	SandboxCommand           string
+	InsecureNoSandbox        bool
+	MoveHome                 string
+	MemoryLimitInMB          int64
+	ProcessLimit             int64
+	WorkDir                  string
+	Environment              string
+	CommitSHA                string
+	SentryDSN                string
+	SentryProfilesSampleRate float64
+	SentryTracesSampleRate   float64
+}
+
+func NewVerifier(config *VerifierConfig) (*Verifier, error) {
+	logger = zerolog.Ctx(log.With().Str("component", "move-verifier").Str("chain", config.Chain).Str("environment", config.Environment).Str("commit_sha", config.CommitSHA).Logger().WithContext(context.Background()))
+
+	sentryClientOptions := sentry.ClientOptions{
+		Dsn:                config.SentryDSN,
+		ServerName:         config.Chain + "-move-verifier",
+		EnableTracing:      true,
+		ProfilesSampleRate: config.SentryProfilesSampleRate,
+		TracesSampleRate:   config.SentryTracesSampleRate,
+		Environment:        config.Environment,
+		Release:            config.CommitSHA,
+		Tags: map[string]string{
+			"chain":       config.Chain,
+			"environment": config.Environment,
+			"component":   "move-verifier",
+			"commit_sha":  config.CommitSHA,
+		},
+	}
+
+	rootCAs, err := gocertifi.CACerts()
+	if err != nil {
+		logger.Fatal().Msgf("Sentry: Error getting root CAs: %v\n", err)
+	} else {
+		sentryClientOptions.CaCerts = rootCAs
+	}
+
+	err = sentry.Init(sentryClientOptions)
+	if err != nil {
+		logger.Fatal().Msgf("Sentry: Error initializing sentry: %v\n", err)
+		return nil, err
+	}
+
+	dbClient, err := db.NewClient(config.DBConnectionString)
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+		logger.Fatal().Msgf("DB: Error creating DB client: %v\n", err)
+		return nil, err
+	}
+
+	compiler, err := NewCompiler(CompilerConfig{
+		SandboxCommand:    config.SandboxCommand,
+		InsecureNoSandbox: config.InsecureNoSandbox,
+		MoveHome:          config.MoveHome,
+		WorkDir:           config.WorkDir,
+		Timeout:           time.Duration(config.CompileTimeoutInSeconds) * time.Second,
+		MemoryLimitInMB:   config.MemoryLimitInMB,
+		ProcessLimit:      config.ProcessLimit,
+	})
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+		logger.Fatal().Msgf("Compiler: Error creating compiler: %v\n", err)
+		return nil, err
+	}
+
+	return &Verifier{
+		dbClient: dbClient,
+		compiler: compiler,
+		config:   config,
+	}, nil
+}
+
+func (v *Verifier) StartVerifying(signalCtx context.Context) {
+	compilerVersion := CompilerVersion()
+	pollInterval := time.Duration(v.config.PollIntervalInSeconds) * time.Second
+	// a verification still compiling after twice the timeout belongs to a worker that stopped
+	staleAfter := 2 * time.Duration(v.config.CompileTimeoutInSeconds) * time.Second
+
+	for {
+		select {
+		case <-signalCtx.Done():
+			return
+		default:
+		}
+
+		requeued, err := db.RequeueStaleModuleVerifications(signalCtx, v.dbClient, time.Now().UTC().Add(-staleAfter))
+		if err != nil {
+			logger.Error().Msgf("DB: Error requeuing stale verifications: %v\n", err)
+		} else if requeued > 0 {
+			logger.Info().Msgf("Requeued %d stale verifications", requeued)
+		}
+
+		verification, err := db.ClaimModuleVerification(signalCtx, v.dbClient, compilerVersion)
+		if err != nil {
+			sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
+			logger.Error().Msgf("DB: Error claiming verification: %v\n", err)
+		}
+		if verification == nil {
+			select {
+			case <-signalCtx.Done():
+				return
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		localHub := sentry.CurrentHub().Clone()
+		localHub.ConfigureScope(func(scope *sentry.Scope) {
+			scope.SetTag("verification_id", fmt.Sprint(verification.ID))
+		})
+		ctx := sentry.SetHubOnContext(signalCtx, localHub)
+		if err := v.verify(ctx, verification); err != nil {
+			// left compiling, it is requeued once it becomes stale
+			sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
+			logger.Error().Msgf("Error verifying %d: %v\n", verification.ID, err)
+		}
+	}
+}
+
+// verify compiles the package of the verification and compares its modules with the published ones.
+func (v *Verifier) verify(parentCtx context.Context, verification *db.ModuleVerification) error {
+	logger.Info().Msgf("Verifying package %d of %s", verification.ID, verification.Address)
+
+	transaction, ctx := sentry_integration.StartSentryTransaction(parentCtx, "Verify", "Compile a Move package and verify it against on-chain modules")
+	defer transaction.Finish()
+
+	files, err := db.QueryModuleVerificationFiles(ctx, v.dbClient, verification.ID)
+	if err != nil {
+		return err
+	}
+
+	compiled, err := v.compiler.Compile(ctx, files)
+	if err != nil {
+		var verificationErr *VerificationError
+		if errors.As(err, &verificationErr) {
+			return v.fail(ctx, verification, verificationErr.Reason)
+		}
+		return err
+	}
+	verification.PackageName = compiled.Name
+
+	verifiedModules, reason, err := v.matchModules(ctx, verification, compiled)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return v.fail(ctx, verification, reason)
+	}
+
+	completedAt := time.Now().UTC()
+	verification.CompletedAt = &completedAt
+	for idx := range verifiedModules {
+		verifiedModules[idx].VerifiedAt = completedAt
+	}
+	if err := db.CompleteModuleVerification(ctx, v.dbClient, verification, verifiedModules); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Verified package %s of %s with %d modules", compiled.Name, verification.Address, len(verifiedModules))
+	return nil
+}
+
+// matchModules returns the compiled modules matching a published version, or the reason the package does not match.
+func (v *Verifier) matchModules(ctx context.Context, verification *db.ModuleVerification, compiled *CompiledPackage) ([]db.VerifiedModule, string, error) {
+	verifiedModules := make([]db.VerifiedModule, 0, len(compiled.Modules))
+	moduleIDs := make([]string, 0, len(compiled.Modules))
+	for _, bytes := range compiled.Modules {
+		abi, err := vmapi.DecodeModuleBytes(bytes)
+		if err != nil {
+			return nil, fmt.Sprintf("failed to decode compiled module: %v", err), nil
+		}
+
+		var module struct {
+			Address string `json:"address"`
+			Name    string `json:"name"`
+		}
+		if err := json.Unmarshal(abi, &module); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal module abi: %w", err)
+		}
+
+		address, err := vmtypes.NewAccountAddress(module.Address)
+		if err != nil {
+			return nil, fmt.Sprintf("invalid module address %s", module.Address), nil
+		}
+		if address.String() != verification.Address {
+			return nil, fmt.Sprintf("module %s is compiled for %s instead of %s", module.Name, address, verification.Address), nil
+		}
+
+		moduleID := fmt.Sprintf("%s::%s", address, module.Name)
+		moduleIDs = append(moduleIDs, moduleID)
+		verifiedModules = append(verifiedModules, db.VerifiedModule{
+			ModuleID:       moduleID,
+			Digest:         parser.GetModuleDigest(bytes),
+			VerificationID: verification.ID,
+			Abi:            db.JSON(abi),
+		})
+	}
+
+	digests, err := db.QueryModuleDigests(ctx, v.dbClient, moduleIDs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the digests of the versions published before module histories recorded them are unknown, a package matching
+	// one of those versions cannot be verified
+	unknownDigests, err := db.QueryModulesWithUnknownDigests(ctx, v.dbClient, moduleIDs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var mismatches []string
+	for _, verifiedModule := range verifiedModules {
+		published, ok := digests[verifiedModule.ModuleID]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("%s is not published", verifiedModule.ModuleID))
+			continue
+		}
+		if published[verifiedModule.Digest] {
+			continue
+		}
+		if unknownDigests[verifiedModule.ModuleID] {
+			mismatches = append(mismatches, fmt.Sprintf("%s does not match any published version of known digest, the versions published before the digests were recorded cannot be verified", verifiedModule.ModuleID))
+		} else {
+			mismatches = append(mismatches, fmt.Sprintf("%s does not match any published version", verifiedModule.ModuleID))
+		}
+	}
+	if len(mismatches) > 0 {
+		return nil, strings.Join(mismatches, "; "), nil
+	}
+
+	return verifiedModules, "", nil
+}
+
+func (v *Verifier) fail(ctx context.Context, verification *db.ModuleVerification, reason string) error {
+	logger.Info().Msgf("Verification %d failed: %s", verification.ID, reason)
+
+	completedAt := time.Now().UTC()
+	verification.CompletedAt = &completedAt
+	return db.FailModuleVerification(ctx, v.dbClient, verification, reason)
+}
+
+func (v *Verifier) Verify() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	defer sentry.Flush(2 * time.Second)
+
+	logger.Info().Msgf("Starting verifier with %s", CompilerVersion())
+	v.StartVerifying(ctx)
+
+	logger.Info().Msgf("Stopping verifier ...")
+	v.close()
+}
+
+func (v *Verifier) close() {
+	db, err := v.dbClient.DB()
+	if err == nil {
+		db.Close()
+	}
+}
@@ -0,0 +1,7 @@
+package main
+
+import "github.com/initia-labs/core-indexer/pipeline/pipeline"
+
+func main() {
+	pipeline.Execute()
+}
@@ -0,0 +1,150 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/core-indexer/pkg/mq"
+)
+
+func Execute() {
+	var rootCmd = &cobra.Command{
+		Use:   "pipeline",
+		Short: "Pipeline",
+		Long:  "Pipeline - Runs the sweeper, the indexers and the uploader in a single process",
+	}
+
+	rootCmd.AddCommand(
+		RunCmd(),
+	)
+
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+const (
+	FlagRPCEndpoints             = "rpc-endpoints"
+	FlagChain                    = "chain"
+	FlagDBConnectionString       = "db"
+	FlagMigrationsDir            = "migrations-dir"
+	FlagClaimCheckBucket         = "claim-check-bucket"
+	FlagClaimCheckThresholdInMB  = "claim-check-threshold-mb"
+	FlagBlockResultsEncoding     = "block-results-encoding"
+	FlagLCDTxResponseBucket      = "lcd-tx-response-bucket"
+	FlagEventFilter              = "event-filter"
+	FlagPartitionSize            = "partition-size"
+	FlagEnvironment              = "environment"
+	FlagSentryDSN                = "sentry-dsn"
+	FlagCommitSHA                = "commit-sha"
+	FlagSentryProfilesSampleRate = "sentry-profiles-sample-rate"
+	FlagSentryTracesSampleRate   = "sentry-traces-sample-rate"
+)
+
+// RunCmd runs the sweeper, the indexers and the uploader in a single process, exchanging their messages through an
+// in-process message bus.
+func RunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Runs the sweeper, the indexers and the uploader in a single process.",
+		Long:  "Runs the sweeper, the indexers and the uploader in a single process, exchanging their messages through an in-process message bus. The messages are not kept across restarts: every service resumes from the state recorded in the database.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rpcEndpoints, _ := cmd.Flags().GetString(FlagRPCEndpoints)
+			chain, _ := cmd.Flags().GetString(FlagChain)
+			dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
+			migrationsDir, _ := cmd.Flags().GetString(FlagMigrationsDir)
+			claimCheckBucket, _ := cmd.Flags().GetString(FlagClaimCheckBucket)
+			claimCheckThresholdInMB, _ := cmd.Flags().GetUint64(FlagClaimCheckThresholdInMB)
+			blockResultsEncoding, _ := cmd.Flags().GetString(FlagBlockResultsEncoding)
+			lcdTxResponseBucket, _ := cmd.Flags().GetString(FlagLCDTxResponseBucket)
+			eventFilter, _ := cmd.Flags().GetString(FlagEventFilter)
+			partitionSize, _ := cmd.Flags().GetUint64(FlagPartitionSize)
+			environment, _ := cmd.Flags().GetString(FlagEnvironment)
+			sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
+			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
+			sentryProfilesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryProfilesSampleRate)
+			sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
+
+			services, err := NewServices(&Config{
+				Chain:                    chain,
+				RPCEndpoints:             rpcEndpoints,
+				DBConnectionString:       dbConnectionString,
+				MigrationsDir:            migrationsDir,
+				ClaimCheckBucket:         claimCheckBucket,
+				ClaimCheckThresholdInMB:  int64(claimCheckThresholdInMB),
+				BlockResultsEncoding:     blockResultsEncoding,
+				LCDTxResponseBucket:      lcdTxResponseBucket,
+				EventFilter:              eventFilter,
+				PartitionSize:            int64(partitionSize),
+				Environment:              environment,
+				SentryDSN:                sentryDSN,
+				CommitSHA:                commitSHA,
+				SentryProfilesSampleRate: sentryProfilesSampleRate,
+				SentryTracesSampleRate:   sentryTracesSampleRate,
+			}, mq.NewMemoryBus())
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			return Run(ctx, services...)
+		},
+	}
+
+	threshold, err := strconv.ParseInt(os.Getenv("CLAIM_CHECK_THRESHOLD_IN_MB"), 10, 64)
+	if err != nil {
+		threshold = 1
+	}
+
+	blockResultsEncoding := os.Getenv("BLOCK_RESULTS_ENCODING")
+	if blockResultsEncoding == "" {
+		blockResultsEncoding = mq.EncodingJSON
+	}
+
+	partitionSize, err := strconv.ParseInt(os.Getenv("PARTITION_SIZE"), 10, 64)
+	if err != nil {
+		partitionSize = 100000
+	}
+
+	environment := os.Getenv("ENVIRONMENT")
+	if environment == "" {
+		environment = "local"
+	}
+
+	sentryProfilesSampleRate, err := strconv.ParseFloat(os.Getenv("SENTRY_PROFILES_SAMPLE_RATE"), 64)
+	if err != nil {
+		sentryProfilesSampleRate = 0.01
+	}
+
+	sentryTracesSampleRate, err := strconv.ParseFloat(os.Getenv("SENTRY_TRACES_SAMPLE_RATE"), 64)
+	if err != nil {
+		sentryTracesSampleRate = 0.01
+	}
+
+	cmd.Flags().String(FlagRPCEndpoints, os.Getenv("RPC_ENDPOINTS"), "RPC endpoints JSON config")
+	cmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID to index")
+	cmd.Flags().String(FlagDBConnectionString, os.Getenv("DB_CONNECTION_STRING"), "Database connection string")
+	cmd.Flags().String(FlagMigrationsDir, "db/migrations", "Migration files directory")
+	cmd.Flags().String(FlagClaimCheckBucket, os.Getenv("CLAIM_CHECK_BUCKET"), "Claim check bucket of the block results and the transaction responses")
+	cmd.Flags().Uint64(FlagClaimCheckThresholdInMB, uint64(threshold), "Claim check threshold in MB")
+	cmd.Flags().String(FlagBlockResultsEncoding, blockResultsEncoding, "Encoding of the block results messages: json or proto+zstd")
+	cmd.Flags().String(FlagLCDTxResponseBucket, os.Getenv("LCD_TX_RESPONSE_BUCKET"), "Bucket of the uploaded transaction responses")
+	cmd.Flags().String(FlagEventFilter, os.Getenv("EVENT_FILTER"), "JSON config of include/exclude rules for indexed events")
+	cmd.Flags().Uint64(FlagPartitionSize, uint64(partitionSize), "Number of blocks per event table partition")
+	cmd.Flags().String(FlagEnvironment, environment, "Environment, local uses the fake GCS server")
+	cmd.Flags().String(FlagSentryDSN, os.Getenv("SENTRY_DSN"), "Sentry DSN")
+	cmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
+	cmd.Flags().Float64(FlagSentryProfilesSampleRate, sentryProfilesSampleRate, "Sentry profiles sample rate")
+	cmd.Flags().Float64(FlagSentryTracesSampleRate, sentryTracesSampleRate, "Sentry traces sample rate")
+
+	return cmd
+}
@@ -0,0 +1,278 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	eventindexer "github.com/initia-labs/core-indexer/event-indexer/indexer"
+	genericindexer "github.com/initia-labs/core-indexer/generic-indexer/indexer"
+	informativeindexer "github.com/initia-labs/core-indexer/informative-indexer/indexer"
+	"github.com/initia-labs/core-indexer/pkg/db"
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+	"github.com/initia-labs/core-indexer/sweeper/sweeper"
+	"github.com/initia-labs/core-indexer/tx-response-uploader/uploader"
+)
+
+var logger = zerolog.Ctx(log.With().Str("component", "pipeline").Logger().WithContext(context.Background()))
+
+// ErrServiceStopped is returned when a service of the pipeline stops before the pipeline is cancelled
+var ErrServiceStopped = errors.New("service stopped")
+
+// Service is a service of the pipeline, run until its context is cancelled
+type Service struct {
+	Name string
+	Run  func(ctx context.Context)
+}
+
+// Run runs the services concurrently until the context is cancelled. A service stopping on its own stops the others,
+// and Run returns once every service returned.
+func Run(ctx context.Context, services ...Service) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		once    sync.Once
+		stopErr error
+	)
+	for _, service := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			logger.Info().Msgf("Starting %s", service.Name)
+			service.Run(ctx)
+			if ctx.Err() == nil {
+				once.Do(func() {
+					stopErr = fmt.Errorf("%w: %s", ErrServiceStopped, service.Name)
+				})
+				cancel()
+			}
+			logger.Info().Msgf("Stopped %s", service.Name)
+		}()
+	}
+	wg.Wait()
+
+	return stopErr
+}
+
+// Config is the config of the services run in a single process
+type Config struct {
+	Chain              string
+	RPCEndpoints       string
+	DBConnectionString string
+	MigrationsDir      string
+
+	// Claim check config, shared by the block results and the transaction responses
+	ClaimCheckBucket        string
+	ClaimCheckThresholdInMB int64
+	BlockResultsEncoding    string
+
+	LCDTxResponseBucket string
+	EventFilter         string
+	PartitionSize       int64
+
+	Environment              string
+	SentryDSN                string
+	CommitSHA                string
+	SentryProfilesSampleRate float64
+	SentryTracesSampleRate   float64
+}
+
+// BlockResultsTopic is the topic of the block results produced by the sweeper
+func (c *Config) BlockResultsTopic() string {
+	return c.Chain + "-block-results"
+}
+
+// TxResponsesTopic is the topic of the transaction responses produced by the generic indexer
+func (c *Config) TxResponsesTopic() string {
+	return c.Chain + "-tx-responses"
+}
+
+// ConsumerGroup is the consumer group of a service of the pipeline
+func (c *Config) ConsumerGroup(service string) string {
+	return c.Chain + "-" + service
+}
+
+// NewServices creates the sweeper, the indexers and the uploader exchanging their messages through the memory bus.
+// The migrations are applied first, the indexers loading their state from the database once created.
+func NewServices(config *Config, bus *mq.MemoryBus) ([]Service, error) {
+	dbClient, err := db.NewClient(config.DBConnectionString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the DB client: %w", err)
+	}
+	if err := db.ApplyMigrationFiles(logger, dbClient, config.MigrationsDir); err != nil {
+		return nil, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	var storageClient storage.Client
+	if config.Environment == "local" {
+		storageClient, err = storage.NewGCSFakeClient()
+	} else {
+		storageClient, err = storage.NewGCSClient()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the storage client: %w", err)
+	}
+
+	informative, err := informativeindexer.NewIndexer(&informativeindexer.Config{
+		ID:                             "pipeline",
+		Chain:                          config.Chain,
+		DBConnectionString:             config.DBConnectionString,
+		RPCEndpoints:                   config.RPCEndpoints,
+		RPCTimeoutInSeconds:            30,
+		MQBackend:                      mq.BackendMemory,
+		MQMemoryBus:                    bus,
+		KafkaBlockResultsTopic:         config.BlockResultsTopic(),
+		KafkaBlockResultsConsumerGroup: config.ConsumerGroup("informative-indexer"),
+		ClaimCheckThresholdInMB:        config.ClaimCheckThresholdInMB,
+		BlockResultsClaimCheckBucket:   config.ClaimCheckBucket,
+		LCDTxResponseClaimCheckBucket:  config.ClaimCheckBucket,
+		Environment:                    config.Environment,
+		SentryDSN:                      config.SentryDSN,
+		CommitSHA:                      config.CommitSHA,
+		SentryProfilesSampleRate:       config.SentryProfilesSampleRate,
+		SentryTracesSampleRate:         config.SentryTracesSampleRate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := eventindexer.New(&eventindexer.Config{
+		ID:                             "pipeline",
+		Chain:                          config.Chain,
+		DBConnectionString:             config.DBConnectionString,
+		RPCEndpoints:                   config.RPCEndpoints,
+		RPCTimeoutInSeconds:            30,
+		MQBackend:                      mq.BackendMemory,
+		MQMemoryBus:                    bus,
+		KafkaBlockResultsTopic:         config.BlockResultsTopic(),
+		KafkaBlockResultsConsumerGroup: config.ConsumerGroup("event-indexer"),
+		ClaimCheckThresholdInMB:        config.ClaimCheckThresholdInMB,
+		BlockResultsClaimCheckBucket:   config.ClaimCheckBucket,
+		EventFilter:                    config.EventFilter,
+		PartitionSize:                  config.PartitionSize,
+		Environment:                    config.Environment,
+		SentryDSN:                      config.SentryDSN,
+		CommitSHA:                      config.CommitSHA,
+		SentryProfilesSampleRate:       config.SentryProfilesSampleRate,
+		SentryTracesSampleRate:         config.SentryTracesSampleRate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	generic, err := genericindexer.New(&genericindexer.IndexerConfig{
+		ID:                            "pipeline",
+		RPCEndpoints:                  config.RPCEndpoints,
+		NumWorkers:                    1,
+		Chain:                         config.Chain,
+		DBConnectionString:            config.DBConnectionString,
+		MQBackend:                     mq.BackendMemory,
+		MQMemoryBus:                   bus,
+		KafkaBlockTopic:               config.BlockResultsTopic(),
+		KafkaTxResponseTopic:          config.TxResponsesTopic(),
+		KafkaBlockConsumerGroup:       config.ConsumerGroup("generic-indexer"),
+		ClaimCheckThresholdInMB:       config.ClaimCheckThresholdInMB,
+		BlockClaimCheckBucket:         config.ClaimCheckBucket,
+		LCDTxResponseClaimCheckBucket: config.ClaimCheckBucket,
+		BlockResultsClaimCheckBucket:  config.ClaimCheckBucket,
+		Environment:                   config.Environment,
+		RPCTimeOutInSeconds:           30,
+		SentryDSN:                     config.SentryDSN,
+		CommitSHA:                     config.CommitSHA,
+		SentryProfilesSampleRate:      config.SentryProfilesSampleRate,
+		SentryTracesSampleRate:        config.SentryTracesSampleRate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	txResponseUploader := uploader.NewTxResponseUploader(&uploader.Config{
+		ID:                      "pipeline",
+		MQBackend:               mq.BackendMemory,
+		MQMemoryBus:             bus,
+		LCDTXResponseBucket:     config.LCDTxResponseBucket,
+		Topic:                   config.TxResponsesTopic(),
+		KafkaConsumerGroup:      config.ConsumerGroup("tx-response-uploader"),
+		Chain:                   config.Chain,
+		Environment:             config.Environment,
+		ClaimCheckBucket:        config.ClaimCheckBucket,
+		StorageClient:           storageClient,
+		SentryDSN:               config.SentryDSN,
+		CommitSHA:               config.CommitSHA,
+		SentryTraceSampleRate:   config.SentryTracesSampleRate,
+		SentryProfileSampleRate: config.SentryProfilesSampleRate,
+	})
+
+	sweep, err := sweeper.NewSweeper(&sweeper.SweeperConfig{
+		RPCEndpoints:             config.RPCEndpoints,
+		RPCTimeOutInSeconds:      30,
+		Chain:                    config.Chain,
+		DBConnectionString:       config.DBConnectionString,
+		NumWorkers:               1,
+		SubscribeNewBlocks:       true,
+		MQBackend:                mq.BackendMemory,
+		MQMemoryBus:              bus,
+		KafkaTopics:              []string{config.BlockResultsTopic()},
+		ClaimCheckBucket:         config.ClaimCheckBucket,
+		ClaimCheckThresholdInMB:  config.ClaimCheckThresholdInMB,
+		BlockResultsEncoding:     config.BlockResultsEncoding,
+		Environment:              config.Environment,
+		SentryDSN:                config.SentryDSN,
+		CommitSHA:                config.CommitSHA,
+		SentryProfilesSampleRate: config.SentryProfilesSampleRate,
+		SentryTracesSampleRate:   config.SentryTracesSampleRate,
+		MigrationsDir:            config.MigrationsDir,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []Service{
+		{Name: "informative-indexer", Run: func(ctx context.Context) {
+			informative.RunPatcher(ctx)
+			informative.Index(ctx)
+		}},
+		{Name: "event-indexer", Run: event.Index},
+		{Name: "generic-indexer", Run: generic.Index},
+		{Name: "tx-response-uploader", Run: txResponseUploader.Run},
+		{Name: "sweeper", Run: func(ctx context.Context) {
+			// the sweeper resumes from the height tracked by the informative indexer, once it indexed the genesis
+			if err := waitForTracking(ctx, dbClient); err != nil {
+				if ctx.Err() == nil {
+					logger.Error().Msgf("Error waiting for the tracking to be initialized: %v", err)
+				}
+				return
+			}
+			sweep.Run(ctx)
+		}},
+	}, nil
+}
+
+// waitForTracking waits for the informative indexer to initialize the tracking
+func waitForTracking(ctx context.Context, dbClient *gorm.DB) error {
+	for {
+		initialized, err := db.IsTrackingInit(ctx, dbClient)
+		if err != nil {
+			return err
+		}
+		if initialized {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
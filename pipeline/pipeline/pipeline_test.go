@@ -0,0 +1,265 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+	"github.com/initia-labs/core-indexer/tx-response-uploader/uploader"
+)
+
+type memoryStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *memoryStorage) UploadFile(bucket string, objectPath string, message []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[bucket+"/"+objectPath] = message
+	return nil
+}
+
+func (s *memoryStorage) ReadFile(bucket string, objectPath string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	object, ok := s.objects[bucket+"/"+objectPath]
+	if !ok {
+		return nil, fmt.Errorf("failed to read object %s, %w", objectPath, storage.ErrObjectNotExist)
+	}
+	return object, nil
+}
+
+func (s *memoryStorage) ListObjects(bucket string, prefix string) ([]storage.ObjectAttrs, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objects := make([]storage.ObjectAttrs, 0)
+	for path, object := range s.objects {
+		if strings.HasPrefix(path, bucket+"/"+prefix) {
+			objects = append(objects, storage.ObjectAttrs{Name: strings.TrimPrefix(path, bucket+"/"), Size: int64(len(object))})
+		}
+	}
+	return objects, nil
+}
+
+func (s *memoryStorage) DeleteFile(bucket string, objectPath string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[bucket+"/"+objectPath]; !ok {
+		return fmt.Errorf("failed to delete object %s, %w", objectPath, storage.ErrObjectNotExist)
+	}
+	delete(s.objects, bucket+"/"+objectPath)
+	return nil
+}
+
+// sweep produces the block results of the heights as the sweeper does, the even heights through a claim check
+func sweep(config *Config, bus *mq.MemoryBus, storageClient storage.Client, heights int64) Service {
+	return Service{Name: "sweeper", Run: func(ctx context.Context) {
+		producer := bus.Producer()
+		defer producer.Close()
+
+		for height := int64(1); height <= heights; height++ {
+			value, err := mq.EncodeBlockResultMsg(&mq.BlockResultMsg{
+				Height: height,
+				Txs:    []mq.TxResult{{Hash: fmt.Sprintf("%064X", height)}},
+			}, config.BlockResultsEncoding)
+			if err != nil {
+				panic(err)
+			}
+
+			threshold := int64(1)
+			if height%2 == 0 {
+				threshold = 0
+			}
+			mq.ProduceWithClaimCheck(producer, &mq.ProduceWithClaimCheckInput{
+				Topic:                   config.BlockResultsTopic(),
+				Key:                     fmt.Appendf(nil, "%s_%d", mq.NEW_BLOCK_RESULTS_KAFKA_MESSAGE_KEY, height),
+				MessageInBytes:          value,
+				Producer:                "sweeper",
+				ClaimCheckKey:           fmt.Appendf(nil, "%s_%d", mq.NEW_BLOCK_RESULTS_CLAIM_CHECK_KAFKA_MESSAGE_KEY, height),
+				ClaimCheckThresholdInMB: threshold,
+				ClaimCheckBucket:        config.ClaimCheckBucket,
+				ClaimCheckObjectPath:    fmt.Sprint(height),
+				StorageClient:           storageClient,
+				Headers: []mq.Header{
+					{Key: "height", Value: fmt.Append(nil, height)},
+					{Key: mq.HeaderEncoding, Value: []byte(config.BlockResultsEncoding)},
+				},
+			}, logger)
+		}
+		<-ctx.Done()
+	}}
+}
+
+// index consumes the block results in the consumer group of the indexer, and produces a transaction response of every
+// transaction through a claim check as the generic indexer does when produceTxResponses is set
+func index(t *testing.T, config *Config, bus *mq.MemoryBus, storageClient storage.Client, name string, produceTxResponses bool) Service {
+	return Service{Name: name, Run: func(ctx context.Context) {
+		consumer := bus.Consumer(config.ConsumerGroup(name))
+		defer consumer.Close()
+		producer := bus.Producer()
+		defer producer.Close()
+
+		if err := consumer.SubscribeTopics([]string{config.BlockResultsTopic()}); err != nil {
+			t.Errorf("%s: %v", name, err)
+			return
+		}
+		for ctx.Err() == nil {
+			message, err := consumer.ReadMessage(10 * time.Millisecond)
+			if errors.Is(err, mq.ErrTimeout) {
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				return
+			}
+
+			value := message.Value
+			if strings.HasPrefix(string(message.Key), mq.NEW_BLOCK_RESULTS_CLAIM_CHECK_KAFKA_MESSAGE_KEY) {
+				var claimCheck mq.ClaimCheckMsg
+				if err := json.Unmarshal(message.Value, &claimCheck); err != nil {
+					t.Errorf("%s: %v", name, err)
+					return
+				}
+				if value, err = storageClient.ReadFile(config.ClaimCheckBucket, claimCheck.ObjectPath); err != nil {
+					t.Errorf("%s: %v", name, err)
+					return
+				}
+			}
+			blockResults, err := mq.DecodeBlockResultMsg(mq.MessageEncoding(message), value)
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				return
+			}
+
+			if produceTxResponses {
+				for _, tx := range blockResults.Txs {
+					mq.ProduceWithClaimCheck(producer, &mq.ProduceWithClaimCheckInput{
+						Topic:                   config.TxResponsesTopic(),
+						Key:                     []byte(mq.NEW_LCD_TX_RESPONSE_KAFKA_MESSAGE_KEY + "_" + tx.Hash),
+						MessageInBytes:          fmt.Appendf(nil, `{"height":%d}`, blockResults.Height),
+						Producer:                name,
+						ClaimCheckKey:           []byte(mq.NEW_LCD_TX_RESPONSE_CLAIM_CHECK_KAFKA_MESSAGE_KEY + "_" + tx.Hash),
+						ClaimCheckThresholdInMB: 0,
+						ClaimCheckBucket:        config.ClaimCheckBucket,
+						ClaimCheckObjectPath:    tx.Hash,
+						StorageClient:           storageClient,
+						Headers: []mq.Header{
+							{Key: "height", Value: fmt.Append(nil, blockResults.Height)},
+							{Key: "tx_hash", Value: []byte(tx.Hash)},
+						},
+					}, logger)
+				}
+			}
+
+			if err := consumer.CommitMessage(message); err != nil {
+				t.Errorf("%s: %v", name, err)
+				return
+			}
+		}
+	}}
+}
+
+func TestRunPipeline(t *testing.T) {
+	config := &Config{
+		Chain:                "test-1",
+		ClaimCheckBucket:     "claim-check",
+		BlockResultsEncoding: mq.EncodingJSON,
+		LCDTxResponseBucket:  "tx-responses",
+		Environment:          "test",
+	}
+	bus := mq.NewMemoryBus()
+	storageClient := &memoryStorage{objects: make(map[string][]byte)}
+
+	txResponseUploader := uploader.NewTxResponseUploader(&uploader.Config{
+		ID:                  "test",
+		MQBackend:           mq.BackendMemory,
+		MQMemoryBus:         bus,
+		LCDTXResponseBucket: config.LCDTxResponseBucket,
+		Topic:               config.TxResponsesTopic(),
+		KafkaConsumerGroup:  config.ConsumerGroup("tx-response-uploader"),
+		Chain:               config.Chain,
+		Environment:         config.Environment,
+		ClaimCheckBucket:    config.ClaimCheckBucket,
+		StorageClient:       storageClient,
+	})
+
+	const heights = 4
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		done <- Run(ctx,
+			index(t, config, bus, storageClient, "informative-indexer", false),
+			index(t, config, bus, storageClient, "event-indexer", false),
+			index(t, config, bus, storageClient, "generic-indexer", true),
+			Service{Name: "tx-response-uploader", Run: txResponseUploader.Run},
+			sweep(config, bus, storageClient, heights),
+		)
+	}()
+
+	// every transaction response is uploaded, read from its claim check object
+	require.Eventually(t, func() bool {
+		objects, err := storageClient.ListObjects(config.LCDTxResponseBucket, "")
+		assert.NoError(t, err)
+		return len(objects) == heights
+	}, 30*time.Second, 10*time.Millisecond)
+	for height := int64(1); height <= heights; height++ {
+		response, err := storageClient.ReadFile(config.LCDTxResponseBucket, fmt.Sprintf("%064X/%d", height, height))
+		require.NoError(t, err)
+		assert.JSONEq(t, fmt.Sprintf(`{"height":%d}`, height), string(response))
+	}
+
+	// every service committed every message of its topic
+	admin := bus.Admin()
+	for _, name := range []string{"informative-indexer", "event-indexer", "generic-indexer"} {
+		require.Eventually(t, func() bool {
+			offsets, err := admin.CommittedOffsets(config.ConsumerGroup(name), config.BlockResultsTopic())
+			assert.NoError(t, err)
+			return offsets[0] == heights
+		}, 30*time.Second, 10*time.Millisecond, name)
+	}
+	require.Eventually(t, func() bool {
+		offsets, err := admin.CommittedOffsets(config.ConsumerGroup("tx-response-uploader"), config.TxResponsesTopic())
+		assert.NoError(t, err)
+		return offsets[0] == heights
+	}, 30*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(30 * time.Second):
+		t.Fatal("pipeline not stopped")
+	}
+}
+
+func TestRunStopsOnServiceStop(t *testing.T) {
+	stopped := make(chan struct{})
+	err := Run(context.Background(),
+		Service{Name: "indexer", Run: func(ctx context.Context) {
+			<-ctx.Done()
+			close(stopped)
+		}},
+		Service{Name: "sweeper", Run: func(context.Context) {}},
+	)
+	assert.ErrorIs(t, err, ErrServiceStopped)
+	assert.ErrorContains(t, err, "sweeper")
+
+	// the other services are stopped before Run returns
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("indexer not stopped")
+	}
+}
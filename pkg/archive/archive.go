@@ -0,0 +1,170 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+// bundleExt is the extension of the bundles, zstd compressed JSON lines of block results messages
+const bundleExt = ".jsonl.zst"
+
+// Bundle is an archived object holding the block results of the consecutive heights From to To, both included
+type Bundle struct {
+	Path string
+	From int64
+	To   int64
+}
+
+// BundlePath returns the path of the bundle of the heights from to to, sortable by height
+func BundlePath(prefix string, from, to int64) string {
+	return path.Join(prefix, fmt.Sprintf("%012d-%012d%s", from, to, bundleExt))
+}
+
+// parseBundlePath returns the bundle of an object path, false when it is not a bundle
+func parseBundlePath(objectPath string) (Bundle, bool) {
+	name, ok := strings.CutSuffix(path.Base(objectPath), bundleExt)
+	if !ok {
+		return Bundle{}, false
+	}
+
+	var bundle Bundle
+	if _, err := fmt.Sscanf(name, "%d-%d", &bundle.From, &bundle.To); err != nil || bundle.From > bundle.To {
+		return Bundle{}, false
+	}
+	bundle.Path = objectPath
+	return bundle, true
+}
+
+// EncodeBundle encodes the block results messages of a bundle
+func EncodeBundle(msgs []mq.BlockResultMsg) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := json.NewEncoder(zw)
+	for i := range msgs {
+		if err := encoder.Encode(&msgs[i]); err != nil {
+			zw.Close()
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeBundle decodes the block results messages of a bundle, rejecting the messages of unknown versions
+func DecodeBundle(data []byte) ([]mq.BlockResultMsg, error) {
+	zr, err := zstd.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	msgs := make([]mq.BlockResultMsg, 0)
+	decoder := json.NewDecoder(zr)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return msgs, nil
+			}
+			return nil, err
+		}
+
+		msg, err := mq.DecodeBlockResultMsg(mq.EncodingJSON, raw)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+// ListBundles returns the bundles archived under the prefix sorted by height
+func ListBundles(storageClient storage.Client, bucket, prefix string) ([]Bundle, error) {
+	objects, err := storageClient.ListObjects(bucket, prefix+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := make([]Bundle, 0, len(objects))
+	for _, object := range objects {
+		if bundle, ok := parseBundlePath(object.Name); ok {
+			bundles = append(bundles, bundle)
+		}
+	}
+	sort.Slice(bundles, func(i, j int) bool { return bundles[i].From < bundles[j].From })
+	return bundles, nil
+}
+
+// Replay calls fn with the archived block results of the heights from to to, both included, in height order. to is
+// the last archived height when 0. It fails before reaching a height missing from the archive.
+func Replay(ctx context.Context, storageClient storage.Client, bucket, prefix string, from, to int64, fn func(msg *mq.BlockResultMsg) error) error {
+	bundles, err := ListBundles(storageClient, bucket, prefix)
+	if err != nil {
+		return err
+	}
+	if len(bundles) == 0 {
+		return fmt.Errorf("archive: no bundle under %s", prefix)
+	}
+	if to == 0 {
+		to = bundles[len(bundles)-1].To
+	}
+	if from > to {
+		return fmt.Errorf("archive: invalid height range %d to %d", from, to)
+	}
+
+	next := from
+	for _, bundle := range bundles {
+		if bundle.To < next {
+			continue
+		}
+		if bundle.From > next {
+			break
+		}
+
+		data, err := storageClient.ReadFile(bucket, bundle.Path)
+		if err != nil {
+			return err
+		}
+		msgs, err := DecodeBundle(data)
+		if err != nil {
+			return fmt.Errorf("archive: invalid bundle %s: %w", bundle.Path, err)
+		}
+
+		for i := range msgs {
+			if msgs[i].Height < next {
+				continue
+			}
+			if msgs[i].Height != next {
+				return fmt.Errorf("archive: bundle %s is missing height %d", bundle.Path, next)
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(&msgs[i]); err != nil {
+				return err
+			}
+			if next == to {
+				return nil
+			}
+			next++
+		}
+	}
+	return fmt.Errorf("archive: heights %d to %d are not archived", next, to)
+}
@@ -0,0 +1,198 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+type memoryStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *memoryStorage) UploadFile(bucket string, objectPath string, message []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[bucket+"/"+objectPath] = message
+	return nil
+}
+
+func (s *memoryStorage) ReadFile(bucket string, objectPath string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	object, ok := s.objects[bucket+"/"+objectPath]
+	if !ok {
+		return nil, fmt.Errorf("failed to get object %s, %w", objectPath, storage.ErrObjectNotExist)
+	}
+	return object, nil
+}
+
+func (s *memoryStorage) ListObjects(bucket string, prefix string) ([]storage.ObjectAttrs, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objects := make([]storage.ObjectAttrs, 0)
+	for path, object := range s.objects {
+		if name, ok := strings.CutPrefix(path, bucket+"/"); ok && strings.HasPrefix(name, prefix) {
+			objects = append(objects, storage.ObjectAttrs{Name: name, Size: int64(len(object))})
+		}
+	}
+	return objects, nil
+}
+
+func (s *memoryStorage) DeleteFile(bucket string, objectPath string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, bucket+"/"+objectPath)
+	return nil
+}
+
+func TestArchiver(t *testing.T) {
+	logger := zerolog.Nop()
+	bus := mq.NewMemoryBus()
+	storageClient := &memoryStorage{objects: make(map[string][]byte)}
+	produce := func(height int64, encoding string, claimCheckThresholdInMB int64) {
+		value, err := mq.EncodeBlockResultMsg(&mq.BlockResultMsg{Height: height, Hash: fmt.Sprint(height), Timestamp: time.Unix(height, 0).UTC()}, encoding)
+		require.NoError(t, err)
+		mq.ProduceWithClaimCheck(bus.Producer(), &mq.ProduceWithClaimCheckInput{
+			Topic:                   "blocks",
+			Key:                     fmt.Appendf(nil, "%s_%d", mq.NEW_BLOCK_RESULTS_KAFKA_MESSAGE_KEY, height),
+			MessageInBytes:          value,
+			Producer:                "sweeper",
+			ClaimCheckKey:           fmt.Appendf(nil, "%s_%d", mq.NEW_BLOCK_RESULTS_CLAIM_CHECK_KAFKA_MESSAGE_KEY, height),
+			ClaimCheckThresholdInMB: claimCheckThresholdInMB,
+			ClaimCheckBucket:        "claim-check",
+			ClaimCheckObjectPath:    fmt.Sprint(height),
+			StorageClient:           storageClient,
+			Headers:                 []mq.Header{{Key: mq.HeaderEncoding, Value: []byte(encoding)}},
+		}, &logger)
+	}
+	produce(3, mq.EncodingJSON, 1)
+	produce(5, mq.EncodingProtoZstd, 1)
+	produce(4, mq.EncodingJSON, 0)
+	produce(6, mq.EncodingJSON, 1)
+	produce(7, mq.EncodingJSON, 1)
+	produce(8, mq.EncodingJSON, 1)
+
+	config := DefaultConfig()
+	config.Bucket = "archive"
+	config.Topic = "blocks"
+	config.BundleSize = 2
+	archiver := NewArchiver(bus.Consumer("archiver"), storageClient, config, &logger)
+	require.NoError(t, archiver.consumer.SubscribeTopics([]string{config.Topic}))
+	archive := func(count int) {
+		for range count {
+			message, err := archiver.consumer.ReadMessage(time.Second)
+			require.NoError(t, err)
+			require.NoError(t, archiver.Archive(context.Background(), message))
+		}
+	}
+
+	// the archive starts at the first bundle boundary, heights 4 and 5 are written once both are read
+	archive(2)
+	bundles, err := ListBundles(storageClient, config.Bucket, config.Prefix)
+	require.NoError(t, err)
+	assert.Empty(t, bundles)
+	archive(1)
+	committed, err := bus.Admin().CommittedOffsets("archiver", "blocks")
+	require.NoError(t, err)
+	assert.Equal(t, map[int32]int64{0: 3}, committed)
+
+	// the message of height 8 is not committed until its bundle is written
+	archive(3)
+	bundles, err = ListBundles(storageClient, config.Bucket, config.Prefix)
+	require.NoError(t, err)
+	assert.Equal(t, []Bundle{
+		{Path: "block-results/000000000004-000000000005.jsonl.zst", From: 4, To: 5},
+		{Path: "block-results/000000000006-000000000007.jsonl.zst", From: 6, To: 7},
+	}, bundles)
+	committed, err = bus.Admin().CommittedOffsets("archiver", "blocks")
+	require.NoError(t, err)
+	assert.Equal(t, map[int32]int64{0: 5}, committed)
+
+	// a restarted archiver resumes after the last bundle
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	restarted := NewArchiver(bus.Consumer("archiver"), storageClient, config, &logger)
+	require.NoError(t, restarted.Run(ctx))
+	assert.Equal(t, int64(8), restarted.next)
+
+	heights := make([]int64, 0)
+	err = Replay(context.Background(), storageClient, config.Bucket, config.Prefix, 5, 0, func(msg *mq.BlockResultMsg) error {
+		assert.Equal(t, fmt.Sprint(msg.Height), msg.Hash)
+		heights = append(heights, msg.Height)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5, 6, 7}, heights)
+}
+
+func TestArchiverFailures(t *testing.T) {
+	logger := zerolog.Nop()
+	storageClient := &memoryStorage{objects: make(map[string][]byte)}
+	message := func(height int64) *mq.Message {
+		value, err := mq.EncodeBlockResultMsg(&mq.BlockResultMsg{Height: height}, mq.EncodingJSON)
+		require.NoError(t, err)
+		return &mq.Message{Topic: "blocks", Offset: height, Value: value}
+	}
+
+	config := DefaultConfig()
+	config.BundleSize = 2
+	config.StartHeight = 4
+	config.MaxPending = 2
+
+	// a message failing to be decoded is not skipped
+	archiver := NewArchiver(nil, storageClient, config, &logger)
+	archiver.next = config.StartHeight
+	err := archiver.Archive(context.Background(), &mq.Message{Topic: "blocks", Offset: 1, Value: []byte("{")})
+	assert.ErrorContains(t, err, "failed to decode the message of partition 0 offset 1")
+
+	// a missing height, no longer retained by the topic, fails the archive once too many heights are read ahead of it
+	archiver = NewArchiver(nil, storageClient, config, &logger)
+	archiver.next = config.StartHeight
+	require.NoError(t, archiver.Archive(context.Background(), message(5)))
+	require.NoError(t, archiver.Archive(context.Background(), message(6)))
+	err = archiver.Archive(context.Background(), message(7))
+	assert.ErrorContains(t, err, "height 4 is missing, 3 heights are read ahead of it")
+}
+
+func TestReplayMissingHeights(t *testing.T) {
+	storageClient := &memoryStorage{objects: make(map[string][]byte)}
+	write := func(from, to int64) {
+		msgs := make([]mq.BlockResultMsg, 0)
+		for height := from; height <= to; height++ {
+			msgs = append(msgs, mq.BlockResultMsg{Height: height})
+		}
+		data, err := EncodeBundle(msgs)
+		require.NoError(t, err)
+		require.NoError(t, storageClient.UploadFile("archive", BundlePath("block-results", from, to), data))
+	}
+	write(10, 19)
+	write(30, 39)
+	noop := func(msg *mq.BlockResultMsg) error { return nil }
+
+	err := Replay(context.Background(), storageClient, "archive", "block-results", 5, 15, noop)
+	assert.ErrorContains(t, err, "heights 5 to 15 are not archived")
+
+	heights := 0
+	err = Replay(context.Background(), storageClient, "archive", "block-results", 15, 35, func(msg *mq.BlockResultMsg) error {
+		heights++
+		return nil
+	})
+	assert.ErrorContains(t, err, "heights 20 to 35 are not archived")
+	assert.Equal(t, 5, heights)
+
+	require.NoError(t, Replay(context.Background(), storageClient, "archive", "block-results", 30, 39, noop))
+	err = Replay(context.Background(), storageClient, "archive", "other", 30, 39, noop)
+	assert.ErrorContains(t, err, "no bundle")
+}
@@ -0,0 +1,248 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog"
+
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+// Config configures the archiving of the block results messages of a topic
+type Config struct {
+	Bucket string
+	// Prefix is the path under which the bundles are written
+	Prefix string
+	Topic  string
+	// BundleSize is the number of heights of a bundle, the bundles start at the heights multiple of it
+	BundleSize int64
+	// StartHeight is the first height archived when nothing is, the first bundle boundary after the first height read
+	// when 0
+	StartHeight int64
+	// ClaimCheckBucket is the bucket of the claim check objects of the messages produced without bucket header
+	ClaimCheckBucket string
+	// MaxPending is the number of heights read ahead of the next height to archive after which the archive fails on
+	// the missing height, e.g. one no longer retained by the topic
+	MaxPending int
+	// PollInterval is the timeout of a read of the topic
+	PollInterval time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Prefix:       "block-results",
+		BundleSize:   1000,
+		MaxPending:   10000,
+		PollInterval: 10 * time.Second,
+	}
+}
+
+// Archiver writes the block results messages of a topic to the storage in bundles of consecutive heights. It reads the
+// topic as a consumer group of its own and commits a message once its height is written, so that it resumes after the
+// last bundle written after a restart.
+type Archiver struct {
+	consumer      mq.Consumer
+	storageClient storage.Client
+	config        Config
+	logger        *zerolog.Logger
+
+	// next is the next height to append to the bundle, 0 until it is known
+	next   int64
+	bundle []mq.BlockResultMsg
+	// pending are the messages read ahead of the next height, by height
+	pending map[int64]mq.BlockResultMsg
+	// uncommitted are the messages read and not committed yet, in read order by partition
+	uncommitted map[int32][]readMessage
+}
+
+type readMessage struct {
+	message *mq.Message
+	height  int64
+}
+
+func NewArchiver(consumer mq.Consumer, storageClient storage.Client, config Config, logger *zerolog.Logger) *Archiver {
+	return &Archiver{
+		consumer:      consumer,
+		storageClient: storageClient,
+		config:        config,
+		logger:        logger,
+		pending:       make(map[int64]mq.BlockResultMsg),
+		uncommitted:   make(map[int32][]readMessage),
+	}
+}
+
+// Run archives the messages of the topic until the context is done, the bundle in progress is written again after a
+// restart
+func (a *Archiver) Run(ctx context.Context) error {
+	bundles, err := ListBundles(a.storageClient, a.config.Bucket, a.config.Prefix)
+	if err != nil {
+		return err
+	}
+	if len(bundles) > 0 {
+		a.next = bundles[len(bundles)-1].To + 1
+		a.logger.Info().Msgf("Resuming archive at height %d", a.next)
+	} else if a.config.StartHeight != 0 {
+		a.next = a.config.StartHeight
+	}
+
+	if err := a.consumer.SubscribeTopics([]string{a.config.Topic}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		message, err := a.consumer.ReadMessage(a.config.PollInterval)
+		if err != nil {
+			if !errors.Is(err, mq.ErrTimeout) {
+				sentry_integration.CaptureCurrentHubException(err, sentry.LevelWarning)
+				a.logger.Error().Msgf("Error reading message: %v", err)
+			}
+			continue
+		}
+
+		if err := a.Archive(ctx, message); err != nil {
+			if ctx.Err() != nil {
+				// the message is archived again after a restart
+				return nil
+			}
+			sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+			return err
+		}
+	}
+}
+
+// Archive adds the message to the bundle of its height, writing the bundles completed by it and committing the
+// messages they hold. It fails once the context is done, when the message or a bundle cannot be encoded, or when more
+// than MaxPending heights are read ahead of a missing one, the archive having no gap.
+func (a *Archiver) Archive(ctx context.Context, message *mq.Message) error {
+	msg, err := a.decode(ctx, message)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("archive: failed to decode the message of partition %d offset %d: %w", message.Partition, message.Offset, err)
+	}
+
+	if a.next == 0 {
+		// the archive starts at a bundle boundary, the messages of the heights around the first one read may come
+		// out of order
+		a.next = (msg.Height + a.config.BundleSize - 1) / a.config.BundleSize * a.config.BundleSize
+		a.logger.Info().Msgf("Starting archive at height %d", a.next)
+	}
+	if msg.Height >= a.next {
+		a.pending[msg.Height] = msg
+	}
+	a.uncommitted[message.Partition] = append(a.uncommitted[message.Partition], readMessage{message: message, height: msg.Height})
+
+	for {
+		pending, ok := a.pending[a.next]
+		if !ok {
+			if len(a.pending) > a.config.MaxPending {
+				return fmt.Errorf("archive: height %d is missing, %d heights are read ahead of it", a.next, len(a.pending))
+			}
+			return nil
+		}
+		delete(a.pending, a.next)
+		a.bundle = append(a.bundle, pending)
+		a.next++
+
+		if a.next%a.config.BundleSize == 0 {
+			if err := a.flush(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// flush writes the bundle, retrying until the context is done, and commits the messages of the heights written
+func (a *Archiver) flush(ctx context.Context) error {
+	data, err := EncodeBundle(a.bundle)
+	if err != nil {
+		return err
+	}
+
+	objectPath := BundlePath(a.config.Prefix, a.bundle[0].Height, a.next-1)
+	for {
+		err := a.storageClient.UploadFile(a.config.Bucket, objectPath, data)
+		if err == nil {
+			break
+		}
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelWarning)
+		a.logger.Error().Msgf("Error writing bundle %s: %v", objectPath, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+	a.logger.Info().Msgf("Archived heights %d to %d", a.bundle[0].Height, a.next-1)
+	a.bundle = nil
+
+	for partition, messages := range a.uncommitted {
+		// a message is committed once every message read before it from its partition is archived
+		archived := 0
+		for archived < len(messages) && messages[archived].height < a.next {
+			archived++
+		}
+		if archived == 0 {
+			continue
+		}
+
+		if err := a.consumer.CommitMessage(messages[archived-1].message); err != nil {
+			sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
+			a.logger.Error().Msgf("Error committing message: %v", err)
+			continue
+		}
+		a.uncommitted[partition] = messages[archived:]
+	}
+	return nil
+}
+
+// decode returns the block results of a message, reading its claim check object until the context is done
+func (a *Archiver) decode(ctx context.Context, message *mq.Message) (mq.BlockResultMsg, error) {
+	value := message.Value
+	if strings.HasPrefix(string(message.Key), mq.NEW_BLOCK_RESULTS_CLAIM_CHECK_KAFKA_MESSAGE_KEY) {
+		bucket, object := a.config.ClaimCheckBucket, ""
+		if header, ok := message.Header(mq.HeaderClaimCheckBucket); ok {
+			bucket = string(header)
+		}
+		if header, ok := message.Header(mq.HeaderClaimCheckObject); ok {
+			object = string(header)
+		} else {
+			var claimCheckMsg mq.ClaimCheckMsg
+			if err := json.Unmarshal(message.Value, &claimCheckMsg); err != nil {
+				return mq.BlockResultMsg{}, err
+			}
+			object = claimCheckMsg.ObjectPath
+		}
+
+		for {
+			var err error
+			value, err = a.storageClient.ReadFile(bucket, object)
+			if err == nil {
+				break
+			}
+			a.logger.Error().Msgf("Error reading block_results from storage: %v", err)
+			select {
+			case <-ctx.Done():
+				return mq.BlockResultMsg{}, ctx.Err()
+			case <-time.After(time.Second):
+			}
+		}
+	}
+
+	return mq.DecodeBlockResultMsg(mq.MessageEncoding(message), value)
+}
@@ -0,0 +1,262 @@
+package claimcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog"
+
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+// legacyObjectPath matches the paths of the claim check objects written before they were namespaced by the topic and
+// the producer of their message: a block height, or a transaction hash and height
+var legacyObjectPath = regexp.MustCompile(`^([0-9]+|[0-9A-F]{64}/[0-9]+)$`)
+
+// Config configures the garbage collection of the claim check objects produced to topics
+type Config struct {
+	Topics []string
+	// Buckets are the claim check buckets scanned for the orphaned objects of the topics, by topic
+	Buckets map[string]string
+	// ConsumerGroups are the groups reading the topics, an object is deleted once all of them committed its message
+	ConsumerGroups []string
+	// Retention is the age from which an object is deleted even though some groups did not commit its message yet
+	Retention time.Duration
+	// PollInterval is the interval at which the offsets committed by the groups are refreshed
+	PollInterval time.Duration
+	// OrphanGracePeriod is how much older than the last message collected from its topic an object must be to be
+	// reported as orphaned
+	OrphanGracePeriod time.Duration
+	// OrphanScanInterval is the interval between two scans for orphaned objects
+	OrphanScanInterval time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Retention:          7 * 24 * time.Hour,
+		PollInterval:       10 * time.Second,
+		OrphanGracePeriod:  time.Hour,
+		OrphanScanInterval: time.Hour,
+	}
+}
+
+// GC deletes the claim check objects of the messages consumed by every registered consumer group. It reads the
+// topics as a consumer group of its own, committing a message once its object is deleted, so that it follows the
+// slowest group and resumes where it stopped after a restart.
+type GC struct {
+	consumer      mq.Consumer
+	admin         mq.Admin
+	storageClient storage.Client
+	config        Config
+	logger        *zerolog.Logger
+
+	// committed is the last fetch of the offsets committed by the groups, by topic
+	committed map[string]committedOffsets
+	// collected is the production time of the last message collected, by topic and partition
+	collected map[string]map[int32]time.Time
+}
+
+type committedOffsets struct {
+	// offsets are the offsets committed by partition, by group
+	offsets   []map[int32]int64
+	fetchedAt time.Time
+}
+
+func NewGC(consumer mq.Consumer, admin mq.Admin, storageClient storage.Client, config Config, logger *zerolog.Logger) *GC {
+	return &GC{
+		consumer:      consumer,
+		admin:         admin,
+		storageClient: storageClient,
+		config:        config,
+		logger:        logger,
+		committed:     make(map[string]committedOffsets),
+		collected:     make(map[string]map[int32]time.Time),
+	}
+}
+
+// Run collects the objects of the messages of the topics and scans for orphaned objects until the context is done
+func (g *GC) Run(ctx context.Context) error {
+	if err := g.consumer.SubscribeTopics(g.config.Topics); err != nil {
+		return err
+	}
+
+	lastScan := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		if time.Since(lastScan) >= g.config.OrphanScanInterval {
+			if _, err := g.ReportOrphans(); err != nil {
+				g.logger.Error().Msgf("Error scanning for orphaned claim check objects: %v", err)
+			}
+			lastScan = time.Now()
+		}
+
+		message, err := g.consumer.ReadMessage(g.config.PollInterval)
+		if err != nil {
+			if !errors.Is(err, mq.ErrTimeout) {
+				sentry_integration.CaptureCurrentHubException(err, sentry.LevelWarning)
+				g.logger.Error().Msgf("Error reading message: %v", err)
+			}
+			continue
+		}
+
+		if err := g.Collect(ctx, message); err != nil {
+			// the context is done, the message is collected again after a restart
+			return nil
+		}
+		if err := g.consumer.CommitMessage(message); err != nil {
+			sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
+			g.logger.Error().Msgf("Error committing message: %v", err)
+		}
+	}
+}
+
+// Collect waits until every group committed the message, or until it is older than the retention, and deletes its
+// claim check object. Messages without claim check are collected right away. It only fails once the context is done.
+func (g *GC) Collect(ctx context.Context, message *mq.Message) error {
+	bucket, hasBucket := message.Header(mq.HeaderClaimCheckBucket)
+	object, hasObject := message.Header(mq.HeaderClaimCheckObject)
+	if !hasBucket || !hasObject {
+		g.markCollected(message)
+		return nil
+	}
+
+	for {
+		consumed, err := g.consumed(message)
+		if err != nil {
+			g.logger.Warn().Msgf("Error fetching the offsets committed to topic %s: %v", message.Topic, err)
+		}
+		expired := time.Since(message.Timestamp) >= g.config.Retention
+
+		if consumed || expired {
+			if !consumed {
+				g.logger.Warn().Msgf("Deleting claim check object %s of partition %d offset %d past its retention before every group committed it", object, message.Partition, message.Offset)
+			}
+			err := g.storageClient.DeleteFile(string(bucket), string(object))
+			if err == nil || errors.Is(err, storage.ErrObjectNotExist) {
+				g.logger.Info().Msgf("Deleted claim check object %s", object)
+				g.markCollected(message)
+				return nil
+			}
+			g.logger.Error().Msgf("Error deleting claim check object %s: %v", object, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(g.config.PollInterval):
+		}
+	}
+}
+
+// consumed tells whether every group committed past the message, refreshing the committed offsets at most once per
+// poll interval
+func (g *GC) consumed(message *mq.Message) (bool, error) {
+	committed, ok := g.committed[message.Topic]
+	if !ok || time.Since(committed.fetchedAt) >= g.config.PollInterval {
+		offsets := make([]map[int32]int64, 0, len(g.config.ConsumerGroups))
+		for _, group := range g.config.ConsumerGroups {
+			groupOffsets, err := g.admin.CommittedOffsets(group, message.Topic)
+			if err != nil {
+				return false, fmt.Errorf("group %s: %w", group, err)
+			}
+			offsets = append(offsets, groupOffsets)
+		}
+		committed = committedOffsets{offsets: offsets, fetchedAt: time.Now()}
+		g.committed[message.Topic] = committed
+	}
+
+	for _, offsets := range committed.offsets {
+		if offset, ok := offsets[message.Partition]; !ok || offset <= message.Offset {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (g *GC) markCollected(message *mq.Message) {
+	partitions, ok := g.collected[message.Topic]
+	if !ok {
+		partitions = make(map[int32]time.Time)
+		g.collected[message.Topic] = partitions
+	}
+	partitions[message.Partition] = message.Timestamp
+}
+
+// ReportOrphans returns the claim check objects of the topics which no message refers to: as the objects are uploaded
+// right before their message is produced, an object older than every partition's last message collected by more than
+// the grace period would have been deleted along its message.
+func (g *GC) ReportOrphans() ([]storage.ObjectAttrs, error) {
+	orphans := make([]storage.ObjectAttrs, 0)
+	for _, topic := range g.config.Topics {
+		partitions := g.collected[topic]
+		if len(partitions) == 0 {
+			continue
+		}
+		var watermark time.Time
+		for _, timestamp := range partitions {
+			if watermark.IsZero() || timestamp.Before(watermark) {
+				watermark = timestamp
+			}
+		}
+
+		bucket := g.config.Buckets[topic]
+		objects, err := g.storageClient.ListObjects(bucket, topic+"/")
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			if object.Created.Before(watermark.Add(-g.config.OrphanGracePeriod)) {
+				g.logger.Warn().Msgf("Orphaned claim check object %s/%s created at %s", bucket, object.Name, object.Created)
+				orphans = append(orphans, object)
+			}
+		}
+	}
+
+	if len(orphans) > 0 {
+		sentry_integration.CaptureCurrentHubException(fmt.Errorf("found %d orphaned claim check objects", len(orphans)), sentry.LevelWarning)
+	}
+	return orphans, nil
+}
+
+// DeleteLegacyObjects deletes the claim check objects of the buckets written under the legacy paths once older than
+// the retention. The orphan scan only lists the objects under their topic, so the legacy objects are otherwise never
+// collected.
+func (g *GC) DeleteLegacyObjects() (int, error) {
+	buckets := make([]string, 0, len(g.config.Buckets))
+	for _, bucket := range g.config.Buckets {
+		if !slices.Contains(buckets, bucket) {
+			buckets = append(buckets, bucket)
+		}
+	}
+
+	deleted := 0
+	for _, bucket := range buckets {
+		objects, err := g.storageClient.ListObjects(bucket, "")
+		if err != nil {
+			return deleted, err
+		}
+		for _, object := range objects {
+			if !legacyObjectPath.MatchString(object.Name) || time.Since(object.Created) < g.config.Retention {
+				continue
+			}
+			if err := g.storageClient.DeleteFile(bucket, object.Name); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+	g.logger.Info().Msgf("Deleted %d legacy claim check objects", deleted)
+	return deleted, nil
+}
@@ -0,0 +1,252 @@
+package claimcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+type memoryStorage struct {
+	mu      sync.Mutex
+	objects map[string]storage.ObjectAttrs
+}
+
+func (s *memoryStorage) UploadFile(bucket string, objectPath string, message []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[bucket+"/"+objectPath] = storage.ObjectAttrs{Name: objectPath, Size: int64(len(message)), Created: time.Now()}
+	return nil
+}
+
+func (s *memoryStorage) ReadFile(bucket string, objectPath string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	object, ok := s.objects[bucket+"/"+objectPath]
+	if !ok {
+		return nil, fmt.Errorf("failed to read object %s, %w", objectPath, storage.ErrObjectNotExist)
+	}
+	return make([]byte, object.Size), nil
+}
+
+func (s *memoryStorage) ListObjects(bucket string, prefix string) ([]storage.ObjectAttrs, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objects := make([]storage.ObjectAttrs, 0)
+	for path, object := range s.objects {
+		if strings.HasPrefix(path, bucket+"/"+prefix) {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+func (s *memoryStorage) DeleteFile(bucket string, objectPath string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[bucket+"/"+objectPath]; !ok {
+		return fmt.Errorf("failed to delete object %s, %w", objectPath, storage.ErrObjectNotExist)
+	}
+	delete(s.objects, bucket+"/"+objectPath)
+	return nil
+}
+
+func (s *memoryStorage) exists(objectPath string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.objects["bucket/"+objectPath]
+	return ok
+}
+
+func TestGC(t *testing.T) {
+	logger := zerolog.Nop()
+	bus := mq.NewMemoryBus()
+	storageClient := &memoryStorage{objects: make(map[string]storage.ObjectAttrs)}
+	produce := func(height int, size int) {
+		mq.ProduceWithClaimCheck(bus.Producer(), &mq.ProduceWithClaimCheckInput{
+			Topic:                   "blocks",
+			Key:                     fmt.Appendf(nil, "block_%d", height),
+			MessageInBytes:          make([]byte, size),
+			Producer:                "sweeper",
+			ClaimCheckKey:           fmt.Appendf(nil, "claim_check_%d", height),
+			ClaimCheckThresholdInMB: 1,
+			ClaimCheckBucket:        "bucket",
+			ClaimCheckObjectPath:    fmt.Sprint(height),
+			StorageClient:           storageClient,
+		}, &logger)
+	}
+	produce(1, 2*1024*1024)
+	produce(2, 10)
+	produce(3, 2*1024*1024)
+	assert.True(t, storageClient.exists("blocks/sweeper/1"))
+	assert.True(t, storageClient.exists("blocks/sweeper/3"))
+
+	config := Config{
+		Topics:             []string{"blocks"},
+		Buckets:            map[string]string{"blocks": "bucket"},
+		ConsumerGroups:     []string{"indexer", "uploader"},
+		Retention:          time.Hour,
+		PollInterval:       5 * time.Millisecond,
+		OrphanGracePeriod:  time.Hour,
+		OrphanScanInterval: time.Hour,
+	}
+	gc := NewGC(bus.Consumer("gc"), bus.Admin(), storageClient, config, &logger)
+	require.NoError(t, gc.consumer.SubscribeTopics(config.Topics))
+
+	consume := func(group string, count int) {
+		consumer := bus.Consumer(group)
+		require.NoError(t, consumer.SubscribeTopics(config.Topics))
+		for range count {
+			message, err := consumer.ReadMessage(time.Second)
+			require.NoError(t, err)
+			require.NoError(t, consumer.CommitMessage(message))
+		}
+	}
+	consume("indexer", 3)
+
+	// the object is kept until every group committed its message
+	message, err := gc.consumer.ReadMessage(time.Second)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, gc.Collect(ctx, message), context.DeadlineExceeded)
+	assert.True(t, storageClient.exists("blocks/sweeper/1"))
+
+	consume("uploader", 1)
+	require.NoError(t, gc.Collect(context.Background(), message))
+	assert.False(t, storageClient.exists("blocks/sweeper/1"))
+
+	// a message without claim check is collected right away
+	message, err = gc.consumer.ReadMessage(time.Second)
+	require.NoError(t, err)
+	require.NoError(t, gc.Collect(context.Background(), message))
+
+	// an object past its retention is deleted even though a group did not commit its message
+	gc.config.Retention = 0
+	message, err = gc.consumer.ReadMessage(time.Second)
+	require.NoError(t, err)
+	require.NoError(t, gc.Collect(context.Background(), message))
+	assert.False(t, storageClient.exists("blocks/sweeper/3"))
+}
+
+func TestGCKeepsDLQObjects(t *testing.T) {
+	logger := zerolog.Nop()
+	bus := mq.NewMemoryBus()
+	storageClient := &memoryStorage{objects: make(map[string]storage.ObjectAttrs)}
+	mq.ProduceWithClaimCheck(bus.Producer(), &mq.ProduceWithClaimCheckInput{
+		Topic:                   "blocks",
+		Key:                     []byte("block_1"),
+		MessageInBytes:          make([]byte, 2*1024*1024),
+		Producer:                "sweeper",
+		ClaimCheckKey:           []byte("claim_check_1"),
+		ClaimCheckThresholdInMB: 1,
+		ClaimCheckBucket:        "bucket",
+		ClaimCheckObjectPath:    "1",
+		StorageClient:           storageClient,
+	}, &logger)
+
+	// the indexer sends the message to its DLQ and commits it
+	consumer := bus.Consumer("indexer")
+	require.NoError(t, consumer.SubscribeTopics([]string{"blocks"}))
+	message, err := consumer.ReadMessage(time.Second)
+	require.NoError(t, err)
+	mq.ProduceToDLQ(bus.Producer(), storageClient, "chain", "indexer", message, errors.New("failed"), &logger)
+	require.NoError(t, consumer.CommitMessage(message))
+
+	config := DefaultConfig()
+	config.Topics = []string{"blocks"}
+	config.Buckets = map[string]string{"blocks": "bucket"}
+	config.ConsumerGroups = []string{"indexer"}
+	config.PollInterval = 5 * time.Millisecond
+	gc := NewGC(bus.Consumer("gc"), bus.Admin(), storageClient, config, &logger)
+	require.NoError(t, gc.consumer.SubscribeTopics(config.Topics))
+	message, err = gc.consumer.ReadMessage(time.Second)
+	require.NoError(t, err)
+	require.NoError(t, gc.Collect(context.Background(), message))
+	assert.False(t, storageClient.exists("blocks/sweeper/1"))
+
+	// the DLQ message points to its own copy of the object, which the GC does not collect
+	dlq := bus.Consumer("replay")
+	require.NoError(t, dlq.SubscribeTopics([]string{"dlq-chain-indexer"}))
+	message, err = dlq.ReadMessage(time.Second)
+	require.NoError(t, err)
+	object, ok := message.Header(mq.HeaderClaimCheckObject)
+	require.True(t, ok)
+	assert.Equal(t, "dlq-chain-indexer/blocks/sweeper/1", string(object))
+	assert.JSONEq(t, `{"object_path":"dlq-chain-indexer/blocks/sweeper/1"}`, string(message.Value))
+	assert.True(t, storageClient.exists("dlq-chain-indexer/blocks/sweeper/1"))
+}
+
+func TestGCDeleteLegacyObjects(t *testing.T) {
+	logger := zerolog.Nop()
+	hash := strings.Repeat("AB", 32)
+	old := time.Now().Add(-48 * time.Hour)
+	storageClient := &memoryStorage{objects: map[string]storage.ObjectAttrs{
+		"bucket/100":                 {Name: "100", Created: old},
+		"bucket/" + hash + "/100":    {Name: hash + "/100", Created: old},
+		"bucket/101":                 {Name: "101", Created: time.Now()},
+		"bucket/blocks/sweeper/100":  {Name: "blocks/sweeper/100", Created: old},
+		"bucket/dlq-chain-indexer/1": {Name: "dlq-chain-indexer/1", Created: old},
+		"txs/102":                    {Name: "102", Created: old},
+	}}
+	config := DefaultConfig()
+	config.Topics = []string{"blocks", "txs"}
+	config.Buckets = map[string]string{"blocks": "bucket", "txs": "txs"}
+	config.Retention = 24 * time.Hour
+	gc := NewGC(nil, nil, storageClient, config, &logger)
+
+	// only the legacy objects past the retention are deleted
+	deleted, err := gc.DeleteLegacyObjects()
+	require.NoError(t, err)
+	assert.Equal(t, 3, deleted)
+	assert.False(t, storageClient.exists("100"))
+	assert.False(t, storageClient.exists(hash+"/100"))
+	assert.True(t, storageClient.exists("101"))
+	assert.True(t, storageClient.exists("blocks/sweeper/100"))
+	assert.True(t, storageClient.exists("dlq-chain-indexer/1"))
+	_, ok := storageClient.objects["txs/102"]
+	assert.False(t, ok)
+}
+
+func TestGCReportOrphans(t *testing.T) {
+	logger := zerolog.Nop()
+	storageClient := &memoryStorage{objects: map[string]storage.ObjectAttrs{
+		"bucket/blocks/sweeper/1": {Name: "blocks/sweeper/1", Created: time.Now().Add(-3 * time.Hour)},
+		"bucket/blocks/sweeper/2": {Name: "blocks/sweeper/2", Created: time.Now().Add(-30 * time.Minute)},
+		"bucket/txs/indexer/1":    {Name: "txs/indexer/1", Created: time.Now().Add(-3 * time.Hour)},
+		"txs/txs/indexer/2":       {Name: "txs/indexer/2", Created: time.Now().Add(-3 * time.Hour)},
+	}}
+	config := DefaultConfig()
+	config.Topics = []string{"blocks", "txs"}
+	config.Buckets = map[string]string{"blocks": "bucket", "txs": "txs"}
+	gc := NewGC(nil, nil, storageClient, config, &logger)
+
+	// nothing is reported before messages of the topic were collected
+	orphans, err := gc.ReportOrphans()
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+
+	gc.markCollected(&mq.Message{Topic: "blocks", Partition: 0, Timestamp: time.Now()})
+	gc.markCollected(&mq.Message{Topic: "blocks", Partition: 1, Timestamp: time.Now().Add(-time.Minute)})
+	orphans, err = gc.ReportOrphans()
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+	assert.Equal(t, "blocks/sweeper/1", orphans[0].Name)
+
+	// each topic is scanned in its own bucket
+	gc.markCollected(&mq.Message{Topic: "txs", Partition: 0, Timestamp: time.Now()})
+	orphans, err = gc.ReportOrphans()
+	require.NoError(t, err)
+	require.Len(t, orphans, 2)
+	assert.ElementsMatch(t, []string{"blocks/sweeper/1", "txs/indexer/2"}, []string{orphans[0].Name, orphans[1].Name})
+}
@@ -3,3 +3,6 @@ package cosmosrpc
 import "errors"
 
 var ErrorZeroActiveClients = errors.New("zero active clients")
+
+// errStaleHeight is returned when a client answers a query with an older height than the one requested
+var errStaleHeight = errors.New("RPC: Stale data")
@@ -0,0 +1,184 @@
+package cosmosrpc
+
+import (
+	"sync"
+	"time"
+)
+
+type BreakerState string
+
+const (
+	// BreakerClosed lets the requests through
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen rejects the requests until the open duration elapses
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets a single probe request through, closing the breaker when it succeeds
+	BreakerHalfOpen BreakerState = "half-open"
+
+	// healthDecay is the weight of the latest request in the latency and error rate moving averages
+	healthDecay = 0.2
+	// errorRatePenalty weighs the error rate against the latency when scoring a client
+	errorRatePenalty = 10
+)
+
+// HealthConfig configures the circuit breaker and the request hedging of a Hub
+type HealthConfig struct {
+	// FailureThreshold is the number of consecutive failures opening the breaker of a client
+	FailureThreshold int
+	// OpenDuration is the time an open breaker waits before letting a probe request through
+	OpenDuration time.Duration
+	// ExclusionDuration is the time a client disagreeing with the quorum of a block verification is excluded for
+	ExclusionDuration time.Duration
+	// HedgeDelay is the time after which a request still running is sent to a second client as well, 0 disables
+	// hedging
+	HedgeDelay time.Duration
+}
+
+func DefaultHealthConfig() HealthConfig {
+	return HealthConfig{
+		FailureThreshold:  5,
+		OpenDuration:      30 * time.Second,
+		ExclusionDuration: time.Hour,
+	}
+}
+
+// ClientHealth is the state of a client as tracked by a Hub
+type ClientHealth struct {
+	Identifier          string
+	Height              int64
+	State               BreakerState
+	Latency             time.Duration
+	ErrorRate           float64
+	ConsecutiveFailures int
+	Requests            int64
+	Failures            int64
+	// ExcludedUntil is set while the client is excluded for serving blocks the other clients disagree with
+	ExcludedUntil time.Time
+}
+
+// clientHealth tracks the latency and the errors of a client, and its circuit breaker
+type clientHealth struct {
+	mu                  sync.Mutex
+	config              HealthConfig
+	state               BreakerState
+	openedAt            time.Time
+	probing             bool
+	latency             time.Duration
+	errorRate           float64
+	consecutiveFailures int
+	requests            int64
+	failures            int64
+	excludedUntil       time.Time
+}
+
+func newClientHealth(config HealthConfig) *clientHealth {
+	return &clientHealth{config: config, state: BreakerClosed}
+}
+
+// allow returns whether the breaker lets a request through, claiming the probe of a half-open breaker
+func (c *clientHealth) allow(now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case BreakerOpen:
+		if now.Sub(c.openedAt) < c.config.OpenDuration {
+			return false
+		}
+		c.state = BreakerHalfOpen
+		c.probing = true
+		return true
+	case BreakerHalfOpen:
+		if c.probing {
+			return false
+		}
+		c.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// release gives back the probe of a half-open breaker whose request was abandoned
+func (c *clientHealth) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.probing = false
+}
+
+// record accounts a request that took latency and failed with err, if any
+func (c *clientHealth) record(latency time.Duration, err error, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests++
+	if c.requests == 1 {
+		c.latency = latency
+	} else {
+		c.latency = time.Duration(healthDecay*float64(latency) + (1-healthDecay)*float64(c.latency))
+	}
+
+	failed := 0.0
+	if err != nil {
+		failed = 1
+	}
+	c.errorRate = healthDecay*failed + (1-healthDecay)*c.errorRate
+
+	if err == nil {
+		c.consecutiveFailures = 0
+		// a request forced through an open breaker closes it as well when it succeeds
+		if c.state != BreakerClosed {
+			c.state = BreakerClosed
+			c.probing = false
+		}
+		return
+	}
+
+	c.failures++
+	c.consecutiveFailures++
+	switch {
+	case c.state == BreakerHalfOpen:
+		c.state = BreakerOpen
+		c.openedAt = now
+		c.probing = false
+	case c.state == BreakerClosed && c.consecutiveFailures >= c.config.FailureThreshold:
+		c.state = BreakerOpen
+		c.openedAt = now
+	}
+}
+
+// exclude keeps the client away from the queries until the exclusion duration elapsed
+func (c *clientHealth) exclude(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.excludedUntil = now.Add(c.config.ExclusionDuration)
+}
+
+func (c *clientHealth) excluded(now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return now.Before(c.excludedUntil)
+}
+
+// score is the expected cost of a request to the client, lower is better
+func (c *clientHealth) score() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return float64(c.latency) * (1 + errorRatePenalty*c.errorRate)
+}
+
+func (c *clientHealth) snapshot(identifier string, height int64) ClientHealth {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ClientHealth{
+		Identifier:          identifier,
+		Height:              height,
+		State:               c.state,
+		Latency:             c.latency,
+		ErrorRate:           c.errorRate,
+		ConsecutiveFailures: c.consecutiveFailures,
+		Requests:            c.requests,
+		Failures:            c.failures,
+		ExcludedUntil:       c.excludedUntil,
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	initiagovtypes "github.com/initia-labs/initia/x/gov/types"
 	movetypes "github.com/initia-labs/initia/x/move/types"
 	mstakingtypes "github.com/initia-labs/initia/x/mstaking/types"
@@ -25,6 +26,8 @@ type Hub struct {
 	mu            sync.Mutex
 	Clients       []CosmosJSONRPCClient
 	activeClients []ActiveClient
+	health        map[string]*clientHealth
+	healthConfig  HealthConfig
 	logger        *zerolog.Logger
 	timeout       time.Duration
 }
@@ -38,6 +41,12 @@ type CosmosJSONRPCHub interface {
 	CosmosJSONRPCClient
 	Rebalance(ctx context.Context) error
 	GetActiveClients() []ActiveClient
+	// SubscribeNewBlocks pushes the height of every committed block, see NewBlockSubscriber
+	SubscribeNewBlocks(ctx context.Context) (<-chan int64, error)
+	// VerifyBlock cross-checks a block and its results against the other active clients, see Hub.VerifyBlock
+	VerifyBlock(ctx context.Context, block *coretypes.ResultBlock, blockResults *coretypes.ResultBlockResults, quorum int) error
+	// Health returns the latency, error rate and breaker state of every client
+	Health() []ClientHealth
 }
 
 func NewHub(configs []ClientConfig, logger *zerolog.Logger, timeout time.Duration) *Hub {
@@ -51,11 +60,41 @@ func NewHub(configs []ClientConfig, logger *zerolog.Logger, timeout time.Duratio
 		}
 		clients = append(clients, client)
 	}
-	return &Hub{
+	hub := &Hub{
 		Clients: clients,
 		logger:  logger,
 		timeout: timeout,
 	}
+	return hub.WithHealthConfig(DefaultHealthConfig())
+}
+
+// WithHealthConfig sets the circuit breaker and hedging configuration of the hub, resetting the tracked health
+func (h *Hub) WithHealthConfig(config HealthConfig) *Hub {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.healthConfig = config
+	h.health = make(map[string]*clientHealth, len(h.Clients))
+	for _, client := range h.Clients {
+		h.health[client.GetIdentifier()] = newClientHealth(config)
+	}
+	return h
+}
+
+// clientHealth returns the tracked health of a client
+func (h *Hub) clientHealth(client CosmosJSONRPCClient) *clientHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.health == nil {
+		h.health = make(map[string]*clientHealth)
+	}
+	health, ok := h.health[client.GetIdentifier()]
+	if !ok {
+		health = newClientHealth(h.healthConfig)
+		h.health[client.GetIdentifier()] = health
+	}
+	return health
 }
 
 func (h *Hub) Rebalance(ctx context.Context) error {
@@ -68,7 +107,9 @@ func (h *Hub) Rebalance(ctx context.Context) error {
 	for _, client := range h.Clients {
 		ctx, cancel := createTimeoutContext(ctx, h.timeout)
 		defer cancel()
+		start := time.Now()
 		result, err = client.Status(ctx)
+		h.clientHealth(client).record(time.Since(start), err, time.Now())
 		if err != nil {
 			err = handleTimeoutError(err)
 			h.logger.Error().Err(err).Msgf("Failed to get client from id :%s status: %s", client.GetIdentifier(), err)
@@ -95,7 +136,7 @@ func (h *Hub) Status(ctx context.Context) (*coretypes.ResultStatus, error) {
 	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubStatus", "Calling /status from RPCs")
 	defer span.Finish()
 
-	result, err := handleQuery(ctx, h.timeout, h.activeClients, func(ctx context.Context, c ActiveClient) (*coretypes.ResultStatus, error) {
+	result, err := handleQuery(ctx, h, nil, func(ctx context.Context, c ActiveClient) (*coretypes.ResultStatus, error) {
 		return c.Client.Status(ctx)
 	})
 	if err != nil {
@@ -109,12 +150,12 @@ func (h *Hub) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock,
 	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubBlock", "Calling /block from RPCs")
 	defer span.Finish()
 
-	result, err := handleQuery(ctx, h.timeout, h.activeClients, func(ctx context.Context, c ActiveClient) (*coretypes.ResultBlock, error) {
+	result, err := handleQuery(ctx, h, height, func(ctx context.Context, c ActiveClient) (*coretypes.ResultBlock, error) {
 		result, err := c.Client.Block(ctx, height)
 		if err != nil {
 			return nil, err
 		} else if result.Block.Header.Height < *height {
-			return nil, fmt.Errorf("RPC: Stale block data")
+			return nil, fmt.Errorf("%w: block %d", errStaleHeight, result.Block.Header.Height)
 		} else {
 			return result, nil
 		}
@@ -130,12 +171,12 @@ func (h *Hub) BlockResults(ctx context.Context, height *int64) (*coretypes.Resul
 	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubBlockResults", "Calling /block_results from RPCs")
 	defer span.Finish()
 
-	result, err := handleQuery(ctx, h.timeout, h.activeClients, func(ctx context.Context, c ActiveClient) (*coretypes.ResultBlockResults, error) {
+	result, err := handleQuery(ctx, h, height, func(ctx context.Context, c ActiveClient) (*coretypes.ResultBlockResults, error) {
 		result, err := c.Client.BlockResults(ctx, height)
 		if err != nil {
 			return nil, err
 		} else if result.Height < *height {
-			return nil, fmt.Errorf("RPC: Stale block results data")
+			return nil, fmt.Errorf("%w: block results %d", errStaleHeight, result.Height)
 		} else {
 			return result, nil
 		}
@@ -151,7 +192,7 @@ func (h *Hub) Proposal(ctx context.Context, proposalID int32, height *int64) (*i
 	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubProposal", "Calling /proposal from RPCs")
 	defer span.Finish()
 
-	result, err := handleQuery(ctx, h.timeout, h.activeClients, func(ctx context.Context, c ActiveClient) (*initiagovtypes.QueryProposalResponse, error) {
+	result, err := handleQuery(ctx, h, height, func(ctx context.Context, c ActiveClient) (*initiagovtypes.QueryProposalResponse, error) {
 		return c.Client.Proposal(ctx, proposalID, height)
 	})
 	if err != nil {
@@ -161,11 +202,39 @@ func (h *Hub) Proposal(ctx context.Context, proposalID int32, height *int64) (*i
 	return result, nil
 }
 
+func (h *Hub) TallyResult(ctx context.Context, proposalID int32, height *int64) (*initiagovtypes.QueryTallyResultResponse, error) {
+	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubTallyResult", "Calling /tally_result from RPCs")
+	defer span.Finish()
+
+	result, err := handleQuery(ctx, h, height, func(ctx context.Context, c ActiveClient) (*initiagovtypes.QueryTallyResultResponse, error) {
+		return c.Client.TallyResult(ctx, proposalID, height)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tally result: %v", err)
+	}
+
+	return result, nil
+}
+
+func (h *Hub) GovParams(ctx context.Context, height *int64) (*initiagovtypes.QueryParamsResponse, error) {
+	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubGovParams", "Calling /gov_params from RPCs")
+	defer span.Finish()
+
+	result, err := handleQuery(ctx, h, height, func(ctx context.Context, c ActiveClient) (*initiagovtypes.QueryParamsResponse, error) {
+		return c.Client.GovParams(ctx, height)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gov params: %v", err)
+	}
+
+	return result, nil
+}
+
 func (h *Hub) Validator(ctx context.Context, validatorAddress string, height *int64) (*mstakingtypes.QueryValidatorResponse, error) {
 	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubValidator", "Calling /validator from RPCs")
 	defer span.Finish()
 
-	result, err := handleQuery(ctx, h.timeout, h.activeClients, func(ctx context.Context, c ActiveClient) (*mstakingtypes.QueryValidatorResponse, error) {
+	result, err := handleQuery(ctx, h, height, func(ctx context.Context, c ActiveClient) (*mstakingtypes.QueryValidatorResponse, error) {
 		return c.Client.Validator(ctx, validatorAddress, height)
 	})
 	if err != nil {
@@ -179,7 +248,7 @@ func (h *Hub) Validators(ctx context.Context, status string, height *int64) (*[]
 	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubValidatorInfos", "Calling validator infos from RPCs")
 	defer span.Finish()
 
-	result, err := handleQuery(ctx, h.timeout, h.activeClients, func(ctx context.Context, c ActiveClient) (*[]mstakingtypes.Validator, error) {
+	result, err := handleQuery(ctx, h, height, func(ctx context.Context, c ActiveClient) (*[]mstakingtypes.Validator, error) {
 		return c.Client.Validators(ctx, status, height)
 	})
 	if err != nil {
@@ -193,7 +262,7 @@ func (h *Hub) Module(ctx context.Context, address, moduleName string, height *in
 	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubModule", "Calling /module from RPCs")
 	defer span.Finish()
 
-	result, err := handleQuery(ctx, h.timeout, h.activeClients, func(ctx context.Context, c ActiveClient) (*movetypes.QueryModuleResponse, error) {
+	result, err := handleQuery(ctx, h, height, func(ctx context.Context, c ActiveClient) (*movetypes.QueryModuleResponse, error) {
 		return c.Client.Module(ctx, address, moduleName, height)
 	})
 	if err != nil {
@@ -207,7 +276,7 @@ func (h *Hub) Resource(ctx context.Context, address, structTag string, height *i
 	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubResource", "Calling /resource from RPCs")
 	defer span.Finish()
 
-	result, err := handleQuery(ctx, h.timeout, h.activeClients, func(ctx context.Context, c ActiveClient) (*movetypes.QueryResourceResponse, error) {
+	result, err := handleQuery(ctx, h, height, func(ctx context.Context, c ActiveClient) (*movetypes.QueryResourceResponse, error) {
 		return c.Client.Resource(ctx, address, structTag, height)
 	})
 	if err != nil {
@@ -221,7 +290,7 @@ func (h *Hub) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubGenesis", "Calling /genesis from RPCs")
 	defer span.Finish()
 
-	result, err := handleQuery(ctx, h.timeout, h.activeClients, func(ctx context.Context, c ActiveClient) (*coretypes.ResultGenesis, error) {
+	result, err := handleQuery(ctx, h, nil, func(ctx context.Context, c ActiveClient) (*coretypes.ResultGenesis, error) {
 		return c.Client.Genesis(ctx)
 	})
 	if err != nil {
@@ -231,12 +300,68 @@ func (h *Hub) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	return result, nil
 }
 
+func (h *Hub) Account(ctx context.Context, address string, height *int64) (*authtypes.QueryAccountResponse, error) {
+	span, ctx := sentry_integration.StartSentrySpan(ctx, "HubAccount", "Calling /account from RPCs")
+	defer span.Finish()
+
+	result, err := handleQuery(ctx, h, height, func(ctx context.Context, c ActiveClient) (*authtypes.QueryAccountResponse, error) {
+		return c.Client.Account(ctx, address, height)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %v", err)
+	}
+
+	return result, nil
+}
+
 func (h *Hub) GetActiveClients() []ActiveClient {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	return h.activeClients
 }
 
+func (h *Hub) Health() []ClientHealth {
+	heights := make(map[string]int64)
+	for _, active := range h.GetActiveClients() {
+		heights[active.Client.GetIdentifier()] = active.Height
+	}
+
+	health := make([]ClientHealth, 0, len(h.Clients))
+	for _, client := range h.Clients {
+		health = append(health, h.clientHealth(client).snapshot(client.GetIdentifier(), heights[client.GetIdentifier()]))
+	}
+	return health
+}
+
+func (h *Hub) hedgeDelay() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.healthConfig.HedgeDelay
+}
+
+// candidates returns the active clients not excluded with their health, the best scored first and the highest first among equal
+// scores
+func (h *Hub) candidates() []trackedClient {
+	active := h.GetActiveClients()
+	candidates := make([]trackedClient, 0, len(active))
+	now := time.Now()
+	for _, client := range active {
+		health := h.clientHealth(client.Client)
+		if health.excluded(now) {
+			continue
+		}
+		candidates = append(candidates, trackedClient{ActiveClient: client, health: health, score: health.score()})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score < candidates[j].score
+		}
+		return candidates[i].Height > candidates[j].Height
+	})
+	return candidates
+}
+
 func (h *Hub) GetIdentifier() string {
 	if len(h.Clients) == 0 {
 		return ""
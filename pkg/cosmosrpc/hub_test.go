@@ -0,0 +1,242 @@
+package cosmosrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	CosmosJSONRPCClient
+	identifier string
+	height     int64
+	delay      time.Duration
+	err        atomic.Pointer[error]
+	calls      atomic.Int64
+	// stale makes the client answer the block queries with the previous block
+	stale atomic.Bool
+}
+
+func newFakeClient(identifier string, height int64, delay time.Duration) *fakeClient {
+	return &fakeClient{identifier: identifier, height: height, delay: delay}
+}
+
+func (c *fakeClient) fail(err error) {
+	c.err.Store(&err)
+}
+
+func (c *fakeClient) GetIdentifier() string {
+	return c.identifier
+}
+
+func (c *fakeClient) Status(ctx context.Context) (*coretypes.ResultStatus, error) {
+	c.calls.Add(1)
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if err := c.err.Load(); err != nil && *err != nil {
+		return nil, *err
+	}
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: c.height}}, nil
+}
+
+func (c *fakeClient) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	c.calls.Add(1)
+	if err := c.err.Load(); err != nil && *err != nil {
+		return nil, *err
+	}
+	if *height > c.height {
+		return nil, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d", *height, c.height)
+	}
+	if c.stale.Load() {
+		return &coretypes.ResultBlock{Block: &cmttypes.Block{Header: cmttypes.Header{Height: *height - 1}}}, nil
+	}
+	return &coretypes.ResultBlock{Block: &cmttypes.Block{Header: cmttypes.Header{Height: *height}}}, nil
+}
+
+func newTestHub(t *testing.T, config HealthConfig, clients ...*fakeClient) *Hub {
+	logger := zerolog.Nop()
+	rpcClients := make([]CosmosJSONRPCClient, 0, len(clients))
+	for _, client := range clients {
+		rpcClients = append(rpcClients, client)
+	}
+	hub := (&Hub{Clients: rpcClients, logger: &logger, timeout: time.Second}).WithHealthConfig(config)
+	require.NoError(t, hub.Rebalance(context.Background()))
+	return hub
+}
+
+func healthOf(hub *Hub, identifier string) ClientHealth {
+	for _, health := range hub.Health() {
+		if health.Identifier == identifier {
+			return health
+		}
+	}
+	return ClientHealth{}
+}
+
+func TestClientHealthBreaker(t *testing.T) {
+	health := newClientHealth(HealthConfig{FailureThreshold: 2, OpenDuration: time.Minute})
+	now := time.Now()
+	failure := errors.New("unavailable")
+
+	health.record(time.Millisecond, failure, now)
+	assert.Equal(t, BreakerClosed, health.state)
+	health.record(time.Millisecond, failure, now)
+	assert.Equal(t, BreakerOpen, health.state)
+	assert.False(t, health.allow(now.Add(time.Second)))
+
+	// a single probe goes through once the open duration elapsed, and a failing probe opens the breaker again
+	assert.True(t, health.allow(now.Add(time.Minute)))
+	assert.Equal(t, BreakerHalfOpen, health.state)
+	assert.False(t, health.allow(now.Add(time.Minute)))
+	health.record(time.Millisecond, failure, now.Add(time.Minute))
+	assert.Equal(t, BreakerOpen, health.state)
+
+	// an abandoned probe is given back
+	assert.True(t, health.allow(now.Add(2*time.Minute)))
+	health.release()
+	assert.True(t, health.allow(now.Add(2*time.Minute)))
+
+	health.record(time.Millisecond, nil, now.Add(2*time.Minute))
+	assert.Equal(t, BreakerClosed, health.state)
+	assert.Zero(t, health.consecutiveFailures)
+	assert.Equal(t, int64(4), health.requests)
+	assert.Equal(t, int64(3), health.failures)
+}
+
+func TestHubRoutesToHealthiestClient(t *testing.T) {
+	slow := newFakeClient("slow", 110, 20*time.Millisecond)
+	fast := newFakeClient("fast", 100, 0)
+	hub := newTestHub(t, DefaultHealthConfig(), slow, fast)
+
+	for range 5 {
+		_, err := hub.Status(context.Background())
+		require.NoError(t, err)
+	}
+	// one call each from the rebalance, the queries then go to the faster client
+	assert.Equal(t, int64(1), slow.calls.Load())
+	assert.Equal(t, int64(6), fast.calls.Load())
+
+	assert.Equal(t, int64(110), healthOf(hub, "slow").Height)
+	assert.Equal(t, int64(6), healthOf(hub, "fast").Requests)
+}
+
+func TestHubOpensBreakerAndFailsOver(t *testing.T) {
+	primary := newFakeClient("primary", 100, 0)
+	secondary := newFakeClient("secondary", 100, time.Millisecond)
+	hub := newTestHub(t, HealthConfig{FailureThreshold: 2, OpenDuration: time.Hour}, primary, secondary)
+
+	primary.fail(errors.New("unavailable"))
+	for range 4 {
+		_, err := hub.Status(context.Background())
+		require.NoError(t, err)
+	}
+
+	health := healthOf(hub, "primary")
+	assert.Equal(t, BreakerOpen, health.State)
+	assert.Equal(t, int64(2), health.Failures)
+	// the open breaker keeps the requests away from the failing client
+	assert.Equal(t, int64(3), primary.calls.Load())
+
+	// with every breaker open the best client is tried anyway, and closes its breaker when it recovers
+	secondary.fail(errors.New("unavailable"))
+	for range 2 {
+		_, err := hub.Status(context.Background())
+		assert.Error(t, err)
+	}
+	assert.Equal(t, BreakerOpen, healthOf(hub, "secondary").State)
+
+	primary.fail(nil)
+	_, err := hub.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, BreakerClosed, healthOf(hub, "primary").State)
+}
+
+func TestHubHedgesSlowRequests(t *testing.T) {
+	slow := newFakeClient("slow", 100, 0)
+	fast := newFakeClient("fast", 100, 0)
+	hub := newTestHub(t, HealthConfig{FailureThreshold: 5, OpenDuration: time.Minute, HedgeDelay: 10 * time.Millisecond}, slow, fast)
+
+	// the slow client scores best, but answers after the hedged request to the other client
+	hub.health["fast"].latency = time.Millisecond
+	slow.delay = 200 * time.Millisecond
+	start := time.Now()
+	result, err := hub.Status(context.Background())
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 150*time.Millisecond)
+	assert.Equal(t, int64(2), slow.calls.Load())
+	assert.Equal(t, int64(2), fast.calls.Load())
+	assert.NotNil(t, result)
+
+	// the cancelled request is not held against the slow client
+	assert.Eventually(t, func() bool {
+		return healthOf(hub, "slow").Requests == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.Zero(t, healthOf(hub, "slow").Failures)
+}
+
+func TestHubCountsOneFailurePerCall(t *testing.T) {
+	client := newFakeClient("client", 100, 0)
+	hub := newTestHub(t, HealthConfig{FailureThreshold: 2, OpenDuration: time.Hour}, client)
+
+	// the retries of a call fail on the same client, but account for a single failure
+	client.fail(errors.New("unavailable"))
+	_, err := hub.Status(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int64(1+MAX_RETRY_COUNT), client.calls.Load())
+
+	health := healthOf(hub, "client")
+	assert.Equal(t, BreakerClosed, health.State)
+	assert.Equal(t, int64(1), health.Failures)
+	assert.Equal(t, 1, health.ConsecutiveFailures)
+}
+
+func TestHubQueriesAtTheTip(t *testing.T) {
+	clients := []*fakeClient{newFakeClient("a", 10, 0), newFakeClient("b", 10, 0), newFakeClient("c", 10, 0)}
+	hub := newTestHub(t, DefaultHealthConfig(), clients...)
+
+	// the next block is not committed yet, which is not held against the clients
+	height := int64(11)
+	for range 2 {
+		_, err := hub.Block(context.Background(), &height)
+		assert.Error(t, err)
+	}
+	for _, client := range clients {
+		health := healthOf(hub, client.identifier)
+		assert.Equal(t, BreakerClosed, health.State)
+		assert.Zero(t, health.Failures)
+	}
+
+	// nor are the answers with an older block
+	for _, client := range clients {
+		client.stale.Store(true)
+	}
+	height = 10
+	_, err := hub.Block(context.Background(), &height)
+	assert.Error(t, err)
+	for _, client := range clients {
+		assert.Zero(t, healthOf(hub, client.identifier).Failures)
+		client.stale.Store(false)
+	}
+
+	// the failures at the heights the clients have committed do count, once per call
+	for _, client := range clients {
+		client.fail(errors.New("unavailable"))
+	}
+	_, err = hub.Block(context.Background(), &height)
+	assert.Error(t, err)
+	for _, client := range clients {
+		assert.Equal(t, int64(1), healthOf(hub, client.identifier).Failures)
+	}
+}
@@ -11,11 +11,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	initiagovtypes "github.com/initia-labs/initia/x/gov/types"
 	movetypes "github.com/initia-labs/initia/x/move/types"
 	mstakingtypes "github.com/initia-labs/initia/x/mstaking/types"
 	"github.com/ybbus/jsonrpc/v3"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
 )
@@ -24,6 +27,7 @@ type Client struct {
 	jc         jsonrpc.RPCClient
 	clientCtx  client.Context
 	identifier string
+	headers    map[string]string
 }
 
 func appendHeightHeader(ctx context.Context, height *int64) context.Context {
@@ -54,11 +58,16 @@ type CosmosJSONRPCClient interface {
 	Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error)
 	BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error)
 	Proposal(ctx context.Context, proposalId int32, height *int64) (*initiagovtypes.QueryProposalResponse, error)
+	// TallyResult returns the live tally of a proposal in voting period, and the final tally of a resolved one
+	TallyResult(ctx context.Context, proposalId int32, height *int64) (*initiagovtypes.QueryTallyResultResponse, error)
+	GovParams(ctx context.Context, height *int64) (*initiagovtypes.QueryParamsResponse, error)
 	Validator(ctx context.Context, validatorAddress string, height *int64) (*mstakingtypes.QueryValidatorResponse, error)
 	Validators(ctx context.Context, status string, height *int64) (*[]mstakingtypes.Validator, error)
 	Module(ctx context.Context, address, moduleName string, height *int64) (*movetypes.QueryModuleResponse, error)
 	Resource(ctx context.Context, address, structTag string, height *int64) (*movetypes.QueryResourceResponse, error)
 	Genesis(ctx context.Context) (*coretypes.ResultGenesis, error)
+	// Account returns a response without account when the address has no auth account yet
+	Account(ctx context.Context, address string, height *int64) (*authtypes.QueryAccountResponse, error)
 	GetIdentifier() string
 }
 
@@ -74,7 +83,7 @@ func NewClient(url string) *Client {
 	if err != nil {
 		panic(err)
 	}
-	return &Client{jsonrpc.NewClient(url), client.Context{}.WithClient(c), url}
+	return &Client{jsonrpc.NewClient(url), client.Context{}.WithClient(c), url, nil}
 }
 
 func NewClientWithOption(url string, option ClientOption) *Client {
@@ -91,6 +100,7 @@ func NewClientWithOption(url string, option ClientOption) *Client {
 		}),
 		client.Context{}.WithClient(c),
 		url,
+		option.CustomHeaders,
 	}
 }
 
@@ -148,6 +158,36 @@ func (c *Client) Proposal(ctx context.Context, proposalID int32, height *int64)
 	return result, nil
 }
 
+func (c *Client) TallyResult(ctx context.Context, proposalID int32, height *int64) (*initiagovtypes.QueryTallyResultResponse, error) {
+	span, ctx := sentry_integration.StartSentrySpan(ctx, c.identifier+"/tally_result", "Calling tally_result of "+c.identifier)
+	defer span.Finish()
+
+	queryClient := initiagovtypes.NewQueryClient(c.clientCtx)
+	request := initiagovtypes.QueryTallyResultRequest{
+		ProposalId: uint64(proposalID),
+	}
+
+	result, err := queryClient.TallyResult(appendHeightHeader(ctx, height), &request)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) GovParams(ctx context.Context, height *int64) (*initiagovtypes.QueryParamsResponse, error) {
+	span, ctx := sentry_integration.StartSentrySpan(ctx, c.identifier+"/gov_params", "Calling gov_params of "+c.identifier)
+	defer span.Finish()
+
+	queryClient := initiagovtypes.NewQueryClient(c.clientCtx)
+	result, err := queryClient.Params(appendHeightHeader(ctx, height), &initiagovtypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (c *Client) Validator(ctx context.Context, ValidatorAddr string, height *int64) (*mstakingtypes.QueryValidatorResponse, error) {
 	span, ctx := sentry_integration.StartSentrySpan(ctx, c.identifier+"/validator", "Calling validator of "+c.identifier)
 	defer span.Finish()
@@ -231,6 +271,24 @@ func (c *Client) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error)
 	return handleResponseAndGetResult[coretypes.ResultGenesis](jsonResponse, err)
 }
 
+func (c *Client) Account(ctx context.Context, address string, height *int64) (*authtypes.QueryAccountResponse, error) {
+	span, ctx := sentry_integration.StartSentrySpan(ctx, c.identifier+"/account", "Calling account of "+c.identifier)
+	defer span.Finish()
+
+	queryClient := authtypes.NewQueryClient(c.clientCtx)
+	request := authtypes.QueryAccountRequest{
+		Address: address,
+	}
+	result, err := queryClient.Account(appendHeightHeader(ctx, height), &request)
+	if status.Code(err) == codes.NotFound {
+		return &authtypes.QueryAccountResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *Client) GetIdentifier() string {
 	return c.identifier
 }
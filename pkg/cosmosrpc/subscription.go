@@ -0,0 +1,177 @@
+package cosmosrpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	newBlockQuery = "tm.event='NewBlock'"
+	// wsReadTimeout bounds the silence of a subscription, the node pings its websocket clients well within it
+	wsReadTimeout  = time.Minute
+	wsWriteTimeout = 10 * time.Second
+)
+
+// NewBlockSubscriber is implemented by the clients able to push the height of every block committed by the node
+type NewBlockSubscriber interface {
+	// SubscribeNewBlocks subscribes to the NewBlock events of the node. The returned channel receives the height of
+	// every committed block and is closed when the connection drops or ctx is done.
+	SubscribeNewBlocks(ctx context.Context) (<-chan int64, error)
+}
+
+type newBlockMessage struct {
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+	Result struct {
+		Data struct {
+			Value struct {
+				Block struct {
+					Header struct {
+						Height int64 `json:"height,string"`
+					} `json:"header"`
+				} `json:"block"`
+			} `json:"value"`
+		} `json:"data"`
+	} `json:"result"`
+}
+
+// websocketURL returns the websocket endpoint of a CometBFT RPC URL
+func websocketURL(rpcURL string) (string, error) {
+	u, err := url.Parse(rpcURL)
+	if err != nil {
+		return "", err
+	}
+
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	case "ws", "wss":
+	default:
+		return "", fmt.Errorf("unsupported RPC url scheme %q", u.Scheme)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/websocket"
+	return u.String(), nil
+}
+
+func (c *Client) SubscribeNewBlocks(ctx context.Context) (<-chan int64, error) {
+	wsURL, err := websocketURL(c.identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make(http.Header)
+	for key, value := range c.headers {
+		header.Set(key, value)
+	}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, header)
+	if err != nil {
+		return nil, fmt.Errorf("RPC: failed to connect to %s: %w", wsURL, err)
+	}
+
+	if err := subscribe(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	heights := make(chan int64)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+	go func() {
+		defer close(heights)
+		defer close(done)
+		for {
+			height, err := readNewBlock(conn)
+			if err != nil {
+				return
+			}
+			if height > 0 && !sendHeight(ctx, heights, height) {
+				return
+			}
+		}
+	}()
+
+	return heights, nil
+}
+
+// subscribe sends the NewBlock subscription and waits for its response
+func subscribe(conn *websocket.Conn) error {
+	conn.SetPingHandler(func(data string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(wsWriteTimeout))
+	})
+
+	_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	err := conn.WriteJSON(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      0,
+		"method":  "subscribe",
+		"params":  map[string]string{"query": newBlockQuery},
+	})
+	if err == nil {
+		_, err = readNewBlock(conn)
+	}
+	if err != nil {
+		return fmt.Errorf("RPC: failed to subscribe to new blocks: %w", err)
+	}
+	return nil
+}
+
+// readNewBlock reads the next message of the subscription and returns the height of its block, 0 for a message
+// without block such as the subscription response
+func readNewBlock(conn *websocket.Conn) (int64, error) {
+	_ = conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+	var message newBlockMessage
+	if err := conn.ReadJSON(&message); err != nil {
+		return 0, err
+	}
+	if message.Error != nil {
+		return 0, fmt.Errorf("%s: %s", message.Error.Message, message.Error.Data)
+	}
+	return message.Result.Data.Value.Block.Header.Height, nil
+}
+
+func sendHeight(ctx context.Context, heights chan<- int64, height int64) bool {
+	select {
+	case heights <- height:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// SubscribeNewBlocks subscribes to the new blocks of the best scored client able to, so that blocks are fetched as
+// soon as they are committed
+func (h *Hub) SubscribeNewBlocks(ctx context.Context) (<-chan int64, error) {
+	var lastError error = ErrorZeroActiveClients
+	for _, candidate := range h.candidates() {
+		subscriber, ok := candidate.Client.(NewBlockSubscriber)
+		if !ok {
+			continue
+		}
+		heights, err := subscriber.SubscribeNewBlocks(ctx)
+		if err != nil {
+			h.logger.Warn().Err(err).Msgf("Failed to subscribe to new blocks of client %s", candidate.Client.GetIdentifier())
+			lastError = err
+			continue
+		}
+		return heights, nil
+	}
+	return nil, fmt.Errorf("RPC: no client subscribed to new blocks. Last error: %w", lastError)
+}
@@ -0,0 +1,101 @@
+package cosmosrpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebsocketURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+		err      bool
+	}{
+		{url: "http://localhost:26657", expected: "ws://localhost:26657/websocket"},
+		{url: "https://rpc.test/", expected: "wss://rpc.test/websocket"},
+		{url: "https://rpc.test/initia", expected: "wss://rpc.test/initia/websocket"},
+		{url: "tcp://localhost:26657", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			wsURL, err := websocketURL(tc.url)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, wsURL)
+		})
+	}
+}
+
+func newBlockServer(t *testing.T, subscribeError string, heights ...int64) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/websocket" || r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var request struct {
+			Method string            `json:"method"`
+			Params map[string]string `json:"params"`
+		}
+		require.NoError(t, conn.ReadJSON(&request))
+		assert.Equal(t, "subscribe", request.Method)
+		assert.Equal(t, newBlockQuery, request.Params["query"])
+
+		if subscribeError != "" {
+			_ = conn.WriteMessage(websocket.TextMessage, fmt.Appendf(nil, `{"jsonrpc":"2.0","id":0,"error":{"code":-32603,"message":"Internal error","data":%q}}`, subscribeError))
+			return
+		}
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":0,"result":{}}`))
+		for _, height := range heights {
+			_ = conn.WriteMessage(websocket.TextMessage, fmt.Appendf(nil, `{"jsonrpc":"2.0","id":0,"result":{"query":"tm.event='NewBlock'","data":{"type":"tendermint/event/NewBlock","value":{"block":{"header":{"height":"%d"}}}}}}`, height))
+		}
+	}))
+}
+
+func TestSubscribeNewBlocks(t *testing.T) {
+	server := newBlockServer(t, "", 10, 11, 12)
+	defer server.Close()
+	client := &Client{identifier: server.URL, headers: map[string]string{"X-Api-Key": "secret"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	heights, err := client.SubscribeNewBlocks(ctx)
+	require.NoError(t, err)
+
+	received := make([]int64, 0)
+	for height := range heights {
+		received = append(received, height)
+	}
+	// the channel is closed once the node drops the connection
+	assert.Equal(t, []int64{10, 11, 12}, received)
+}
+
+func TestSubscribeNewBlocksErrors(t *testing.T) {
+	server := newBlockServer(t, "max_subscriptions_per_client 5 reached")
+	defer server.Close()
+	ctx := context.Background()
+
+	client := &Client{identifier: server.URL, headers: map[string]string{"X-Api-Key": "secret"}}
+	_, err := client.SubscribeNewBlocks(ctx)
+	assert.ErrorContains(t, err, "max_subscriptions_per_client 5 reached")
+
+	client = &Client{identifier: server.URL}
+	_, err = client.SubscribeNewBlocks(ctx)
+	assert.ErrorContains(t, err, "failed to connect")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -28,15 +29,84 @@ func handleTimeoutError(err error) error {
 	return err
 }
 
-func handleQuery[T any, C ActiveClient](ctx context.Context, timeout time.Duration, clients []C, queryFn func(context.Context, C) (*T, error)) (*T, error) {
+// trackedClient is an active client with its health and its score when the query started
+type trackedClient struct {
+	ActiveClient
+	health *clientHealth
+	score  float64
+}
+
+// queryCall is a hub query along its retries, a client failing several times within a call accounts for a single
+// failure so that a call does not open breakers by itself
+type queryCall struct {
+	// height is the height the query targets, nil for the latest one
+	height *int64
+	mu     sync.Mutex
+	failed map[*clientHealth]bool
+}
+
+func newQueryCall(height *int64) *queryCall {
+	return &queryCall{height: height, failed: make(map[*clientHealth]bool)}
+}
+
+// record accounts the outcome of a request unless the client already failed within the call
+func (q *queryCall) record(health *clientHealth, latency time.Duration, err error) {
+	if err != nil {
+		q.mu.Lock()
+		failed := q.failed[health]
+		q.failed[health] = true
+		q.mu.Unlock()
+		if failed {
+			return
+		}
+	}
+	health.record(latency, err, time.Now())
+}
+
+// heightNotAvailable returns whether a request failed because the client has not committed the height it targets
+// yet, or answered with an older one. The client is not at fault, which is the usual case of the queries at the tip.
+func (q *queryCall) heightNotAvailable(client ActiveClient, err error) bool {
+	if errors.Is(err, errStaleHeight) {
+		return true
+	}
+	return q.height != nil && *q.height > 0 && client.Height < *q.height
+}
+
+type queryResult[T any] struct {
+	result *T
+	err    error
+}
+
+// handleQuery runs the query on the best scored client whose breaker lets it through, failing over to the next ones.
+// When every breaker is open the best client is tried anyway, so that the hub never fails without querying. height is
+// the height the query targets, nil for the queries on the latest state.
+func handleQuery[T any](ctx context.Context, h *Hub, height *int64, queryFn func(context.Context, ActiveClient) (*T, error)) (*T, error) {
+	call := newQueryCall(height)
 	var lastError error
 	for range MAX_RETRY_COUNT {
-		for _, client := range clients {
-			ctx, cancel := createTimeoutContext(ctx, timeout)
-			defer cancel()
-			result, err := queryFn(ctx, client)
+		candidates := h.candidates()
+		used := make([]bool, len(candidates))
+		attempted := false
+		for i := range candidates {
+			if used[i] || !candidates[i].health.allow(time.Now()) {
+				continue
+			}
+			used[i] = true
+			attempted = true
+			result, err := hedgeQuery(ctx, h, call, candidates, i, used, queryFn)
 			if err != nil {
-				lastError = handleTimeoutError(err)
+				lastError = err
+				continue
+			}
+			return result, nil
+		}
+
+		if !attempted && len(candidates) > 0 {
+			ctx, cancel := createTimeoutContext(ctx, h.timeout)
+			result, err := runQuery(ctx, call, candidates[0], queryFn)
+			cancel()
+			if err != nil {
+				lastError = err
 				continue
 			}
 			return result, nil
@@ -44,3 +114,71 @@ func handleQuery[T any, C ActiveClient](ctx context.Context, timeout time.Durati
 	}
 	return nil, fmt.Errorf("RPC: All RPC Clients failed to query. Last error: %v", lastError)
 }
+
+// hedgeQuery runs the query on candidates[index] and, when it is still running after the hedge delay, on the next
+// unused candidate whose breaker lets it through as well. The first success wins and the other request is cancelled.
+func hedgeQuery[T any](ctx context.Context, h *Hub, call *queryCall, candidates []trackedClient, index int, used []bool, queryFn func(context.Context, ActiveClient) (*T, error)) (*T, error) {
+	ctx, cancel := createTimeoutContext(ctx, h.timeout)
+	defer cancel()
+
+	hedgeDelay := h.hedgeDelay()
+	if hedgeDelay <= 0 {
+		return runQuery(ctx, call, candidates[index], queryFn)
+	}
+
+	ctx, cancelAll := context.WithCancel(ctx)
+	defer cancelAll()
+	// buffered so that the request losing the race does not block once the winner returned
+	results := make(chan queryResult[T], 2)
+	start := func(client trackedClient) {
+		go func() {
+			result, err := runQuery(ctx, call, client, queryFn)
+			results <- queryResult[T]{result: result, err: err}
+		}()
+	}
+
+	start(candidates[index])
+	pending := 1
+	timer := time.NewTimer(hedgeDelay)
+	defer timer.Stop()
+
+	var lastError error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			for i := range candidates {
+				if used[i] || !candidates[i].health.allow(time.Now()) {
+					continue
+				}
+				used[i] = true
+				start(candidates[i])
+				pending++
+				break
+			}
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				return r.result, nil
+			}
+			lastError = r.err
+		}
+	}
+	return nil, lastError
+}
+
+// runQuery runs the query on a client and records its latency and outcome. A request cancelled by the caller or by a
+// winning hedged request, or targeting a height the client does not have yet, is not held against the client.
+func runQuery[T any](ctx context.Context, call *queryCall, client trackedClient, queryFn func(context.Context, ActiveClient) (*T, error)) (*T, error) {
+	start := time.Now()
+	result, err := queryFn(ctx, client.ActiveClient)
+	if err != nil && (errors.Is(ctx.Err(), context.Canceled) || call.heightNotAvailable(client.ActiveClient, err)) {
+		client.health.release()
+		return nil, handleTimeoutError(err)
+	}
+
+	call.record(client.health, time.Since(start), err)
+	if err != nil {
+		return nil, handleTimeoutError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package cosmosrpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cjson "github.com/cometbft/cometbft/libs/json"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+)
+
+// BlockDigest identifies the content of a block and of its results
+type BlockDigest struct {
+	BlockHash   string
+	ResultsHash string
+}
+
+// Disagreement is the digest served by a client disagreeing with the verified block
+type Disagreement struct {
+	Identifier string
+	BlockDigest
+}
+
+// VerificationError is returned when fewer clients than the quorum agree with a block
+type VerificationError struct {
+	Height        int64
+	Agreements    int
+	Quorum        int
+	Disagreements []Disagreement
+}
+
+func (e *VerificationError) Error() string {
+	identifiers := make([]string, 0, len(e.Disagreements))
+	for _, disagreement := range e.Disagreements {
+		identifiers = append(identifiers, disagreement.Identifier)
+	}
+	return fmt.Sprintf("RPC: block %d is confirmed by %d other clients out of the %d required, disagreeing clients: [%s]", e.Height, e.Agreements, e.Quorum, strings.Join(identifiers, ", "))
+}
+
+// BlockResultsHash hashes the content of block results the nodes agree on, leaving out the logs and infos of the
+// transactions which are not part of the consensus
+func BlockResultsHash(blockResults *coretypes.ResultBlockResults) (string, error) {
+	stripped := *blockResults
+	stripped.TxsResults = make([]*abci.ExecTxResult, len(blockResults.TxsResults))
+	for i, txResult := range blockResults.TxsResults {
+		if txResult == nil {
+			continue
+		}
+		deterministic := *txResult
+		deterministic.Log = ""
+		deterministic.Info = ""
+		stripped.TxsResults[i] = &deterministic
+	}
+
+	bz, err := cjson.Marshal(stripped)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(bz)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// NewBlockDigest returns the digest of a block and of its results
+func NewBlockDigest(block *coretypes.ResultBlock, blockResults *coretypes.ResultBlockResults) (BlockDigest, error) {
+	resultsHash, err := BlockResultsHash(blockResults)
+	if err != nil {
+		return BlockDigest{}, err
+	}
+	return BlockDigest{BlockHash: block.BlockID.Hash.String(), ResultsHash: resultsHash}, nil
+}
+
+// VerifyBlock fetches the block and its results from every active client and requires quorum clients besides the one
+// having served them to agree, that is quorum+1 clients. Clients lagging behind or failing are not counted. The clients
+// disagreeing with a digest served by more than quorum clients are excluded for the exclusion duration.
+func (h *Hub) VerifyBlock(ctx context.Context, block *coretypes.ResultBlock, blockResults *coretypes.ResultBlockResults, quorum int) error {
+	expected, err := NewBlockDigest(block, blockResults)
+	if err != nil {
+		return err
+	}
+	height := block.Block.Height
+
+	candidates := h.candidates()
+	digests := make([]*BlockDigest, len(candidates))
+	var wg sync.WaitGroup
+	for i, candidate := range candidates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			digest, err := fetchBlockDigest(ctx, h.timeout, candidate, height)
+			if err != nil {
+				h.logger.Warn().Err(err).Msgf("Failed to fetch block %d from client %s for verification", height, candidate.Client.GetIdentifier())
+				return
+			}
+			digests[i] = &digest
+		}()
+	}
+	wg.Wait()
+
+	counts := make(map[BlockDigest]int)
+	for _, digest := range digests {
+		if digest != nil {
+			counts[*digest]++
+		}
+	}
+	majority, majorityCount := expected, counts[expected]
+	for digest, count := range counts {
+		if count > majorityCount {
+			majority, majorityCount = digest, count
+		}
+	}
+
+	disagreements := make([]Disagreement, 0)
+	now := time.Now()
+	for i, digest := range digests {
+		if digest == nil || *digest == expected {
+			continue
+		}
+		identifier := candidates[i].Client.GetIdentifier()
+		disagreements = append(disagreements, Disagreement{Identifier: identifier, BlockDigest: *digest})
+		h.logger.Warn().Msgf("Client %s disagrees on block %d: block hash %s, results hash %s, expected block hash %s, results hash %s", identifier, height, digest.BlockHash, digest.ResultsHash, expected.BlockHash, expected.ResultsHash)
+	}
+
+	if majorityCount > quorum {
+		for i, digest := range digests {
+			if digest != nil && *digest != majority {
+				h.logger.Error().Msgf("Excluding client %s disagreeing with %d clients on block %d", candidates[i].Client.GetIdentifier(), majorityCount, height)
+				candidates[i].health.exclude(now)
+			}
+		}
+	}
+
+	if agreements := counts[expected] - 1; agreements < quorum {
+		return &VerificationError{Height: height, Agreements: max(agreements, 0), Quorum: quorum, Disagreements: disagreements}
+	}
+	return nil
+}
+
+func fetchBlockDigest(ctx context.Context, timeout time.Duration, client trackedClient, height int64) (BlockDigest, error) {
+	ctx, cancel := createTimeoutContext(ctx, timeout)
+	defer cancel()
+
+	call := newQueryCall(&height)
+	block, err := runQuery(ctx, call, client, func(ctx context.Context, c ActiveClient) (*coretypes.ResultBlock, error) {
+		return c.Client.Block(ctx, &height)
+	})
+	if err != nil {
+		return BlockDigest{}, err
+	}
+	blockResults, err := runQuery(ctx, call, client, func(ctx context.Context, c ActiveClient) (*coretypes.ResultBlockResults, error) {
+		return c.Client.BlockResults(ctx, &height)
+	})
+	if err != nil {
+		return BlockDigest{}, err
+	}
+	return NewBlockDigest(block, blockResults)
+}
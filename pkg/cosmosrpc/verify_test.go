@@ -0,0 +1,108 @@
+package cosmosrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blockClient struct {
+	*fakeClient
+	blockHash string
+	gasUsed   int64
+	log       string
+	err       error
+}
+
+func (c *blockClient) Block(_ context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &coretypes.ResultBlock{
+		BlockID: cmttypes.BlockID{Hash: []byte(c.blockHash)},
+		Block:   &cmttypes.Block{Header: cmttypes.Header{Height: *height}},
+	}, nil
+}
+
+func (c *blockClient) BlockResults(_ context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return &coretypes.ResultBlockResults{
+		Height:     *height,
+		TxsResults: []*abci.ExecTxResult{{GasUsed: c.gasUsed, Log: c.log}},
+	}, nil
+}
+
+func newVerifyingHub(t *testing.T, clients ...*blockClient) *Hub {
+	fakes := make([]*fakeClient, 0, len(clients))
+	for _, client := range clients {
+		fakes = append(fakes, client.fakeClient)
+	}
+	hub := newTestHub(t, DefaultHealthConfig(), fakes...)
+	for i, client := range clients {
+		hub.Clients[i] = client
+		hub.activeClients[i].Client = client
+	}
+	return hub
+}
+
+func TestBlockResultsHash(t *testing.T) {
+	hash := func(txResult *abci.ExecTxResult) string {
+		h, err := BlockResultsHash(&coretypes.ResultBlockResults{Height: 10, TxsResults: []*abci.ExecTxResult{txResult}})
+		require.NoError(t, err)
+		return h
+	}
+
+	// logs and infos are not part of the consensus
+	assert.Equal(t, hash(&abci.ExecTxResult{GasUsed: 100, Log: "a"}), hash(&abci.ExecTxResult{GasUsed: 100, Info: "b"}))
+	assert.NotEqual(t, hash(&abci.ExecTxResult{GasUsed: 100}), hash(&abci.ExecTxResult{GasUsed: 101}))
+}
+
+func TestVerifyBlock(t *testing.T) {
+	honest := func(identifier string) *blockClient {
+		return &blockClient{fakeClient: newFakeClient(identifier, 100, 0), blockHash: "honest", gasUsed: 100, log: identifier}
+	}
+	liar := &blockClient{fakeClient: newFakeClient("liar", 100, 0), blockHash: "honest", gasUsed: 999}
+	lagging := honest("lagging")
+	lagging.err = errors.New("height 10 must be less than or equal to the current blockchain height 9")
+	hub := newVerifyingHub(t, honest("a"), honest("b"), liar, lagging)
+	ctx := context.Background()
+
+	height := int64(10)
+	block, err := hub.Clients[0].Block(ctx, &height)
+	require.NoError(t, err)
+	blockResults, err := hub.Clients[0].BlockResults(ctx, &height)
+	require.NoError(t, err)
+
+	// the liar disagreeing with the majority is excluded, the lagging client is not counted
+	require.NoError(t, hub.VerifyBlock(ctx, block, blockResults, 1))
+	assert.True(t, healthOf(hub, "liar").ExcludedUntil.After(time.Now()))
+	assert.True(t, healthOf(hub, "lagging").ExcludedUntil.IsZero())
+	for _, candidate := range hub.candidates() {
+		assert.NotEqual(t, "liar", candidate.Client.GetIdentifier())
+	}
+
+	err = hub.VerifyBlock(ctx, block, blockResults, 2)
+	var verificationError *VerificationError
+	require.ErrorAs(t, err, &verificationError)
+	assert.Equal(t, 1, verificationError.Agreements)
+	assert.Empty(t, verificationError.Disagreements)
+
+	// a block served by the liar is rejected
+	lied, err := liar.BlockResults(ctx, &height)
+	require.NoError(t, err)
+	err = hub.VerifyBlock(ctx, block, lied, 1)
+	require.ErrorAs(t, err, &verificationError)
+	assert.Zero(t, verificationError.Agreements)
+	identifiers := make([]string, 0, len(verificationError.Disagreements))
+	for _, disagreement := range verificationError.Disagreements {
+		identifiers = append(identifiers, disagreement.Identifier)
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, identifiers)
+	assert.ErrorContains(t, err, "confirmed by 0 other clients out of the 1 required")
+}
@@ -0,0 +1,225 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAccountStatsNotCounted is returned when the backfill runs before the indexer counted the activity of a block
+var ErrAccountStatsNotCounted = errors.New("the indexer has not counted the account stats of a block yet")
+
+var accountStatCounters = []string{
+	"tx_count",
+	"signer_tx_count",
+	"send_tx_count",
+	"ibc_tx_count",
+	"move_execute_tx_count",
+	"move_publish_tx_count",
+	"nft_tx_count",
+}
+
+// NewAccountStats aggregates the account transactions of a block into the activity they add to each account.
+// The transactions of the block give the category of each account transaction.
+func NewAccountStats(accountTxs []AccountTransaction, txs []Transaction, timestamp time.Time) []AccountStat {
+	txsByID := make(map[string]Transaction, len(txs))
+	for _, tx := range txs {
+		txsByID[tx.ID] = tx
+	}
+
+	statsByAccount := make(map[string]*AccountStat)
+	for _, accountTx := range accountTxs {
+		stat, ok := statsByAccount[accountTx.AccountID]
+		if !ok {
+			stat = &AccountStat{
+				AccountID:           accountTx.AccountID,
+				FirstSeenHeight:     accountTx.BlockHeight,
+				FirstSeenTimestamp:  timestamp,
+				LastActiveHeight:    accountTx.BlockHeight,
+				LastActiveTimestamp: timestamp,
+			}
+			statsByAccount[accountTx.AccountID] = stat
+		}
+
+		stat.TxCount++
+		if accountTx.IsSigner {
+			stat.SignerTxCount++
+		}
+
+		tx, ok := txsByID[accountTx.TransactionID]
+		if !ok {
+			continue
+		}
+		if tx.IsSend {
+			stat.SendTxCount++
+		}
+		if tx.IsIbc {
+			stat.IbcTxCount++
+		}
+		if tx.IsMoveExecute {
+			stat.MoveExecuteTxCount++
+		}
+		if tx.IsMovePublish {
+			stat.MovePublishTxCount++
+		}
+		if tx.IsNftTransfer || tx.IsNftMint || tx.IsNftBurn {
+			stat.NftTxCount++
+		}
+	}
+
+	stats := make([]AccountStat, 0, len(statsByAccount))
+	for _, stat := range statsByAccount {
+		stats = append(stats, *stat)
+	}
+	// a stable order keeps concurrent upserts from deadlocking
+	sort.Slice(stats, func(i, j int) bool { return stats[i].AccountID < stats[j].AccountID })
+
+	return stats
+}
+
+// UpsertAccountStats adds the activity of a block to the account stats. The counters of an account are left as they
+// are when its stats already cover the block, so that indexing a block again does not count it twice.
+func UpsertAccountStats(ctx context.Context, dbTx *gorm.DB, stats []AccountStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	assignments := map[string]any{
+		"first_seen_height":     gorm.Expr("LEAST(account_stats.first_seen_height, excluded.first_seen_height)"),
+		"first_seen_timestamp":  gorm.Expr("CASE WHEN excluded.first_seen_height < account_stats.first_seen_height THEN excluded.first_seen_timestamp ELSE account_stats.first_seen_timestamp END"),
+		"last_active_height":    gorm.Expr("GREATEST(account_stats.last_active_height, excluded.last_active_height)"),
+		"last_active_timestamp": gorm.Expr("CASE WHEN excluded.last_active_height > account_stats.last_active_height THEN excluded.last_active_timestamp ELSE account_stats.last_active_timestamp END"),
+	}
+	for _, counter := range accountStatCounters {
+		assignments[counter] = gorm.Expr(fmt.Sprintf("account_stats.%[1]s + CASE WHEN excluded.last_active_height > account_stats.last_active_height THEN excluded.%[1]s ELSE 0 END", counter))
+	}
+
+	return dbTx.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "account_id"}},
+			DoUpdates: clause.Assignments(assignments),
+		}).
+		CreateInBatches(stats, BatchSize).Error
+}
+
+// incrementAccountProposalsVoted counts a proposal the account had not voted on yet
+func incrementAccountProposalsVoted(ctx context.Context, dbTx *gorm.DB, voter string) error {
+	return dbTx.WithContext(ctx).
+		Model(&AccountStat{}).
+		Where("account_id = ?", voter).
+		UpdateColumn("proposals_voted", gorm.Expr("proposals_voted + 1")).Error
+}
+
+// MarkAccountStatsFromHeight records the height as the first one whose activity is counted by the indexer, unless one
+// is recorded already. The heights below it are counted by BackfillAccountStats.
+func MarkAccountStatsFromHeight(ctx context.Context, dbTx *gorm.DB, height int64) error {
+	return dbTx.WithContext(ctx).
+		Model(&Tracking{}).
+		Where("account_stats_from_height IS NULL").
+		Update("account_stats_from_height", height).Error
+}
+
+// BackfillAccountStats adds the activity of the next batchSize heights after the backfilled height to the account
+// stats, stopping below the first height counted by the indexer, and records the new backfilled height in the same
+// transaction. It returns the backfilled height and whether heights are left to backfill.
+func BackfillAccountStats(ctx context.Context, dbTx *gorm.DB, batchSize int64) (int64, bool, error) {
+	var tracking Tracking
+	if err := dbTx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&tracking).Error; err != nil {
+		return 0, false, err
+	}
+	if tracking.AccountStatsFromHeight == nil {
+		return 0, false, ErrAccountStatsNotCounted
+	}
+
+	from := tracking.AccountStatsBackfilledHeight
+	last := *tracking.AccountStatsFromHeight - 1
+	to := min(from+batchSize, last)
+	if to <= from {
+		return from, false, nil
+	}
+
+	// the transactions are read by height through their block height index, the account transactions by their key
+	if err := dbTx.WithContext(ctx).Exec(`
+		INSERT INTO account_stats (account_id, first_seen_height, first_seen_timestamp, last_active_height, last_active_timestamp,
+			tx_count, signer_tx_count, send_tx_count, ibc_tx_count, move_execute_tx_count, move_publish_tx_count, nft_tx_count, proposals_voted)
+		SELECT
+			account_transactions.account_id,
+			MIN(account_transactions.block_height),
+			MIN(blocks.timestamp),
+			MAX(account_transactions.block_height),
+			MAX(blocks.timestamp),
+			COUNT(*),
+			COUNT(*) FILTER (WHERE account_transactions.is_signer),
+			COUNT(*) FILTER (WHERE transactions.is_send),
+			COUNT(*) FILTER (WHERE transactions.is_ibc),
+			COUNT(*) FILTER (WHERE transactions.is_move_execute),
+			COUNT(*) FILTER (WHERE transactions.is_move_publish),
+			COUNT(*) FILTER (WHERE transactions.is_nft_transfer OR transactions.is_nft_mint OR transactions.is_nft_burn),
+			0
+		FROM transactions
+		JOIN account_transactions ON account_transactions.transaction_id = transactions.id
+		JOIN blocks ON blocks.height = transactions.block_height
+		WHERE transactions.block_height > ? AND transactions.block_height <= ?
+		GROUP BY account_transactions.account_id
+		ORDER BY account_transactions.account_id
+		ON CONFLICT (account_id) DO UPDATE SET
+			first_seen_height = LEAST(account_stats.first_seen_height, excluded.first_seen_height),
+			first_seen_timestamp = CASE WHEN excluded.first_seen_height < account_stats.first_seen_height THEN excluded.first_seen_timestamp ELSE account_stats.first_seen_timestamp END,
+			last_active_height = GREATEST(account_stats.last_active_height, excluded.last_active_height),
+			last_active_timestamp = CASE WHEN excluded.last_active_height > account_stats.last_active_height THEN excluded.last_active_timestamp ELSE account_stats.last_active_timestamp END,
+			tx_count = account_stats.tx_count + excluded.tx_count,
+			signer_tx_count = account_stats.signer_tx_count + excluded.signer_tx_count,
+			send_tx_count = account_stats.send_tx_count + excluded.send_tx_count,
+			ibc_tx_count = account_stats.ibc_tx_count + excluded.ibc_tx_count,
+			move_execute_tx_count = account_stats.move_execute_tx_count + excluded.move_execute_tx_count,
+			move_publish_tx_count = account_stats.move_publish_tx_count + excluded.move_publish_tx_count,
+			nft_tx_count = account_stats.nft_tx_count + excluded.nft_tx_count`, from, to).Error; err != nil {
+		return 0, false, err
+	}
+
+	if err := dbTx.WithContext(ctx).
+		Model(&Tracking{}).
+		Where("chain_id = ?", tracking.ChainID).
+		Update("account_stats_backfilled_height", to).Error; err != nil {
+		return 0, false, err
+	}
+
+	return to, to < last, nil
+}
+
+// RecountAccountProposalsVoted counts again the proposals voted on by up to limit accounts following after, in
+// account order, and returns the last account recounted. The rows are locked first so that the votes indexed
+// meanwhile are either seen by the recount or counted after it.
+func RecountAccountProposalsVoted(ctx context.Context, dbTx *gorm.DB, after string, limit int) (string, error) {
+	var accountIDs []string
+	if err := dbTx.WithContext(ctx).
+		Model(&AccountStat{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("account_id > ?", after).
+		Order("account_id").
+		Limit(limit).
+		Pluck("account_id", &accountIDs).Error; err != nil {
+		return "", err
+	}
+	if len(accountIDs) == 0 {
+		return "", nil
+	}
+
+	if err := dbTx.WithContext(ctx).Exec(`
+		UPDATE account_stats SET proposals_voted = (
+			SELECT COUNT(DISTINCT proposal_votes.proposal_id) FROM proposal_votes
+			WHERE proposal_votes.voter = account_stats.account_id
+		)
+		WHERE account_stats.account_id IN ?`, accountIDs).Error; err != nil {
+		return "", err
+	}
+
+	return accountIDs[len(accountIDs)-1], nil
+}
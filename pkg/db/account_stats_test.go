@@ -0,0 +1,47 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccountStats(t *testing.T) {
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	txs := []Transaction{
+		{ID: "tx1", IsSend: true},
+		{ID: "tx2", IsMoveExecute: true, IsNftMint: true},
+	}
+	accountTxs := []AccountTransaction{
+		{TransactionID: "tx1", AccountID: "init1b", BlockHeight: 10, IsSigner: true},
+		{TransactionID: "tx1", AccountID: "init1a", BlockHeight: 10},
+		{TransactionID: "tx2", AccountID: "init1b", BlockHeight: 10, IsSigner: true},
+		{TransactionID: "unknown", AccountID: "init1a", BlockHeight: 10},
+	}
+
+	assert.Equal(t, []AccountStat{
+		{
+			AccountID:           "init1a",
+			FirstSeenHeight:     10,
+			FirstSeenTimestamp:  timestamp,
+			LastActiveHeight:    10,
+			LastActiveTimestamp: timestamp,
+			TxCount:             2,
+			SendTxCount:         1,
+		},
+		{
+			AccountID:           "init1b",
+			FirstSeenHeight:     10,
+			FirstSeenTimestamp:  timestamp,
+			LastActiveHeight:    10,
+			LastActiveTimestamp: timestamp,
+			TxCount:             2,
+			SignerTxCount:       2,
+			SendTxCount:         1,
+			MoveExecuteTxCount:  1,
+			NftTxCount:          1,
+		},
+	}, NewAccountStats(accountTxs, txs, timestamp))
+}
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ChainStatGranularityHour = "hour"
+	ChainStatGranularityDay  = "day"
+)
+
+// ChainStatPeriod returns the start and the end of the hour or the UTC day t falls in
+func ChainStatPeriod(granularity string, t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	if granularity == ChainStatGranularityDay {
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 1)
+	}
+
+	start := t.Truncate(time.Hour)
+	return start, start.Add(time.Hour)
+}
+
+// QueryChainStatsResumeTime returns the hour the rollups resume from: the latest hourly rollup, which may have been
+// computed before all of its blocks were indexed, or the hour of the first block. It is nil when there is no block yet.
+func QueryChainStatsResumeTime(ctx context.Context, dbTx *gorm.DB) (*time.Time, error) {
+	var latest *time.Time
+	if err := dbTx.WithContext(ctx).
+		Model(&ChainStat{}).
+		Select("MAX(timestamp)").
+		Where("granularity = ?", ChainStatGranularityHour).
+		Scan(&latest).Error; err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		return latest, nil
+	}
+
+	var first *time.Time
+	if err := dbTx.WithContext(ctx).
+		Model(&Block{}).
+		Select("MIN(timestamp)").
+		Scan(&first).Error; err != nil {
+		return nil, err
+	}
+	if first == nil {
+		return nil, nil
+	}
+
+	start, _ := ChainStatPeriod(ChainStatGranularityHour, *first)
+	return &start, nil
+}
+
+// QueryBlockTimestamp returns the timestamp of the block at the given height
+func QueryBlockTimestamp(ctx context.Context, dbTx *gorm.DB, height int64) (time.Time, error) {
+	var block Block
+	if err := dbTx.WithContext(ctx).
+		Select("timestamp").
+		Where("height = ?", height).
+		First(&block).Error; err != nil {
+		return time.Time{}, err
+	}
+
+	return block.Timestamp, nil
+}
+
+// ComputeChainStat rolls up the activity of the blocks of the hour or the day starting at start, up to maxHeight.
+// Active accounts are the distinct transaction senders, and new accounts the ones sending their first transaction.
+func ComputeChainStat(ctx context.Context, dbTx *gorm.DB, granularity string, start time.Time, maxHeight int64) (ChainStat, error) {
+	start, end := ChainStatPeriod(granularity, start)
+	stat := ChainStat{
+		Granularity: granularity,
+		Timestamp:   start,
+		Fees:        JSON("[]"),
+	}
+
+	var blocks struct {
+		BlockCount int64
+		MinHeight  *int64
+		MaxHeight  *int64
+		Span       float64
+	}
+	if err := dbTx.WithContext(ctx).
+		Model(&Block{}).
+		Select("COUNT(*) AS block_count, MIN(height) AS min_height, MAX(height) AS max_height, COALESCE(EXTRACT(EPOCH FROM MAX(timestamp) - MIN(timestamp)), 0) AS span").
+		Where("timestamp >= ? AND timestamp < ? AND height <= ?", start, end, maxHeight).
+		Scan(&blocks).Error; err != nil {
+		return ChainStat{}, err
+	}
+	if blocks.MinHeight == nil || blocks.MaxHeight == nil {
+		return stat, nil
+	}
+
+	stat.BlockCount = blocks.BlockCount
+	if blocks.BlockCount > 1 {
+		stat.AvgBlockTime = blocks.Span / float64(blocks.BlockCount-1)
+	}
+
+	var txs struct {
+		TxCount        int64
+		SuccessTxCount int64
+		ActiveAccounts int64
+		GasUsed        int64
+	}
+	if err := dbTx.WithContext(ctx).
+		Model(&Transaction{}).
+		Select("COUNT(*) AS tx_count, COUNT(*) FILTER (WHERE success) AS success_tx_count, COUNT(DISTINCT sender) AS active_accounts, COALESCE(SUM(gas_used), 0) AS gas_used").
+		Where("block_height BETWEEN ? AND ?", *blocks.MinHeight, *blocks.MaxHeight).
+		Scan(&txs).Error; err != nil {
+		return ChainStat{}, err
+	}
+	stat.TxCount = txs.TxCount
+	stat.SuccessTxCount = txs.SuccessTxCount
+	stat.FailedTxCount = txs.TxCount - txs.SuccessTxCount
+	stat.ActiveAccounts = txs.ActiveAccounts
+	stat.GasUsed = txs.GasUsed
+
+	if err := dbTx.WithContext(ctx).Raw(`
+		SELECT COUNT(DISTINCT transactions.sender) FROM transactions
+		WHERE transactions.block_height BETWEEN ? AND ?
+		AND NOT EXISTS (
+			SELECT 1 FROM account_transactions
+			WHERE account_transactions.account_id = transactions.sender
+			AND account_transactions.is_signer
+			AND account_transactions.block_height < ?
+		)`, *blocks.MinHeight, *blocks.MaxHeight, *blocks.MinHeight).
+		Scan(&stat.NewAccounts).Error; err != nil {
+		return ChainStat{}, err
+	}
+
+	// gas fees are stored as coins strings such as 100uinit,20uusdc
+	fees := make([]struct {
+		Denom  string `json:"denom"`
+		Amount string `json:"amount"`
+	}, 0)
+	if err := dbTx.WithContext(ctx).Raw(`
+		SELECT fee[2] AS denom, SUM(fee[1]::numeric)::text AS amount
+		FROM transactions CROSS JOIN LATERAL regexp_matches(transactions.gas_fee, '([0-9]+)([a-zA-Z][a-zA-Z0-9/:._-]*)', 'g') AS fee
+		WHERE transactions.block_height BETWEEN ? AND ?
+		GROUP BY denom
+		ORDER BY denom`, *blocks.MinHeight, *blocks.MaxHeight).
+		Scan(&fees).Error; err != nil {
+		return ChainStat{}, err
+	}
+	feesJSON, err := json.Marshal(fees)
+	if err != nil {
+		return ChainStat{}, err
+	}
+	stat.Fees = JSON(feesJSON)
+
+	if err := dbTx.WithContext(ctx).
+		Model(&ModuleFunctionCall{}).
+		Where("block_height BETWEEN ? AND ?", *blocks.MinHeight, *blocks.MaxHeight).
+		Count(&stat.ModuleCalls).Error; err != nil {
+		return ChainStat{}, err
+	}
+
+	if err := dbTx.WithContext(ctx).
+		Model(&NftTransaction{}).
+		Where("is_nft_mint AND block_height BETWEEN ? AND ?", *blocks.MinHeight, *blocks.MaxHeight).
+		Count(&stat.NftMints).Error; err != nil {
+		return ChainStat{}, err
+	}
+
+	return stat, nil
+}
+
+// UpsertChainStats stores rollups, replacing the ones already computed for the same period
+func UpsertChainStats(ctx context.Context, dbTx *gorm.DB, stats []ChainStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	return dbTx.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		CreateInBatches(stats, BatchSize).Error
+}
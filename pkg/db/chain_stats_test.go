@@ -0,0 +1,50 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainStatPeriod(t *testing.T) {
+	timestamp := time.Date(2025, 3, 31, 23, 42, 7, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		granularity string
+		timestamp   time.Time
+		wantStart   time.Time
+		wantEnd     time.Time
+	}{
+		{
+			name:        "hour",
+			granularity: ChainStatGranularityHour,
+			timestamp:   timestamp,
+			wantStart:   time.Date(2025, 3, 31, 23, 0, 0, 0, time.UTC),
+			wantEnd:     time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "day",
+			granularity: ChainStatGranularityDay,
+			timestamp:   timestamp,
+			wantStart:   time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+			wantEnd:     time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "day in another time zone",
+			granularity: ChainStatGranularityDay,
+			timestamp:   timestamp.In(time.FixedZone("UTC+2", 2*60*60)),
+			wantStart:   time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+			wantEnd:     time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := ChainStatPeriod(tt.granularity, tt.timestamp)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpsertConsumerOffset records the offset of the last message processed by a consumer group from a partition. It is
+// written in the transaction of the data indexed from the message, so that the consumer resumes right after the last
+// message whose data was committed. An offset without consumer group, of data reindexed without message bus, is not
+// recorded.
+func UpsertConsumerOffset(ctx context.Context, dbTx *gorm.DB, offset ConsumerOffset) error {
+	if offset.ConsumerGroup == "" {
+		return nil
+	}
+	offset.UpdatedAt = time.Now().UTC()
+	return dbTx.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "consumer_group"}, {Name: "topic"}, {Name: "partition"}},
+			DoUpdates: clause.AssignmentColumns([]string{"offset", "updated_at"}),
+		}).
+		Create(&offset).Error
+}
+
+// QueryConsumerOffset returns the offset of the last message processed by a consumer group from a partition, or false
+// when none was recorded
+func QueryConsumerOffset(ctx context.Context, dbClient *gorm.DB, consumerGroup, topic string, partition int32) (int64, bool, error) {
+	var offset ConsumerOffset
+	err := dbClient.WithContext(ctx).
+		Where(&ConsumerOffset{ConsumerGroup: consumerGroup, Topic: topic, Partition: partition}, "ConsumerGroup", "Topic", "Partition").
+		First(&offset).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return offset.Offset, true, nil
+}
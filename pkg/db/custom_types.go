@@ -9,6 +9,8 @@ const (
 	ContinuousVestingAccount AccountType = "ContinuousVestingAccount"
 	DelayedVestingAccount    AccountType = "DelayedVestingAccount"
 	ClawbackVestingAccount   AccountType = "ClawbackVestingAccount"
+	PermanentLockedAccount   AccountType = "PermanentLockedAccount"
+	PeriodicVestingAccount   AccountType = "PeriodicVestingAccount"
 	ContractAccount          AccountType = "ContractAccount"
 )
 
@@ -36,6 +38,13 @@ const (
 	Unjailed SlashType = "Unjailed"
 )
 
+type DowntimeType string
+
+const (
+	MissedBlockStreak DowntimeType = "MissedBlockStreak"
+	MissedBlockRate   DowntimeType = "MissedBlockRate"
+)
+
 type FinalizeBlockEventsMode string
 
 const (
@@ -55,3 +64,12 @@ const (
 	ProposalStatusInactive      ProposalStatus = "Inactive"
 	ProposalStatusCancelled     ProposalStatus = "Cancelled"
 )
+
+type VerificationStatus string
+
+const (
+	VerificationPending   VerificationStatus = "Pending"
+	VerificationCompiling VerificationStatus = "Compiling"
+	VerificationVerified  VerificationStatus = "Verified"
+	VerificationFailed    VerificationStatus = "Failed"
+)
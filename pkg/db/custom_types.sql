@@ -72,3 +72,15 @@ BEGIN
             );
     END IF;
 END $$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'verificationstatus') THEN
+        CREATE TYPE verificationstatus AS ENUM (
+            'Pending',
+            'Compiling',
+            'Verified',
+            'Failed'
+            );
+    END IF;
+END $$;
@@ -87,6 +87,66 @@ func InsertAccountsIgnoreConflict(ctx context.Context, dbTx *gorm.DB, accounts [
 	return result.Error
 }
 
+// QueryAccountTypes returns the stored types of the given accounts, accounts that do not exist are omitted.
+func QueryAccountTypes(ctx context.Context, dbTx *gorm.DB, addresses []string) (map[string]AccountType, error) {
+	accountTypes := make(map[string]AccountType, len(addresses))
+	if len(addresses) == 0 {
+		return accountTypes, nil
+	}
+
+	var accounts []Account
+	if err := dbTx.WithContext(ctx).
+		Table(TableNameAccount).
+		Select("address, type").
+		Where("address IN ?", addresses).
+		Scan(&accounts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, account := range accounts {
+		accountTypes[account.Address] = AccountType(account.Type)
+	}
+	return accountTypes, nil
+}
+
+// QueryAccountAddressesByType returns up to limit addresses of the accounts of the type following after, in address
+// order
+func QueryAccountAddressesByType(ctx context.Context, dbTx *gorm.DB, accountType AccountType, after string, limit int) ([]string, error) {
+	var addresses []string
+	if err := dbTx.WithContext(ctx).
+		Table(TableNameAccount).
+		Where("type = ? AND address > ?", accountType, after).
+		Order("address").
+		Limit(limit).
+		Pluck("address", &addresses).Error; err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// UpdateAccountTypes sets the types of existing accounts.
+func UpdateAccountTypes(ctx context.Context, dbTx *gorm.DB, accountTypes map[string]AccountType) error {
+	span := sentry.StartSpan(ctx, "UpdateAccountTypes")
+	span.Description = "Update the types of accounts in the database"
+	defer span.Finish()
+
+	addressesByType := make(map[AccountType][]string)
+	for address, accountType := range accountTypes {
+		addressesByType[accountType] = append(addressesByType[accountType], address)
+	}
+
+	for accountType, addresses := range addressesByType {
+		if err := dbTx.WithContext(ctx).
+			Table(TableNameAccount).
+			Where("address IN ? AND type IS DISTINCT FROM ?", addresses, accountType).
+			Update("type", accountType).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func InsertVMAddressesIgnoreConflict(ctx context.Context, dbTx *gorm.DB, addresses []VMAddress) error {
 	span := sentry.StartSpan(ctx, "InsertVMAddress")
 	span.Description = "Bulk insert VM addresses into the database"
@@ -155,38 +215,54 @@ func UpsertValidators(ctx context.Context, dbTx *gorm.DB, validators []Validator
 	return result.Error
 }
 
-// UpsertValidatorIdentityImages updates identity_image for validators.
-// Since we only update existing validators, we use UPDATE instead of INSERT with ON CONFLICT
+// QueryValidatorsDueIdentityImage returns the validators whose identity image was never resolved, was resolved for
+// another identity or before staleBefore
+func QueryValidatorsDueIdentityImage(ctx context.Context, dbTx *gorm.DB, staleBefore time.Time) ([]Validator, error) {
+	validators := make([]Validator, 0)
+	if err := dbTx.WithContext(ctx).
+		Model(&Validator{}).
+		Select("operator_address, identity, identity_image_url, identity_image_identity, identity_image_updated_at").
+		Where("identity_image_updated_at IS NULL OR identity_image_updated_at < ? OR identity_image_identity <> identity", staleBefore).
+		Order("operator_address").
+		Find(&validators).Error; err != nil {
+		return nil, err
+	}
+
+	return validators, nil
+}
+
+// UpdateValidatorIdentityImages updates identity_image_url for validators, recording the identity it was resolved
+// for. Since we only update existing validators, we use UPDATE instead of INSERT with ON CONFLICT
 // to avoid NOT NULL constraint violations on other fields.
-func UpsertValidatorIdentityImages(ctx context.Context, dbTx *gorm.DB, validators []Validator) error {
+func UpdateValidatorIdentityImages(ctx context.Context, dbTx *gorm.DB, validators []Validator, updatedAt time.Time) error {
 	if len(validators) == 0 {
 		return nil
 	}
 
 	// Process in batches to avoid large queries
 	for i := 0; i < len(validators); i += BatchSize {
-		end := i + BatchSize
-		if end > len(validators) {
-			end = len(validators)
-		}
+		end := min(i+BatchSize, len(validators))
 		batch := validators[i:end]
 
 		// Build VALUES clause for batch update using PostgreSQL syntax
 		var values []any
 		var placeholders []string
 		for idx, val := range batch {
-			paramNum := idx*2 + 1
-			placeholders = append(placeholders, fmt.Sprintf("($%d, $%d)", paramNum, paramNum+1))
-			values = append(values, val.OperatorAddress, val.IdentityImage)
+			paramNum := idx*3 + 1
+			placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d)", paramNum, paramNum+1, paramNum+2))
+			values = append(values, val.OperatorAddress, val.IdentityImageURL, val.IdentityImageIdentity)
 		}
+		values = append(values, updatedAt)
 
-		// Use raw SQL to update only identity_image for existing validators
+		// Use raw SQL to update only the identity image columns for existing validators
 		query := fmt.Sprintf(`
-			UPDATE validators 
-			SET identity_image = v.identity_image
-			FROM (VALUES %s) AS v(operator_address, identity_image)
+			UPDATE validators
+			SET identity_image_url = v.identity_image_url,
+				identity_image_identity = v.identity_image_identity,
+				identity_image_updated_at = $%d
+			FROM (VALUES %s) AS v(operator_address, identity_image_url, identity_image_identity)
 			WHERE validators.operator_address = v.operator_address
-		`, strings.Join(placeholders, ", "))
+		`, len(batch)*3+1, strings.Join(placeholders, ", "))
 
 		if err := dbTx.WithContext(ctx).Exec(query, values...).Error; err != nil {
 			return err
@@ -362,11 +438,17 @@ func UpsertModules(ctx context.Context, dbTx *gorm.DB, modules []Module) error {
 		"upgrade_policy",
 		"digest",
 	}
+	// An upgraded module is verified only if its new digest has been verified before
+	assignments := append(clause.AssignmentColumns(columns), clause.Assignment{
+		Column: clause.Column{Name: "is_verify"},
+		Value: gorm.Expr(`EXISTS (SELECT 1 FROM verified_modules
+			WHERE verified_modules.module_id = excluded.id AND verified_modules.digest = excluded.digest)`),
+	})
 
 	result := dbTx.WithContext(ctx).
 		Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "id"}},
-			DoUpdates: clause.AssignmentColumns(columns),
+			DoUpdates: assignments,
 			UpdateAll: false,
 		}).
 		CreateInBatches(&modules, BatchSize)
@@ -840,6 +922,9 @@ func UpsertProposalVotes(ctx context.Context, dbTx *gorm.DB, proposalVotes []Pro
 				if err := dbTx.WithContext(ctx).Create(&vote).Error; err != nil {
 					return fmt.Errorf("failed to insert proposal vote: %w", err)
 				}
+				if err := incrementAccountProposalsVoted(ctx, dbTx, vote.Voter); err != nil {
+					return fmt.Errorf("failed to count proposal vote: %w", err)
+				}
 			} else {
 				return fmt.Errorf("failed to check existing vote: %w", result.Error)
 			}
@@ -2,21 +2,32 @@
 package db
 
 var AllModels = []any{
+	&AccountStat{},
 	&AccountTransaction{},
 	&Account{},
 	&Block{},
+	&ChainStat{},
+	&CollectionMetadata{},
 	&CollectionMutationEvent{},
 	&CollectionProposal{},
 	&CollectionTransaction{},
 	&Collection{},
+	&ConsumerOffset{},
 	&FinalizeBlockEvent{},
 	&LcdTxResult{},
+	&ModuleFunctionCaller{},
+	&ModuleFunctionCall{},
+	&ModuleFunctionStat{},
 	&ModuleHistory{},
 	&ModuleProposal{},
 	&ModuleTransaction{},
+	&ModuleVerificationFile{},
+	&ModuleVerification{},
 	&Module{},
 	&MoveEvent{},
+	&NftAttribute{},
 	&NftHistory{},
+	&NftMetadata{},
 	&NftMutationEvent{},
 	&NftProposal{},
 	&NftTransaction{},
@@ -25,6 +36,7 @@ var AllModels = []any{
 	&ProposalDeposit{},
 	&ProposalVote{},
 	&ProposalVotesLegacy{},
+	&ProposalTallySnapshot{},
 	&Proposal{},
 	&SchemaMigration{},
 	&Tracking{},
@@ -32,9 +44,11 @@ var AllModels = []any{
 	&Transaction{},
 	&ValidatorBondedTokenChange{},
 	&ValidatorCommitSignature{},
+	&ValidatorDowntimeEpisode{},
 	&ValidatorHistoricalPower{},
 	&ValidatorSlashEvent{},
 	&ValidatorVoteCount{},
 	&Validator{},
+	&VerifiedModule{},
 	&VMAddress{},
 }
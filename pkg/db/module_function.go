@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// InsertModuleFunctionCalls inserts function calls and folds them into the daily function stats, the daily callers
+// and the entry execution count of the modules. All calls must be of the given height, whose block is already inserted.
+// The calls of the transactions already inserted, by a block indexed again, are skipped so that they are counted once.
+func InsertModuleFunctionCalls(ctx context.Context, dbTx *gorm.DB, height int64, calls []ModuleFunctionCall) error {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	var insertedTxIDs []string
+	if err := dbTx.WithContext(ctx).
+		Model(&ModuleFunctionCall{}).
+		Where("block_height = ?", height).
+		Distinct().
+		Pluck("tx_id", &insertedTxIDs).Error; err != nil {
+		return err
+	}
+	newCalls, txIDs := skipInsertedFunctionCalls(calls, insertedTxIDs)
+	if len(newCalls) == 0 {
+		return nil
+	}
+
+	if err := dbTx.WithContext(ctx).CreateInBatches(newCalls, BatchSize).Error; err != nil {
+		return err
+	}
+
+	if err := dbTx.WithContext(ctx).Exec(`
+		INSERT INTO module_function_stats (module_id, function_name, date, call_count, failure_count, gas_used)
+		SELECT calls.module_id, calls.function_name, blocks.timestamp::date, COUNT(*), COUNT(*) FILTER (WHERE NOT calls.success), SUM(calls.gas_used)
+		FROM module_function_calls AS calls
+		JOIN blocks ON blocks.height = calls.block_height
+		WHERE calls.block_height = ? AND calls.tx_id IN ?
+		GROUP BY calls.module_id, calls.function_name, blocks.timestamp::date
+		ON CONFLICT (module_id, function_name, date) DO UPDATE SET
+			call_count = module_function_stats.call_count + excluded.call_count,
+			failure_count = module_function_stats.failure_count + excluded.failure_count,
+			gas_used = module_function_stats.gas_used + excluded.gas_used`, height, txIDs).Error; err != nil {
+		return err
+	}
+
+	if err := dbTx.WithContext(ctx).Exec(`
+		INSERT INTO module_function_callers (module_id, function_name, date, sender)
+		SELECT DISTINCT calls.module_id, calls.function_name, blocks.timestamp::date, calls.sender
+		FROM module_function_calls AS calls
+		JOIN blocks ON blocks.height = calls.block_height
+		WHERE calls.block_height = ? AND calls.tx_id IN ?
+		ON CONFLICT DO NOTHING`, height, txIDs).Error; err != nil {
+		return err
+	}
+
+	return dbTx.WithContext(ctx).Exec(`
+		UPDATE modules SET module_entry_executed = modules.module_entry_executed + executed.count
+		FROM (
+			SELECT module_id, COUNT(*) AS count FROM module_function_calls
+			WHERE block_height = ? AND tx_id IN ? AND success
+			GROUP BY module_id
+		) AS executed
+		WHERE modules.id = executed.module_id`, height, txIDs).Error
+}
+
+// skipInsertedFunctionCalls returns the calls of the transactions not inserted yet, along with their transaction ids
+func skipInsertedFunctionCalls(calls []ModuleFunctionCall, insertedTxIDs []string) ([]ModuleFunctionCall, []string) {
+	inserted := make(map[string]bool, len(insertedTxIDs))
+	for _, txID := range insertedTxIDs {
+		inserted[txID] = true
+	}
+
+	newCalls := make([]ModuleFunctionCall, 0, len(calls))
+	txIDs := make([]string, 0)
+	for _, call := range calls {
+		if inserted[call.TxID] {
+			continue
+		}
+		if !slices.Contains(txIDs, call.TxID) {
+			txIDs = append(txIDs, call.TxID)
+		}
+		newCalls = append(newCalls, call)
+	}
+	return newCalls, txIDs
+}
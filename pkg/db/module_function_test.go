@@ -0,0 +1,29 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipInsertedFunctionCalls(t *testing.T) {
+	calls := []ModuleFunctionCall{
+		{TxID: "tx1", MsgIndex: 0},
+		{TxID: "tx1", MsgIndex: 1},
+		{TxID: "tx2", MsgIndex: 0},
+		{TxID: "tx3", MsgIndex: 0},
+	}
+
+	newCalls, txIDs := skipInsertedFunctionCalls(calls, nil)
+	assert.Equal(t, calls, newCalls)
+	assert.Equal(t, []string{"tx1", "tx2", "tx3"}, txIDs)
+
+	// a block indexed again only folds the calls of the transactions not inserted yet
+	newCalls, txIDs = skipInsertedFunctionCalls(calls, []string{"tx1", "tx3"})
+	assert.Equal(t, []ModuleFunctionCall{{TxID: "tx2", MsgIndex: 0}}, newCalls)
+	assert.Equal(t, []string{"tx2"}, txIDs)
+
+	newCalls, txIDs = skipInsertedFunctionCalls(calls, []string{"tx1", "tx2", "tx3"})
+	assert.Empty(t, newCalls)
+	assert.Empty(t, txIDs)
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MetadataStatusPending = "pending"
+	MetadataStatusFetched = "fetched"
+	MetadataStatusFailed  = "failed"
+)
+
+// MetadataFetch is an Nft or a collection whose off-chain metadata is due to be fetched
+type MetadataFetch struct {
+	ID       string
+	URI      string
+	Attempts int32
+}
+
+// QueueNftMetadataFetches queues the metadata of up to limit Nfts that have none yet, or whose URI changed since it
+// was fetched. It returns the number of queued Nfts.
+func QueueNftMetadataFetches(ctx context.Context, dbTx *gorm.DB, now time.Time, limit int) (int64, error) {
+	return queueMetadataFetches(ctx, dbTx, TableNameNftMetadata, TableNameNft, "NOT nfts.is_burned", now, limit)
+}
+
+// QueueCollectionMetadataFetches queues the metadata of up to limit collections that have none yet, or whose URI
+// changed since it was fetched. It returns the number of queued collections.
+func QueueCollectionMetadataFetches(ctx context.Context, dbTx *gorm.DB, now time.Time, limit int) (int64, error) {
+	return queueMetadataFetches(ctx, dbTx, TableNameCollectionMetadata, TableNameCollection, "TRUE", now, limit)
+}
+
+func queueMetadataFetches(ctx context.Context, dbTx *gorm.DB, metadataTable, sourceTable, condition string, now time.Time, limit int) (int64, error) {
+	result := dbTx.WithContext(ctx).Exec(fmt.Sprintf(`
+		INSERT INTO %[1]s (id, uri, status, attempts, next_attempt_at, metadata, name, description, image, thumbnail_url, updated_at)
+		SELECT %[2]s.id, %[2]s.uri, ?, 0, ?, '{}', '', '', '', '', ?
+		FROM %[2]s LEFT JOIN %[1]s ON %[1]s.id = %[2]s.id
+		WHERE %[2]s.uri <> '' AND %[3]s AND (%[1]s.id IS NULL OR %[1]s.uri <> %[2]s.uri)
+		LIMIT ?
+		ON CONFLICT (id) DO UPDATE SET
+			uri = excluded.uri,
+			status = excluded.status,
+			attempts = 0,
+			next_attempt_at = excluded.next_attempt_at,
+			last_error = NULL,
+			updated_at = excluded.updated_at`, metadataTable, sourceTable, condition),
+		MetadataStatusPending, now, now, limit)
+
+	return result.RowsAffected, result.Error
+}
+
+// QueryDueNftMetadataFetches returns up to limit Nfts whose metadata is due to be fetched, oldest first, leaving out
+// the ones that failed maxAttempts times
+func QueryDueNftMetadataFetches(ctx context.Context, dbTx *gorm.DB, now time.Time, maxAttempts int32, limit int) ([]MetadataFetch, error) {
+	return queryDueMetadataFetches(ctx, dbTx, &NftMetadata{}, now, maxAttempts, limit)
+}
+
+// QueryDueCollectionMetadataFetches returns up to limit collections whose metadata is due to be fetched, oldest
+// first, leaving out the ones that failed maxAttempts times
+func QueryDueCollectionMetadataFetches(ctx context.Context, dbTx *gorm.DB, now time.Time, maxAttempts int32, limit int) ([]MetadataFetch, error) {
+	return queryDueMetadataFetches(ctx, dbTx, &CollectionMetadata{}, now, maxAttempts, limit)
+}
+
+func queryDueMetadataFetches(ctx context.Context, dbTx *gorm.DB, model any, now time.Time, maxAttempts int32, limit int) ([]MetadataFetch, error) {
+	fetches := make([]MetadataFetch, 0)
+	if err := dbTx.WithContext(ctx).
+		Model(model).
+		Select("id, uri, attempts").
+		Where("status IN ? AND next_attempt_at <= ? AND attempts < ?", []string{MetadataStatusPending, MetadataStatusFailed}, now, maxAttempts).
+		Order("next_attempt_at").
+		Limit(limit).
+		Scan(&fetches).Error; err != nil {
+		return nil, err
+	}
+
+	return fetches, nil
+}
+
+// SaveNftMetadata stores the metadata fetched for an Nft, replacing its attributes when the metadata is parsed
+func SaveNftMetadata(ctx context.Context, dbTx *gorm.DB, metadata NftMetadata, attributes []NftAttribute) error {
+	return dbTx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&metadata).Error; err != nil {
+			return err
+		}
+
+		if metadata.FetchedAt == nil {
+			return nil
+		}
+
+		if err := tx.Where("nft_id = ?", metadata.ID).Delete(&NftAttribute{}).Error; err != nil {
+			return err
+		}
+		if len(attributes) == 0 {
+			return nil
+		}
+
+		return tx.CreateInBatches(attributes, BatchSize).Error
+	})
+}
+
+// SaveCollectionMetadata stores the metadata fetched for a collection
+func SaveCollectionMetadata(ctx context.Context, dbTx *gorm.DB, metadata CollectionMetadata) error {
+	return dbTx.WithContext(ctx).Save(&metadata).Error
+}
+
+// UpdateNftMetadataFetchFailure records a failed metadata fetch of an Nft, keeping the metadata fetched before
+func UpdateNftMetadataFetchFailure(ctx context.Context, dbTx *gorm.DB, id string, attempts int32, nextAttemptAt time.Time, lastError string, now time.Time) error {
+	return updateMetadataFetchFailure(ctx, dbTx, &NftMetadata{}, id, attempts, nextAttemptAt, lastError, now)
+}
+
+// UpdateCollectionMetadataFetchFailure records a failed metadata fetch of a collection, keeping the metadata fetched
+// before
+func UpdateCollectionMetadataFetchFailure(ctx context.Context, dbTx *gorm.DB, id string, attempts int32, nextAttemptAt time.Time, lastError string, now time.Time) error {
+	return updateMetadataFetchFailure(ctx, dbTx, &CollectionMetadata{}, id, attempts, nextAttemptAt, lastError, now)
+}
+
+func updateMetadataFetchFailure(ctx context.Context, dbTx *gorm.DB, model any, id string, attempts int32, nextAttemptAt time.Time, lastError string, now time.Time) error {
+	return dbTx.WithContext(ctx).
+		Model(model).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":          MetadataStatusFailed,
+			"attempts":        attempts,
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastError,
+			"updated_at":      now,
+		}).Error
+}
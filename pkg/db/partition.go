@@ -0,0 +1,174 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// Partition is a child table of a table range-partitioned by block_height.
+// From is inclusive and To is exclusive; math.MinInt64 stands for MINVALUE and math.MaxInt64 for MAXVALUE.
+type Partition struct {
+	Name string
+	From int64
+	To   int64
+}
+
+var partitionBoundRegex = regexp.MustCompile(`FOR VALUES FROM \('?(-?\d+|MINVALUE)'?\) TO \('?(-?\d+|MAXVALUE)'?\)`)
+
+// IsPartitionedTable reports whether the table is declaratively partitioned.
+func IsPartitionedTable(ctx context.Context, dbClient *gorm.DB, table string) (bool, error) {
+	if !isValidTableName(table) {
+		return false, fmt.Errorf("invalid table name: %s", table)
+	}
+
+	var count int64
+	result := dbClient.WithContext(ctx).
+		Raw(`SELECT COUNT(*) FROM pg_partitioned_table pt
+			JOIN pg_class c ON c.oid = pt.partrelid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = ? AND c.relname = ?`, SchemaName, table).
+		Scan(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// ListPartitions returns the partitions of the table ordered by their lower bound.
+func ListPartitions(ctx context.Context, dbClient *gorm.DB, table string) ([]Partition, error) {
+	if !isValidTableName(table) {
+		return nil, fmt.Errorf("invalid table name: %s", table)
+	}
+
+	var rows []struct {
+		Name  string `gorm:"column:name"`
+		Bound string `gorm:"column:bound"`
+	}
+	result := dbClient.WithContext(ctx).
+		Raw(`SELECT child.relname AS name, pg_get_expr(child.relpartbound, child.oid) AS bound
+			FROM pg_inherits i
+			JOIN pg_class parent ON parent.oid = i.inhparent
+			JOIN pg_class child ON child.oid = i.inhrelid
+			JOIN pg_namespace n ON n.oid = parent.relnamespace
+			WHERE n.nspname = ? AND parent.relname = ?`, SchemaName, table).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	partitions := make([]Partition, 0, len(rows))
+	for _, row := range rows {
+		from, to, err := parsePartitionBound(row.Bound)
+		if err != nil {
+			return nil, fmt.Errorf("partition %s: %w", row.Name, err)
+		}
+		partitions = append(partitions, Partition{Name: row.Name, From: from, To: to})
+	}
+
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i].From < partitions[j].From })
+
+	return partitions, nil
+}
+
+func parsePartitionBound(bound string) (int64, int64, error) {
+	matches := partitionBoundRegex.FindStringSubmatch(bound)
+	if matches == nil {
+		return 0, 0, fmt.Errorf("unsupported partition bound: %s", bound)
+	}
+
+	from := int64(math.MinInt64)
+	if matches[1] != "MINVALUE" {
+		v, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		from = v
+	}
+
+	to := int64(math.MaxInt64)
+	if matches[2] != "MAXVALUE" {
+		v, err := strconv.ParseInt(matches[2], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		to = v
+	}
+
+	return from, to, nil
+}
+
+// PartitionName returns the name of the partition of the table starting at the given height.
+func PartitionName(table string, from int64) string {
+	return fmt.Sprintf("%s_p%d", table, from)
+}
+
+// EnsurePartitions creates the partitions of the table needed to store every block_height up to and including height.
+// New partitions are appended after the last existing one and end on a multiple of partitionSize, so ranges never overlap.
+// It returns the exclusive upper bound covered by the partitions.
+func EnsurePartitions(ctx context.Context, dbClient *gorm.DB, table string, height, partitionSize int64) (int64, error) {
+	if partitionSize <= 0 {
+		return 0, fmt.Errorf("invalid partition size: %d", partitionSize)
+	}
+
+	partitions, err := ListPartitions(ctx, dbClient, table)
+	if err != nil {
+		return 0, err
+	}
+
+	var upper int64
+	if len(partitions) == 0 {
+		upper = (height / partitionSize) * partitionSize
+	} else {
+		upper = partitions[len(partitions)-1].To
+	}
+
+	for upper <= height {
+		from := upper
+		to := (from/partitionSize + 1) * partitionSize
+		if err := dbClient.WithContext(ctx).Exec(fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM (%d) TO (%d)`,
+			PartitionName(table, from), table, from, to,
+		)).Error; err != nil {
+			return 0, fmt.Errorf("failed to create partition of %s from %d to %d: %w", table, from, to, err)
+		}
+		upper = to
+	}
+
+	return upper, nil
+}
+
+// BuildPartitionQuery returns a query over all rows of a single partition.
+func BuildPartitionQuery(ctx context.Context, dbClient *gorm.DB, partition Partition) *gorm.DB {
+	return dbClient.WithContext(ctx).Table(partition.Name)
+}
+
+// DeletePartitionRowsToPrune deletes the rows of the partition at or below the threshold, for a partition straddling it.
+func DeletePartitionRowsToPrune(ctx context.Context, dbClient *gorm.DB, partition Partition, threshold int64) error {
+	return BuildPartitionQuery(ctx, dbClient, partition).
+		Where("block_height <= ?", threshold).
+		Delete(nil).Error
+}
+
+// DropPartition detaches the partition from the table and drops it.
+func DropPartition(ctx context.Context, dbClient *gorm.DB, table string, partition Partition) error {
+	if !isValidTableName(table) {
+		return fmt.Errorf("invalid table name: %s", table)
+	}
+
+	return dbClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s DETACH PARTITION %s`, table, partition.Name)).Error; err != nil {
+			return fmt.Errorf("failed to detach partition %s: %w", partition.Name, err)
+		}
+		if err := tx.Exec(fmt.Sprintf(`DROP TABLE %s`, partition.Name)).Error; err != nil {
+			return fmt.Errorf("failed to drop partition %s: %w", partition.Name, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePartitionBound(t *testing.T) {
+	tests := []struct {
+		bound string
+		from  int64
+		to    int64
+	}{
+		{"FOR VALUES FROM (100000) TO (200000)", 100000, 200000},
+		{"FOR VALUES FROM (MINVALUE) TO (12345)", math.MinInt64, 12345},
+		{"FOR VALUES FROM ('0') TO (MAXVALUE)", 0, math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bound, func(t *testing.T) {
+			from, to, err := parsePartitionBound(tt.bound)
+			require.NoError(t, err)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+
+	_, _, err := parsePartitionBound("DEFAULT")
+	assert.Error(t, err)
+}
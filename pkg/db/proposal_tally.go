@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	initiagovtypes "github.com/initia-labs/initia/x/gov/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NewProposalTallySnapshot builds a tally snapshot of a proposal from its live tally and the quorum and threshold it is
+// resolved with. historicalStakingPower is used as total staking power when the tally does not report one.
+func NewProposalTallySnapshot(proposalID int32, tally initiagovtypes.TallyResult, quorum, threshold string, historicalStakingPower int64, timestamp time.Time) (ProposalTallySnapshot, error) {
+	snapshot := ProposalTallySnapshot{
+		ProposalID:  proposalID,
+		TallyHeight: int64(tally.TallyHeight),
+		Timestamp:   timestamp.UTC(),
+	}
+
+	if tally.V1TallyResult != nil {
+		for option, count := range map[string]struct {
+			value string
+			dest  *int64
+		}{
+			"yes":          {tally.V1TallyResult.YesCount, &snapshot.Yes},
+			"no":           {tally.V1TallyResult.NoCount, &snapshot.No},
+			"abstain":      {tally.V1TallyResult.AbstainCount, &snapshot.Abstain},
+			"no_with_veto": {tally.V1TallyResult.NoWithVetoCount, &snapshot.NoWithVeto},
+		} {
+			parsed, err := parseOptionalInt(count.value)
+			if err != nil {
+				return ProposalTallySnapshot{}, fmt.Errorf("failed to parse %s count: %w", option, err)
+			}
+			*count.dest = parsed
+		}
+	}
+
+	var err error
+	if snapshot.TotalStakingPower, err = parseOptionalInt(tally.TotalStakingPower); err != nil {
+		return ProposalTallySnapshot{}, fmt.Errorf("failed to parse total staking power: %w", err)
+	}
+	if snapshot.TotalStakingPower == 0 {
+		snapshot.TotalStakingPower = historicalStakingPower
+	}
+	if snapshot.TotalVestingPower, err = parseOptionalInt(tally.TotalVestingPower); err != nil {
+		return ProposalTallySnapshot{}, fmt.Errorf("failed to parse total vesting power: %w", err)
+	}
+	if snapshot.Quorum, err = strconv.ParseFloat(quorum, 64); err != nil {
+		return ProposalTallySnapshot{}, fmt.Errorf("failed to parse quorum: %w", err)
+	}
+	if snapshot.Threshold, err = strconv.ParseFloat(threshold, 64); err != nil {
+		return ProposalTallySnapshot{}, fmt.Errorf("failed to parse threshold: %w", err)
+	}
+
+	// the same ratios the chain compares with the quorum and the threshold when the proposal is resolved
+	voted := snapshot.Yes + snapshot.No + snapshot.Abstain + snapshot.NoWithVeto
+	if totalPower := snapshot.TotalStakingPower + snapshot.TotalVestingPower; totalPower > 0 {
+		snapshot.QuorumProgress = float64(voted) / float64(totalPower)
+	}
+	if nonAbstained := voted - snapshot.Abstain; nonAbstained > 0 {
+		snapshot.ThresholdProgress = float64(snapshot.Yes) / float64(nonAbstained)
+	}
+
+	return snapshot, nil
+}
+
+func parseOptionalInt(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// QueryVotingProposalIDs returns the ids of the proposals in voting period
+func QueryVotingProposalIDs(ctx context.Context, dbTx *gorm.DB) ([]int32, error) {
+	var ids []int32
+	if err := dbTx.WithContext(ctx).
+		Model(&Proposal{}).
+		Where("status = ?", ProposalStatusVotingPeriod).
+		Order("id").
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// QueryLatestTotalVotingPower sums the voting power of the validators in their latest historical power snapshot
+func QueryLatestTotalVotingPower(ctx context.Context, dbTx *gorm.DB) (int64, error) {
+	var total int64
+	if err := dbTx.WithContext(ctx).
+		Model(&ValidatorHistoricalPower{}).
+		Select("COALESCE(SUM(voting_power), 0)").
+		Where("hour_rounded_timestamp = (SELECT MAX(hour_rounded_timestamp) FROM validator_historical_powers)").
+		Scan(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// InsertProposalTallySnapshots stores tally snapshots, skipping the ones already taken at the same height
+func InsertProposalTallySnapshots(ctx context.Context, dbTx *gorm.DB, snapshots []ProposalTallySnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	return dbTx.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(snapshots, BatchSize).Error
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	initiagovtypes "github.com/initia-labs/initia/x/gov/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProposalTallySnapshot(t *testing.T) {
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                   string
+		tally                  initiagovtypes.TallyResult
+		historicalStakingPower int64
+		want                   ProposalTallySnapshot
+		wantErr                bool
+	}{
+		{
+			name: "live tally",
+			tally: initiagovtypes.TallyResult{
+				TallyHeight:       120,
+				TotalStakingPower: "800",
+				TotalVestingPower: "200",
+				V1TallyResult: &v1.TallyResult{
+					YesCount:        "300",
+					NoCount:         "50",
+					AbstainCount:    "100",
+					NoWithVetoCount: "50",
+				},
+			},
+			historicalStakingPower: 900,
+			want: ProposalTallySnapshot{
+				ProposalID:        7,
+				TallyHeight:       120,
+				Timestamp:         timestamp,
+				Yes:               300,
+				No:                50,
+				Abstain:           100,
+				NoWithVeto:        50,
+				TotalStakingPower: 800,
+				TotalVestingPower: 200,
+				Quorum:            0.334,
+				Threshold:         0.5,
+				QuorumProgress:    0.5,
+				ThresholdProgress: 0.75,
+			},
+		},
+		{
+			name: "no votes falls back to historical staking power",
+			tally: initiagovtypes.TallyResult{
+				TallyHeight: 120,
+				V1TallyResult: &v1.TallyResult{
+					YesCount:        "0",
+					NoCount:         "0",
+					AbstainCount:    "0",
+					NoWithVetoCount: "0",
+				},
+			},
+			historicalStakingPower: 900,
+			want: ProposalTallySnapshot{
+				ProposalID:        7,
+				TallyHeight:       120,
+				Timestamp:         timestamp,
+				TotalStakingPower: 900,
+				Quorum:            0.334,
+				Threshold:         0.5,
+			},
+		},
+		{
+			name: "invalid count",
+			tally: initiagovtypes.TallyResult{
+				V1TallyResult: &v1.TallyResult{YesCount: "1.5"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, err := NewProposalTallySnapshot(7, tt.tally, "0.334000000000000000", "0.500000000000000000", tt.historicalStakingPower, timestamp)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, snapshot)
+		})
+	}
+}
@@ -5,21 +5,32 @@ import (
 )
 
 const (
+	TableNameAccountStat                = "account_stats"
 	TableNameAccountTransaction         = "account_transactions"
 	TableNameAccount                    = "accounts"
 	TableNameBlock                      = "blocks"
+	TableNameChainStat                  = "chain_stats"
+	TableNameCollectionMetadata         = "collection_metadata"
 	TableNameCollectionMutationEvent    = "collection_mutation_events"
 	TableNameCollectionProposal         = "collection_proposals"
 	TableNameCollectionTransaction      = "collection_transactions"
 	TableNameCollection                 = "collections"
+	TableNameConsumerOffset             = "consumer_offsets"
 	TableNameFinalizeBlockEvent         = "finalize_block_events"
 	TableNameLcdTxResult                = "lcd_tx_results"
+	TableNameModuleFunctionCaller       = "module_function_callers"
+	TableNameModuleFunctionCall         = "module_function_calls"
+	TableNameModuleFunctionStat         = "module_function_stats"
 	TableNameModuleHistory              = "module_histories"
 	TableNameModuleProposal             = "module_proposals"
 	TableNameModuleTransaction          = "module_transactions"
+	TableNameModuleVerificationFile     = "module_verification_files"
+	TableNameModuleVerification         = "module_verifications"
 	TableNameModule                     = "modules"
 	TableNameMoveEvent                  = "move_events"
+	TableNameNftAttribute               = "nft_attributes"
 	TableNameNftHistory                 = "nft_histories"
+	TableNameNftMetadata                = "nft_metadata"
 	TableNameNftMutationEvent           = "nft_mutation_events"
 	TableNameNftProposal                = "nft_proposals"
 	TableNameNftTransaction             = "nft_transactions"
@@ -28,6 +39,7 @@ const (
 	TableNameProposalDeposit            = "proposal_deposits"
 	TableNameProposalVote               = "proposal_votes"
 	TableNameProposalVotesLegacy        = "proposal_votes_legacy"
+	TableNameProposalTallySnapshot      = "proposal_tally_snapshots"
 	TableNameProposal                   = "proposals"
 	TableNameSchemaMigration            = "schema_migrations"
 	TableNameTracking                   = "tracking"
@@ -35,13 +47,40 @@ const (
 	TableNameTransaction                = "transactions"
 	TableNameValidatorBondedTokenChange = "validator_bonded_token_changes"
 	TableNameValidatorCommitSignature   = "validator_commit_signatures"
+	TableNameValidatorDowntimeEpisode   = "validator_downtime_episodes"
 	TableNameValidatorHistoricalPower   = "validator_historical_powers"
 	TableNameValidatorSlashEvent        = "validator_slash_events"
 	TableNameValidatorVoteCount         = "validator_vote_counts"
 	TableNameValidator                  = "validators"
+	TableNameVerifiedModule             = "verified_modules"
 	TableNameVMAddress                  = "vm_addresses"
 )
 
+// AccountStat mapped from table <account_stats>
+type AccountStat struct {
+	AccountID           string    `gorm:"column:account_id;primaryKey;type:character varying" json:"account_id"`
+	FirstSeenHeight     int64     `gorm:"column:first_seen_height;not null" json:"first_seen_height"`
+	FirstSeenTimestamp  time.Time `gorm:"column:first_seen_timestamp;not null;type:timestamp" json:"first_seen_timestamp"`
+	LastActiveHeight    int64     `gorm:"column:last_active_height;not null" json:"last_active_height"`
+	LastActiveTimestamp time.Time `gorm:"column:last_active_timestamp;not null;type:timestamp" json:"last_active_timestamp"`
+	TxCount             int64     `gorm:"column:tx_count;not null" json:"tx_count"`
+	SignerTxCount       int64     `gorm:"column:signer_tx_count;not null" json:"signer_tx_count"`
+	SendTxCount         int64     `gorm:"column:send_tx_count;not null" json:"send_tx_count"`
+	IbcTxCount          int64     `gorm:"column:ibc_tx_count;not null" json:"ibc_tx_count"`
+	MoveExecuteTxCount  int64     `gorm:"column:move_execute_tx_count;not null" json:"move_execute_tx_count"`
+	MovePublishTxCount  int64     `gorm:"column:move_publish_tx_count;not null" json:"move_publish_tx_count"`
+	NftTxCount          int64     `gorm:"column:nft_tx_count;not null" json:"nft_tx_count"`
+	ProposalsVoted      int64     `gorm:"column:proposals_voted;not null" json:"proposals_voted"`
+
+	// Foreign key relationships
+	Account Account `gorm:"foreignKey:AccountID;references:Address" json:"-"`
+}
+
+// TableName AccountStat's table name
+func (*AccountStat) TableName() string {
+	return TableNameAccountStat
+}
+
 // AccountTransaction mapped from table <account_transactions>
 type AccountTransaction struct {
 	IsSigner      bool   `gorm:"column:is_signer;not null;index:ix_account_transactions_signer,priority:2" json:"is_signer"`
@@ -61,8 +100,8 @@ func (*AccountTransaction) TableName() string {
 
 // Account mapped from table <accounts>
 type Account struct {
-	Address     string `gorm:"column:address;primaryKey;type:character varying" json:"address"`
-	Type        string `gorm:"column:type;type:accounttype" json:"type"`
+	Address     string `gorm:"column:address;primaryKey;type:character varying;index:ix_accounts_type_address,priority:2" json:"address"`
+	Type        string `gorm:"column:type;type:accounttype;index:ix_accounts_type_address,priority:1" json:"type"`
 	Name        string `gorm:"column:name;type:character varying" json:"name"`
 	VMAddressID string `gorm:"column:vm_address_id;type:character varying" json:"vm_address_id"`
 
@@ -91,6 +130,53 @@ func (*Block) TableName() string {
 	return TableNameBlock
 }
 
+// ChainStat mapped from table <chain_stats>
+type ChainStat struct {
+	Granularity    string    `gorm:"column:granularity;primaryKey;type:character varying" json:"granularity"`
+	Timestamp      time.Time `gorm:"column:timestamp;primaryKey;type:timestamp" json:"timestamp"`
+	BlockCount     int64     `gorm:"column:block_count;not null" json:"block_count"`
+	AvgBlockTime   float64   `gorm:"column:avg_block_time;not null" json:"avg_block_time"`
+	TxCount        int64     `gorm:"column:tx_count;not null" json:"tx_count"`
+	SuccessTxCount int64     `gorm:"column:success_tx_count;not null" json:"success_tx_count"`
+	FailedTxCount  int64     `gorm:"column:failed_tx_count;not null" json:"failed_tx_count"`
+	ActiveAccounts int64     `gorm:"column:active_accounts;not null" json:"active_accounts"`
+	NewAccounts    int64     `gorm:"column:new_accounts;not null" json:"new_accounts"`
+	GasUsed        int64     `gorm:"column:gas_used;not null" json:"gas_used"`
+	Fees           JSON      `gorm:"column:fees;type:json;not null" json:"fees"`
+	ModuleCalls    int64     `gorm:"column:module_calls;not null" json:"module_calls"`
+	NftMints       int64     `gorm:"column:nft_mints;not null" json:"nft_mints"`
+}
+
+// TableName ChainStat's table name
+func (*ChainStat) TableName() string {
+	return TableNameChainStat
+}
+
+// CollectionMetadata mapped from table <collection_metadata>
+type CollectionMetadata struct {
+	ID            string     `gorm:"column:id;primaryKey;type:text" json:"id"`
+	URI           string     `gorm:"column:uri;not null;type:character varying" json:"uri"`
+	Status        string     `gorm:"column:status;not null;type:character varying;index:ix_collection_metadata_status_next_attempt_at,priority:1" json:"status"`
+	Attempts      int32      `gorm:"column:attempts;not null" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null;type:timestamp;index:ix_collection_metadata_status_next_attempt_at,priority:2" json:"next_attempt_at"`
+	LastError     *string    `gorm:"column:last_error;type:character varying" json:"last_error"`
+	FetchedAt     *time.Time `gorm:"column:fetched_at;type:timestamp" json:"fetched_at"`
+	Metadata      JSON       `gorm:"column:metadata;type:json;not null" json:"metadata"`
+	Name          string     `gorm:"column:name;not null;type:character varying" json:"name"`
+	Description   string     `gorm:"column:description;not null;type:character varying" json:"description"`
+	Image         string     `gorm:"column:image;not null;type:character varying" json:"image"`
+	ThumbnailURL  string     `gorm:"column:thumbnail_url;not null;type:character varying" json:"thumbnail_url"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;not null;type:timestamp" json:"updated_at"`
+
+	// Foreign key relationships
+	Collection Collection `gorm:"foreignKey:ID;references:ID" json:"-"`
+}
+
+// TableName CollectionMetadata's table name
+func (*CollectionMetadata) TableName() string {
+	return TableNameCollectionMetadata
+}
+
 // CollectionMutationEvent mapped from table <collection_mutation_events>
 type CollectionMutationEvent struct {
 	MutatedFieldName string `gorm:"column:mutated_field_name;not null;type:character varying" json:"mutated_field_name"`
@@ -174,6 +260,20 @@ func (*Collection) TableName() string {
 	return TableNameCollection
 }
 
+// ConsumerOffset mapped from table <consumer_offsets>
+type ConsumerOffset struct {
+	ConsumerGroup string    `gorm:"column:consumer_group;primaryKey;type:character varying" json:"consumer_group"`
+	Topic         string    `gorm:"column:topic;primaryKey;type:character varying" json:"topic"`
+	Partition     int32     `gorm:"column:partition;primaryKey" json:"partition"`
+	Offset        int64     `gorm:"column:offset;not null" json:"offset"`
+	UpdatedAt     time.Time `gorm:"column:updated_at;not null;type:timestamp" json:"updated_at"`
+}
+
+// TableName ConsumerOffset's table name
+func (*ConsumerOffset) TableName() string {
+	return TableNameConsumerOffset
+}
+
 // FinalizeBlockEvent mapped from table <finalize_block_events>
 type FinalizeBlockEvent struct {
 	BlockHeight int64  `gorm:"column:block_height;primaryKey;index:ix_finalize_block_events_event_key_block_height_desc,priority:2,sort:desc" json:"block_height"`
@@ -204,6 +304,55 @@ func (*LcdTxResult) TableName() string {
 	return TableNameLcdTxResult
 }
 
+// ModuleFunctionCaller mapped from table <module_function_callers>
+type ModuleFunctionCaller struct {
+	ModuleID     string    `gorm:"column:module_id;primaryKey;type:character varying" json:"module_id"`
+	FunctionName string    `gorm:"column:function_name;primaryKey;type:character varying" json:"function_name"`
+	Date         time.Time `gorm:"column:date;primaryKey;type:date" json:"date"`
+	Sender       string    `gorm:"column:sender;primaryKey;type:character varying" json:"sender"`
+}
+
+// TableName ModuleFunctionCaller's table name
+func (*ModuleFunctionCaller) TableName() string {
+	return TableNameModuleFunctionCaller
+}
+
+// ModuleFunctionCall mapped from table <module_function_calls>
+type ModuleFunctionCall struct {
+	TxID         string `gorm:"column:tx_id;primaryKey;type:character varying" json:"tx_id"`
+	MsgIndex     int32  `gorm:"column:msg_index;primaryKey" json:"msg_index"`
+	ModuleID     string `gorm:"column:module_id;not null;type:character varying;index:ix_module_function_calls_module_id_function_name_block_height,priority:1" json:"module_id"`
+	FunctionName string `gorm:"column:function_name;not null;type:character varying;index:ix_module_function_calls_module_id_function_name_block_height,priority:2" json:"function_name"`
+	Sender       string `gorm:"column:sender;not null;type:character varying" json:"sender"`
+	Success      bool   `gorm:"column:success;not null" json:"success"`
+	GasUsed      int64  `gorm:"column:gas_used;not null" json:"gas_used"`
+	BlockHeight  int64  `gorm:"column:block_height;not null;index:ix_module_function_calls_block_height;index:ix_module_function_calls_module_id_function_name_block_height,priority:3" json:"block_height"`
+
+	// Foreign key relationships
+	Block       Block       `gorm:"foreignKey:BlockHeight;references:Height" json:"-"`
+	Transaction Transaction `gorm:"foreignKey:TxID;references:ID" json:"-"`
+}
+
+// TableName ModuleFunctionCall's table name
+func (*ModuleFunctionCall) TableName() string {
+	return TableNameModuleFunctionCall
+}
+
+// ModuleFunctionStat mapped from table <module_function_stats>
+type ModuleFunctionStat struct {
+	ModuleID     string    `gorm:"column:module_id;primaryKey;type:character varying" json:"module_id"`
+	FunctionName string    `gorm:"column:function_name;primaryKey;type:character varying" json:"function_name"`
+	Date         time.Time `gorm:"column:date;primaryKey;type:date" json:"date"`
+	CallCount    int64     `gorm:"column:call_count;not null" json:"call_count"`
+	FailureCount int64     `gorm:"column:failure_count;not null" json:"failure_count"`
+	GasUsed      int64     `gorm:"column:gas_used;not null" json:"gas_used"`
+}
+
+// TableName ModuleFunctionStat's table name
+func (*ModuleFunctionStat) TableName() string {
+	return TableNameModuleFunctionStat
+}
+
 // ModuleHistory mapped from table <module_histories>
 type ModuleHistory struct {
 	UpgradePolicy string  `gorm:"column:upgrade_policy;not null;type:upgradepolicy" json:"upgrade_policy"`
@@ -212,6 +361,7 @@ type ModuleHistory struct {
 	ProposalID    *int32  `gorm:"column:proposal_id" json:"proposal_id"`
 	TxID          *string `gorm:"column:tx_id;type:character varying" json:"tx_id"`
 	ModuleID      string  `gorm:"column:module_id;type:character varying;index:ix_module_histories_module_id" json:"module_id"`
+	Digest        *string `gorm:"column:digest;type:character varying" json:"digest"`
 
 	// Foreign key relationships
 	Block       Block       `gorm:"foreignKey:BlockHeight;references:Height" json:"-"`
@@ -258,6 +408,39 @@ func (*ModuleTransaction) TableName() string {
 	return TableNameModuleTransaction
 }
 
+// ModuleVerificationFile mapped from table <module_verification_files>
+type ModuleVerificationFile struct {
+	VerificationID int64  `gorm:"column:verification_id;primaryKey" json:"verification_id"`
+	Path           string `gorm:"column:path;primaryKey;type:character varying" json:"path"`
+	Content        string `gorm:"column:content;not null;type:text" json:"content"`
+
+	// Foreign key relationships
+	Verification ModuleVerification `gorm:"foreignKey:VerificationID;references:ID" json:"-"`
+}
+
+// TableName ModuleVerificationFile's table name
+func (*ModuleVerificationFile) TableName() string {
+	return TableNameModuleVerificationFile
+}
+
+// ModuleVerification mapped from table <module_verifications>
+type ModuleVerification struct {
+	ID              int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Address         string     `gorm:"column:address;not null;type:character varying;index:ix_module_verifications_address" json:"address"`
+	PackageName     string     `gorm:"column:package_name;not null;type:character varying" json:"package_name"`
+	Status          string     `gorm:"column:status;not null;type:verificationstatus;index:ix_module_verifications_status_id,priority:1" json:"status"`
+	Error           string     `gorm:"column:error;not null;type:text" json:"error"`
+	CompilerVersion string     `gorm:"column:compiler_version;not null;type:character varying" json:"compiler_version"`
+	SubmittedAt     time.Time  `gorm:"column:submitted_at;not null;type:timestamp" json:"submitted_at"`
+	StartedAt       *time.Time `gorm:"column:started_at;type:timestamp" json:"started_at"`
+	CompletedAt     *time.Time `gorm:"column:completed_at;type:timestamp" json:"completed_at"`
+}
+
+// TableName ModuleVerification's table name
+func (*ModuleVerification) TableName() string {
+	return TableNameModuleVerification
+}
+
 // Module mapped from table <modules>
 type Module struct {
 	Name                string  `gorm:"column:name;not null;type:varchar" json:"name"`
@@ -293,15 +476,30 @@ func (*MoveEvent) TableName() string {
 	return TableNameMoveEvent
 }
 
+// NftAttribute mapped from table <nft_attributes>
+type NftAttribute struct {
+	NftID     string `gorm:"column:nft_id;primaryKey;type:character varying" json:"nft_id"`
+	TraitType string `gorm:"column:trait_type;primaryKey;type:character varying;index:ix_nft_attributes_trait_type_value,priority:1" json:"trait_type"`
+	Value     string `gorm:"column:value;primaryKey;type:character varying;index:ix_nft_attributes_trait_type_value,priority:2" json:"value"`
+
+	// Foreign key relationships
+	Nft Nft `gorm:"foreignKey:NftID;references:ID" json:"-"`
+}
+
+// TableName NftAttribute's table name
+func (*NftAttribute) TableName() string {
+	return TableNameNftAttribute
+}
+
 // NftHistory mapped from table <nft_histories>
 type NftHistory struct {
-	BlockHeight int64  `gorm:"column:block_height;not null;index:ix_nft_histories_block_height" json:"block_height"`
-	Remark      JSON   `gorm:"column:remark;type:json;not null" json:"remark"`
-	ProposalID  *int32 `gorm:"column:proposal_id" json:"proposal_id"`
-	TxID        string `gorm:"column:tx_id;type:character varying" json:"tx_id"`
-	From        string `gorm:"column:from;type:character varying" json:"from"`
-	To          string `gorm:"column:to;type:character varying" json:"to"`
-	NftID       string `gorm:"column:nft_id;type:character varying" json:"nft_id"`
+	BlockHeight int64   `gorm:"column:block_height;not null;index:ix_nft_histories_block_height;index:ix_nft_histories_nft_id_block_height,priority:2" json:"block_height"`
+	Remark      JSON    `gorm:"column:remark;type:json;not null" json:"remark"`
+	ProposalID  *int32  `gorm:"column:proposal_id" json:"proposal_id"`
+	TxID        string  `gorm:"column:tx_id;type:character varying" json:"tx_id"`
+	From        *string `gorm:"column:from;type:character varying" json:"from"`
+	To          string  `gorm:"column:to;type:character varying" json:"to"`
+	NftID       string  `gorm:"column:nft_id;type:character varying;index:ix_nft_histories_nft_id_block_height,priority:1" json:"nft_id"`
 
 	// Foreign key relationships
 	Block       Block       `gorm:"foreignKey:BlockHeight;references:Height" json:"-"`
@@ -317,6 +515,31 @@ func (*NftHistory) TableName() string {
 	return TableNameNftHistory
 }
 
+// NftMetadata mapped from table <nft_metadata>
+type NftMetadata struct {
+	ID            string     `gorm:"column:id;primaryKey;type:character varying" json:"id"`
+	URI           string     `gorm:"column:uri;not null;type:character varying" json:"uri"`
+	Status        string     `gorm:"column:status;not null;type:character varying;index:ix_nft_metadata_status_next_attempt_at,priority:1" json:"status"`
+	Attempts      int32      `gorm:"column:attempts;not null" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null;type:timestamp;index:ix_nft_metadata_status_next_attempt_at,priority:2" json:"next_attempt_at"`
+	LastError     *string    `gorm:"column:last_error;type:character varying" json:"last_error"`
+	FetchedAt     *time.Time `gorm:"column:fetched_at;type:timestamp" json:"fetched_at"`
+	Metadata      JSON       `gorm:"column:metadata;type:json;not null" json:"metadata"`
+	Name          string     `gorm:"column:name;not null;type:character varying" json:"name"`
+	Description   string     `gorm:"column:description;not null;type:character varying" json:"description"`
+	Image         string     `gorm:"column:image;not null;type:character varying" json:"image"`
+	ThumbnailURL  string     `gorm:"column:thumbnail_url;not null;type:character varying" json:"thumbnail_url"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;not null;type:timestamp" json:"updated_at"`
+
+	// Foreign key relationships
+	Nft Nft `gorm:"foreignKey:ID;references:ID" json:"-"`
+}
+
+// TableName NftMetadata's table name
+func (*NftMetadata) TableName() string {
+	return TableNameNftMetadata
+}
+
 // NftMutationEvent mapped from table <nft_mutation_events>
 type NftMutationEvent struct {
 	MutatedFieldName string `gorm:"column:mutated_field_name;not null;type:character varying" json:"mutated_field_name"`
@@ -447,10 +670,10 @@ func (*OpinitTransaction) TableName() string {
 
 // ProposalDeposit mapped from table <proposal_deposits>
 type ProposalDeposit struct {
-	ProposalID    int32  `gorm:"column:proposal_id;not null" json:"proposal_id"`
+	ProposalID    int32  `gorm:"column:proposal_id;not null;index:ix_proposal_deposits_proposal_id" json:"proposal_id"`
 	Amount        JSON   `gorm:"column:amount;not null;type:json" json:"amount"`
 	TransactionID string `gorm:"column:transaction_id;type:character varying" json:"transaction_id"`
-	Depositor     string `gorm:"column:depositor;type:character varying" json:"depositor"`
+	Depositor     string `gorm:"column:depositor;type:character varying;index:ix_proposal_deposits_depositor" json:"depositor"`
 
 	// Foreign key relationships
 	DepositorAccount Account     `gorm:"foreignKey:Depositor;references:Address" json:"-"`
@@ -513,6 +736,31 @@ func (*ProposalVotesLegacy) TableName() string {
 	return TableNameProposalVotesLegacy
 }
 
+// ProposalTallySnapshot mapped from table <proposal_tally_snapshots>
+type ProposalTallySnapshot struct {
+	ProposalID        int32     `gorm:"column:proposal_id;primaryKey" json:"proposal_id"`
+	TallyHeight       int64     `gorm:"column:tally_height;primaryKey" json:"tally_height"`
+	Timestamp         time.Time `gorm:"column:timestamp;type:timestamp;not null" json:"timestamp"`
+	Yes               int64     `gorm:"column:yes;not null" json:"yes"`
+	No                int64     `gorm:"column:no;not null" json:"no"`
+	Abstain           int64     `gorm:"column:abstain;not null" json:"abstain"`
+	NoWithVeto        int64     `gorm:"column:no_with_veto;not null" json:"no_with_veto"`
+	TotalStakingPower int64     `gorm:"column:total_staking_power;not null" json:"total_staking_power"`
+	TotalVestingPower int64     `gorm:"column:total_vesting_power;not null" json:"total_vesting_power"`
+	Quorum            float64   `gorm:"column:quorum;not null" json:"quorum"`
+	Threshold         float64   `gorm:"column:threshold;not null" json:"threshold"`
+	QuorumProgress    float64   `gorm:"column:quorum_progress;not null" json:"quorum_progress"`
+	ThresholdProgress float64   `gorm:"column:threshold_progress;not null" json:"threshold_progress"`
+
+	// Foreign key relationship
+	Proposal Proposal `gorm:"foreignKey:ProposalID;references:ID" json:"-"`
+}
+
+// TableName ProposalTallySnapshot's table name
+func (*ProposalTallySnapshot) TableName() string {
+	return TableNameProposalTallySnapshot
+}
+
 // Proposal mapped from table <proposals>
 type Proposal struct {
 	ID                     int32      `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
@@ -527,6 +775,7 @@ type Proposal struct {
 	VotingEndTime          *time.Time `gorm:"column:voting_end_time;type:timestamp" json:"voting_end_time"`
 	Content                JSON       `gorm:"column:content;type:json" json:"content"`
 	TotalDeposit           JSON       `gorm:"column:total_deposit;not null;type:json" json:"total_deposit"`
+	MinDeposit             JSON       `gorm:"column:min_deposit;type:json" json:"min_deposit"`
 	Yes                    int64      `gorm:"column:yes;not null" json:"yes"`
 	No                     int64      `gorm:"column:no;not null" json:"no"`
 	Abstain                int64      `gorm:"column:abstain;not null" json:"abstain"`
@@ -578,6 +827,8 @@ type Tracking struct {
 	ReplayOffset                 int32  `gorm:"column:replay_offset;not null" json:"replay_offset"`
 	LatestInformativeBlockHeight int64  `gorm:"column:latest_informative_block_height" json:"latest_informative_block_height"`
 	TxCount                      int64  `gorm:"column:tx_count;not null;default:0" json:"tx_count"`
+	AccountStatsFromHeight       *int64 `gorm:"column:account_stats_from_height" json:"account_stats_from_height"`
+	AccountStatsBackfilledHeight int64  `gorm:"column:account_stats_backfilled_height;not null;default:0" json:"account_stats_backfilled_height"`
 }
 
 // TableName Tracking's table name
@@ -674,6 +925,27 @@ func (*ValidatorCommitSignature) TableName() string {
 	return TableNameValidatorCommitSignature
 }
 
+// ValidatorDowntimeEpisode mapped from table <validator_downtime_episodes>
+type ValidatorDowntimeEpisode struct {
+	ID               int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ValidatorAddress string `gorm:"column:validator_address;type:character varying;not null;index:ix_validator_downtime_episodes_validator_address_start_height,priority:1;uniqueIndex:ix_validator_downtime_episodes_open,priority:1,where:NOT is_resolved" json:"validator_address"`
+	Type             string `gorm:"column:type;type:character varying;not null;uniqueIndex:ix_validator_downtime_episodes_open,priority:2,where:NOT is_resolved" json:"type"`
+	StartHeight      int64  `gorm:"column:start_height;not null;index:ix_validator_downtime_episodes_validator_address_start_height,priority:2" json:"start_height"`
+	EndHeight        int64  `gorm:"column:end_height;not null" json:"end_height"`
+	MissedCount      int64  `gorm:"column:missed_count;not null" json:"missed_count"`
+	IsResolved       bool   `gorm:"column:is_resolved;not null" json:"is_resolved"`
+	OpenedNotified   bool   `gorm:"column:opened_notified;not null" json:"opened_notified"`
+	ResolvedNotified bool   `gorm:"column:resolved_notified;not null" json:"resolved_notified"`
+
+	// Foreign key relationship
+	Validator Validator `gorm:"foreignKey:ValidatorAddress;references:OperatorAddress" json:"-"`
+}
+
+// TableName ValidatorDowntimeEpisode's table name
+func (*ValidatorDowntimeEpisode) TableName() string {
+	return TableNameValidatorDowntimeEpisode
+}
+
 // ValidatorHistoricalPower mapped from table <validator_historical_powers>
 type ValidatorHistoricalPower struct {
 	ValidatorAddress     string    `gorm:"column:validator_address;type:character varying;index:unique_validator_historicail_power,unique" json:"validator_address"`
@@ -693,9 +965,13 @@ func (*ValidatorHistoricalPower) TableName() string {
 
 // ValidatorSlashEvent mapped from table <validator_slash_events>
 type ValidatorSlashEvent struct {
-	ValidatorAddress string `gorm:"column:validator_address;type:character varying;not null" json:"validator_address"`
-	BlockHeight      int64  `gorm:"column:block_height;not null;type:bigint" json:"block_height"`
-	Type             string `gorm:"column:type;type:slashtype" json:"type"`
+	ValidatorAddress string  `gorm:"column:validator_address;type:character varying;not null;index:ix_validator_slash_events_validator_address_block_height,priority:1" json:"validator_address"`
+	BlockHeight      int64   `gorm:"column:block_height;not null;type:bigint;index:ix_validator_slash_events_validator_address_block_height,priority:2" json:"block_height"`
+	Type             string  `gorm:"column:type;type:slashtype" json:"type"`
+	Reason           *string `gorm:"column:reason;type:character varying" json:"reason"`
+	Power            *int64  `gorm:"column:power;type:bigint" json:"power"`
+	BurnedCoins      JSON    `gorm:"column:burned_coins;type:json" json:"burned_coins"`
+	InfractionHeight *int64  `gorm:"column:infraction_height;type:bigint" json:"infraction_height"`
 
 	// Foreign key relationships
 	Block     Block     `gorm:"foreignKey:BlockHeight;references:Height" json:"-"`
@@ -739,7 +1015,12 @@ type Validator struct {
 	IsActive        bool   `gorm:"column:is_active" json:"is_active"`
 	ConsensusPubkey string `gorm:"column:consensus_pubkey;type:character varying" json:"consensus_pubkey"`
 	AccountID       string `gorm:"column:account_id;type:character varying" json:"account_id"`
-	IdentityImage   string `gorm:"column:identity_image;type:text" json:"identity_image"`
+	// IdentityImage is the base64 identity image served until IdentityImageURL is resolved
+	IdentityImage string `gorm:"column:identity_image;type:text" json:"identity_image"`
+	// IdentityImageURL is the hosted identity image, resolved for IdentityImageIdentity at IdentityImageUpdatedAt
+	IdentityImageURL       string     `gorm:"column:identity_image_url;not null;type:character varying" json:"identity_image_url"`
+	IdentityImageIdentity  string     `gorm:"column:identity_image_identity;not null;type:character varying" json:"identity_image_identity"`
+	IdentityImageUpdatedAt *time.Time `gorm:"column:identity_image_updated_at;type:timestamp" json:"identity_image_updated_at"`
 
 	// Foreign key relationship
 	Account Account `gorm:"foreignKey:AccountID;references:Address" json:"-"`
@@ -750,6 +1031,24 @@ func (*Validator) TableName() string {
 	return TableNameValidator
 }
 
+// VerifiedModule mapped from table <verified_modules>
+type VerifiedModule struct {
+	ModuleID       string    `gorm:"column:module_id;primaryKey;type:character varying" json:"module_id"`
+	Digest         string    `gorm:"column:digest;primaryKey;type:character varying" json:"digest"`
+	VerificationID int64     `gorm:"column:verification_id;not null;index:ix_verified_modules_verification_id" json:"verification_id"`
+	Abi            JSON      `gorm:"column:abi;not null;type:json" json:"abi"`
+	VerifiedAt     time.Time `gorm:"column:verified_at;not null;type:timestamp" json:"verified_at"`
+
+	// Foreign key relationships
+	Module       Module             `gorm:"foreignKey:ModuleID;references:ID" json:"-"`
+	Verification ModuleVerification `gorm:"foreignKey:VerificationID;references:ID" json:"-"`
+}
+
+// TableName VerifiedModule's table name
+func (*VerifiedModule) TableName() string {
+	return TableNameVerifiedModule
+}
+
 // VMAddress mapped from table <vm_addresses>
 type VMAddress struct {
 	VMAddress string `gorm:"column:vm_address;uniqueIndex:vm_addresses_vm_address_key;primaryKey;not null;type:character varying" json:"vm_address"`
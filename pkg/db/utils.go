@@ -19,6 +19,31 @@ func NormalizeEscapeString(str string) string {
 	return strings.ReplaceAll(str, "\x00", "\uFFFD")
 }
 
+// accountTypesByMessageName maps the proto message names of auth accounts, without their package, to account types
+var accountTypesByMessageName = map[string]AccountType{
+	"BaseAccount":              BaseAccount,
+	"ModuleAccount":            ModuleAccount,
+	"ContinuousVestingAccount": ContinuousVestingAccount,
+	"DelayedVestingAccount":    DelayedVestingAccount,
+	"ClawbackVestingAccount":   ClawbackVestingAccount,
+	"PermanentLockedAccount":   PermanentLockedAccount,
+	"PeriodicVestingAccount":   PeriodicVestingAccount,
+	"InterchainAccount":        InterchainAccount,
+	"ObjectAccount":            ContractAccount,
+	"TableAccount":             ContractAccount,
+}
+
+// AccountTypeFromTypeURL returns the account type of an auth account packed with the given type URL,
+// falling back to BaseAccount for unknown accounts.
+func AccountTypeFromTypeURL(typeURL string) AccountType {
+	messageName := typeURL[strings.LastIndex(typeURL, ".")+1:]
+	if accountType, ok := accountTypesByMessageName[messageName]; ok {
+		return accountType
+	}
+	return BaseAccount
+}
+
+// NewAccountFromSDKAddress creates a BaseAccount, the type of which is resolved separately when needed.
 func NewAccountFromSDKAddress(address sdk.AccAddress) Account {
 	return Account{
 		Address:     address.String(),
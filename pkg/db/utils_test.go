@@ -0,0 +1,32 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountTypeFromTypeURL(t *testing.T) {
+	tests := []struct {
+		typeURL string
+		want    AccountType
+	}{
+		{"/cosmos.auth.v1beta1.BaseAccount", BaseAccount},
+		{"/cosmos.auth.v1beta1.ModuleAccount", ModuleAccount},
+		{"/cosmos.vesting.v1beta1.ContinuousVestingAccount", ContinuousVestingAccount},
+		{"/cosmos.vesting.v1beta1.DelayedVestingAccount", DelayedVestingAccount},
+		{"/cosmos.vesting.v1beta1.PermanentLockedAccount", PermanentLockedAccount},
+		{"/cosmos.vesting.v1beta1.PeriodicVestingAccount", PeriodicVestingAccount},
+		{"/ibc.applications.interchain_accounts.v1.InterchainAccount", InterchainAccount},
+		{"/initia.move.v1.ObjectAccount", ContractAccount},
+		{"/initia.move.v1.TableAccount", ContractAccount},
+		{"/cosmos.auth.v1beta1.UnknownAccount", BaseAccount},
+		{"", BaseAccount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeURL, func(t *testing.T) {
+			assert.Equal(t, tt.want, AccountTypeFromTypeURL(tt.typeURL))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// QueryValidatorCommitSignaturesFrom returns the commit signatures from the given height, ordered by validator and height
+func QueryValidatorCommitSignaturesFrom(ctx context.Context, dbTx *gorm.DB, fromHeight int64) ([]ValidatorCommitSignature, error) {
+	var votes []ValidatorCommitSignature
+	result := dbTx.WithContext(ctx).
+		Model(&ValidatorCommitSignature{}).
+		Select("validator_address, vote, block_height").
+		Where("block_height >= ?", fromHeight).
+		Order("validator_address, block_height").
+		Scan(&votes)
+
+	return votes, result.Error
+}
+
+// QueryOpenValidatorDowntimeEpisodes returns the downtime episodes that are not resolved yet
+func QueryOpenValidatorDowntimeEpisodes(ctx context.Context, dbTx *gorm.DB) ([]ValidatorDowntimeEpisode, error) {
+	var episodes []ValidatorDowntimeEpisode
+	result := dbTx.WithContext(ctx).
+		Model(&ValidatorDowntimeEpisode{}).
+		Where("NOT is_resolved").
+		Find(&episodes)
+
+	return episodes, result.Error
+}
+
+// QueryUnnotifiedValidatorDowntimeEpisodes returns the episodes whose opening or resolution is not notified yet
+func QueryUnnotifiedValidatorDowntimeEpisodes(ctx context.Context, dbTx *gorm.DB) ([]ValidatorDowntimeEpisode, error) {
+	var episodes []ValidatorDowntimeEpisode
+	result := dbTx.WithContext(ctx).
+		Model(&ValidatorDowntimeEpisode{}).
+		Where("NOT opened_notified OR (is_resolved AND NOT resolved_notified)").
+		Order("id").
+		Find(&episodes)
+
+	return episodes, result.Error
+}
+
+// SaveValidatorDowntimeEpisodes inserts the new episodes and updates the existing ones
+func SaveValidatorDowntimeEpisodes(ctx context.Context, dbTx *gorm.DB, episodes []ValidatorDowntimeEpisode) error {
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	// resolve before opening, so a new episode never conflicts with the open one it replaces
+	for _, resolved := range []bool{true, false} {
+		for idx := range episodes {
+			if episodes[idx].IsResolved != resolved {
+				continue
+			}
+			if err := dbTx.WithContext(ctx).Save(&episodes[idx]).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarkValidatorDowntimeEpisodeNotified records the notification of the opening, and of the resolution when resolved
+func MarkValidatorDowntimeEpisodeNotified(ctx context.Context, dbTx *gorm.DB, episode ValidatorDowntimeEpisode) error {
+	return dbTx.WithContext(ctx).
+		Model(&ValidatorDowntimeEpisode{}).
+		Where("id = ?", episode.ID).
+		Updates(map[string]any{
+			"opened_notified":   true,
+			"resolved_notified": episode.IsResolved,
+		}).Error
+}
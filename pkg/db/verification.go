@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsertModuleVerification queues a Move package verification together with its source files.
+func InsertModuleVerification(ctx context.Context, dbTx *gorm.DB, verification *ModuleVerification, files []ModuleVerificationFile) error {
+	return dbTx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(verification).Error; err != nil {
+			return err
+		}
+
+		for idx := range files {
+			files[idx].VerificationID = verification.ID
+		}
+		return tx.CreateInBatches(&files, BatchSize).Error
+	})
+}
+
+// ClaimModuleVerification marks the oldest pending verification as compiling with the given compiler and returns it,
+// or nil when there is none. Concurrent workers never claim the same verification.
+func ClaimModuleVerification(ctx context.Context, dbTx *gorm.DB, compilerVersion string) (*ModuleVerification, error) {
+	var verification ModuleVerification
+	result := dbTx.WithContext(ctx).
+		Raw(`UPDATE module_verifications SET status = ?, compiler_version = ?, started_at = ?
+			WHERE id = (
+				SELECT id FROM module_verifications WHERE status = ?
+				ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *`, VerificationCompiling, compilerVersion, time.Now().UTC(), VerificationPending).
+		Scan(&verification)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &verification, nil
+}
+
+// RequeueStaleModuleVerifications puts verifications that started compiling before the given time back to pending,
+// so verifications claimed by a worker that stopped are picked up again.
+func RequeueStaleModuleVerifications(ctx context.Context, dbTx *gorm.DB, startedBefore time.Time) (int64, error) {
+	result := dbTx.WithContext(ctx).
+		Model(&ModuleVerification{}).
+		Where("status = ? AND started_at < ?", VerificationCompiling, startedBefore).
+		Updates(map[string]any{"status": VerificationPending, "started_at": nil})
+
+	return result.RowsAffected, result.Error
+}
+
+// QueryModuleVerificationFiles returns the source files of a verification ordered by path.
+func QueryModuleVerificationFiles(ctx context.Context, dbTx *gorm.DB, verificationID int64) ([]ModuleVerificationFile, error) {
+	var files []ModuleVerificationFile
+	if err := dbTx.WithContext(ctx).
+		Where("verification_id = ?", verificationID).
+		Order("path").
+		Find(&files).Error; err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// QueryModuleDigests returns the digests of every published version of the given modules, keyed by module id.
+// Modules that do not exist are omitted.
+func QueryModuleDigests(ctx context.Context, dbTx *gorm.DB, moduleIDs []string) (map[string]map[string]bool, error) {
+	digests := make(map[string]map[string]bool)
+	if len(moduleIDs) == 0 {
+		return digests, nil
+	}
+
+	var rows []struct {
+		ModuleID string
+		Digest   string
+	}
+	if err := dbTx.WithContext(ctx).
+		Raw(`SELECT id AS module_id, digest FROM modules WHERE id IN ? AND digest IS NOT NULL
+			UNION
+			SELECT module_id, digest FROM module_histories WHERE module_id IN ? AND digest IS NOT NULL`, moduleIDs, moduleIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if digests[row.ModuleID] == nil {
+			digests[row.ModuleID] = make(map[string]bool)
+		}
+		digests[row.ModuleID][row.Digest] = true
+	}
+	return digests, nil
+}
+
+// QueryModulesWithUnknownDigests returns the given modules having versions whose digest is unknown, the versions
+// published before the digests of the module histories were recorded
+func QueryModulesWithUnknownDigests(ctx context.Context, dbTx *gorm.DB, moduleIDs []string) (map[string]bool, error) {
+	modules := make(map[string]bool)
+	if len(moduleIDs) == 0 {
+		return modules, nil
+	}
+
+	var rows []string
+	if err := dbTx.WithContext(ctx).
+		Model(&ModuleHistory{}).
+		Where("module_id IN ? AND digest IS NULL", moduleIDs).
+		Distinct().
+		Pluck("module_id", &rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, moduleID := range rows {
+		modules[moduleID] = true
+	}
+	return modules, nil
+}
+
+// CompleteModuleVerification stores the verified modules of a verification, flags the modules whose current
+// digest is verified and marks the verification as verified.
+func CompleteModuleVerification(ctx context.Context, dbTx *gorm.DB, verification *ModuleVerification, verifiedModules []VerifiedModule) error {
+	if len(verifiedModules) == 0 {
+		return errors.New("no verified modules")
+	}
+
+	return dbTx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "module_id"}, {Name: "digest"}},
+			DoUpdates: clause.AssignmentColumns([]string{"verification_id", "abi", "verified_at"}),
+		}).CreateInBatches(&verifiedModules, BatchSize).Error; err != nil {
+			return err
+		}
+
+		for _, verifiedModule := range verifiedModules {
+			if err := tx.Model(&Module{}).
+				Where("id = ? AND digest = ?", verifiedModule.ModuleID, verifiedModule.Digest).
+				Update("is_verify", true).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(verification).
+			Select("status", "package_name", "error", "completed_at").
+			Updates(ModuleVerification{
+				Status:      string(VerificationVerified),
+				PackageName: verification.PackageName,
+				CompletedAt: verification.CompletedAt,
+			}).Error
+	})
+}
+
+// FailModuleVerification marks a verification as failed with the reason.
+func FailModuleVerification(ctx context.Context, dbTx *gorm.DB, verification *ModuleVerification, reason string) error {
+	return dbTx.WithContext(ctx).
+		Model(verification).
+		Select("status", "package_name", "error", "completed_at").
+		Updates(ModuleVerification{
+			Status:      string(VerificationFailed),
+			PackageName: verification.PackageName,
+			Error:       reason,
+			CompletedAt: verification.CompletedAt,
+		}).Error
+}
@@ -0,0 +1,205 @@
+package export
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"gorm.io/gorm/schema"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+type Format string
+
+const (
+	FormatParquet Format = "parquet"
+	FormatNDJSON  Format = "ndjson"
+)
+
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case FormatParquet, FormatNDJSON:
+		return Format(format), nil
+	}
+	return "", fmt.Errorf("unsupported export format: %q", format)
+}
+
+// Extension returns the file extension of the format, including the compression suffix.
+func (f Format) Extension() string {
+	if f == FormatNDJSON {
+		return ".ndjson.gz"
+	}
+	return ".parquet"
+}
+
+// Encoder writes model rows of a single table to a file.
+type Encoder interface {
+	// Encode writes a row, given as a pointer to the table model.
+	Encode(row reflect.Value) error
+	Close() error
+}
+
+func NewEncoder(format Format, w io.Writer, table *Table) (Encoder, error) {
+	switch format {
+	case FormatNDJSON:
+		return newNDJSONEncoder(w), nil
+	case FormatParquet:
+		return newParquetEncoder(w, table)
+	}
+	return nil, fmt.Errorf("unsupported export format: %q", format)
+}
+
+// ndjsonEncoder writes gzipped newline-delimited JSON using the json tags of the models.
+type ndjsonEncoder struct {
+	gzipWriter *gzip.Writer
+	encoder    *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) *ndjsonEncoder {
+	gzipWriter := gzip.NewWriter(w)
+	return &ndjsonEncoder{gzipWriter: gzipWriter, encoder: json.NewEncoder(gzipWriter)}
+}
+
+func (e *ndjsonEncoder) Encode(row reflect.Value) error {
+	return e.encoder.Encode(row.Interface())
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return e.gzipWriter.Close()
+}
+
+// parquetEncoder writes zstd-compressed Parquet with one column per database column of the model.
+type parquetEncoder struct {
+	writer   *parquet.Writer
+	fields   []*schema.Field
+	optional []bool
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	jsonType  = reflect.TypeOf(db.JSON{})
+	jsonbType = reflect.TypeOf(db.JSONB{})
+)
+
+func newParquetEncoder(w io.Writer, table *Table) (*parquetEncoder, error) {
+	group := parquet.Group{}
+	fieldsByName := make(map[string]*schema.Field)
+	for _, field := range table.Columns() {
+		node, err := parquetNode(field.FieldType)
+		if err != nil {
+			return nil, fmt.Errorf("column %s.%s: %w", table.Name, field.DBName, err)
+		}
+		group[field.DBName] = node
+		fieldsByName[field.DBName] = field
+	}
+
+	parquetSchema := parquet.NewSchema(table.Name, group)
+	columns := parquetSchema.Columns()
+	encoder := &parquetEncoder{
+		writer:   parquet.NewWriter(w, parquetSchema, parquet.Compression(&parquet.Zstd)),
+		fields:   make([]*schema.Field, len(columns)),
+		optional: make([]bool, len(columns)),
+	}
+	for idx, path := range columns {
+		encoder.fields[idx] = fieldsByName[path[0]]
+		encoder.optional[idx] = group[path[0]].Optional()
+	}
+
+	return encoder, nil
+}
+
+func parquetNode(t reflect.Type) (parquet.Node, error) {
+	if t.Kind() == reflect.Ptr {
+		node, err := parquetNode(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return parquet.Optional(node), nil
+	}
+
+	switch t {
+	case timeType:
+		return parquet.Timestamp(parquet.Microsecond), nil
+	case jsonType, jsonbType:
+		return parquet.Optional(parquet.JSON()), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return parquet.Leaf(parquet.BooleanType), nil
+	case reflect.Int32:
+		return parquet.Int(32), nil
+	case reflect.Int, reflect.Int64:
+		return parquet.Int(64), nil
+	case reflect.Float64:
+		return parquet.Leaf(parquet.DoubleType), nil
+	case reflect.String:
+		return parquet.String(), nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return parquet.Leaf(parquet.ByteArrayType), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+func (e *parquetEncoder) Encode(row reflect.Value) error {
+	ctx := context.Background()
+	values := make(parquet.Row, len(e.fields))
+	for idx, field := range e.fields {
+		value := parquetValue(field.ReflectValueOf(ctx, row))
+		definitionLevel := 0
+		if e.optional[idx] && !value.IsNull() {
+			definitionLevel = 1
+		}
+		values[idx] = value.Level(0, definitionLevel, idx)
+	}
+
+	_, err := e.writer.WriteRows([]parquet.Row{values})
+	return err
+}
+
+func parquetValue(v reflect.Value) parquet.Value {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return parquet.NullValue()
+		}
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case timeType:
+		return parquet.Int64Value(v.Interface().(time.Time).UnixMicro())
+	case jsonType, jsonbType:
+		if v.Len() == 0 {
+			return parquet.NullValue()
+		}
+		return parquet.ByteArrayValue(v.Bytes())
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return parquet.BooleanValue(v.Bool())
+	case reflect.Int32:
+		return parquet.Int32Value(int32(v.Int()))
+	case reflect.Int, reflect.Int64:
+		return parquet.Int64Value(v.Int())
+	case reflect.Float64:
+		return parquet.DoubleValue(v.Float())
+	case reflect.String:
+		return parquet.ByteArrayValue([]byte(v.String()))
+	default:
+		return parquet.ByteArrayValue(v.Bytes())
+	}
+}
+
+func (e *parquetEncoder) Close() error {
+	return e.writer.Close()
+}
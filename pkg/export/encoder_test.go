@@ -0,0 +1,110 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+func TestTables(t *testing.T) {
+	names, err := TableNames()
+	require.NoError(t, err)
+
+	for _, name := range []string{
+		db.TableNameBlock,
+		db.TableNameTransaction,
+		db.TableNameAccountTransaction,
+		db.TableNameMoveEvent,
+		db.TableNameProposalVote,
+	} {
+		assert.Contains(t, names, name)
+	}
+	assert.NotContains(t, names, db.TableNameAccount)
+
+	_, err = LookupTables([]string{db.TableNameValidator})
+	assert.Error(t, err)
+}
+
+func TestParquetEncoder(t *testing.T) {
+	tables, err := LookupTables([]string{db.TableNameTransaction})
+	require.NoError(t, err)
+
+	errMsg := "out of gas"
+	rows := []*db.Transaction{
+		{ID: "AA/1", Hash: []byte{0xaa}, BlockHeight: 10, GasUsed: 100, Messages: db.JSON(`[]`), Success: true},
+		{ID: "BB/1", Hash: []byte{0xbb}, BlockHeight: 11, GasUsed: 200, Messages: db.JSON(`[{"type":"send"}]`), ErrMsg: &errMsg},
+	}
+
+	var buffer bytes.Buffer
+	encoder, err := NewEncoder(FormatParquet, &buffer, tables[0])
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, encoder.Encode(reflect.ValueOf(row)))
+	}
+	require.NoError(t, encoder.Close())
+
+	file, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), file.NumRows())
+
+	reader := parquet.NewReader(file)
+	got := make([]map[string]any, 0, 2)
+	for {
+		row := map[string]any{}
+		if err := reader.Read(&row); err != nil {
+			require.ErrorIs(t, err, io.EOF)
+			break
+		}
+		got = append(got, row)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, "BB/1", got[1]["id"])
+	assert.Equal(t, int64(11), got[1]["block_height"])
+	assert.Equal(t, errMsg, got[1]["err_msg"])
+	assert.Nil(t, got[0]["err_msg"])
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	tables, err := LookupTables([]string{db.TableNameBlock})
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+	encoder, err := NewEncoder(FormatNDJSON, &buffer, tables[0])
+	require.NoError(t, err)
+	require.NoError(t, encoder.Encode(reflect.ValueOf(&db.Block{Height: 1, Timestamp: time.Unix(0, 0).UTC(), Hash: []byte{0x01}})))
+	require.NoError(t, encoder.Encode(reflect.ValueOf(&db.Block{Height: 2, Timestamp: time.Unix(1, 0).UTC(), Hash: []byte{0x02}})))
+	require.NoError(t, encoder.Close())
+
+	gzipReader, err := gzip.NewReader(&buffer)
+	require.NoError(t, err)
+	data, err := io.ReadAll(gzipReader)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var block map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &block))
+	assert.Equal(t, float64(2), block["height"])
+	assert.NotContains(t, block, "ProposerValidator")
+}
+
+func TestManifestCompatibility(t *testing.T) {
+	config := &Config{FromHeight: 1, ToHeight: 100, ChunkSize: 10, Format: FormatNDJSON}
+	manifest := newManifest(config)
+	assert.NoError(t, manifest.checkCompatible(config))
+	assert.Equal(t, int64(0), manifest.table(db.TableNameBlock).Checkpoint)
+
+	assert.Error(t, manifest.checkCompatible(&Config{FromHeight: 1, ToHeight: 100, ChunkSize: 10, Format: FormatParquet}))
+}
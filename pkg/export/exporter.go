@@ -0,0 +1,211 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+type Config struct {
+	// Tables to export, every exportable table if empty
+	Tables []string
+
+	// FromHeight and ToHeight are both inclusive
+	FromHeight int64
+	ToHeight   int64
+
+	// ChunkSize is the number of blocks covered by a single file
+	ChunkSize int64
+	Format    Format
+
+	Bucket string
+	Prefix string
+}
+
+// Exporter streams indexed tables for a height range into files uploaded through storage.Client.
+// Files are partitioned by height range and recorded in a manifest, which also serves as the resume checkpoint.
+type Exporter struct {
+	dbClient      *gorm.DB
+	storageClient storage.Client
+	config        *Config
+	tables        []*Table
+	logger        *zerolog.Logger
+}
+
+func NewExporter(dbClient *gorm.DB, storageClient storage.Client, config *Config, logger *zerolog.Logger) (*Exporter, error) {
+	if config.FromHeight < 0 || config.ToHeight < config.FromHeight {
+		return nil, fmt.Errorf("invalid height range: %d-%d", config.FromHeight, config.ToHeight)
+	}
+	if config.ChunkSize <= 0 {
+		return nil, fmt.Errorf("invalid chunk size: %d", config.ChunkSize)
+	}
+	if _, err := ParseFormat(string(config.Format)); err != nil {
+		return nil, err
+	}
+
+	tables, err := LookupTables(config.Tables)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Exporter{
+		dbClient:      dbClient,
+		storageClient: storageClient,
+		config:        config,
+		tables:        tables,
+		logger:        logger,
+	}, nil
+}
+
+// Run exports every table, resuming from the checkpoints of an existing manifest under the same prefix.
+func (e *Exporter) Run(ctx context.Context) error {
+	manifest, err := e.loadManifest()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range e.tables {
+		if err := e.exportTable(ctx, manifest, table); err != nil {
+			return fmt.Errorf("failed to export table %s: %w", table.Name, err)
+		}
+	}
+
+	e.logger.Info().Msgf("Export completed: %s/%s", e.config.Bucket, e.objectPath(ManifestFileName))
+	return nil
+}
+
+func (e *Exporter) loadManifest() (*Manifest, error) {
+	data, err := e.storageClient.ReadFile(e.config.Bucket, e.objectPath(ManifestFileName))
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return newManifest(e.config), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	manifest, err := parseManifest(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := manifest.checkCompatible(e.config); err != nil {
+		return nil, err
+	}
+
+	e.logger.Info().Msgf("Resuming export from existing manifest")
+	return manifest, nil
+}
+
+func (e *Exporter) saveManifest(manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	return e.storageClient.UploadFile(e.config.Bucket, e.objectPath(ManifestFileName), data)
+}
+
+func (e *Exporter) exportTable(ctx context.Context, manifest *Manifest, table *Table) error {
+	tableManifest := manifest.table(table.Name)
+
+	for from := tableManifest.Checkpoint + 1; from <= e.config.ToHeight; from = tableManifest.Checkpoint + 1 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		to := min(from+e.config.ChunkSize-1, e.config.ToHeight)
+		file, err := e.exportChunk(ctx, table, from, to)
+		if err != nil {
+			return err
+		}
+
+		if file != nil {
+			tableManifest.Files = append(tableManifest.Files, *file)
+			e.logger.Info().Msgf("Exported %d rows of table %s for heights %d-%d", file.Rows, table.Name, from, to)
+		}
+		tableManifest.Checkpoint = to
+
+		if err := e.saveManifest(manifest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportChunk streams the rows of the table between from and to into a single file, uploaded as it is written.
+// It returns nil without creating anything when there are no rows.
+func (e *Exporter) exportChunk(ctx context.Context, table *Table, from, to int64) (*FileManifest, error) {
+	rows, err := table.Query(e.dbClient.WithContext(ctx), from, to).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rows for heights %d-%d: %w", from, to, err)
+	}
+	defer rows.Close()
+
+	path := e.objectPath(fmt.Sprintf("%s/%s_%012d_%012d%s", table.Name, table.Name, from, to, e.config.Format.Extension()))
+	uploadCtx, cancel := context.WithCancel(ctx)
+	writer := storage.NewWriter(uploadCtx, e.storageClient, e.config.Bucket, path)
+	closed := false
+	defer func() {
+		cancel()
+		if !closed {
+			// the upload is cancelled before the writer is closed, which discards the object
+			_ = writer.Close()
+		}
+	}()
+	checksum := sha256.New()
+	encoder, err := NewEncoder(e.config.Format, io.MultiWriter(writer, checksum), table)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	for rows.Next() {
+		row := reflect.New(table.Schema.ModelType)
+		if err := e.dbClient.ScanRows(rows, row.Interface()); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := encoder.Encode(row); err != nil {
+			return nil, fmt.Errorf("failed to encode row: %w", err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close encoder: %w", err)
+	}
+
+	if count == 0 {
+		return nil, nil
+	}
+
+	closed = true
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to upload file %s: %w", path, err)
+	}
+
+	return &FileManifest{
+		Path:       path,
+		FromHeight: from,
+		ToHeight:   to,
+		Rows:       count,
+		SHA256:     hex.EncodeToString(checksum.Sum(nil)),
+	}, nil
+}
+
+func (e *Exporter) objectPath(name string) string {
+	if e.config.Prefix == "" {
+		return name
+	}
+	return fmt.Sprintf("%s/%s", e.config.Prefix, name)
+}
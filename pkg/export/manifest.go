@@ -0,0 +1,93 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+const ManifestFileName = "manifest.json"
+
+// Manifest describes an export and doubles as its checkpoint, it is rewritten after every uploaded file.
+type Manifest struct {
+	Format     Format                    `json:"format"`
+	FromHeight int64                     `json:"from_height"`
+	ToHeight   int64                     `json:"to_height"`
+	ChunkSize  int64                     `json:"chunk_size"`
+	Tables     map[string]*TableManifest `json:"tables"`
+}
+
+type TableManifest struct {
+	// Checkpoint is the last height fully exported for the table
+	Checkpoint int64          `json:"checkpoint"`
+	Files      []FileManifest `json:"files"`
+}
+
+type FileManifest struct {
+	Path       string `json:"path"`
+	FromHeight int64  `json:"from_height"`
+	ToHeight   int64  `json:"to_height"`
+	Rows       int64  `json:"rows"`
+	SHA256     string `json:"sha256"`
+}
+
+func newManifest(config *Config) *Manifest {
+	return &Manifest{
+		Format:     config.Format,
+		FromHeight: config.FromHeight,
+		ToHeight:   config.ToHeight,
+		ChunkSize:  config.ChunkSize,
+		Tables:     make(map[string]*TableManifest),
+	}
+}
+
+func parseManifest(data []byte) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+	if manifest.Tables == nil {
+		manifest.Tables = make(map[string]*TableManifest)
+	}
+	return &manifest, nil
+}
+
+// checkCompatible makes sure an existing manifest can be resumed with the given config.
+func (m *Manifest) checkCompatible(config *Config) error {
+	if m.Format != config.Format || m.FromHeight != config.FromHeight || m.ToHeight != config.ToHeight || m.ChunkSize != config.ChunkSize {
+		return fmt.Errorf("existing manifest (format %s, heights %d-%d, chunk size %d) does not match the export config, use another prefix",
+			m.Format, m.FromHeight, m.ToHeight, m.ChunkSize)
+	}
+	return nil
+}
+
+// table returns the manifest of the table, starting it right before FromHeight if it does not exist yet.
+func (m *Manifest) table(name string) *TableManifest {
+	tableManifest, ok := m.Tables[name]
+	if !ok {
+		tableManifest = &TableManifest{Checkpoint: m.FromHeight - 1, Files: []FileManifest{}}
+		m.Tables[name] = tableManifest
+	}
+	return tableManifest
+}
+
+// Checksum returns the hex encoded SHA-256 of an uploaded file, as recorded in manifests.
+func Checksum(data []byte) string {
+	checksum := sha256.Sum256(data)
+	return hex.EncodeToString(checksum[:])
+}
+
+// VerifyFile reads back an uploaded file and checks it against its manifest entry.
+func VerifyFile(storageClient storage.Client, bucket string, file FileManifest) error {
+	data, err := storageClient.ReadFile(bucket, file.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file.Path, err)
+	}
+	if checksum := Checksum(data); checksum != file.SHA256 {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", file.Path, file.SHA256, checksum)
+	}
+	return nil
+}
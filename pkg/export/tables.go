@@ -0,0 +1,134 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/initia-labs/core-indexer/pkg/db"
+)
+
+// Table is an exportable table whose rows can be selected by block height.
+type Table struct {
+	Name   string
+	Schema *schema.Schema
+
+	heightFilter func(query *gorm.DB, from, to int64) *gorm.DB
+	order        []string
+}
+
+// Columns returns the fields of the model mapped to a database column, in model order.
+func (t *Table) Columns() []*schema.Field {
+	columns := make([]*schema.Field, 0, len(t.Schema.Fields))
+	for _, field := range t.Schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		columns = append(columns, field)
+	}
+	return columns
+}
+
+// Query selects the rows of the table indexed between from and to, both inclusive.
+func (t *Table) Query(dbClient *gorm.DB, from, to int64) *gorm.DB {
+	query := t.heightFilter(dbClient.Table(t.Name), from, to)
+	for _, column := range t.order {
+		query = query.Order(column)
+	}
+	return query
+}
+
+var (
+	tablesOnce sync.Once
+	tables     map[string]*Table
+	tablesErr  error
+)
+
+// Tables returns every exportable table keyed by name. A table is exportable when its model in pkg/db
+// has a height or block_height column, or references a transaction it can be joined with.
+func Tables() (map[string]*Table, error) {
+	tablesOnce.Do(func() {
+		tables = make(map[string]*Table)
+		cache := &sync.Map{}
+		for _, model := range db.AllModels {
+			s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+			if err != nil {
+				tablesErr = fmt.Errorf("failed to parse schema of %T: %w", model, err)
+				return
+			}
+
+			table := &Table{Name: s.Table, Schema: s}
+			switch {
+			case s.Table == db.TableNameBlock:
+				table.heightFilter = heightColumnFilter("height")
+				table.order = []string{"height"}
+			case s.LookUpField("block_height") != nil:
+				table.heightFilter = heightColumnFilter("block_height")
+				table.order = append([]string{"block_height"}, s.PrimaryFieldDBNames...)
+			case s.LookUpField("transaction_id") != nil:
+				table.heightFilter = transactionHeightFilter
+				table.order = []string{"transaction_id"}
+			default:
+				continue
+			}
+			tables[table.Name] = table
+		}
+	})
+
+	return tables, tablesErr
+}
+
+// TableNames returns the names of every exportable table in alphabetical order.
+func TableNames() ([]string, error) {
+	all, err := Tables()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LookupTables resolves table names, returning every exportable table if names is empty.
+func LookupTables(names []string) ([]*Table, error) {
+	all, err := Tables()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		if names, err = TableNames(); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*Table, 0, len(names))
+	for _, name := range names {
+		table, ok := all[name]
+		if !ok {
+			return nil, fmt.Errorf("table %s cannot be exported", name)
+		}
+		result = append(result, table)
+	}
+	return result, nil
+}
+
+func heightColumnFilter(column string) func(query *gorm.DB, from, to int64) *gorm.DB {
+	return func(query *gorm.DB, from, to int64) *gorm.DB {
+		return query.Where(fmt.Sprintf("%s BETWEEN ? AND ?", column), from, to)
+	}
+}
+
+func transactionHeightFilter(query *gorm.DB, from, to int64) *gorm.DB {
+	return query.Where(
+		fmt.Sprintf("transaction_id IN (SELECT id FROM %s WHERE block_height BETWEEN ? AND ?)", db.TableNameTransaction),
+		from, to,
+	)
+}
@@ -0,0 +1,291 @@
+package mq
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// HeaderEncoding names the encoding of the value of a block results message, or of its claim check object, JSON
+	// when the header is missing
+	HeaderEncoding = "encoding"
+
+	EncodingJSON = "json"
+	// EncodingProtoZstd is the protobuf wire encoding of the fields of the message, compressed with zstd
+	EncodingProtoZstd = "proto+zstd"
+
+	// BlockResultMsgVersion is the version of the BlockResultMsg schema, messages of any other version are rejected
+	BlockResultMsgVersion int64 = 0
+)
+
+var (
+	ErrUnsupportedEncoding = errors.New("mq: unsupported block results encoding")
+	ErrUnsupportedVersion  = errors.New("mq: unsupported block results version")
+)
+
+// field numbers of the protobuf encoding of BlockResultMsg
+const (
+	blockFieldVersion protowire.Number = iota + 1
+	blockFieldHash
+	blockFieldHeight
+	blockFieldTimestampSeconds
+	blockFieldTimestampNanos
+	blockFieldTx
+	blockFieldFinalizeBlockEvent
+	blockFieldLastCommit
+	blockFieldProposerConsensusAddress
+	blockFieldMisbehavior
+)
+
+// field numbers of the protobuf encoding of TxResult
+const (
+	txFieldHash protowire.Number = iota + 1
+	txFieldExecTxResults
+	txFieldTx
+)
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// MessageEncoding returns the encoding named by the header of a message
+func MessageEncoding(message *Message) string {
+	encoding, ok := message.Header(HeaderEncoding)
+	if !ok {
+		return EncodingJSON
+	}
+	return string(encoding)
+}
+
+// EncodeBlockResultMsg encodes a block results message, its encoding is to be set in the HeaderEncoding header
+func EncodeBlockResultMsg(msg *BlockResultMsg, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", EncodingJSON:
+		return json.Marshal(msg)
+	case EncodingProtoZstd:
+		bz, err := marshalBlockResultMsg(msg)
+		if err != nil {
+			return nil, err
+		}
+		return zstdEncoder.EncodeAll(bz, nil), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedEncoding, encoding)
+	}
+}
+
+// DecodeBlockResultMsg decodes a block results message, rejecting the encodings and versions it does not know
+func DecodeBlockResultMsg(encoding string, data []byte) (BlockResultMsg, error) {
+	var msg BlockResultMsg
+	switch encoding {
+	case "", EncodingJSON:
+		if err := json.Unmarshal(data, &msg); err != nil {
+			// a message of another version may not fit the schema, it is rejected for its version
+			var envelope struct {
+				Version int64 `json:"version"`
+			}
+			if json.Unmarshal(data, &envelope) == nil && envelope.Version != BlockResultMsgVersion {
+				return BlockResultMsg{}, fmt.Errorf("%w: %d", ErrUnsupportedVersion, envelope.Version)
+			}
+			return BlockResultMsg{}, err
+		}
+	case EncodingProtoZstd:
+		bz, err := zstdDecoder.DecodeAll(data, nil)
+		if err != nil {
+			return BlockResultMsg{}, err
+		}
+		if msg, err = unmarshalBlockResultMsg(bz); err != nil {
+			return BlockResultMsg{}, err
+		}
+	default:
+		return BlockResultMsg{}, fmt.Errorf("%w: %q", ErrUnsupportedEncoding, encoding)
+	}
+
+	if msg.Version != BlockResultMsgVersion {
+		return BlockResultMsg{}, fmt.Errorf("%w: %d", ErrUnsupportedVersion, msg.Version)
+	}
+	return msg, nil
+}
+
+func marshalBlockResultMsg(msg *BlockResultMsg) ([]byte, error) {
+	var b []byte
+	b = protowire.AppendTag(b, blockFieldVersion, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(msg.Version))
+	b = appendString(b, blockFieldHash, msg.Hash)
+	b = protowire.AppendTag(b, blockFieldHeight, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(msg.Height))
+	b = protowire.AppendTag(b, blockFieldTimestampSeconds, protowire.VarintType)
+	b = protowire.AppendVarint(b, protowire.EncodeZigZag(msg.Timestamp.Unix()))
+	b = protowire.AppendTag(b, blockFieldTimestampNanos, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(msg.Timestamp.Nanosecond()))
+
+	for _, tx := range msg.Txs {
+		var txBytes []byte
+		txBytes = appendString(txBytes, txFieldHash, tx.Hash)
+		if tx.ExecTxResults != nil {
+			bz, err := tx.ExecTxResults.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			txBytes = appendBytes(txBytes, txFieldExecTxResults, bz)
+		}
+		txBytes = appendBytes(txBytes, txFieldTx, tx.Tx)
+		b = appendBytes(b, blockFieldTx, txBytes)
+	}
+
+	for _, event := range msg.FinalizeBlockEvents {
+		bz, err := event.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = appendBytes(b, blockFieldFinalizeBlockEvent, bz)
+	}
+
+	if msg.LastCommit != nil {
+		bz, err := msg.LastCommit.ToProto().Marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = appendBytes(b, blockFieldLastCommit, bz)
+	}
+
+	b = appendString(b, blockFieldProposerConsensusAddress, msg.ProposerConsensusAddress)
+
+	for _, misbehavior := range msg.Misbehaviors {
+		bz, err := misbehavior.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = appendBytes(b, blockFieldMisbehavior, bz)
+	}
+	return b, nil
+}
+
+func unmarshalBlockResultMsg(b []byte) (BlockResultMsg, error) {
+	var msg BlockResultMsg
+	var seconds, nanos int64
+	err := consumeFields(b, func(number protowire.Number, value uint64, bz []byte) error {
+		switch number {
+		case blockFieldVersion:
+			// the version comes first, the fields of another version are not parsed
+			msg.Version = int64(value)
+			if msg.Version != BlockResultMsgVersion {
+				return fmt.Errorf("%w: %d", ErrUnsupportedVersion, msg.Version)
+			}
+		case blockFieldHash:
+			msg.Hash = string(bz)
+		case blockFieldHeight:
+			msg.Height = int64(value)
+		case blockFieldTimestampSeconds:
+			seconds = protowire.DecodeZigZag(value)
+		case blockFieldTimestampNanos:
+			nanos = int64(value)
+		case blockFieldTx:
+			tx, err := unmarshalTxResult(bz)
+			if err != nil {
+				return err
+			}
+			msg.Txs = append(msg.Txs, tx)
+		case blockFieldFinalizeBlockEvent:
+			var event abci.Event
+			if err := event.Unmarshal(bz); err != nil {
+				return err
+			}
+			msg.FinalizeBlockEvents = append(msg.FinalizeBlockEvents, event)
+		case blockFieldLastCommit:
+			var commit cmtproto.Commit
+			if err := commit.Unmarshal(bz); err != nil {
+				return err
+			}
+			lastCommit, err := types.CommitFromProto(&commit)
+			if err != nil {
+				return err
+			}
+			msg.LastCommit = lastCommit
+		case blockFieldProposerConsensusAddress:
+			msg.ProposerConsensusAddress = string(bz)
+		case blockFieldMisbehavior:
+			var misbehavior abci.Misbehavior
+			if err := misbehavior.Unmarshal(bz); err != nil {
+				return err
+			}
+			msg.Misbehaviors = append(msg.Misbehaviors, misbehavior)
+		}
+		return nil
+	})
+	if err != nil {
+		return BlockResultMsg{}, err
+	}
+
+	msg.Timestamp = time.Unix(seconds, nanos).UTC()
+	return msg, nil
+}
+
+func unmarshalTxResult(b []byte) (TxResult, error) {
+	var tx TxResult
+	err := consumeFields(b, func(number protowire.Number, _ uint64, bz []byte) error {
+		switch number {
+		case txFieldHash:
+			tx.Hash = string(bz)
+		case txFieldExecTxResults:
+			tx.ExecTxResults = &abci.ExecTxResult{}
+			return tx.ExecTxResults.Unmarshal(bz)
+		case txFieldTx:
+			tx.Tx = bz
+		}
+		return nil
+	})
+	return tx, err
+}
+
+// consumeFields calls fn with the value of each varint field, or the content of each length delimited field, skipping
+// the fields of other types
+func consumeFields(b []byte, fn func(number protowire.Number, value uint64, bz []byte) error) error {
+	for len(b) > 0 {
+		number, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("mq: invalid block results: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		var value uint64
+		var bz []byte
+		switch typ {
+		case protowire.VarintType:
+			value, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			bz, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(number, typ, b)
+		}
+		if n < 0 {
+			return fmt.Errorf("mq: invalid block results field %d: %w", number, protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		if typ == protowire.VarintType || typ == protowire.BytesType {
+			if err := fn(number, value, bz); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func appendString(b []byte, number protowire.Number, value string) []byte {
+	b = protowire.AppendTag(b, number, protowire.BytesType)
+	return protowire.AppendString(b, value)
+}
+
+func appendBytes(b []byte, number protowire.Number, value []byte) []byte {
+	b = protowire.AppendTag(b, number, protowire.BytesType)
+	return protowire.AppendBytes(b, value)
+}
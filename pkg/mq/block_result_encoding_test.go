@@ -0,0 +1,91 @@
+package mq
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBlockResultMsg() *BlockResultMsg {
+	events := []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "amount", Value: "100uinit", Index: true}}}}
+	timestamp := time.Date(2026, 10, 19, 12, 0, 0, 123456789, time.UTC)
+	return &BlockResultMsg{
+		Hash:      "0A0B",
+		Height:    100,
+		Timestamp: timestamp,
+		Txs: []TxResult{
+			{Hash: "AA", ExecTxResults: &abci.ExecTxResult{Code: 0, Data: bytes.Repeat([]byte{1}, 64), GasUsed: 100, Events: events}, Tx: types.Tx("tx")},
+			{Hash: "BB", Tx: types.Tx("failed")},
+		},
+		FinalizeBlockEvents: events,
+		LastCommit: &types.Commit{
+			Height:  99,
+			BlockID: types.BlockID{Hash: bytes.Repeat([]byte{2}, 32)},
+			Signatures: []types.CommitSig{{
+				BlockIDFlag:      types.BlockIDFlagCommit,
+				ValidatorAddress: bytes.Repeat([]byte{3}, 20),
+				Timestamp:        timestamp,
+				Signature:        bytes.Repeat([]byte{4}, 64),
+			}},
+		},
+		ProposerConsensusAddress: "initvalcons1",
+		Misbehaviors:             []abci.Misbehavior{{Type: abci.MisbehaviorType_DUPLICATE_VOTE, Height: 98, Time: timestamp, TotalVotingPower: 10}},
+		Version:                  BlockResultMsgVersion,
+	}
+}
+
+func TestBlockResultMsgEncoding(t *testing.T) {
+	msg := testBlockResultMsg()
+	expected, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	for _, encoding := range []string{EncodingJSON, EncodingProtoZstd} {
+		t.Run(encoding, func(t *testing.T) {
+			data, err := EncodeBlockResultMsg(msg, encoding)
+			require.NoError(t, err)
+			decoded, err := DecodeBlockResultMsg(encoding, data)
+			require.NoError(t, err)
+
+			actual, err := json.Marshal(decoded)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+
+	compact, err := EncodeBlockResultMsg(msg, EncodingProtoZstd)
+	require.NoError(t, err)
+	assert.Less(t, len(compact), len(expected))
+
+	// a message without encoding header is JSON
+	assert.Equal(t, EncodingJSON, MessageEncoding(&Message{}))
+	assert.Equal(t, EncodingProtoZstd, MessageEncoding(&Message{Headers: []Header{{Key: HeaderEncoding, Value: []byte(EncodingProtoZstd)}}}))
+}
+
+func TestBlockResultMsgRejected(t *testing.T) {
+	msg := testBlockResultMsg()
+	msg.Version = BlockResultMsgVersion + 1
+	for _, encoding := range []string{EncodingJSON, EncodingProtoZstd} {
+		data, err := EncodeBlockResultMsg(msg, encoding)
+		require.NoError(t, err)
+		_, err = DecodeBlockResultMsg(encoding, data)
+		assert.ErrorIs(t, err, ErrUnsupportedVersion, encoding)
+	}
+
+	// a message of another version not fitting the schema is rejected for its version
+	_, err := DecodeBlockResultMsg(EncodingJSON, []byte(`{"version":2,"height":"100"}`))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = DecodeBlockResultMsg("cbor", nil)
+	assert.ErrorIs(t, err, ErrUnsupportedEncoding)
+	_, err = EncodeBlockResultMsg(msg, "cbor")
+	assert.ErrorIs(t, err, ErrUnsupportedEncoding)
+
+	_, err = DecodeBlockResultMsg(EncodingProtoZstd, []byte("not zstd"))
+	assert.Error(t, err)
+}
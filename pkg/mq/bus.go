@@ -0,0 +1,105 @@
+package mq
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	BackendKafka  = "kafka"
+	BackendMemory = "memory"
+	BackendFile   = "file"
+)
+
+// Config selects and configures the message bus of a service
+type Config struct {
+	// Backend is one of kafka, memory and file, kafka when empty
+	Backend string
+	// Dir is the directory of the file backend
+	Dir string
+	// Memory is the bus of the memory backend, the bus shared by the process when nil
+	Memory *MemoryBus
+	Kafka  KafkaConfig
+}
+
+func (c Config) memoryBus() *MemoryBus {
+	if c.Memory != nil {
+		return c.Memory
+	}
+	return DefaultMemoryBus()
+}
+
+// CheckStandalone rejects the memory backend for a service running in its own process, where no other service
+// reads or produces to its bus. The services sharing a memory bus are run together by the pipeline command.
+func CheckStandalone(backend string) error {
+	if backend == BackendMemory {
+		return fmt.Errorf("the %s message bus backend only connects the services of a single process, run them with the pipeline command or use the %s backend", backend, BackendFile)
+	}
+	return nil
+}
+
+func NewProducer(config Config, logger *zerolog.Logger) (Producer, error) {
+	switch config.Backend {
+	case "", BackendKafka:
+		producer, err := NewKafkaProducer(config.Kafka, logger)
+		if err != nil {
+			return nil, err
+		}
+		return producer, nil
+	case BackendMemory:
+		return config.memoryBus().Producer(), nil
+	case BackendFile:
+		bus, err := OpenFileBus(config.Dir)
+		if err != nil {
+			return nil, err
+		}
+		return bus.Producer(), nil
+	default:
+		return nil, fmt.Errorf("unknown message bus backend %q", config.Backend)
+	}
+}
+
+// NewAdmin creates an admin inspecting the consumer groups of the bus
+func NewAdmin(config Config) (Admin, error) {
+	switch config.Backend {
+	case "", BackendKafka:
+		admin, err := NewKafkaAdmin(config.Kafka)
+		if err != nil {
+			return nil, err
+		}
+		return admin, nil
+	case BackendMemory:
+		return config.memoryBus().Admin(), nil
+	case BackendFile:
+		bus, err := OpenFileBus(config.Dir)
+		if err != nil {
+			return nil, err
+		}
+		return bus.Admin(), nil
+	default:
+		return nil, fmt.Errorf("unknown message bus backend %q", config.Backend)
+	}
+}
+
+// NewConsumer creates a consumer member of the consumer group
+func NewConsumer(config Config, group string) (Consumer, error) {
+	switch config.Backend {
+	case "", BackendKafka:
+		consumer, err := NewKafkaConsumer(config.Kafka, group)
+		if err != nil {
+			return nil, err
+		}
+		return consumer, nil
+	case BackendMemory:
+		return config.memoryBus().Consumer(group), nil
+	case BackendFile:
+		bus, err := OpenFileBus(config.Dir)
+		if err != nil {
+			return nil, err
+		}
+		return bus.Consumer(group), nil
+	default:
+		return nil, fmt.Errorf("unknown message bus backend %q", config.Backend)
+	}
+}
@@ -0,0 +1,176 @@
+package mq
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBus(t *testing.T, config Config) {
+	logger := zerolog.Nop()
+	producer, err := NewProducer(config, &logger)
+	require.NoError(t, err)
+	defer producer.Close()
+
+	consumer, err := NewConsumer(config, "indexer")
+	require.NoError(t, err)
+	require.NoError(t, consumer.SubscribeTopics([]string{"blocks", "txs"}))
+
+	_, err = consumer.ReadMessage(10 * time.Millisecond)
+	assert.ErrorIs(t, err, ErrTimeout)
+
+	for height := 1; height <= 3; height++ {
+		require.NoError(t, producer.Produce(&Message{
+			Topic:   "blocks",
+			Key:     fmt.Appendf(nil, "block_%d", height),
+			Value:   fmt.Appendf(nil, `{"height":%d}`, height),
+			Headers: []Header{{Key: "height", Value: fmt.Append(nil, height)}},
+		}))
+	}
+	require.NoError(t, producer.Produce(&Message{Topic: "txs", Key: []byte("tx"), Value: []byte("{}")}))
+	assert.Zero(t, producer.Flush(time.Second))
+
+	read := make(map[string][]string)
+	var first, second *Message
+	for range 4 {
+		message, err := consumer.ReadMessage(time.Second)
+		require.NoError(t, err)
+		read[message.Topic] = append(read[message.Topic], string(message.Key))
+		if string(message.Key) == "block_1" {
+			first = message
+		}
+		if string(message.Key) == "block_2" {
+			second = message
+			height, ok := message.Header("height")
+			assert.True(t, ok)
+			assert.Equal(t, "2", string(height))
+		}
+	}
+	assert.Equal(t, map[string][]string{"blocks": {"block_1", "block_2", "block_3"}, "txs": {"tx"}}, read)
+
+	// a message produced while waiting is read as soon as it is produced
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = producer.Produce(&Message{Topic: "blocks", Key: []byte("block_4")})
+	}()
+	message, err := consumer.ReadMessage(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "block_4", string(message.Key))
+
+	// a new member of the group resumes after the committed messages
+	require.NoError(t, consumer.CommitMessage(second))
+	require.NoError(t, consumer.Close())
+	_, err = consumer.ReadMessage(time.Millisecond)
+	assert.ErrorIs(t, err, ErrClosed)
+
+	consumer, err = NewConsumer(config, "indexer")
+	require.NoError(t, err)
+	require.NoError(t, consumer.SubscribeTopics([]string{"blocks"}))
+	message, err = consumer.ReadMessage(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "block_3", string(message.Key))
+
+	// another group reads the topic from its start
+	other, err := NewConsumer(config, "uploader")
+	require.NoError(t, err)
+	require.NoError(t, other.SubscribeTopics([]string{"blocks"}))
+	message, err = other.ReadMessage(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "block_1", string(message.Key))
+
+	// offsets kept by the consumer take precedence over the offsets committed by the group
+	loaded, err := NewConsumer(config, "indexer")
+	require.NoError(t, err)
+	require.NoError(t, loaded.SubscribeTopicsFrom([]string{"blocks", "txs"}, func(topic string, partition int32) (int64, bool, error) {
+		assert.Zero(t, partition)
+		return first.Offset, topic == "blocks", nil
+	}))
+	message, err = loaded.ReadMessage(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "block_2", string(message.Key))
+	message, err = loaded.ReadMessage(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "tx", string(message.Key))
+
+	failing, err := NewConsumer(config, "indexer")
+	require.NoError(t, err)
+	err = failing.SubscribeTopicsFrom([]string{"blocks"}, func(string, int32) (int64, bool, error) {
+		return 0, false, assert.AnError
+	})
+	assert.ErrorIs(t, err, ErrOffsetsNotLoaded)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMemoryBus(t *testing.T) {
+	testBus(t, Config{Backend: BackendMemory, Memory: NewMemoryBus()})
+}
+
+func TestMemoryBusClose(t *testing.T) {
+	bus := NewMemoryBus()
+	consumer := bus.Consumer("indexer")
+	require.NoError(t, consumer.SubscribeTopics([]string{"blocks"}))
+
+	// closing the consumer wakes up a read waiting for a message
+	read := make(chan error)
+	go func() {
+		_, err := consumer.ReadMessage(time.Minute)
+		read <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, consumer.Close())
+	select {
+	case err := <-read:
+		assert.ErrorIs(t, err, ErrClosed)
+	case <-time.After(time.Second):
+		t.Fatal("read not stopped by close")
+	}
+
+	producer := bus.Producer()
+	producer.Close()
+	assert.ErrorIs(t, producer.Produce(&Message{Topic: "blocks"}), ErrClosed)
+}
+
+func TestCheckStandalone(t *testing.T) {
+	assert.Error(t, CheckStandalone(BackendMemory))
+	for _, backend := range []string{"", BackendKafka, BackendFile} {
+		assert.NoError(t, CheckStandalone(backend))
+	}
+}
+
+func TestFileBus(t *testing.T) {
+	testBus(t, Config{Backend: BackendFile, Dir: t.TempDir()})
+}
+
+func TestFileBusPartialMessage(t *testing.T) {
+	bus, err := OpenFileBus(t.TempDir())
+	require.NoError(t, err)
+	consumer := bus.Consumer("indexer")
+	require.NoError(t, consumer.SubscribeTopics([]string{"blocks"}))
+
+	// a message being written is not read until its line is complete
+	file, err := os.OpenFile(bus.topicPath("blocks"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.WriteString(`{"key":"YmxvY2tfMQ==","va`)
+	require.NoError(t, err)
+	_, err = consumer.ReadMessage(10 * time.Millisecond)
+	assert.ErrorIs(t, err, ErrTimeout)
+
+	_, err = file.WriteString(`lue":"e30="}` + "\n")
+	require.NoError(t, err)
+	message, err := consumer.ReadMessage(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "block_1", string(message.Key))
+	assert.Equal(t, "{}", string(message.Value))
+	assert.Zero(t, message.Offset)
+}
+
+func TestUnknownBackend(t *testing.T) {
+	_, err := NewConsumer(Config{Backend: "redis"}, "indexer")
+	assert.Error(t, err)
+}
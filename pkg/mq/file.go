@@ -0,0 +1,319 @@
+package mq
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// filePollInterval is the time a file consumer waits before looking for new messages again
+const filePollInterval = 50 * time.Millisecond
+
+// FileBus is a message bus backed by a directory, so that services running in separate processes on the same host can
+// exchange messages without a broker. Each topic is an append-only file holding a JSON message per line, the offset of
+// a message being its position in the file, and the offsets committed by the consumer groups are kept next to them.
+type FileBus struct {
+	dir string
+}
+
+type fileRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+	Key       []byte    `json:"key"`
+	Value     []byte    `json:"value"`
+	Headers   []Header  `json:"headers,omitempty"`
+}
+
+func OpenFileBus(dir string) (*FileBus, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "offsets"), 0o755); err != nil {
+		return nil, err
+	}
+	return &FileBus{dir: dir}, nil
+}
+
+func (b *FileBus) Producer() Producer {
+	return &fileProducer{bus: b, files: make(map[string]*os.File)}
+}
+
+func (b *FileBus) Consumer(group string) Consumer {
+	return &fileConsumer{bus: b, group: group}
+}
+
+func (b *FileBus) Admin() Admin {
+	return &fileAdmin{bus: b}
+}
+
+func (b *FileBus) topicPath(topic string) string {
+	return filepath.Join(b.dir, topic+".log")
+}
+
+func (b *FileBus) offsetPath(group, topic string) string {
+	return filepath.Join(b.dir, "offsets", group+"."+topic)
+}
+
+type fileProducer struct {
+	bus    *FileBus
+	mu     sync.Mutex
+	files  map[string]*os.File
+	closed bool
+}
+
+// Produce appends the message to the file of its topic in a single write, so that the consumers never see a message
+// partially written by another producer
+func (p *fileProducer) Produce(message *Message) error {
+	line, err := json.Marshal(fileRecord{Timestamp: time.Now(), Key: message.Key, Value: message.Value, Headers: message.Headers})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrClosed
+	}
+
+	file, ok := p.files[message.Topic]
+	if !ok {
+		file, err = os.OpenFile(p.bus.topicPath(message.Topic), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		p.files[message.Topic] = file
+	}
+	_, err = file.Write(line)
+	return err
+}
+
+// Flush returns right away, the messages are written as they are produced
+func (p *fileProducer) Flush(time.Duration) int {
+	return 0
+}
+
+func (p *fileProducer) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, file := range p.files {
+		file.Close()
+	}
+	p.closed = true
+}
+
+type fileTopic struct {
+	name   string
+	file   *os.File
+	reader *bufio.Reader
+	// position is the offset of the next message
+	position int64
+	// pending is the offset following each message read and not committed yet
+	pending map[int64]int64
+}
+
+type fileConsumer struct {
+	bus    *FileBus
+	group  string
+	topics []*fileTopic
+	next   int
+	closed bool
+}
+
+// SubscribeTopics starts reading the topics from the offsets committed by the group
+func (c *fileConsumer) SubscribeTopics(topics []string) error {
+	return c.SubscribeTopicsFrom(topics, nil)
+}
+
+func (c *fileConsumer) SubscribeTopicsFrom(topics []string, offsets OffsetLoader) error {
+	c.closeFiles()
+	c.topics = make([]*fileTopic, 0, len(topics))
+	for _, topic := range topics {
+		position, err := c.position(topic, offsets)
+		if err != nil {
+			return err
+		}
+		c.topics = append(c.topics, &fileTopic{name: topic, position: position, pending: make(map[int64]int64)})
+	}
+	return nil
+}
+
+// position returns the offset following the last message processed according to the loader, or the offset committed
+// by the group
+func (c *fileConsumer) position(topic string, offsets OffsetLoader) (int64, error) {
+	if offsets == nil {
+		return c.committed(topic)
+	}
+	offset, ok, err := offsets(topic, 0)
+	if err != nil {
+		return 0, fmt.Errorf("%w of topic %s: %w", ErrOffsetsNotLoaded, topic, err)
+	}
+	if !ok {
+		return c.committed(topic)
+	}
+
+	// the offset of a message being its position in the file, the next one follows its line
+	file, err := os.Open(c.bus.topicPath(topic))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	line, err := bufio.NewReader(file).ReadBytes('\n')
+	if err != nil {
+		return 0, fmt.Errorf("mq: no message at offset %d of topic %s: %w", offset, topic, err)
+	}
+	return offset + int64(len(line)), nil
+}
+
+func (c *fileConsumer) committed(topic string) (int64, error) {
+	data, err := os.ReadFile(c.bus.offsetPath(c.group, topic))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(bytes.TrimSpace(data)), 10, 64)
+}
+
+func (c *fileConsumer) ReadMessage(timeout time.Duration) (*Message, error) {
+	if c.closed {
+		return nil, ErrClosed
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		for range c.topics {
+			topic := c.topics[c.next%len(c.topics)]
+			c.next++
+			message, err := c.read(topic)
+			if err != nil {
+				return nil, err
+			}
+			if message != nil {
+				return message, nil
+			}
+		}
+
+		if !time.Now().Before(deadline) {
+			return nil, ErrTimeout
+		}
+		time.Sleep(min(filePollInterval, time.Until(deadline)))
+	}
+}
+
+// read returns the next message of the topic, or nil when none was written completely yet
+func (c *fileConsumer) read(topic *fileTopic) (*Message, error) {
+	if topic.file == nil {
+		file, err := os.Open(c.bus.topicPath(topic.name))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err := file.Seek(topic.position, io.SeekStart); err != nil {
+			file.Close()
+			return nil, err
+		}
+		topic.file, topic.reader = file, bufio.NewReader(file)
+	}
+
+	line, err := topic.reader.ReadBytes('\n')
+	if errors.Is(err, io.EOF) {
+		// the last line is being written, it is read again from its start on the next poll
+		if len(line) > 0 {
+			if _, err := topic.file.Seek(topic.position, io.SeekStart); err != nil {
+				return nil, err
+			}
+			topic.reader.Reset(topic.file)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record fileRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return nil, fmt.Errorf("mq: invalid message at offset %d of topic %s: %w", topic.position, topic.name, err)
+	}
+
+	offset := topic.position
+	topic.position += int64(len(line))
+	topic.pending[offset] = topic.position
+	return &Message{
+		Topic:     topic.name,
+		Offset:    offset,
+		Timestamp: record.Timestamp,
+		Key:       record.Key,
+		Value:     record.Value,
+		Headers:   record.Headers,
+	}, nil
+}
+
+// CommitMessage stores the offset following the message, replacing the offset file so that it is never partially
+// written
+func (c *fileConsumer) CommitMessage(message *Message) error {
+	for _, topic := range c.topics {
+		if topic.name != message.Topic {
+			continue
+		}
+		next, ok := topic.pending[message.Offset]
+		if !ok {
+			return fmt.Errorf("mq: message at offset %d of topic %s was not read", message.Offset, message.Topic)
+		}
+		for offset := range topic.pending {
+			if offset <= message.Offset {
+				delete(topic.pending, offset)
+			}
+		}
+
+		path := c.bus.offsetPath(c.group, topic.name)
+		if err := os.WriteFile(path+".tmp", []byte(strconv.FormatInt(next, 10)), 0o644); err != nil {
+			return err
+		}
+		return os.Rename(path+".tmp", path)
+	}
+	return fmt.Errorf("mq: not subscribed to topic %s", message.Topic)
+}
+
+func (c *fileConsumer) Close() error {
+	c.closeFiles()
+	c.closed = true
+	return nil
+}
+
+func (c *fileConsumer) closeFiles() {
+	for _, topic := range c.topics {
+		if topic.file != nil {
+			topic.file.Close()
+		}
+	}
+}
+
+type fileAdmin struct {
+	bus *FileBus
+}
+
+func (a *fileAdmin) CommittedOffsets(group, topic string) (map[int32]int64, error) {
+	offsets := make(map[int32]int64)
+	if _, err := os.Stat(a.bus.offsetPath(group, topic)); errors.Is(err, os.ErrNotExist) {
+		return offsets, nil
+	}
+	offset, err := (&fileConsumer{bus: a.bus, group: group}).committed(topic)
+	if err != nil {
+		return nil, err
+	}
+	offsets[0] = offset
+	return offsets, nil
+}
+
+func (a *fileAdmin) Close() {}
@@ -0,0 +1,243 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/rs/zerolog"
+)
+
+// KafkaConfig configures the connection to a Kafka cluster
+type KafkaConfig struct {
+	BootstrapServer string
+	ClientID        string
+	APIKey          string
+	APISecret       string
+	// Plaintext connects without SASL_SSL, for local clusters
+	Plaintext bool
+}
+
+func (c KafkaConfig) configMap(values kafka.ConfigMap) *kafka.ConfigMap {
+	config := kafka.ConfigMap{
+		"bootstrap.servers": c.BootstrapServer,
+		"client.id":         c.ClientID,
+	}
+	if c.Plaintext {
+		config["security.protocol"] = "PLAINTEXT"
+	} else {
+		config["security.protocol"] = "SASL_SSL"
+		config["sasl.mechanisms"] = "PLAIN"
+		config["sasl.username"] = c.APIKey
+		config["sasl.password"] = c.APISecret
+	}
+	for key, value := range values {
+		config[key] = value
+	}
+	return &config
+}
+
+type KafkaConsumer struct {
+	consumer *kafka.Consumer
+	// err is the error loading the offsets of the partitions assigned, returned by every read from then on
+	err error
+}
+
+func NewKafkaConsumer(config KafkaConfig, group string) (*KafkaConsumer, error) {
+	c, err := kafka.NewConsumer(config.configMap(kafka.ConfigMap{
+		"group.id":             group,
+		"enable.auto.commit":   false,
+		"auto.offset.reset":    "earliest",
+		"max.poll.interval.ms": 600000,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &KafkaConsumer{consumer: c}, nil
+}
+
+func (c *KafkaConsumer) SubscribeTopics(topics []string) error {
+	return c.consumer.SubscribeTopics(topics, nil)
+}
+
+// SubscribeTopicsFrom loads the offsets of the partitions as they are assigned to the consumer. When they fail to load,
+// the partitions are unassigned rather than resumed from the offsets committed by the group, and every read returns the
+// error so that the consumer is restarted.
+func (c *KafkaConsumer) SubscribeTopicsFrom(topics []string, offsets OffsetLoader) error {
+	return c.consumer.SubscribeTopics(topics, func(consumer *kafka.Consumer, event kafka.Event) error {
+		assigned, ok := event.(kafka.AssignedPartitions)
+		if !ok {
+			return nil
+		}
+
+		partitions := make([]kafka.TopicPartition, 0, len(assigned.Partitions))
+		for _, partition := range assigned.Partitions {
+			partition.Offset = kafka.OffsetStored
+			offset, ok, err := offsets(*partition.Topic, partition.Partition)
+			if err != nil {
+				c.err = fmt.Errorf("%w of partition %d of topic %s: %w", ErrOffsetsNotLoaded, partition.Partition, *partition.Topic, err)
+				return consumer.Unassign()
+			}
+			if ok {
+				partition.Offset = kafka.Offset(offset + 1)
+			}
+			partitions = append(partitions, partition)
+		}
+		return consumer.Assign(partitions)
+	})
+}
+
+func (c *KafkaConsumer) ReadMessage(timeout time.Duration) (*Message, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	message, err := c.consumer.ReadMessage(timeout)
+	if err != nil {
+		var kafkaErr kafka.Error
+		if errors.As(err, &kafkaErr) && kafkaErr.IsTimeout() {
+			return nil, ErrTimeout
+		}
+		return nil, err
+	}
+
+	return fromKafkaMessage(message), nil
+}
+
+func (c *KafkaConsumer) CommitMessage(message *Message) error {
+	_, err := c.consumer.CommitOffsets([]kafka.TopicPartition{{
+		Topic:     &message.Topic,
+		Partition: message.Partition,
+		Offset:    kafka.Offset(message.Offset + 1),
+	}})
+	return err
+}
+
+func (c *KafkaConsumer) Close() error {
+	return c.consumer.Close()
+}
+
+type KafkaProducer struct {
+	producer *kafka.Producer
+}
+
+// NewKafkaProducer creates a producer logging the messages failing to be delivered
+func NewKafkaProducer(config KafkaConfig, logger *zerolog.Logger) (*KafkaProducer, error) {
+	p, err := kafka.NewProducer(config.configMap(kafka.ConfigMap{
+		"acks":              "all",
+		"linger.ms":         200,
+		"message.max.bytes": 7340032,
+		"compression.codec": "lz4",
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for e := range p.Events() {
+			switch ev := e.(type) {
+			case *kafka.Message:
+				if ev.TopicPartition.Error != nil {
+					logger.Error().Msgf("Failed to deliver message: %v\n", ev.TopicPartition)
+				}
+			}
+		}
+	}()
+
+	return &KafkaProducer{p}, nil
+}
+
+func (p *KafkaProducer) Produce(message *Message) error {
+	err := p.producer.Produce(toKafkaMessage(message), nil)
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) && kafkaErr.Code() == kafka.ErrQueueFull {
+		return ErrQueueFull
+	}
+	return err
+}
+
+func (p *KafkaProducer) Flush(timeout time.Duration) int {
+	return p.producer.Flush(int(timeout.Milliseconds()))
+}
+
+func (p *KafkaProducer) Close() {
+	p.producer.Close()
+}
+
+type KafkaAdmin struct {
+	admin *kafka.AdminClient
+}
+
+func NewKafkaAdmin(config KafkaConfig) (*KafkaAdmin, error) {
+	a, err := kafka.NewAdminClient(config.configMap(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return &KafkaAdmin{a}, nil
+}
+
+func (a *KafkaAdmin) CommittedOffsets(group, topic string) (map[int32]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := a.admin.ListConsumerGroupOffsets(ctx, []kafka.ConsumerGroupTopicPartitions{{Group: group}})
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make(map[int32]int64)
+	for _, groupPartitions := range result.ConsumerGroupsTopicPartitions {
+		for _, partition := range groupPartitions.Partitions {
+			if partition.Error != nil {
+				return nil, partition.Error
+			}
+			if partition.Topic == nil || *partition.Topic != topic || partition.Offset < 0 {
+				continue
+			}
+			offsets[partition.Partition] = int64(partition.Offset)
+		}
+	}
+	return offsets, nil
+}
+
+func (a *KafkaAdmin) Close() {
+	a.admin.Close()
+}
+
+func toKafkaMessage(message *Message) *kafka.Message {
+	topic := message.Topic
+	headers := make([]kafka.Header, 0, len(message.Headers))
+	for _, header := range message.Headers {
+		headers = append(headers, kafka.Header{Key: header.Key, Value: header.Value})
+	}
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            message.Key,
+		Value:          message.Value,
+		Headers:        headers,
+	}
+}
+
+func fromKafkaMessage(message *kafka.Message) *Message {
+	headers := make([]Header, 0, len(message.Headers))
+	for _, header := range message.Headers {
+		headers = append(headers, Header{Key: header.Key, Value: header.Value})
+	}
+	var topic string
+	if message.TopicPartition.Topic != nil {
+		topic = *message.TopicPartition.Topic
+	}
+	return &Message{
+		Topic:     topic,
+		Partition: message.TopicPartition.Partition,
+		Offset:    int64(message.TopicPartition.Offset),
+		Timestamp: message.Timestamp,
+		Key:       message.Key,
+		Value:     message.Value,
+		Headers:   headers,
+	}
+}
@@ -3,13 +3,11 @@ package mq
 import (
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/cometbft/cometbft/types"
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"github.com/rs/zerolog"
 )
@@ -24,7 +22,7 @@ const (
 )
 
 type KafkaMsg interface {
-	Emit() kafka.Message
+	Emit() Message
 }
 
 type TxResult struct {
@@ -34,16 +32,17 @@ type TxResult struct {
 }
 
 type BlockResultMsg struct {
-	Hash                     string        `json:"hash"`
-	Height                   int64         `json:"height"`
-	Timestamp                time.Time     `json:"timestamp"`
-	Txs                      []TxResult    `json:"txs"`
-	FinalizeBlockEvents      []abci.Event  `json:"finalize_block_events"`
-	LastCommit               *types.Commit `json:"last_commit"`
-	ProposerConsensusAddress string        `json:"proposer_consensus_address"`
+	Hash                     string             `json:"hash"`
+	Height                   int64              `json:"height"`
+	Timestamp                time.Time          `json:"timestamp"`
+	Txs                      []TxResult         `json:"txs"`
+	FinalizeBlockEvents      []abci.Event       `json:"finalize_block_events"`
+	LastCommit               *types.Commit      `json:"last_commit"`
+	ProposerConsensusAddress string             `json:"proposer_consensus_address"`
+	Misbehaviors             []abci.Misbehavior `json:"misbehaviors,omitempty"`
 
 	// version is used to track the version of the message
-	// consumer can use this to track the version of the message and handle the message accordingly
+	// DecodeBlockResultMsg rejects the messages of another version than BlockResultMsgVersion
 	Version int64 `json:"version"`
 }
 
@@ -51,7 +50,8 @@ type ClaimCheckMsg struct {
 	ObjectPath string `json:"object_path"`
 }
 
-func NewBlockResultMsgBytes(block *coretypes.ResultBlock, blockResult *coretypes.ResultBlockResults) ([]byte, error) {
+// NewBlockResultMsg builds the block results message of a block, to be encoded with EncodeBlockResultMsg
+func NewBlockResultMsg(block *coretypes.ResultBlock, blockResult *coretypes.ResultBlockResults) (*BlockResultMsg, error) {
 	consensusAddress, err := bech32.ConvertAndEncode("initvalcons", block.Block.ProposerAddress)
 	if err != nil {
 		logger.Error().Msgf("Failed to convert and encode Bech32: %v\n", err)
@@ -76,15 +76,11 @@ func NewBlockResultMsgBytes(block *coretypes.ResultBlock, blockResult *coretypes
 		FinalizeBlockEvents:      blockResult.FinalizeBlockEvents,
 		LastCommit:               block.Block.LastCommit,
 		ProposerConsensusAddress: consensusAddress,
+		Misbehaviors:             block.Block.Evidence.Evidence.ToABCI(),
 
 		// version is used to track the version of the message
-		Version: 0,
+		Version: BlockResultMsgVersion,
 	}
 
-	v, err := json.Marshal(msg)
-	if err != nil {
-		return nil, err
-	}
-
-	return v, nil
+	return msg, nil
 }
@@ -0,0 +1,219 @@
+package mq
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryBus is an in-process message bus, each topic has a single partition kept in memory. It lets services
+// running in the same process, such as integration tests, exchange messages without a broker.
+type MemoryBus struct {
+	mu     sync.Mutex
+	topics map[string][]*Message
+	// committed is the next offset to read by topic, by consumer group
+	committed map[string]map[string]int64
+	// changed is closed and replaced whenever a message is produced, waking up the consumers
+	changed chan struct{}
+}
+
+var defaultMemoryBus = NewMemoryBus()
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		topics:    make(map[string][]*Message),
+		committed: make(map[string]map[string]int64),
+		changed:   make(chan struct{}),
+	}
+}
+
+// DefaultMemoryBus returns the memory bus shared by the process
+func DefaultMemoryBus() *MemoryBus {
+	return defaultMemoryBus
+}
+
+func (b *MemoryBus) Producer() Producer {
+	return &memoryProducer{bus: b}
+}
+
+func (b *MemoryBus) Consumer(group string) Consumer {
+	return &memoryConsumer{bus: b, group: group}
+}
+
+func (b *MemoryBus) Admin() Admin {
+	return &memoryAdmin{bus: b}
+}
+
+func (b *MemoryBus) produce(producer *memoryProducer, message *Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if producer.closed {
+		return ErrClosed
+	}
+
+	produced := *message
+	produced.Partition = 0
+	produced.Offset = int64(len(b.topics[message.Topic]))
+	produced.Timestamp = time.Now()
+	b.topics[message.Topic] = append(b.topics[message.Topic], &produced)
+
+	b.notify()
+	return nil
+}
+
+// notify wakes up the consumers waiting for a message, it is called with the mutex held
+func (b *MemoryBus) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+type memoryProducer struct {
+	bus *MemoryBus
+	// closed is guarded by the mutex of the bus
+	closed bool
+}
+
+func (p *memoryProducer) Produce(message *Message) error {
+	return p.bus.produce(p, message)
+}
+
+// Flush returns right away, the messages are delivered as they are produced
+func (p *memoryProducer) Flush(time.Duration) int {
+	return 0
+}
+
+func (p *memoryProducer) Close() {
+	p.bus.mu.Lock()
+	defer p.bus.mu.Unlock()
+
+	p.closed = true
+}
+
+type memoryConsumer struct {
+	bus    *MemoryBus
+	group  string
+	topics []string
+	// positions is the next offset to read by topic
+	positions map[string]int64
+	next      int
+	// closed is guarded by the mutex of the bus
+	closed bool
+}
+
+// SubscribeTopics starts reading the topics from the offsets committed by the group
+func (c *memoryConsumer) SubscribeTopics(topics []string) error {
+	return c.SubscribeTopicsFrom(topics, nil)
+}
+
+func (c *memoryConsumer) SubscribeTopicsFrom(topics []string, offsets OffsetLoader) error {
+	positions := make(map[string]int64, len(topics))
+	for _, topic := range topics {
+		if offsets == nil {
+			continue
+		}
+		offset, ok, err := offsets(topic, 0)
+		if err != nil {
+			return fmt.Errorf("%w of topic %s: %w", ErrOffsetsNotLoaded, topic, err)
+		}
+		if ok {
+			positions[topic] = offset + 1
+		}
+	}
+
+	c.bus.mu.Lock()
+	defer c.bus.mu.Unlock()
+
+	for _, topic := range topics {
+		if _, ok := positions[topic]; !ok {
+			positions[topic] = c.bus.committed[c.group][topic]
+		}
+	}
+	c.topics = topics
+	c.positions = positions
+	return nil
+}
+
+func (c *memoryConsumer) ReadMessage(timeout time.Duration) (*Message, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		message, changed, err := c.poll()
+		if err != nil {
+			return nil, err
+		}
+		if message != nil {
+			return message, nil
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return nil, ErrTimeout
+		}
+	}
+}
+
+// poll returns the next message of the subscribed topics, taken in turns, or the channel closed on the next message
+// or once the consumer is closed
+func (c *memoryConsumer) poll() (*Message, <-chan struct{}, error) {
+	c.bus.mu.Lock()
+	defer c.bus.mu.Unlock()
+
+	if c.closed {
+		return nil, nil, ErrClosed
+	}
+	for range c.topics {
+		topic := c.topics[c.next%len(c.topics)]
+		c.next++
+		messages := c.bus.topics[topic]
+		if position := c.positions[topic]; position < int64(len(messages)) {
+			c.positions[topic] = position + 1
+			message := *messages[position]
+			return &message, nil, nil
+		}
+	}
+	return nil, c.bus.changed, nil
+}
+
+func (c *memoryConsumer) CommitMessage(message *Message) error {
+	c.bus.mu.Lock()
+	defer c.bus.mu.Unlock()
+
+	committed, ok := c.bus.committed[c.group]
+	if !ok {
+		committed = make(map[string]int64)
+		c.bus.committed[c.group] = committed
+	}
+	committed[message.Topic] = max(committed[message.Topic], message.Offset+1)
+	return nil
+}
+
+// Close stops the consumer, waking up a ReadMessage waiting for a message
+func (c *memoryConsumer) Close() error {
+	c.bus.mu.Lock()
+	defer c.bus.mu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		c.bus.notify()
+	}
+	return nil
+}
+
+type memoryAdmin struct {
+	bus *MemoryBus
+}
+
+func (a *memoryAdmin) CommittedOffsets(group, topic string) (map[int32]int64, error) {
+	a.bus.mu.Lock()
+	defer a.bus.mu.Unlock()
+
+	offsets := make(map[int32]int64)
+	if offset, ok := a.bus.committed[group][topic]; ok {
+		offsets[0] = offset
+	}
+	return offsets, nil
+}
+
+func (a *memoryAdmin) Close() {}
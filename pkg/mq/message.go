@@ -0,0 +1,80 @@
+package mq
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrTimeout is returned by Consumer.ReadMessage when no message arrived before the timeout
+	ErrTimeout = errors.New("mq: no message before the timeout")
+	// ErrQueueFull is returned by Producer.Produce while the queue of the producer is full
+	ErrQueueFull = errors.New("mq: producer queue is full")
+	// ErrClosed is returned when using a closed producer or consumer
+	ErrClosed = errors.New("mq: closed")
+	// ErrOffsetsNotLoaded is returned by a consumer subscribed with an offset loader failing to load the offsets of its
+	// partitions, which are not read from then on
+	ErrOffsetsNotLoaded = errors.New("mq: failed to load the offsets")
+)
+
+type Header struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Message is a message produced to or read from a topic. Partition and Offset locate a read message in its topic, and
+// Timestamp is the time it was produced.
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Timestamp time.Time
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+}
+
+// Header returns the value of the first header with the key
+func (m *Message) Header(key string) ([]byte, bool) {
+	for _, header := range m.Headers {
+		if header.Key == key {
+			return header.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Producer publishes messages to the topics of a message bus
+type Producer interface {
+	// Produce enqueues a message, delivery failures are logged by the producer
+	Produce(message *Message) error
+	// Flush waits up to timeout for the enqueued messages to be delivered and returns the number of messages left
+	Flush(timeout time.Duration) int
+	Close()
+}
+
+// OffsetLoader returns the offset of the last message processed from a partition, or false when none was, for
+// consumers keeping their offsets along with the data they write
+type OffsetLoader func(topic string, partition int32) (offset int64, ok bool, err error)
+
+// Consumer reads the messages of topics as a member of a consumer group. A message is read again by the group after a
+// restart until it is committed.
+type Consumer interface {
+	SubscribeTopics(topics []string) error
+	// SubscribeTopicsFrom subscribes to the topics and resumes each partition assigned to the consumer after the offset
+	// returned by the loader, falling back to the offset committed by the group when it has none. The partitions are
+	// not read when the loader fails, the error wraps ErrOffsetsNotLoaded.
+	SubscribeTopicsFrom(topics []string, offsets OffsetLoader) error
+	// ReadMessage returns the next message, or ErrTimeout when none arrived before the timeout
+	ReadMessage(timeout time.Duration) (*Message, error)
+	CommitMessage(message *Message) error
+	Close() error
+}
+
+// Admin inspects the progress of the consumer groups
+type Admin interface {
+	// CommittedOffsets returns the offset following the last message committed by the group by partition of the topic,
+	// leaving out the partitions the group has not committed yet
+	CommittedOffsets(group, topic string) (map[int32]int64, error)
+	Close()
+}
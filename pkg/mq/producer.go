@@ -2,51 +2,31 @@ package mq
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/rs/zerolog"
 
 	"github.com/initia-labs/core-indexer/pkg/storage"
 )
 
-type Producer struct {
-	*kafka.Producer
-}
-
-func NewProducer(config *kafka.ConfigMap) (*Producer, error) {
-	p, err := kafka.NewProducer(config)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Producer{p}, nil
-}
-
-func (p *Producer) ListenToKafkaProduceEvents(logger *zerolog.Logger) {
-	go func() {
-		for e := range p.Events() {
-			switch ev := e.(type) {
-			case *kafka.Message:
-				if ev.TopicPartition.Error != nil {
-					logger.Error().Msgf("Failed to deliver message: %v\n", ev.TopicPartition)
-				}
-			}
-		}
-	}()
-}
-
-func (p *Producer) RetryableProduce(message kafka.Message, logger *zerolog.Logger) {
+// RetryableProduce produces a message, retrying until it is enqueued
+func RetryableProduce(producer Producer, message Message, logger *zerolog.Logger) {
 	for {
-		err := p.Produce(&message, nil)
+		err := producer.Produce(&message)
 		if err != nil {
-			if err.(kafka.Error).Code() == kafka.ErrQueueFull {
+			if errors.Is(err, ErrQueueFull) {
 				// Producer queue is full, wait 1s for messages
 				// to be delivered then try again.
 				time.Sleep(time.Second)
 				continue
 			}
+			if errors.Is(err, ErrClosed) {
+				logger.Error().Msgf("Failed to produce message to closed producer: %s\n", message.Key)
+				return
+			}
 
 			logger.Error().Msgf("Failed to produce message: %v\n", err)
 			continue
@@ -56,10 +36,23 @@ func (p *Producer) RetryableProduce(message kafka.Message, logger *zerolog.Logge
 	}
 }
 
+const (
+	// HeaderClaimCheckBucket and HeaderClaimCheckObject locate the object holding the payload of a claim check message
+	HeaderClaimCheckBucket = "claim_check_bucket"
+	HeaderClaimCheckObject = "claim_check_object"
+)
+
+// ClaimCheckObjectPath namespaces the object path of a claim check by the topic of its message and its producer
+func ClaimCheckObjectPath(topic, producer, objectPath string) string {
+	return topic + "/" + producer + "/" + objectPath
+}
+
 type ProduceWithClaimCheckInput struct {
 	Topic          string
 	Key            []byte
 	MessageInBytes []byte
+	// Producer names the component producing the message, namespacing its claim check objects
+	Producer string
 
 	ClaimCheckKey           []byte
 	ClaimCheckThresholdInMB int64
@@ -68,7 +61,7 @@ type ProduceWithClaimCheckInput struct {
 
 	StorageClient storage.Client
 
-	Headers []kafka.Header
+	Headers []Header
 }
 
 func uploadToStorage(storageClient storage.Client, bucket string, objectPath string, msg []byte, logger *zerolog.Logger) {
@@ -84,40 +77,83 @@ func uploadToStorage(storageClient storage.Client, bucket string, objectPath str
 	}
 }
 
-func (p *Producer) ProduceWithClaimCheck(input *ProduceWithClaimCheckInput, logger *zerolog.Logger) {
+// ProduceWithClaimCheck produces a message, uploading it to the storage and producing its claim check instead when it
+// exceeds the threshold
+func ProduceWithClaimCheck(producer Producer, input *ProduceWithClaimCheckInput, logger *zerolog.Logger) {
 	claimCheckThreshold := int(input.ClaimCheckThresholdInMB * 1024 * 1024)
-	var kafkaMessage kafka.Message
+	var message Message
 	if len(input.MessageInBytes) > claimCheckThreshold {
-		uploadToStorage(input.StorageClient, input.ClaimCheckBucket, input.ClaimCheckObjectPath, input.MessageInBytes, logger)
+		objectPath := ClaimCheckObjectPath(input.Topic, input.Producer, input.ClaimCheckObjectPath)
+		uploadToStorage(input.StorageClient, input.ClaimCheckBucket, objectPath, input.MessageInBytes, logger)
 
 		blockClaimCheckMsgBytes, _ := json.Marshal(ClaimCheckMsg{
-			ObjectPath: input.ClaimCheckObjectPath,
+			ObjectPath: objectPath,
 		})
 
-		kafkaMessage = kafka.Message{
-			TopicPartition: kafka.TopicPartition{Topic: &input.Topic, Partition: int32(kafka.PartitionAny)},
-			Key:            input.ClaimCheckKey,
-			Value:          blockClaimCheckMsgBytes,
-			Headers:        input.Headers,
+		message = Message{
+			Topic: input.Topic,
+			Key:   input.ClaimCheckKey,
+			Value: blockClaimCheckMsgBytes,
+			Headers: append(slices.Clip(input.Headers),
+				Header{Key: HeaderClaimCheckBucket, Value: []byte(input.ClaimCheckBucket)},
+				Header{Key: HeaderClaimCheckObject, Value: []byte(objectPath)},
+			),
 		}
 	} else {
-		kafkaMessage = kafka.Message{
-			TopicPartition: kafka.TopicPartition{Topic: &input.Topic, Partition: int32(kafka.PartitionAny)},
-			Key:            input.Key,
-			Value:          input.MessageInBytes,
-			Headers:        input.Headers,
+		message = Message{
+			Topic:   input.Topic,
+			Key:     input.Key,
+			Value:   input.MessageInBytes,
+			Headers: input.Headers,
 		}
 	}
 
-	p.RetryableProduce(kafkaMessage, logger)
+	RetryableProduce(producer, message, logger)
 }
 
-func (p *Producer) ProduceToDLQ(chain, component string, message *kafka.Message, err error, logger *zerolog.Logger) {
-	DLQTopic := fmt.Sprintf("dlq-%s-%s", chain, component)
-	p.RetryableProduce(kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &DLQTopic, Partition: int32(kafka.PartitionAny)},
-		Key:            message.Key,
-		Value:          message.Value,
-		Headers:        append(message.Headers, kafka.Header{Key: "error", Value: []byte(err.Error())}, kafka.Header{Key: "timestamp", Value: []byte(fmt.Sprint(time.Now().Unix()))}),
+// ProduceToDLQ produces a message failing to be processed to the dead letter queue of the component. The claim check
+// object of the message is copied under the dead letter queue topic, which the claim check GC does not collect, so
+// that the message can still be replayed once the original object is deleted.
+func ProduceToDLQ(producer Producer, storageClient storage.Client, chain, component string, message *Message, err error, logger *zerolog.Logger) {
+	topic := fmt.Sprintf("dlq-%s-%s", chain, component)
+	value, headers := message.Value, slices.Clip(message.Headers)
+	if storageClient != nil {
+		value, headers = copyClaimCheckToDLQ(storageClient, topic, message, logger)
+	}
+
+	RetryableProduce(producer, Message{
+		Topic:   topic,
+		Key:     message.Key,
+		Value:   value,
+		Headers: append(headers, Header{Key: "error", Value: []byte(err.Error())}, Header{Key: "timestamp", Value: []byte(fmt.Sprint(time.Now().Unix()))}),
 	}, logger)
 }
+
+// copyClaimCheckToDLQ copies the claim check object of a message under the dead letter queue topic, and returns the
+// value and the headers of the message pointing to the copy. A message without claim check, or whose object cannot
+// be read, is kept as is.
+func copyClaimCheckToDLQ(storageClient storage.Client, topic string, message *Message, logger *zerolog.Logger) ([]byte, []Header) {
+	bucket, hasBucket := message.Header(HeaderClaimCheckBucket)
+	object, hasObject := message.Header(HeaderClaimCheckObject)
+	if !hasBucket || !hasObject {
+		return message.Value, slices.Clip(message.Headers)
+	}
+
+	data, err := storageClient.ReadFile(string(bucket), string(object))
+	if err != nil {
+		logger.Error().Msgf("Failed to copy claim check object %s to the DLQ: %v", object, err)
+		return message.Value, slices.Clip(message.Headers)
+	}
+	objectPath := topic + "/" + string(object)
+	uploadToStorage(storageClient, string(bucket), objectPath, data, logger)
+
+	value, _ := json.Marshal(ClaimCheckMsg{ObjectPath: objectPath})
+	headers := make([]Header, 0, len(message.Headers))
+	for _, header := range message.Headers {
+		if header.Key == HeaderClaimCheckObject {
+			header = Header{Key: HeaderClaimCheckObject, Value: []byte(objectPath)}
+		}
+		headers = append(headers, header)
+	}
+	return value, headers
+}
@@ -6,9 +6,13 @@ import (
 	"io"
 
 	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
+// ErrObjectNotExist is returned, wrapped, by ReadFile when the object does not exist.
+var ErrObjectNotExist = storage.ErrObjectNotExist
+
 type BaseGCSClient struct {
 	client *storage.Client
 }
@@ -33,10 +37,15 @@ func (b *BaseGCSClient) UploadFile(bucket string, objectPath string, message []b
 	return nil
 }
 
+// NewWriter returns a writer uploading the object as it is written, see StreamingClient
+func (b *BaseGCSClient) NewWriter(ctx context.Context, bucket string, objectPath string) io.WriteCloser {
+	return b.client.Bucket(bucket).Object(objectPath).NewWriter(ctx)
+}
+
 func (b *BaseGCSClient) ReadFile(bucket string, objectPath string) ([]byte, error) {
 	r, err := b.client.Bucket(bucket).Object(objectPath).NewReader(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get object %s, %v", objectPath, err)
+		return nil, fmt.Errorf("failed to get object %s, %w", objectPath, err)
 	}
 	defer r.Close()
 	content, err := io.ReadAll(r)
@@ -45,3 +54,26 @@ func (b *BaseGCSClient) ReadFile(bucket string, objectPath string) ([]byte, erro
 	}
 	return content, nil
 }
+
+func (b *BaseGCSClient) ListObjects(bucket string, prefix string) ([]ObjectAttrs, error) {
+	objects := make([]ObjectAttrs, 0)
+	it := b.client.Bucket(bucket).Objects(context.Background(), &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects %s, %w", prefix, err)
+		}
+		objects = append(objects, ObjectAttrs{Name: attrs.Name, Size: attrs.Size, Created: attrs.Created})
+	}
+	return objects, nil
+}
+
+func (b *BaseGCSClient) DeleteFile(bucket string, objectPath string) error {
+	if err := b.client.Bucket(bucket).Object(objectPath).Delete(context.Background()); err != nil {
+		return fmt.Errorf("failed to delete object %s, %w", objectPath, err)
+	}
+	return nil
+}
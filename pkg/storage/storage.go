@@ -1,6 +1,19 @@
 package storage
 
+import "time"
+
+// ObjectAttrs describes an object of a bucket
+type ObjectAttrs struct {
+	Name    string
+	Size    int64
+	Created time.Time
+}
+
 type Client interface {
 	UploadFile(bucket string, objectPath string, message []byte) error
 	ReadFile(bucket string, objectPath string) ([]byte, error)
+	// ListObjects returns the objects whose path starts with the prefix
+	ListObjects(bucket string, prefix string) ([]ObjectAttrs, error)
+	// DeleteFile deletes an object, returning an error wrapping ErrObjectNotExist when it does not exist
+	DeleteFile(bucket string, objectPath string) error
 }
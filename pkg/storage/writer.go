@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+// StreamingClient is implemented by the clients uploading an object as it is written
+type StreamingClient interface {
+	// NewWriter returns a writer uploading the object as it is written. The object is created once the writer is
+	// closed, and is discarded when the context is cancelled before.
+	NewWriter(ctx context.Context, bucket string, objectPath string) io.WriteCloser
+}
+
+// NewWriter returns a writer uploading an object through the client, streaming it when the client implements
+// StreamingClient and buffering it until the writer is closed otherwise. The object is created once the writer is
+// closed, and is discarded when the context is cancelled before.
+func NewWriter(ctx context.Context, client Client, bucket string, objectPath string) io.WriteCloser {
+	if streamingClient, ok := client.(StreamingClient); ok {
+		return streamingClient.NewWriter(ctx, bucket, objectPath)
+	}
+	return &bufferedWriter{ctx: ctx, client: client, bucket: bucket, objectPath: objectPath}
+}
+
+// bufferedWriter uploads an object in a single request once closed
+type bufferedWriter struct {
+	ctx        context.Context
+	client     Client
+	bucket     string
+	objectPath string
+	buffer     bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(p []byte) (int, error) {
+	return w.buffer.Write(p)
+}
+
+func (w *bufferedWriter) Close() error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	return w.client.UploadFile(w.bucket, w.objectPath, w.buffer.Bytes())
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryClient struct {
+	objects map[string][]byte
+}
+
+func (c *memoryClient) UploadFile(bucket string, objectPath string, message []byte) error {
+	c.objects[bucket+"/"+objectPath] = message
+	return nil
+}
+
+func (c *memoryClient) ReadFile(bucket string, objectPath string) ([]byte, error) {
+	data, ok := c.objects[bucket+"/"+objectPath]
+	if !ok {
+		return nil, fmt.Errorf("failed to get object %s, %w", objectPath, ErrObjectNotExist)
+	}
+	return data, nil
+}
+
+func (c *memoryClient) ListObjects(bucket string, prefix string) ([]ObjectAttrs, error) {
+	return nil, nil
+}
+
+func (c *memoryClient) DeleteFile(bucket string, objectPath string) error {
+	delete(c.objects, bucket+"/"+objectPath)
+	return nil
+}
+
+func TestBufferedWriter(t *testing.T) {
+	client := &memoryClient{objects: make(map[string][]byte)}
+
+	// the object is created once the writer is closed
+	writer := NewWriter(context.Background(), client, "bucket", "file")
+	_, err := writer.Write([]byte("hello "))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("world"))
+	require.NoError(t, err)
+	assert.Empty(t, client.objects)
+	require.NoError(t, writer.Close())
+	assert.Equal(t, []byte("hello world"), client.objects["bucket/file"])
+
+	// and discarded when the context is cancelled before
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	writer = NewWriter(ctx, client, "bucket", "discarded")
+	_, err = writer.Write([]byte("hello"))
+	require.NoError(t, err)
+	cancel()
+	assert.ErrorIs(t, writer.Close(), context.Canceled)
+	_, err = client.ReadFile("bucket", "discarded")
+	assert.ErrorIs(t, err, ErrObjectNotExist)
+}
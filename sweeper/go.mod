@@ -5,7 +5,7 @@ go 1.25.8
 require (
 	github.com/certifi/gocertifi v0.0.0-20210507211836-431795d63e8d
 	github.com/cometbft/cometbft v0.38.20
-	github.com/confluentinc/confluent-kafka-go/v2 v2.6.1
+	github.com/confluentinc/confluent-kafka-go/v2 v2.6.1 // indirect
 	github.com/getsentry/sentry-go v0.29.1
 	github.com/initia-labs/core-indexer/pkg v0.0.0-00010101000000-000000000000
 	github.com/rs/zerolog v1.33.0
@@ -0,0 +1,123 @@
+package sweeper
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/certifi/gocertifi"
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/initia-labs/core-indexer/pkg/archive"
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+type ArchiveConfig struct {
+	Chain                    string
+	Topic                    string
+	Bucket                   string
+	Prefix                   string
+	BundleSize               int64
+	StartHeight              int64
+	MaxPending               int
+	MQBackend                string
+	MQDir                    string
+	KafkaBootstrapServer     string
+	KafkaAPIKey              string
+	KafkaAPISecret           string
+	ClaimCheckBucket         string
+	Environment              string
+	CommitSHA                string
+	SentryDSN                string
+	SentryProfilesSampleRate float64
+	SentryTracesSampleRate   float64
+}
+
+// RunArchive writes the block results messages of the topic to the archive bucket in bundles of heights until it is
+// interrupted
+func RunArchive(config *ArchiveConfig) error {
+	logger = zerolog.Ctx(log.With().Str("component", "archiver").Str("chain", config.Chain).Str("environment", config.Environment).Str("commit_sha", config.CommitSHA).Logger().WithContext(context.Background()))
+
+	sentryClientOptions := sentry.ClientOptions{
+		Dsn:                config.SentryDSN,
+		ServerName:         config.Chain + "-archiver",
+		EnableTracing:      true,
+		ProfilesSampleRate: config.SentryProfilesSampleRate,
+		TracesSampleRate:   config.SentryTracesSampleRate,
+		Environment:        config.Environment,
+		Release:            config.CommitSHA,
+		Tags: map[string]string{
+			"chain":       config.Chain,
+			"environment": config.Environment,
+			"component":   "archiver",
+			"commit_sha":  config.CommitSHA,
+		},
+	}
+
+	rootCAs, err := gocertifi.CACerts()
+	if err != nil {
+		logger.Fatal().Msgf("Sentry: Error getting root CAs: %v\n", err)
+	} else {
+		sentryClientOptions.CaCerts = rootCAs
+	}
+
+	err = sentry.Init(sentryClientOptions)
+	if err != nil {
+		logger.Fatal().Msgf("Sentry: Error initializing sentry: %v\n", err)
+		return err
+	}
+	defer sentry.Flush(2 * time.Second)
+
+	group := config.Chain + "-archiver"
+	consumer, err := mq.NewConsumer(mq.Config{
+		Backend: config.MQBackend,
+		Dir:     config.MQDir,
+		Kafka: mq.KafkaConfig{
+			BootstrapServer: config.KafkaBootstrapServer,
+			ClientID:        group,
+			APIKey:          config.KafkaAPIKey,
+			APISecret:       config.KafkaAPISecret,
+			Plaintext:       config.Environment == "local",
+		},
+	}, group)
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+		logger.Fatal().Msgf("MQ: Error creating consumer: %v\n", err)
+		return err
+	}
+	defer consumer.Close()
+
+	var storageClient storage.Client
+	if config.Environment == "local" {
+		storageClient, err = storage.NewGCSFakeClient()
+	} else {
+		storageClient, err = storage.NewGCSClient()
+	}
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+		logger.Fatal().Msgf("Storage: Error creating storage client: %v\n", err)
+		return err
+	}
+
+	archiveConfig := archive.DefaultConfig()
+	archiveConfig.Bucket = config.Bucket
+	archiveConfig.Prefix = config.Prefix
+	archiveConfig.Topic = config.Topic
+	archiveConfig.BundleSize = config.BundleSize
+	archiveConfig.StartHeight = config.StartHeight
+	archiveConfig.MaxPending = config.MaxPending
+	archiveConfig.ClaimCheckBucket = config.ClaimCheckBucket
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info().Msgf("Archiving topic %s to %s/%s as consumer group %s", config.Topic, config.Bucket, config.Prefix, group)
+	err = archive.NewArchiver(consumer, storageClient, archiveConfig, logger).Run(ctx)
+	logger.Info().Msgf("Shutting down ...")
+	return err
+}
@@ -1,12 +1,16 @@
 package sweeper
 
 import (
+	"fmt"
 	"os"
 	"runtime"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/core-indexer/pkg/archive"
+	"github.com/initia-labs/core-indexer/pkg/mq"
 )
 
 func Execute() {
@@ -18,6 +22,8 @@ func Execute() {
 
 	rootCmd.AddCommand(
 		SweepCmd(),
+		ClaimCheckGCCmd(),
+		ArchiveCmd(),
 	)
 
 	err := rootCmd.Execute()
@@ -33,18 +39,34 @@ const (
 	FlagDBConnectionString       = "db"
 	FlagNumWorkers               = "workers"
 	FlagRebalanceInterval        = "rebalance-interval"
+	FlagRPCHedgeDelayInMs        = "rpc-hedge-delay-in-ms"
+	FlagSubscribeNewBlocks       = "subscribe-new-blocks"
+	FlagVerificationQuorum       = "verification-quorum"
+	FlagMQBackend                = "mq-backend"
+	FlagMQDir                    = "mq-dir"
 	FlagKafkaBootstrapServer     = "bootstrap-server"
 	FlagKafkaTopics              = "block-results-topics"
 	FlagKafkaAPIKey              = "kafka-api-key"
 	FlagKafkaAPISecret           = "kafka-api-secret"
 	FlagClaimCheckBucket         = "claim-check-bucket"
 	FlagClaimCheckThresholdInMB  = "claim-check-threshold-mb"
+	FlagBlockResultsEncoding     = "block-results-encoding"
 	FlagEnvironment              = "environment"
 	FlagSentryDSN                = "sentry-dsn"
 	FlagCommitSHA                = "commit-sha"
 	FlagSentryProfilesSampleRate = "sentry-profiles-sample-rate"
 	FlagSentryTracesSampleRate   = "sentry-traces-sample-rate"
 	FlagMigrationsDir            = "migrations-dir"
+	FlagClaimCheckTopics         = "claim-check-topics"
+	FlagClaimCheckGroups         = "claim-check-consumer-groups"
+	FlagClaimCheckRetention      = "claim-check-retention-hours"
+	FlagClaimCheckDeleteLegacy   = "claim-check-delete-legacy-objects"
+	FlagArchiveTopic             = "archive-topic"
+	FlagArchiveBucket            = "archive-bucket"
+	FlagArchivePrefix            = "archive-prefix"
+	FlagArchiveBundleSize        = "archive-bundle-size"
+	FlagArchiveStartHeight       = "archive-start-height"
+	FlagArchiveMaxPending        = "archive-max-pending"
 )
 
 func SweepCmd() *cobra.Command {
@@ -59,12 +81,18 @@ func SweepCmd() *cobra.Command {
 			dbConnectionString, _ := cmd.Flags().GetString(FlagDBConnectionString)
 			numWorkers, _ := cmd.Flags().GetInt(FlagNumWorkers)
 			rebalanceInterval, _ := cmd.Flags().GetInt64(FlagRebalanceInterval)
+			rpcHedgeDelayInMs, _ := cmd.Flags().GetInt64(FlagRPCHedgeDelayInMs)
+			subscribeNewBlocks, _ := cmd.Flags().GetBool(FlagSubscribeNewBlocks)
+			verificationQuorum, _ := cmd.Flags().GetInt64(FlagVerificationQuorum)
+			mqBackend, _ := cmd.Flags().GetString(FlagMQBackend)
+			mqDir, _ := cmd.Flags().GetString(FlagMQDir)
 			kafkaBootstrapServer, _ := cmd.Flags().GetString(FlagKafkaBootstrapServer)
 			kafkaTopics, _ := cmd.Flags().GetString(FlagKafkaTopics)
 			kafkaAPIKey, _ := cmd.Flags().GetString(FlagKafkaAPIKey)
 			kafkaAPISecret, _ := cmd.Flags().GetString(FlagKafkaAPISecret)
 			claimCheckBucket, _ := cmd.Flags().GetString(FlagClaimCheckBucket)
 			claimCheckThresholdInMB, _ := cmd.Flags().GetUint64(FlagClaimCheckThresholdInMB)
+			blockResultsEncoding, _ := cmd.Flags().GetString(FlagBlockResultsEncoding)
 			environment, _ := cmd.Flags().GetString(FlagEnvironment)
 			sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
 			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
@@ -72,6 +100,10 @@ func SweepCmd() *cobra.Command {
 			sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
 			migrationsDir, _ := cmd.Flags().GetString(FlagMigrationsDir)
 
+			if err := mq.CheckStandalone(mqBackend); err != nil {
+				return err
+			}
+
 			s, err := NewSweeper(&SweeperConfig{
 				RPCEndpoints:             rpcEndpoints,
 				RPCTimeOutInSeconds:      rpcTimeOutInSeconds,
@@ -79,12 +111,18 @@ func SweepCmd() *cobra.Command {
 				DBConnectionString:       dbConnectionString,
 				NumWorkers:               int64(numWorkers),
 				RebalanceInterval:        rebalanceInterval,
+				RPCHedgeDelayInMs:        rpcHedgeDelayInMs,
+				SubscribeNewBlocks:       subscribeNewBlocks,
+				VerificationQuorum:       verificationQuorum,
+				MQBackend:                mqBackend,
+				MQDir:                    mqDir,
 				KafkaBootstrapServer:     kafkaBootstrapServer,
 				KafkaTopics:              strings.Split(kafkaTopics, ","),
 				KafkaAPIKey:              kafkaAPIKey,
 				KafkaAPISecret:           kafkaAPISecret,
 				ClaimCheckBucket:         claimCheckBucket,
 				ClaimCheckThresholdInMB:  int64(claimCheckThresholdInMB),
+				BlockResultsEncoding:     blockResultsEncoding,
 				Environment:              environment,
 				SentryDSN:                sentryDSN,
 				CommitSHA:                commitSHA,
@@ -113,11 +151,31 @@ func SweepCmd() *cobra.Command {
 		rebalanceInterval = 0
 	}
 
+	rpcHedgeDelayInMs, err := strconv.ParseInt(os.Getenv("RPC_HEDGE_DELAY_IN_MS"), 10, 64)
+	if err != nil {
+		rpcHedgeDelayInMs = 0
+	}
+
+	subscribeNewBlocks, err := strconv.ParseBool(os.Getenv("SUBSCRIBE_NEW_BLOCKS"))
+	if err != nil {
+		subscribeNewBlocks = true
+	}
+
+	verificationQuorum, err := strconv.ParseInt(os.Getenv("VERIFICATION_QUORUM"), 10, 64)
+	if err != nil {
+		verificationQuorum = 0
+	}
+
 	threshold, err := strconv.ParseInt(os.Getenv("CLAIM_CHECK_THRESHOLD_IN_MB"), 10, 64)
 	if err != nil {
 		threshold = 1
 	}
 
+	blockResultsEncoding := os.Getenv("BLOCK_RESULTS_ENCODING")
+	if blockResultsEncoding == "" {
+		blockResultsEncoding = mq.EncodingJSON
+	}
+
 	sentryProfilesSampleRate, err := strconv.ParseFloat(os.Getenv("SENTRY_PROFILES_SAMPLE_RATE"), 64)
 	if err != nil {
 		sentryProfilesSampleRate = 0.01
@@ -134,12 +192,18 @@ func SweepCmd() *cobra.Command {
 	cmd.Flags().String(FlagDBConnectionString, os.Getenv("DB_CONNECTION_STRING"), "Database connection string")
 	cmd.Flags().Uint64(FlagNumWorkers, uint64(runtime.NumCPU()), "Worker count")
 	cmd.Flags().Int64(FlagRebalanceInterval, rebalanceInterval, "RPC providers rebalance interval")
+	cmd.Flags().Bool(FlagSubscribeNewBlocks, subscribeNewBlocks, "Fetch the blocks as soon as they are committed through a websocket subscription, polling while it is down")
+	cmd.Flags().Int64(FlagVerificationQuorum, verificationQuorum, "Number of other RPC providers that must agree with a block before it is published, 0 disables the verification")
+	cmd.Flags().Int64(FlagRPCHedgeDelayInMs, rpcHedgeDelayInMs, "Delay after which a slow RPC request is sent to a second provider as well, 0 disables hedging")
+	cmd.Flags().String(FlagMQBackend, os.Getenv("MQ_BACKEND"), "Message bus backend: kafka (default) or file")
+	cmd.Flags().String(FlagMQDir, os.Getenv("MQ_DIR"), "Directory of the file message bus backend")
 	cmd.Flags().String(FlagKafkaBootstrapServer, os.Getenv("BOOTSTRAP_SERVER"), "<host>:<port> to Kafka bootstrap server")
 	cmd.Flags().String(FlagKafkaTopics, os.Getenv("BLOCK_RESULTS_TOPICS"), "Kafka topics")
 	cmd.Flags().String(FlagKafkaAPIKey, os.Getenv("KAFKA_API_KEY"), "Kafka API key")
 	cmd.Flags().String(FlagKafkaAPISecret, os.Getenv("KAFKA_API_SECRET"), "Kafka API secret")
 	cmd.Flags().String(FlagClaimCheckBucket, os.Getenv("CLAIM_CHECK_BUCKET"), "Claim check bucket")
 	cmd.Flags().Uint64(FlagClaimCheckThresholdInMB, uint64(threshold), "Claim check threshold in MB")
+	cmd.Flags().String(FlagBlockResultsEncoding, blockResultsEncoding, "Encoding of the block results messages: json or proto+zstd, consumers must support it before it is switched")
 	cmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
 	cmd.Flags().String(FlagSentryDSN, os.Getenv("SENTRY_DSN"), "Sentry DSN")
 	cmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
@@ -149,3 +213,245 @@ func SweepCmd() *cobra.Command {
 
 	return cmd
 }
+
+func ClaimCheckGCCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-check-gc",
+		Short: "Delete the claim check objects once consumed",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, _ := cmd.Flags().GetString(FlagChain)
+			topics, _ := cmd.Flags().GetString(FlagClaimCheckTopics)
+			consumerGroups, _ := cmd.Flags().GetString(FlagClaimCheckGroups)
+			retentionInHours, _ := cmd.Flags().GetInt64(FlagClaimCheckRetention)
+			deleteLegacyObjects, _ := cmd.Flags().GetBool(FlagClaimCheckDeleteLegacy)
+			mqBackend, _ := cmd.Flags().GetString(FlagMQBackend)
+			mqDir, _ := cmd.Flags().GetString(FlagMQDir)
+			kafkaBootstrapServer, _ := cmd.Flags().GetString(FlagKafkaBootstrapServer)
+			kafkaAPIKey, _ := cmd.Flags().GetString(FlagKafkaAPIKey)
+			kafkaAPISecret, _ := cmd.Flags().GetString(FlagKafkaAPISecret)
+			claimCheckBucket, _ := cmd.Flags().GetString(FlagClaimCheckBucket)
+			environment, _ := cmd.Flags().GetString(FlagEnvironment)
+			sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
+			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
+			sentryProfilesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryProfilesSampleRate)
+			sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
+
+			if err := mq.CheckStandalone(mqBackend); err != nil {
+				return err
+			}
+
+			topicList, topicBuckets, err := parseClaimCheckTopics(topics, claimCheckBucket)
+			if err != nil {
+				return err
+			}
+			groupList, err := parseList(FlagClaimCheckGroups, consumerGroups)
+			if err != nil {
+				return err
+			}
+
+			return RunClaimCheckGC(&ClaimCheckGCConfig{
+				Chain:                    chain,
+				Topics:                   topicList,
+				TopicBuckets:             topicBuckets,
+				ConsumerGroups:           groupList,
+				RetentionInHours:         retentionInHours,
+				DeleteLegacyObjects:      deleteLegacyObjects,
+				MQBackend:                mqBackend,
+				MQDir:                    mqDir,
+				KafkaBootstrapServer:     kafkaBootstrapServer,
+				KafkaAPIKey:              kafkaAPIKey,
+				KafkaAPISecret:           kafkaAPISecret,
+				Environment:              environment,
+				SentryDSN:                sentryDSN,
+				CommitSHA:                commitSHA,
+				SentryProfilesSampleRate: sentryProfilesSampleRate,
+				SentryTracesSampleRate:   sentryTracesSampleRate,
+			})
+		},
+	}
+
+	retentionInHours, err := strconv.ParseInt(os.Getenv("CLAIM_CHECK_RETENTION_IN_HOURS"), 10, 64)
+	if err != nil {
+		retentionInHours = 7 * 24
+	}
+
+	deleteLegacyObjects, _ := strconv.ParseBool(os.Getenv("CLAIM_CHECK_DELETE_LEGACY_OBJECTS"))
+
+	sentryProfilesSampleRate, err := strconv.ParseFloat(os.Getenv("SENTRY_PROFILES_SAMPLE_RATE"), 64)
+	if err != nil {
+		sentryProfilesSampleRate = 0.01
+	}
+
+	sentryTracesSampleRate, err := strconv.ParseFloat(os.Getenv("SENTRY_TRACES_SAMPLE_RATE"), 64)
+	if err != nil {
+		sentryTracesSampleRate = 0.01
+	}
+
+	cmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID")
+	cmd.Flags().String(FlagClaimCheckTopics, os.Getenv("CLAIM_CHECK_TOPICS"), "Comma separated topics whose claim check objects are collected, as <topic>=<bucket> for a topic whose objects are not in the claim check bucket")
+	cmd.Flags().String(FlagClaimCheckGroups, os.Getenv("CLAIM_CHECK_CONSUMER_GROUPS"), "Comma separated consumer groups that must commit a message before its claim check object is deleted")
+	cmd.Flags().Int64(FlagClaimCheckRetention, retentionInHours, "Hours after which a claim check object is deleted even though not every consumer group committed its message")
+	cmd.Flags().Bool(FlagClaimCheckDeleteLegacy, deleteLegacyObjects, "Delete the claim check objects written under the legacy height and transaction paths once past the retention, on startup")
+	cmd.Flags().String(FlagMQBackend, os.Getenv("MQ_BACKEND"), "Message bus backend: kafka (default) or file")
+	cmd.Flags().String(FlagMQDir, os.Getenv("MQ_DIR"), "Directory of the file message bus backend")
+	cmd.Flags().String(FlagKafkaBootstrapServer, os.Getenv("BOOTSTRAP_SERVER"), "<host>:<port> to Kafka bootstrap server")
+	cmd.Flags().String(FlagKafkaAPIKey, os.Getenv("KAFKA_API_KEY"), "Kafka API key")
+	cmd.Flags().String(FlagKafkaAPISecret, os.Getenv("KAFKA_API_SECRET"), "Kafka API secret")
+	cmd.Flags().String(FlagClaimCheckBucket, os.Getenv("CLAIM_CHECK_BUCKET"), "Claim check bucket")
+	cmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
+	cmd.Flags().String(FlagSentryDSN, os.Getenv("SENTRY_DSN"), "Sentry DSN")
+	cmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
+	cmd.Flags().Float64(FlagSentryProfilesSampleRate, sentryProfilesSampleRate, "Sentry profiles sample rate")
+	cmd.Flags().Float64(FlagSentryTracesSampleRate, sentryTracesSampleRate, "Sentry traces sample rate")
+
+	return cmd
+}
+
+// parseList returns the entries of a comma separated list, rejecting an empty list or entry
+func parseList(flag, value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("--%s is empty", flag)
+	}
+
+	entries := strings.Split(value, ",")
+	for idx, entry := range entries {
+		entries[idx] = strings.TrimSpace(entry)
+		if entries[idx] == "" {
+			return nil, fmt.Errorf("--%s has an empty entry: %q", flag, value)
+		}
+	}
+	return entries, nil
+}
+
+// parseClaimCheckTopics returns the topics of the claim check garbage collection along with their claim check bucket,
+// the default one for the topics listed without bucket
+func parseClaimCheckTopics(value, defaultBucket string) ([]string, map[string]string, error) {
+	entries, err := parseList(FlagClaimCheckTopics, value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	topics := make([]string, 0, len(entries))
+	buckets := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		topic, bucket, ok := strings.Cut(entry, "=")
+		if !ok {
+			bucket = defaultBucket
+		}
+		if topic == "" || bucket == "" {
+			return nil, nil, fmt.Errorf("no claim check bucket for topic entry %q of --%s, set --%s or list it as <topic>=<bucket>", entry, FlagClaimCheckTopics, FlagClaimCheckBucket)
+		}
+		topics = append(topics, topic)
+		buckets[topic] = bucket
+	}
+	return topics, buckets, nil
+}
+
+func ArchiveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Write the block results messages to the archive bucket in bundles of heights",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, _ := cmd.Flags().GetString(FlagChain)
+			topic, _ := cmd.Flags().GetString(FlagArchiveTopic)
+			bucket, _ := cmd.Flags().GetString(FlagArchiveBucket)
+			prefix, _ := cmd.Flags().GetString(FlagArchivePrefix)
+			bundleSize, _ := cmd.Flags().GetInt64(FlagArchiveBundleSize)
+			startHeight, _ := cmd.Flags().GetInt64(FlagArchiveStartHeight)
+			maxPending, _ := cmd.Flags().GetInt(FlagArchiveMaxPending)
+			mqBackend, _ := cmd.Flags().GetString(FlagMQBackend)
+			mqDir, _ := cmd.Flags().GetString(FlagMQDir)
+			kafkaBootstrapServer, _ := cmd.Flags().GetString(FlagKafkaBootstrapServer)
+			kafkaAPIKey, _ := cmd.Flags().GetString(FlagKafkaAPIKey)
+			kafkaAPISecret, _ := cmd.Flags().GetString(FlagKafkaAPISecret)
+			claimCheckBucket, _ := cmd.Flags().GetString(FlagClaimCheckBucket)
+			environment, _ := cmd.Flags().GetString(FlagEnvironment)
+			sentryDSN, _ := cmd.Flags().GetString(FlagSentryDSN)
+			commitSHA, _ := cmd.Flags().GetString(FlagCommitSHA)
+			sentryProfilesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryProfilesSampleRate)
+			sentryTracesSampleRate, _ := cmd.Flags().GetFloat64(FlagSentryTracesSampleRate)
+
+			if err := mq.CheckStandalone(mqBackend); err != nil {
+				return err
+			}
+
+			if bundleSize <= 0 {
+				return fmt.Errorf("invalid archive bundle size: %d", bundleSize)
+			}
+
+			return RunArchive(&ArchiveConfig{
+				Chain:                    chain,
+				Topic:                    topic,
+				Bucket:                   bucket,
+				Prefix:                   prefix,
+				BundleSize:               bundleSize,
+				StartHeight:              startHeight,
+				MaxPending:               maxPending,
+				MQBackend:                mqBackend,
+				MQDir:                    mqDir,
+				KafkaBootstrapServer:     kafkaBootstrapServer,
+				KafkaAPIKey:              kafkaAPIKey,
+				KafkaAPISecret:           kafkaAPISecret,
+				ClaimCheckBucket:         claimCheckBucket,
+				Environment:              environment,
+				SentryDSN:                sentryDSN,
+				CommitSHA:                commitSHA,
+				SentryProfilesSampleRate: sentryProfilesSampleRate,
+				SentryTracesSampleRate:   sentryTracesSampleRate,
+			})
+		},
+	}
+
+	prefix := os.Getenv("ARCHIVE_PREFIX")
+	if prefix == "" {
+		prefix = archive.DefaultConfig().Prefix
+	}
+
+	bundleSize, err := strconv.ParseInt(os.Getenv("ARCHIVE_BUNDLE_SIZE"), 10, 64)
+	if err != nil {
+		bundleSize = archive.DefaultConfig().BundleSize
+	}
+
+	startHeight, err := strconv.ParseInt(os.Getenv("ARCHIVE_START_HEIGHT"), 10, 64)
+	if err != nil {
+		startHeight = 0
+	}
+
+	maxPending, err := strconv.Atoi(os.Getenv("ARCHIVE_MAX_PENDING"))
+	if err != nil {
+		maxPending = archive.DefaultConfig().MaxPending
+	}
+
+	sentryProfilesSampleRate, err := strconv.ParseFloat(os.Getenv("SENTRY_PROFILES_SAMPLE_RATE"), 64)
+	if err != nil {
+		sentryProfilesSampleRate = 0.01
+	}
+
+	sentryTracesSampleRate, err := strconv.ParseFloat(os.Getenv("SENTRY_TRACES_SAMPLE_RATE"), 64)
+	if err != nil {
+		sentryTracesSampleRate = 0.01
+	}
+
+	cmd.Flags().String(FlagChain, os.Getenv("CHAIN"), "Chain ID")
+	cmd.Flags().String(FlagArchiveTopic, os.Getenv("ARCHIVE_TOPIC"), "Block results topic to archive")
+	cmd.Flags().String(FlagArchiveBucket, os.Getenv("ARCHIVE_BUCKET"), "Bucket of the archive")
+	cmd.Flags().String(FlagArchivePrefix, prefix, "Path of the bundles in the archive bucket")
+	cmd.Flags().Int64(FlagArchiveBundleSize, bundleSize, "Number of heights per bundle")
+	cmd.Flags().Int64(FlagArchiveStartHeight, startHeight, "First height of an empty archive, the first bundle boundary after the first message read when 0")
+	cmd.Flags().Int(FlagArchiveMaxPending, maxPending, "Number of heights read ahead of a missing height after which the archive fails")
+	cmd.Flags().String(FlagMQBackend, os.Getenv("MQ_BACKEND"), "Message bus backend: kafka (default) or file")
+	cmd.Flags().String(FlagMQDir, os.Getenv("MQ_DIR"), "Directory of the file message bus backend")
+	cmd.Flags().String(FlagKafkaBootstrapServer, os.Getenv("BOOTSTRAP_SERVER"), "<host>:<port> to Kafka bootstrap server")
+	cmd.Flags().String(FlagKafkaAPIKey, os.Getenv("KAFKA_API_KEY"), "Kafka API key")
+	cmd.Flags().String(FlagKafkaAPISecret, os.Getenv("KAFKA_API_SECRET"), "Kafka API secret")
+	cmd.Flags().String(FlagClaimCheckBucket, os.Getenv("CLAIM_CHECK_BUCKET"), "Claim check bucket")
+	cmd.Flags().String(FlagEnvironment, os.Getenv("ENVIRONMENT"), "Environment")
+	cmd.Flags().String(FlagSentryDSN, os.Getenv("SENTRY_DSN"), "Sentry DSN")
+	cmd.Flags().String(FlagCommitSHA, os.Getenv("COMMIT_SHA"), "Commit SHA")
+	cmd.Flags().Float64(FlagSentryProfilesSampleRate, sentryProfilesSampleRate, "Sentry profiles sample rate")
+	cmd.Flags().Float64(FlagSentryTracesSampleRate, sentryTracesSampleRate, "Sentry traces sample rate")
+
+	return cmd
+}
@@ -0,0 +1,135 @@
+package sweeper
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/certifi/gocertifi"
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/initia-labs/core-indexer/pkg/claimcheck"
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+type ClaimCheckGCConfig struct {
+	Chain                    string
+	Topics                   []string
+	TopicBuckets             map[string]string
+	ConsumerGroups           []string
+	RetentionInHours         int64
+	DeleteLegacyObjects      bool
+	MQBackend                string
+	MQDir                    string
+	KafkaBootstrapServer     string
+	KafkaAPIKey              string
+	KafkaAPISecret           string
+	Environment              string
+	CommitSHA                string
+	SentryDSN                string
+	SentryProfilesSampleRate float64
+	SentryTracesSampleRate   float64
+}
+
+// RunClaimCheckGC deletes the claim check objects of the topics once consumed by the consumer groups until it is
+// interrupted
+func RunClaimCheckGC(config *ClaimCheckGCConfig) error {
+	logger = zerolog.Ctx(log.With().Str("component", "claim-check-gc").Str("chain", config.Chain).Str("environment", config.Environment).Str("commit_sha", config.CommitSHA).Logger().WithContext(context.Background()))
+
+	sentryClientOptions := sentry.ClientOptions{
+		Dsn:                config.SentryDSN,
+		ServerName:         config.Chain + "-claim-check-gc",
+		EnableTracing:      true,
+		ProfilesSampleRate: config.SentryProfilesSampleRate,
+		TracesSampleRate:   config.SentryTracesSampleRate,
+		Environment:        config.Environment,
+		Release:            config.CommitSHA,
+		Tags: map[string]string{
+			"chain":       config.Chain,
+			"environment": config.Environment,
+			"component":   "claim-check-gc",
+			"commit_sha":  config.CommitSHA,
+		},
+	}
+
+	rootCAs, err := gocertifi.CACerts()
+	if err != nil {
+		logger.Fatal().Msgf("Sentry: Error getting root CAs: %v\n", err)
+	} else {
+		sentryClientOptions.CaCerts = rootCAs
+	}
+
+	err = sentry.Init(sentryClientOptions)
+	if err != nil {
+		logger.Fatal().Msgf("Sentry: Error initializing sentry: %v\n", err)
+		return err
+	}
+	defer sentry.Flush(2 * time.Second)
+
+	group := config.Chain + "-claim-check-gc"
+	mqConfig := mq.Config{
+		Backend: config.MQBackend,
+		Dir:     config.MQDir,
+		Kafka: mq.KafkaConfig{
+			BootstrapServer: config.KafkaBootstrapServer,
+			ClientID:        group,
+			APIKey:          config.KafkaAPIKey,
+			APISecret:       config.KafkaAPISecret,
+			Plaintext:       config.Environment == "local",
+		},
+	}
+	consumer, err := mq.NewConsumer(mqConfig, group)
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+		logger.Fatal().Msgf("MQ: Error creating consumer: %v\n", err)
+		return err
+	}
+	defer consumer.Close()
+
+	admin, err := mq.NewAdmin(mqConfig)
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+		logger.Fatal().Msgf("MQ: Error creating admin: %v\n", err)
+		return err
+	}
+	defer admin.Close()
+
+	var storageClient storage.Client
+	if config.Environment == "local" {
+		storageClient, err = storage.NewGCSFakeClient()
+	} else {
+		storageClient, err = storage.NewGCSClient()
+	}
+	if err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+		logger.Fatal().Msgf("Storage: Error creating storage client: %v\n", err)
+		return err
+	}
+
+	gcConfig := claimcheck.DefaultConfig()
+	gcConfig.Topics = config.Topics
+	gcConfig.Buckets = config.TopicBuckets
+	gcConfig.ConsumerGroups = config.ConsumerGroups
+	gcConfig.Retention = time.Duration(config.RetentionInHours) * time.Hour
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	gc := claimcheck.NewGC(consumer, admin, storageClient, gcConfig, logger)
+	if config.DeleteLegacyObjects {
+		if _, err := gc.DeleteLegacyObjects(); err != nil {
+			sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
+			logger.Error().Msgf("Error deleting legacy claim check objects: %v", err)
+		}
+	}
+
+	logger.Info().Msgf("Collecting claim check objects of topics %v consumed by %v", config.Topics, config.ConsumerGroups)
+	err = gc.Run(ctx)
+	logger.Info().Msgf("Shutting down ...")
+	return err
+}
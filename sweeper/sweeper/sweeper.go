@@ -11,7 +11,6 @@ import (
 
 	"github.com/certifi/gocertifi"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/getsentry/sentry-go"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -29,8 +28,9 @@ var logger *zerolog.Logger
 type Sweeper struct {
 	rpcClient     cosmosrpc.CosmosJSONRPCHub
 	dbClient      *gorm.DB
-	producer      *mq.Producer
+	producer      mq.Producer
 	storageClient storage.Client
+	blockWatcher  *BlockWatcher
 	config        *SweeperConfig
 }
 
@@ -41,12 +41,19 @@ type SweeperConfig struct {
 	DBConnectionString       string
 	NumWorkers               int64
 	RebalanceInterval        int64
+	RPCHedgeDelayInMs        int64
+	SubscribeNewBlocks       bool
+	VerificationQuorum       int64
+	MQBackend                string
+	MQDir                    string
+	MQMemoryBus              *mq.MemoryBus
 	KafkaBootstrapServer     string
 	KafkaTopics              []string
 	KafkaAPIKey              string
 	KafkaAPISecret           string
 	ClaimCheckBucket         string
 	ClaimCheckThresholdInMB  int64
+	BlockResultsEncoding     string
 	Environment              string
 	CommitSHA                string
 	SentryDSN                string
@@ -93,6 +100,12 @@ func NewSweeper(config *SweeperConfig) (*Sweeper, error) {
 		return nil, fmt.Errorf("RPC: No RPC endpoints provided")
 	}
 
+	if _, err := mq.EncodeBlockResultMsg(&mq.BlockResultMsg{}, config.BlockResultsEncoding); err != nil {
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
+		logger.Fatal().Msgf("MQ: Error validating block results encoding: %v\n", err)
+		return nil, err
+	}
+
 	var rpcEndpoints mq.RPCEndpoints
 	err = json.Unmarshal([]byte(config.RPCEndpoints), &rpcEndpoints)
 	if err != nil {
@@ -109,7 +122,9 @@ func NewSweeper(config *SweeperConfig) (*Sweeper, error) {
 		})
 	}
 
-	rpcClient := cosmosrpc.NewHub(clientConfigs, logger, time.Duration(config.RPCTimeOutInSeconds)*time.Second)
+	healthConfig := cosmosrpc.DefaultHealthConfig()
+	healthConfig.HedgeDelay = time.Duration(config.RPCHedgeDelayInMs) * time.Millisecond
+	rpcClient := cosmosrpc.NewHub(clientConfigs, logger, time.Duration(config.RPCTimeOutInSeconds)*time.Second).WithHealthConfig(healthConfig)
 	err = rpcClient.Rebalance(context.Background())
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
@@ -124,33 +139,18 @@ func NewSweeper(config *SweeperConfig) (*Sweeper, error) {
 		return nil, err
 	}
 
-	var producer *mq.Producer
-
-	if config.Environment == "local" {
-		producer, err = mq.NewProducer(&kafka.ConfigMap{
-			"bootstrap.servers": config.KafkaBootstrapServer,
-			"client.id":         config.Chain + "-informative-indexer-sweeper",
-			"acks":              "all",
-			"linger.ms":         200,
-			"security.protocol": "PLAINTEXT",
-			"message.max.bytes": 7340032,
-			"compression.codec": "lz4",
-		})
-	} else {
-		producer, err = mq.NewProducer(&kafka.ConfigMap{
-			"bootstrap.servers": config.KafkaBootstrapServer,
-			"client.id":         config.Chain + "-informative-indexer-sweeper",
-			"acks":              "all",
-			"linger.ms":         200,
-			"security.protocol": "SASL_SSL",
-			"sasl.mechanisms":   "PLAIN",
-			"sasl.username":     config.KafkaAPIKey,
-			"sasl.password":     config.KafkaAPISecret,
-			"message.max.bytes": 7340032,
-			"compression.codec": "lz4",
-		})
-	}
-
+	producer, err := mq.NewProducer(mq.Config{
+		Backend: config.MQBackend,
+		Dir:     config.MQDir,
+		Memory:  config.MQMemoryBus,
+		Kafka: mq.KafkaConfig{
+			BootstrapServer: config.KafkaBootstrapServer,
+			ClientID:        config.Chain + "-informative-indexer-sweeper",
+			APIKey:          config.KafkaAPIKey,
+			APISecret:       config.KafkaAPISecret,
+			Plaintext:       config.Environment == "local",
+		},
+	}, logger)
 	if err != nil {
 		sentry_integration.CaptureCurrentHubException(err, sentry.LevelFatal)
 		logger.Fatal().Msgf("Kafka: Error creating producer: %v\n", err)
@@ -174,24 +174,32 @@ func NewSweeper(config *SweeperConfig) (*Sweeper, error) {
 		}
 	}
 
+	var blockWatcher *BlockWatcher
+	if config.SubscribeNewBlocks {
+		blockWatcher = NewBlockWatcher(rpcClient)
+	}
+
 	return &Sweeper{
 		rpcClient:     rpcClient,
 		dbClient:      dbClient,
 		producer:      producer,
 		storageClient: storageClient,
+		blockWatcher:  blockWatcher,
 		config:        config,
 	}, nil
 }
 
 func (s *Sweeper) StartSweeping(signalCtx context.Context) {
-	s.producer.ListenToKafkaProduceEvents(logger)
-
 	height, err := db.GetLatestInformativeBlockHeight(context.Background(), s.dbClient)
 	if err != nil {
 		logger.Error().Msgf("DB: Error getting latest block height: %v\n", err)
 		panic(err)
 	}
 
+	if s.blockWatcher != nil {
+		go s.blockWatcher.Run(signalCtx)
+	}
+
 	for {
 		select {
 		case <-signalCtx.Done():
@@ -204,9 +212,8 @@ func (s *Sweeper) StartSweeping(signalCtx context.Context) {
 					sentry_integration.CaptureCurrentHubException(err, sentry.LevelWarning)
 					logger.Error().Msgf("Error rebalancing clients: %v", err)
 				}
-				actives := s.rpcClient.GetActiveClients()
-				for _, active := range actives {
-					logger.Info().Msgf("Active client url: %s, latest height: %d", active.Client.GetIdentifier(), active.Height)
+				for _, health := range s.rpcClient.Health() {
+					logger.Info().Msgf("Client url: %s, latest height: %d, breaker: %s, latency: %s, error rate: %.2f", health.Identifier, health.Height, health.State, health.Latency, health.ErrorRate)
 				}
 			}
 			localHub := sentry.CurrentHub().Clone()
@@ -214,6 +221,9 @@ func (s *Sweeper) StartSweeping(signalCtx context.Context) {
 				scope.SetTag("height", fmt.Sprint(height))
 			})
 			ctx := sentry.SetHubOnContext(context.Background(), localHub)
+			if s.blockWatcher != nil {
+				s.blockWatcher.WaitFor(signalCtx, height)
+			}
 			err := s.GetBlockFromRPCAndProduce(ctx, height)
 			if err != nil {
 				panic(err)
@@ -228,14 +238,8 @@ func (s *Sweeper) GetBlockFromRPCAndProduce(parentCtx context.Context, height in
 	transaction, ctx := sentry_integration.StartSentryTransaction(parentCtx, "Sweep", "Sweep block_results from RPC and produce to Kafka")
 	defer transaction.Finish()
 
-	block, err := s.GetBlock(ctx, height)
+	block, blockResult, err := s.GetVerifiedBlock(ctx, height)
 	if err != nil {
-		logger.Error().Msgf("RPC: Error getting block %d: %v\n", height, err)
-		return err
-	}
-	blockResult, err := s.GetBlockResults(ctx, height)
-	if err != nil {
-		logger.Error().Msgf("RPC: Error getting block results %d: %v\n", height, err)
 		return err
 	}
 
@@ -247,6 +251,43 @@ func (s *Sweeper) GetBlockFromRPCAndProduce(parentCtx context.Context, height in
 	return nil
 }
 
+// GetVerifiedBlock fetches a block and its results, and when a verification quorum is configured fetches them again
+// until enough other clients agree with them
+func (s *Sweeper) GetVerifiedBlock(ctx context.Context, height int64) (*coretypes.ResultBlock, *coretypes.ResultBlockResults, error) {
+	retryCount := 0
+	for {
+		block, err := s.GetBlock(ctx, height)
+		if err != nil {
+			logger.Error().Msgf("RPC: Error getting block %d: %v\n", height, err)
+			return nil, nil, err
+		}
+		blockResult, err := s.GetBlockResults(ctx, height)
+		if err != nil {
+			logger.Error().Msgf("RPC: Error getting block results %d: %v\n", height, err)
+			return nil, nil, err
+		}
+
+		if s.config.VerificationQuorum <= 0 {
+			return block, blockResult, nil
+		}
+		err = s.rpcClient.VerifyBlock(ctx, block, blockResult, int(s.config.VerificationQuorum))
+		if err == nil {
+			return block, blockResult, nil
+		}
+
+		sentry_integration.CaptureCurrentHubException(err, sentry.LevelWarning)
+		logger.Error().Msgf("RPC: Error verifying block %d: %v\n", height, err)
+		retryCount++
+		if retryCount%10 == 0 {
+			err := s.RebalanceRPCs(ctx)
+			if errors.Is(err, cosmosrpc.ErrorZeroActiveClients) {
+				return nil, nil, err
+			}
+		}
+		time.Sleep(time.Second)
+	}
+}
+
 func (s *Sweeper) GetBlock(ctx context.Context, height int64) (*coretypes.ResultBlock, error) {
 	retryCount := 0
 	for {
@@ -299,23 +340,33 @@ func (s *Sweeper) MakeAndSendBlockResultMsg(ctx context.Context, block *coretype
 	span, _ := sentry_integration.StartSentrySpan(ctx, "MakeAndSendBlockResultMsg", "Make and send block results")
 	defer span.Finish()
 
-	blockResultMsgBytes, err := mq.NewBlockResultMsgBytes(block, blockResult)
+	blockResultMsg, err := mq.NewBlockResultMsg(block, blockResult)
+	if err != nil {
+		logger.Error().Msgf("Failed to make block result message: %v\n", err)
+		return err
+	}
+
+	blockResultMsgBytes, err := mq.EncodeBlockResultMsg(blockResultMsg, s.config.BlockResultsEncoding)
 	if err != nil {
 		logger.Error().Msgf("Failed to marshal into block result message: %v\n", err)
 		return err
 	}
 
 	for _, topic := range s.config.KafkaTopics {
-		s.producer.ProduceWithClaimCheck(&mq.ProduceWithClaimCheckInput{
+		mq.ProduceWithClaimCheck(s.producer, &mq.ProduceWithClaimCheckInput{
 			Topic:                   topic,
 			Key:                     fmt.Appendf(nil, "%s_%d", mq.NEW_BLOCK_RESULTS_KAFKA_MESSAGE_KEY, blockResult.Height),
 			MessageInBytes:          blockResultMsgBytes,
+			Producer:                "sweeper",
 			ClaimCheckKey:           fmt.Appendf(nil, "%s_%d", mq.NEW_BLOCK_RESULTS_CLAIM_CHECK_KAFKA_MESSAGE_KEY, blockResult.Height),
 			ClaimCheckThresholdInMB: s.config.ClaimCheckThresholdInMB,
 			ClaimCheckBucket:        s.config.ClaimCheckBucket,
 			ClaimCheckObjectPath:    fmt.Sprintf("%d", blockResult.Height),
 			StorageClient:           s.storageClient,
-			Headers:                 []kafka.Header{{Key: "height", Value: fmt.Appendf(nil, "%d", blockResult.Height)}},
+			Headers: []mq.Header{
+				{Key: "height", Value: fmt.Appendf(nil, "%d", blockResult.Height)},
+				{Key: mq.HeaderEncoding, Value: []byte(s.config.BlockResultsEncoding)},
+			},
 		}, logger)
 	}
 
@@ -325,6 +376,12 @@ func (s *Sweeper) MakeAndSendBlockResultMsg(ctx context.Context, block *coretype
 func (s *Sweeper) Sweep() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
+
+	s.Run(ctx)
+}
+
+// Run applies the migrations and sweeps the blocks until the context is cancelled
+func (s *Sweeper) Run(ctx context.Context) {
 	defer sentry.Flush(2 * time.Second)
 
 	if err := db.ApplyMigrationFiles(logger, s.dbClient, s.config.MigrationsDir); err != nil {
@@ -345,6 +402,6 @@ func (s *Sweeper) close() {
 		db.Close()
 	}
 
-	s.producer.Flush(30000)
+	s.producer.Flush(30 * time.Second)
 	s.producer.Close()
 }
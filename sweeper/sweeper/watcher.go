@@ -0,0 +1,103 @@
+package sweeper
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/initia-labs/core-indexer/pkg/cosmosrpc"
+)
+
+const (
+	// resubscribeDelay is the time waited before subscribing again after the subscription dropped or failed
+	resubscribeDelay = 5 * time.Second
+	// maxBlockWait bounds the wait for a block event, the sweeper polls the height once it elapses
+	maxBlockWait = 30 * time.Second
+)
+
+// BlockWatcher follows the heights committed by the chain through a new block subscription, so that the sweeper
+// fetches a block as soon as it is committed instead of polling for it. While the subscription is down the sweeper
+// falls back to polling, and the heights committed meanwhile are swept in order once it is back.
+type BlockWatcher struct {
+	rpcClient cosmosrpc.CosmosJSONRPCHub
+
+	mu         sync.Mutex
+	subscribed bool
+	latest     int64
+	// changed is closed and replaced on every new height or subscription change, waking up the waiters
+	changed chan struct{}
+}
+
+func NewBlockWatcher(rpcClient cosmosrpc.CosmosJSONRPCHub) *BlockWatcher {
+	return &BlockWatcher{rpcClient: rpcClient, changed: make(chan struct{})}
+}
+
+// Run keeps the new block subscription up until ctx is done
+func (w *BlockWatcher) Run(ctx context.Context) {
+	for {
+		heights, err := w.rpcClient.SubscribeNewBlocks(ctx)
+		if err != nil {
+			logger.Warn().Err(err).Msg("RPC: Failed to subscribe to new blocks, polling")
+		} else {
+			logger.Info().Msg("RPC: Subscribed to new blocks")
+			w.setSubscribed(true)
+			for height := range heights {
+				w.setLatest(height)
+			}
+			w.setSubscribed(false)
+			logger.Warn().Msg("RPC: New block subscription dropped, polling")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+// WaitFor returns once the height is known to be committed, or right away when the subscription is down. It gives up
+// waiting after maxBlockWait, so that a stalled subscription only delays the sweeper.
+func (w *BlockWatcher) WaitFor(ctx context.Context, height int64) {
+	timeout := time.NewTimer(maxBlockWait)
+	defer timeout.Stop()
+
+	for {
+		w.mu.Lock()
+		if !w.subscribed || w.latest >= height {
+			w.mu.Unlock()
+			return
+		}
+		changed := w.changed
+		w.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-timeout.C:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *BlockWatcher) setSubscribed(subscribed bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribed = subscribed
+	w.notify()
+}
+
+func (w *BlockWatcher) setLatest(height int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if height > w.latest {
+		w.latest = height
+		w.notify()
+	}
+}
+
+func (w *BlockWatcher) notify() {
+	close(w.changed)
+	w.changed = make(chan struct{})
+}
@@ -3,46 +3,34 @@ package main
 import (
 	"os"
 	"strconv"
-)
-
-type Config struct {
-	ID string
-
-	BootstrapServer     string
-	LCDTXResponseBucket string
-
-	KafkaAPIKey        string
-	KafkaAPISecret     string
-	Topic              string
-	KafkaConsumerGroup string
-
-	Chain       string
-	Environment string
-
-	ClaimCheckBucket string
 
-	SentryDSN               string
-	CommitSHA               string
-	SentryTraceSampleRate   float64
-	SentryProfileSampleRate float64
-}
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/tx-response-uploader/uploader"
+)
 
-func getConfig() *Config {
+func getConfig() *uploader.Config {
 	// all env vars are required, panic if doesn't set
 	if os.Getenv("ID") == "" {
 		logger.Fatal().Msg("ID is required")
 	}
-	if os.Getenv("BOOTSTRAP_SERVER") == "" {
-		logger.Fatal().Msg("BOOTSTRAP_SERVER is required")
-	}
 	if os.Getenv("LCD_TX_RESPONSE_BUCKET") == "" {
 		logger.Fatal().Msg("LCD_TX_RESPONSE_BUCKET is required")
 	}
-	if os.Getenv("KAFKA_API_KEY") == "" {
-		logger.Fatal().Msg("KAFKA_API_KEY is required")
+	// the kafka connection is only required by the kafka backend
+	mqBackend := os.Getenv("MQ_BACKEND")
+	if err := mq.CheckStandalone(mqBackend); err != nil {
+		logger.Fatal().Msgf("MQ_BACKEND: %v", err)
 	}
-	if os.Getenv("KAFKA_API_SECRET") == "" {
-		logger.Fatal().Msg("KAFKA_API_SECRET is required")
+	if mqBackend == "" || mqBackend == mq.BackendKafka {
+		if os.Getenv("BOOTSTRAP_SERVER") == "" {
+			logger.Fatal().Msg("BOOTSTRAP_SERVER is required")
+		}
+		if os.Getenv("KAFKA_API_KEY") == "" {
+			logger.Fatal().Msg("KAFKA_API_KEY is required")
+		}
+		if os.Getenv("KAFKA_API_SECRET") == "" {
+			logger.Fatal().Msg("KAFKA_API_SECRET is required")
+		}
 	}
 	if os.Getenv("KAFKA_CONSUMER_GROUP") == "" {
 		logger.Fatal().Msg("KAFKA_CONSUMER_GROUP is required")
@@ -82,8 +70,10 @@ func getConfig() *Config {
 		}
 	}
 
-	return &Config{
+	return &uploader.Config{
 		ID:                      os.Getenv("ID"),
+		MQBackend:               mqBackend,
+		MQDir:                   os.Getenv("MQ_DIR"),
 		BootstrapServer:         os.Getenv("BOOTSTRAP_SERVER"),
 		LCDTXResponseBucket:     os.Getenv("LCD_TX_RESPONSE_BUCKET"),
 		KafkaAPIKey:             os.Getenv("KAFKA_API_KEY"),
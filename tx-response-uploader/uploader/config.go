@@ -0,0 +1,37 @@
+package uploader
+
+import (
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+type Config struct {
+	ID string
+
+	// MQBackend selects the message bus, kafka when empty, see mq.Config
+	MQBackend string
+	MQDir     string
+	// MQMemoryBus is the bus of the memory backend, shared with the services run in the same process
+	MQMemoryBus *mq.MemoryBus
+
+	BootstrapServer     string
+	LCDTXResponseBucket string
+
+	KafkaAPIKey        string
+	KafkaAPISecret     string
+	Topic              string
+	KafkaConsumerGroup string
+
+	Chain       string
+	Environment string
+
+	ClaimCheckBucket string
+
+	// StorageClient is the client of the buckets, a GCS client when nil
+	StorageClient storage.Client
+
+	SentryDSN               string
+	CommitSHA               string
+	SentryTraceSampleRate   float64
+	SentryProfileSampleRate float64
+}
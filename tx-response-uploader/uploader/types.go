@@ -1,4 +1,4 @@
-package main
+package uploader
 
 const (
 	ServiceName                               string = "tx-response-uploader"
@@ -0,0 +1,229 @@
+package uploader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/certifi/gocertifi"
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/initia-labs/core-indexer/pkg/mq"
+	"github.com/initia-labs/core-indexer/pkg/sentry_integration"
+	"github.com/initia-labs/core-indexer/pkg/storage"
+)
+
+var logger *zerolog.Logger
+
+type TxResponseUploader struct {
+	producer mq.Producer
+	consumer mq.Consumer
+
+	storageClient storage.Client
+
+	config *Config
+}
+
+func getHashAndHeightFromHeaders(headers []mq.Header) (hash string, height string) {
+	for _, header := range headers {
+		switch header.Key {
+		case HeaderHashKey:
+			hash = string(header.Value)
+		case HeaderHeightKey:
+			height = string(header.Value)
+		}
+	}
+
+	return
+}
+
+func (u *TxResponseUploader) process(ctx context.Context, message *mq.Message) error {
+	span, ctx := sentry_integration.StartSentrySpan(ctx, "process", "Process MQ Message")
+	defer span.Finish()
+
+	hash, height := getHashAndHeightFromHeaders(message.Headers)
+
+	if hash == "" || height == "" {
+		logger.Error().Msgf("Error getting hash and height from headers")
+		return fmt.Errorf("either no hash or height from headers")
+	}
+
+	var data []byte
+	if strings.HasPrefix(string(message.Key), NewLcdTxResponseClaimCheckKafkaMessageKey) {
+		logger.Info().Msgf("Processing claim check message: %s", string(message.Key))
+		var claimCheckMessage ClaimCheckMessage
+		err := json.Unmarshal(message.Value, &claimCheckMessage)
+		if err != nil {
+			logger.Error().Msgf("Error unmarshalling claim check message: %v", err)
+			return err
+		}
+
+		retryCount := 0
+		// read from storage until succeeds
+		for {
+			retryCount++
+			data, err = u.storageClient.ReadFile(u.config.ClaimCheckBucket, claimCheckMessage.ObjectPath)
+			if err == nil {
+				break
+			}
+
+			if retryCount >= 5 {
+				sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
+			} else {
+				sentry_integration.CaptureCurrentHubException(err, sentry.LevelWarning)
+			}
+
+			logger.Error().Msgf("Error reading file from storage: %v", err)
+		}
+	} else {
+		data = message.Value
+	}
+
+	return u.storageClient.UploadFile(u.config.LCDTXResponseBucket, fmt.Sprintf("%s/%s", strings.ToUpper(hash), height), data)
+}
+
+// Run uploads the transaction responses until the context is cancelled
+func (u *TxResponseUploader) Run(ctx context.Context) {
+	u.StartUploading(ctx)
+
+	logger.Info().Msgf("Shutting down ...")
+	u.close()
+}
+
+func (u *TxResponseUploader) close() {
+	u.producer.Flush(10 * time.Second)
+	u.producer.Close()
+	u.consumer.Close()
+}
+
+func NewTxResponseUploader(config *Config) *TxResponseUploader {
+	logger = zerolog.Ctx(
+		log.With().
+			Str("component", ServiceName).
+			Str("chain", config.Chain).
+			Str("id", config.ID).
+			Str("environment", config.Environment).
+			Str("commit_sha", config.CommitSHA).
+			Logger().
+			WithContext(context.Background()),
+	)
+
+	sentryClientOptions := sentry.ClientOptions{
+		Dsn:                config.SentryDSN,
+		ServerName:         config.Chain + "-" + ServiceName,
+		EnableTracing:      true,
+		ProfilesSampleRate: config.SentryProfileSampleRate,
+		TracesSampleRate:   config.SentryTraceSampleRate,
+		Environment:        config.Environment,
+		Release:            config.CommitSHA,
+		Tags: map[string]string{
+			"chain":       config.Chain,
+			"environment": config.Environment,
+			"component":   ServiceName,
+			"commit_sha":  config.CommitSHA,
+		},
+	}
+
+	rootCAs, err := gocertifi.CACerts()
+	if err != nil {
+		logger.Fatal().Msgf("Sentry: Error getting root CAs: %v\n", err)
+	} else {
+		sentryClientOptions.CaCerts = rootCAs
+	}
+
+	err = sentry.Init(sentryClientOptions)
+
+	if err != nil {
+		logger.Fatal().Msgf("Sentry: Error initializing sentry: %v\n", err)
+	}
+
+	storageClient := config.StorageClient
+	if storageClient == nil {
+		storageClient, err = storage.NewGCSClient()
+		if err != nil {
+			logger.Fatal().Msgf("Error connecting to GCS: %v", err)
+		}
+	}
+
+	mqConfig := mq.Config{
+		Backend: config.MQBackend,
+		Dir:     config.MQDir,
+		Memory:  config.MQMemoryBus,
+		Kafka: mq.KafkaConfig{
+			BootstrapServer: config.BootstrapServer,
+			ClientID:        config.KafkaConsumerGroup + "-" + config.ID,
+			APIKey:          config.KafkaAPIKey,
+			APISecret:       config.KafkaAPISecret,
+		},
+	}
+	consumer, err := mq.NewConsumer(mqConfig, config.KafkaConsumerGroup)
+	if err != nil {
+		logger.Fatal().Msgf("Failed to create consumer: %s\n", err)
+	}
+
+	producer, err := mq.NewProducer(mqConfig, logger)
+	if err != nil {
+		logger.Fatal().Msgf("Failed to create producer: %s\n", err)
+	}
+
+	return &TxResponseUploader{
+		producer:      producer,
+		consumer:      consumer,
+		storageClient: storageClient,
+		config:        config,
+	}
+}
+
+func (u *TxResponseUploader) StartUploading(signalContext context.Context) {
+	logger.Info().Msgf("Starting uploading to GCS")
+
+	err := u.consumer.SubscribeTopics([]string{u.config.Topic})
+	if err != nil {
+		logger.Fatal().Msgf("Failed to subscribe to topic: %s\n", err)
+	}
+
+	logger.Info().Msgf("Subscribed to topic: %s\n", u.config.Topic)
+
+	for {
+		select {
+		case <-signalContext.Done():
+			return
+		default:
+			message, err := u.consumer.ReadMessage(10 * time.Second)
+			if err != nil {
+				if errors.Is(err, mq.ErrTimeout) {
+					continue
+				}
+
+				logger.Error().Msgf("Error reading message: %v", err)
+				continue
+			}
+
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("id", u.config.ID)
+				scope.SetTag("partition", fmt.Sprint(message.Partition))
+				scope.SetTag("offset", fmt.Sprint(message.Offset))
+			})
+
+			transaction, ctx := sentry_integration.StartSentryTransaction(context.Background(), "Upload", "Read Tx Response from Kafka and upload to GCS")
+			err = u.process(ctx, message)
+			if err != nil {
+				sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
+				logger.Error().Msgf("Error processing message: %v", err)
+				mq.ProduceToDLQ(u.producer, u.storageClient, u.config.Chain, "tx-responses", message, err, logger)
+			}
+
+			err = u.consumer.CommitMessage(message)
+			if err != nil {
+				sentry_integration.CaptureCurrentHubException(err, sentry.LevelError)
+				logger.Error().Msgf("Non-retryable Error committing message: %v", err)
+			}
+			transaction.Finish()
+		}
+	}
+}